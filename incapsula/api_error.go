@@ -0,0 +1,210 @@
+package incapsula
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Iinfo"}
+
+// APIError is an error returned by the Incapsula API.
+// It covers the v1 responses (res/res_message/debug_info) as well as the v2 and v3 JSON:API errors array
+type APIError struct {
+	// HTTP status code of the response
+	StatusCode int
+
+	// Error code: the v1 res value or the code of the first JSON:API error
+	Code string
+
+	// Human readable message: the v1 res_message or the title/detail of the first JSON:API error
+	Message string
+
+	// Request identifier, taken from the response headers or the JSON:API error id
+	RequestID string
+
+	// Fields the error refers to, taken from the JSON:API source pointer/parameter or the v1 debug_info
+	Fields []string
+
+	// All the JSON:API errors of the response
+	Errors []APIErrors
+
+	// Raw response body
+	Body string
+}
+
+// NewAPIError parses an error response of the Incapsula API
+func NewAPIError(resp *http.Response, responseBody []byte) *APIError {
+	apiError := &APIError{Body: string(responseBody)}
+	if resp != nil {
+		apiError.StatusCode = resp.StatusCode
+		for _, header := range requestIDHeaders {
+			if value := resp.Header.Get(header); value != "" {
+				apiError.RequestID = value
+				break
+			}
+		}
+	}
+
+	var body struct {
+		Res        interface{}                `json:"res"`
+		ResMessage string                     `json:"res_message"`
+		DebugInfo  map[string]json.RawMessage `json:"debug_info"`
+		Message    string                     `json:"message"`
+		Errors     []struct {
+			Status interface{}       `json:"status"`
+			Id     string            `json:"id"`
+			Code   interface{}       `json:"code"`
+			Source map[string]string `json:"source"`
+			Title  string            `json:"title"`
+			Detail string            `json:"detail"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(responseBody, &body); err != nil {
+		return apiError
+	}
+
+	for _, e := range body.Errors {
+		apiErr := APIErrors{Id: e.Id, Source: e.Source, Title: e.Title, Detail: e.Detail}
+		fmt.Sscan(fmt.Sprint(e.Status), &apiErr.Status)
+		fmt.Sscan(fmt.Sprint(e.Code), &apiErr.Code)
+		apiError.Errors = append(apiError.Errors, apiErr)
+
+		if apiError.Code == "" && e.Code != nil {
+			apiError.Code = fmt.Sprint(e.Code)
+		}
+		if apiError.Message == "" {
+			apiError.Message = firstNonEmpty(e.Detail, e.Title)
+		}
+		if apiError.RequestID == "" {
+			apiError.RequestID = e.Id
+		}
+		if field := firstNonEmpty(e.Source["pointer"], e.Source["parameter"]); field != "" {
+			apiError.Fields = append(apiError.Fields, field)
+		}
+	}
+
+	if body.Res != nil && apiError.Code == "" {
+		apiError.Code = strings.TrimSuffix(fmt.Sprint(body.Res), ".0")
+	}
+	if apiError.Message == "" {
+		apiError.Message = firstNonEmpty(body.ResMessage, body.Message)
+	}
+	if apiError.RequestID == "" {
+		if idInfo, ok := body.DebugInfo["id-info"]; ok {
+			json.Unmarshal(idInfo, &apiError.RequestID)
+		}
+	}
+	debugFields := make([]string, 0)
+	for key := range body.DebugInfo {
+		if key != "id-info" {
+			debugFields = append(debugFields, key)
+		}
+	}
+	sort.Strings(debugFields)
+	apiError.Fields = append(apiError.Fields, debugFields...)
+
+	return apiError
+}
+
+// Error returns the raw response body, as it was reported before the error was typed
+func (e *APIError) Error() string {
+	return e.Body
+}
+
+// Summary returns a short description of the error
+func (e *APIError) Summary() string {
+	summary := e.Message
+	if summary == "" {
+		summary = fmt.Sprintf("Error status code %d from Incapsula service", e.StatusCode)
+	}
+	if e.Code != "" && e.Code != "0" {
+		summary = fmt.Sprintf("%s (code %s)", summary, e.Code)
+	}
+	return summary
+}
+
+// apiErrorDiagnostics returns the diagnostics of an error response, for the clients that return diagnostics.
+// The summary is completed with the message and code of the API, the detail holds the response and the request ID
+func apiErrorDiagnostics(summary string, detail string, resp *http.Response, responseBody []byte) diag.Diagnostics {
+	apiError := NewAPIError(resp, responseBody)
+	diags := diagnosticsFromError(nil, fmt.Errorf("%s: %w", detail, apiError))
+	diags[0].Summary = fmt.Sprintf("%s: %s", summary, apiError.Summary())
+	return diags
+}
+
+// isNotFoundError reports whether the API reported that the object, its site (9413) or its account (9403) doesn't exist
+func isNotFoundError(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	return apiError.StatusCode == http.StatusNotFound || apiError.Code == "9403" || apiError.Code == "9413"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+var camelCaseBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// apiFieldToAttribute converts an API field reference (e.g. /data/0/attributes/siteName or caid) to a schema attribute name
+func apiFieldToAttribute(field string) string {
+	segments := strings.Split(strings.Trim(field, "/"), "/")
+	name := segments[len(segments)-1]
+	if name == "caid" {
+		return "account_id"
+	}
+	name = camelCaseBoundary.ReplaceAllString(name, "${1}_${2}")
+	return strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+}
+
+// diagnosticsFromError converts an error to diagnostics.
+// Incapsula API errors get a short summary and, when the API reports the offending field, the matching attribute path
+func diagnosticsFromError(d *schema.ResourceData, err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return diag.FromErr(err)
+	}
+
+	detail := err.Error()
+	if apiError.RequestID != "" {
+		detail = fmt.Sprintf("%s\nrequest ID: %s", detail, apiError.RequestID)
+	}
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  apiError.Summary(),
+		Detail:   detail,
+	}
+
+	if d != nil {
+		configType := d.GetRawConfig().Type()
+		for _, field := range apiError.Fields {
+			attribute := apiFieldToAttribute(field)
+			if configType.IsObjectType() && configType.HasAttribute(attribute) {
+				diagnostic.AttributePath = cty.GetAttrPath(attribute)
+				break
+			}
+		}
+	}
+
+	return diag.Diagnostics{diagnostic}
+}
@@ -0,0 +1,131 @@
+package incapsula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNewAPIErrorV1(t *testing.T) {
+	body := []byte(`{"res":2,"res_message":"Invalid input","debug_info":{"ssl_port":["ssl is not supported for your site"],"id-info":"999999"}}`)
+	apiError := NewAPIError(&http.Response{StatusCode: 200, Header: http.Header{}}, body)
+	if apiError.Code != "2" {
+		t.Errorf("Should have parsed code 2, got: %s", apiError.Code)
+	}
+	if apiError.Message != "Invalid input" {
+		t.Errorf("Should have parsed the res_message, got: %s", apiError.Message)
+	}
+	if apiError.RequestID != "999999" {
+		t.Errorf("Should have parsed the id-info as request ID, got: %s", apiError.RequestID)
+	}
+	if len(apiError.Fields) != 1 || apiError.Fields[0] != "ssl_port" {
+		t.Errorf("Should have parsed the ssl_port field, got: %v", apiError.Fields)
+	}
+	if apiError.Error() != string(body) {
+		t.Errorf("Should have kept the raw body as error message, got: %s", apiError.Error())
+	}
+}
+
+func TestNewAPIErrorV3(t *testing.T) {
+	body := []byte(`{"errors":[{"status":400,"id":"abc-123","code":"7","source":{"pointer":"/data/attributes/siteName"},"title":"Bad Request","detail":"site name is too long"}]}`)
+	header := http.Header{}
+	header.Set("X-Request-Id", "req-1")
+	apiError := NewAPIError(&http.Response{StatusCode: 400, Header: header}, body)
+	if apiError.StatusCode != 400 {
+		t.Errorf("Should have kept status code 400, got: %d", apiError.StatusCode)
+	}
+	if apiError.Code != "7" {
+		t.Errorf("Should have parsed code 7, got: %s", apiError.Code)
+	}
+	if apiError.Message != "site name is too long" {
+		t.Errorf("Should have parsed the detail, got: %s", apiError.Message)
+	}
+	if apiError.RequestID != "req-1" {
+		t.Errorf("Should have taken the request ID from the headers, got: %s", apiError.RequestID)
+	}
+	if len(apiError.Errors) != 1 || apiError.Errors[0].Status != 400 || apiError.Errors[0].Code != 7 {
+		t.Errorf("Should have parsed the errors array, got: %v", apiError.Errors)
+	}
+	if attribute := apiFieldToAttribute(apiError.Fields[0]); attribute != "site_name" {
+		t.Errorf("Should have mapped the pointer to site_name, got: %s", attribute)
+	}
+}
+
+func TestNewAPIErrorNotJSON(t *testing.T) {
+	apiError := NewAPIError(&http.Response{StatusCode: 502, Header: http.Header{}}, []byte("Bad Gateway"))
+	if apiError.Summary() != "Error status code 502 from Incapsula service" {
+		t.Errorf("Should have described the status code, got: %s", apiError.Summary())
+	}
+}
+
+func TestClientErrorIsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(400)
+		rw.Write([]byte(`{"errors":[{"status":400,"source":{"pointer":"/filter"},"detail":"invalid filter"}]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURLRev2: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.AddIncapRule(context.Background(), "42", &IncapRule{Name: "rule"})
+	if err == nil {
+		t.Fatalf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), "Error status code 400 from Incapsula service when adding Incap Rule for Site ID 42") {
+		t.Errorf("Should have kept the error message, got: %s", err)
+	}
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("Should have wrapped an APIError, got: %T", err)
+	}
+	if apiError.Message != "invalid filter" {
+		t.Errorf("Should have parsed the detail, got: %s", apiError.Message)
+	}
+}
+
+func TestDiagnosticsFromError(t *testing.T) {
+	d := resourceIncapRule().TestResourceData()
+	if diags := diagnosticsFromError(d, errors.New("boom")); len(diags) != 1 || diags[0].Summary != "boom" {
+		t.Errorf("Should have kept a plain error as is, got: %v", diags)
+	}
+
+	apiError := NewAPIError(&http.Response{StatusCode: 400, Header: http.Header{}}, []byte(`{"errors":[{"source":{"pointer":"/filter"},"detail":"invalid filter"}]}`))
+	diags := diagnosticsFromError(nil, apiError)
+	if len(diags) != 1 || diags[0].Summary != "invalid filter" || diags[0].AttributePath != nil {
+		t.Errorf("Should have summarized the API error without an attribute path, got: %v", diags)
+	}
+}
+
+func TestDiagnosticsFromErrorAttributePath(t *testing.T) {
+	raw := map[string]interface{}{"site_id": "42", "name": "rule", "action": "RULE_ACTION_ALERT", "filter": "bad"}
+	d := schema.TestResourceDataRaw(t, resourceIncapRule().Schema, raw)
+	if !d.GetRawConfig().Type().IsObjectType() {
+		t.Skip("raw config is not available on test resource data")
+	}
+
+	apiError := NewAPIError(&http.Response{StatusCode: 400, Header: http.Header{}}, []byte(`{"errors":[{"source":{"pointer":"/filter"},"detail":"invalid filter"}]}`))
+	diags := diagnosticsFromError(d, apiError)
+	if len(diags) != 1 || !diags[0].AttributePath.Equals(cty.GetAttrPath("filter")) {
+		t.Errorf("Should have pointed to the filter attribute, got: %v", diags)
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	header := http.Header{}
+	if !isNotFoundError(fmt.Errorf("wrapped: %w", NewAPIError(&http.Response{StatusCode: 200, Header: header}, []byte(`{"res":9403}`)))) {
+		t.Errorf("Should have detected the unknown account")
+	}
+	if !isNotFoundError(NewAPIError(&http.Response{StatusCode: 404, Header: header}, []byte(`{}`))) {
+		t.Errorf("Should have detected the 404 status code")
+	}
+	if isNotFoundError(NewAPIError(&http.Response{StatusCode: 200, Header: header}, []byte(`{"res":1}`))) || isNotFoundError(errors.New("boom")) {
+		t.Errorf("Should not have detected other errors")
+	}
+}
@@ -0,0 +1,186 @@
+package incapsula
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// The custom certificates are inspected locally, before they are uploaded. Only PEM and DER certificates can be
+// inspected, PFX files are uploaded as is
+
+// decodeCertificateInput returns the content of a certificate or key argument, which holds the file in base64 format,
+// or the PEM file itself
+func decodeCertificateInput(value string) []byte {
+	compact := strings.Join(strings.Fields(value), "")
+	if decoded, err := base64.StdEncoding.DecodeString(compact); err == nil {
+		return decoded
+	}
+	return []byte(value)
+}
+
+// parseCertificateChain parses the certificates of a PEM file, the leaf certificate first, or a single DER
+// certificate. It returns nil for the files that cannot be inspected, such as PFX files
+func parseCertificateChain(certificate string) ([]*x509.Certificate, error) {
+	content := decodeCertificateInput(certificate)
+
+	var chain []*x509.Certificate
+	for rest := content; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate #%d of the certificate file: %w", len(chain)+1, err)
+		}
+		chain = append(chain, parsed)
+	}
+	if len(chain) > 0 {
+		return chain, nil
+	}
+
+	if bytes.Contains(content, []byte("-----BEGIN")) {
+		return nil, errors.New("the certificate file doesn't hold any PEM certificate")
+	}
+	if parsed, err := x509.ParseCertificate(content); err == nil {
+		return []*x509.Certificate{parsed}, nil
+	}
+	return nil, nil
+}
+
+// checkCertificatePrivateKey checks that the private key matches the leaf certificate. Encrypted keys are not checked
+func checkCertificatePrivateKey(leaf *x509.Certificate, privateKey string) error {
+	block, _ := pem.Decode(decodeCertificateInput(privateKey))
+	if block == nil {
+		return errors.New("the private key is not a PEM private key")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" || block.Headers["Proc-Type"] != "" {
+		return nil
+	}
+
+	var key crypto.Signer
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid RSA private key: %w", err)
+		}
+		key = rsaKey
+	case "EC PRIVATE KEY":
+		ecKey, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid EC private key: %w", err)
+		}
+		key = ecKey
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return fmt.Errorf("unsupported private key type %T", parsed)
+		}
+		key = signer
+	default:
+		return fmt.Errorf("unsupported private key PEM block %q", block.Type)
+	}
+
+	switch public := key.Public().(type) {
+	case *rsa.PublicKey:
+		if public.Equal(leaf.PublicKey) {
+			return nil
+		}
+	case *ecdsa.PublicKey:
+		if public.Equal(leaf.PublicKey) {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if public.Equal(leaf.PublicKey) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the private key doesn't match the certificate of %s", leaf.Subject)
+}
+
+// checkCertificateChain checks that the certificate file holds the intermediate certificates between the leaf
+// certificate and a root. The root is usually left out of the file: a chain ending at an intermediate certificate is
+// complete. A leaf certificate without intermediate certificates must be issued by a root trusted by the system, which
+// is not checked when the system roots are not available
+func checkCertificateChain(chain []*x509.Certificate) error {
+	current := chain[0]
+	visited := map[*x509.Certificate]bool{current: true}
+	for {
+		if bytes.Equal(current.RawIssuer, current.RawSubject) && current.CheckSignatureFrom(current) == nil {
+			return nil
+		}
+
+		var issuer *x509.Certificate
+		for _, candidate := range chain {
+			if !visited[candidate] && bytes.Equal(current.RawIssuer, candidate.RawSubject) && current.CheckSignatureFrom(candidate) == nil {
+				issuer = candidate
+				break
+			}
+		}
+		if issuer == nil {
+			break
+		}
+		visited[issuer] = true
+		current = issuer
+	}
+	if current != chain[0] && current.IsCA {
+		return nil
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		log.Printf("[WARN] Could not load the system roots, the issuer of %s is not checked: %s\n", current.Subject, err)
+		return nil
+	}
+	_, err = current.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: current.NotBefore.Add(time.Second),
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("the certificate chain is incomplete, the certificate of %s, issuer of %s, is missing. "+
+		"Append the intermediate certificates to the certificate file, and the root certificate of private certificate authorities", current.Issuer, current.Subject)
+}
+
+// certificateSANs returns the subject alternative names of a certificate
+func certificateSANs(certificate *x509.Certificate) []string {
+	sans := append([]string{}, certificate.DNSNames...)
+	for _, ip := range certificate.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, certificate.EmailAddresses...)
+	for _, uri := range certificate.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
+// certificateFingerprintSHA256 returns the lower case hexadecimal SHA-256 fingerprint of a certificate
+func certificateFingerprintSHA256(certificate *x509.Certificate) string {
+	fingerprint := sha256.Sum256(certificate.Raw)
+	return hex.EncodeToString(fingerprint[:])
+}
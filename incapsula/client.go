@@ -2,9 +2,9 @@ package incapsula
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"io"
 	"io/ioutil"
 	"log"
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -20,21 +21,29 @@ import (
 const contentTypeApplicationUrlEncoded = "application/x-www-form-urlencoded"
 const contentTypeApplicationJson = "application/json"
 
-const durationOfRetriesInSeconds = 30
-
 // Client represents an internal client that brokers calls to the Incapsula API
 type Client struct {
 	config          *Config
 	httpClient      *http.Client
 	providerVersion string
 	accountStatus   *AccountStatusResponse
+	limiter         *requestLimiter
+	siteLocks       *keyedMutex
+	credentials     *credentialProcess
 }
 
 // NewClient creates a new client with the provided configuration
-func NewClient(config *Config) *Client {
-	client := &http.Client{}
+func NewClient(config *Config) (*Client, error) {
+	client, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+	if cassettes := cassetteTransportFromEnv(client.Transport); cassettes != nil {
+		client.Transport = cassettes
+	}
+	installLogRedaction(config.LogMetadataOnly)
 
-	return &Client{config: config, httpClient: client, providerVersion: "3.35.1"}
+	return &Client{config: config, httpClient: client, providerVersion: "3.35.1", limiter: newRequestLimiter(config), siteLocks: newKeyedMutex()}, nil
 }
 
 func (c *Client) CreateFormDataBody(bodyMap map[string]interface{}) ([]byte, string) {
@@ -67,13 +76,14 @@ func (c *Client) CreateFormDataBody(bodyMap map[string]interface{}) ([]byte, str
 }
 
 // Verify checks the API credentials
-func (c *Client) Verify() (*AccountStatusResponse, error) {
+func (c *Client) Verify(ctx context.Context) (*AccountStatusResponse, error) {
 	log.Println("[INFO] Checking API credentials against Incapsula API")
 
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountStatus)
 	data := url.Values{}
 
-	resp, err := c.PostFormWithHeaders(reqURL, data, VerifyAccount)
+	// The credentials are checked against their own account, whatever the default_account_id is
+	resp, err := c.PostFormWithHeaders(contextWithAccountID(ctx, 0), reqURL, data, VerifyAccount)
 	if err != nil {
 		return nil, fmt.Errorf("Error checking account: %s", err)
 	}
@@ -102,14 +112,14 @@ func (c *Client) Verify() (*AccountStatusResponse, error) {
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return &accountStatusResponse, fmt.Errorf("Error from Incapsula service when checking account: %s", string(responseBody))
+		return &accountStatusResponse, fmt.Errorf("Error from Incapsula service when checking account: %w", NewAPIError(resp, responseBody))
 	}
 
 	return &accountStatusResponse, nil
 }
 
-func (c *Client) PostFormWithHeaders(url string, data url.Values, operation string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(data.Encode()))
+func (c *Client) PostFormWithHeaders(ctx context.Context, url string, data url.Values, operation string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("Error preparing request: %s", err)
 	}
@@ -118,12 +128,12 @@ func (c *Client) PostFormWithHeaders(url string, data url.Values, operation stri
 	return c.executeRequest(req)
 }
 
-func (c *Client) GetWithHeaders(url string, queryParams url.Values, operation string) (*http.Response, error) {
+func (c *Client) GetWithHeaders(ctx context.Context, url string, queryParams url.Values, operation string) (*http.Response, error) {
 	reqURL := url
 	if len(queryParams) > 0 {
 		reqURL = fmt.Sprintf("%s?%s", url, queryParams.Encode())
 	}
-	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Error preparing request: %s", err)
 	}
@@ -132,8 +142,8 @@ func (c *Client) GetWithHeaders(url string, queryParams url.Values, operation st
 	return c.executeRequest(req)
 }
 
-func (c *Client) DoJsonRequestWithCustomHeaders(method string, url string, data []byte, headers map[string]string, operation string) (*http.Response, error) {
-	req, err := PrepareJsonRequest(method, url, data)
+func (c *Client) DoJsonRequestWithCustomHeaders(ctx context.Context, method string, url string, data []byte, headers map[string]string, operation string) (*http.Response, error) {
+	req, err := PrepareJsonRequest(ctx, method, url, data)
 	if err != nil {
 		return nil, fmt.Errorf("Error preparing request: %s", err)
 	}
@@ -143,12 +153,12 @@ func (c *Client) DoJsonRequestWithCustomHeaders(method string, url string, data
 	return c.executeRequest(req)
 }
 
-func (c *Client) DoJsonRequestWithHeaders(method string, url string, data []byte, operation string) (*http.Response, error) {
-	return c.DoJsonRequestWithCustomHeaders(method, url, data, nil, operation)
+func (c *Client) DoJsonRequestWithHeaders(ctx context.Context, method string, url string, data []byte, operation string) (*http.Response, error) {
+	return c.DoJsonRequestWithCustomHeaders(ctx, method, url, data, nil, operation)
 }
 
-func (c *Client) DoJsonAndQueryParamsRequestWithHeaders(method string, url string, data []byte, params map[string]string, operation string) (*http.Response, error) {
-	req, err := PrepareJsonRequest(method, url, data)
+func (c *Client) DoJsonAndQueryParamsRequestWithHeaders(ctx context.Context, method string, url string, data []byte, params map[string]string, operation string) (*http.Response, error) {
+	req, err := PrepareJsonRequest(ctx, method, url, data)
 	if err != nil {
 		return nil, fmt.Errorf("Error preparing request: %s", err)
 	}
@@ -174,8 +184,100 @@ func GetRequestParamsWithCaid(accountId int) map[string]string {
 	return params
 }
 
-func (c *Client) DoFormDataRequestWithHeaders(method string, url string, data []byte, contentType string, operation string) (*http.Response, error) {
-	req, err := PrepareJsonRequest(method, url, data)
+type accountIDContextKey struct{}
+
+// contextWithAccountID returns a context whose requests are sent with the given caid, overriding the provider default_account_id.
+// An account ID of 0 sends the requests without caid, on behalf of the account of the API credentials
+func contextWithAccountID(ctx context.Context, accountID int) context.Context {
+	return context.WithValue(ctx, accountIDContextKey{}, accountID)
+}
+
+// siteEndpoint matches the site level endpoints of the APIv1 (sites/...), v2 and v3 (/sites/...), which take caid.
+// The account management endpoints (accounts, sub accounts, users, account policies) are not site level
+var siteEndpoint = regexp.MustCompile(`(^|/)sites(/|$)`)
+
+// applyAccountID adds the caid query param to the site level requests that don't target an account yet.
+// The account of the context takes precedence over the provider default_account_id
+func (c *Client) applyAccountID(req *http.Request) {
+	accountID := 0
+	if c.config != nil {
+		accountID = c.config.DefaultAccountID
+	}
+	if contextAccountID, ok := req.Context().Value(accountIDContextKey{}).(int); ok {
+		accountID = contextAccountID
+	}
+	if accountID == 0 || !siteEndpoint.MatchString(req.URL.Path) || requestHasAccountID(req) {
+		return
+	}
+
+	query := req.URL.Query()
+	query.Set("caid", strconv.Itoa(accountID))
+	req.URL.RawQuery = query.Encode()
+}
+
+// requestHasAccountID reports whether the request already targets an account, with the caid query param, the account_id
+// form field of the APIv1, or an account field of a JSON body
+func requestHasAccountID(req *http.Request) bool {
+	if req.URL.Query().Get("caid") != "" {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+
+	switch {
+	case strings.HasPrefix(req.Header.Get("Content-Type"), contentTypeApplicationUrlEncoded):
+		values, err := url.ParseQuery(string(data))
+		return err == nil && values.Get("account_id") != ""
+	case strings.HasPrefix(req.Header.Get("Content-Type"), contentTypeApplicationJson):
+		return jsonHasAccountID(data)
+	}
+	return false
+}
+
+// jsonHasAccountID reports whether a JSON body, or its JSON:API data, holds an accountId, account_id or caid field
+func jsonHasAccountID(data []byte) bool {
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return false
+	}
+	objects := []interface{}{body}
+	if object, ok := body.(map[string]interface{}); ok {
+		objects = append(objects, object["data"])
+	}
+	if items, ok := body.([]interface{}); ok {
+		objects = items
+	}
+	for len(objects) > 0 {
+		value := objects[0]
+		objects = objects[1:]
+		switch value := value.(type) {
+		case []interface{}:
+			objects = append(objects, value...)
+		case map[string]interface{}:
+			for key, field := range value {
+				switch strings.ToLower(key) {
+				case "accountid", "account_id", "caid":
+					if field != nil && fmt.Sprint(field) != "" && fmt.Sprint(field) != "0" {
+						return true
+					}
+				case "attributes":
+					objects = append(objects, field)
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (c *Client) DoFormDataRequestWithHeaders(ctx context.Context, method string, url string, data []byte, contentType string, operation string) (*http.Response, error) {
+	req, err := PrepareJsonRequest(ctx, method, url, data)
 	if err != nil {
 		return nil, fmt.Errorf("Error preparing request: %s", err)
 	}
@@ -184,12 +286,12 @@ func (c *Client) DoFormDataRequestWithHeaders(method string, url string, data []
 	return c.executeRequest(req)
 }
 
-func PrepareJsonRequest(method string, url string, data []byte) (*http.Request, error) {
+func PrepareJsonRequest(ctx context.Context, method string, url string, data []byte) (*http.Request, error) {
 	if data == nil {
-		return http.NewRequest(method, url, nil)
+		return http.NewRequestWithContext(ctx, method, url, nil)
 	}
 
-	return http.NewRequest(method, url, bytes.NewReader(data))
+	return http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
 }
 
 func SetHeaders(c *Client, req *http.Request, contentType string, operation string, customHeaders map[string]string) {
@@ -208,26 +310,14 @@ func SetHeaders(c *Client, req *http.Request, contentType string, operation stri
 	}
 }
 
-func (c *Client) executeRequest(req *http.Request) (*http.Response, error) {
-	//if "read" action then we want to allow retries in case of timeout from incapsula service
-	operation := req.Header.Get("x-tf-operation")
-	if req.Method == http.MethodGet || (req.Method == http.MethodPost && strings.HasPrefix(strings.ToLower(operation), "read")) {
-		var responseOnRequest *http.Response
-		var errorOnRequest error
-		resource.Retry(durationOfRetriesInSeconds*time.Second, func() *resource.RetryError {
-			responseOnRequest, errorOnRequest = c.httpClient.Do(req)
-			if errorOnRequest != nil {
-				log.Printf("[ERROR] Error from Incapsula service when reading resource")
-				return resource.NonRetryableError(errorOnRequest)
-			}
-			if responseOnRequest.StatusCode == 502 {
-				log.Printf("[WARN] Error from Incapsula service when reading resource, performing retry")
-				return resource.RetryableError(fmt.Errorf("error code 502 from incapsula service when reading resource, performing retry"))
-			}
-			return nil
-		})
-		return responseOnRequest, errorOnRequest
+// sleepWithContext waits for the given duration, returning early with an error if the context is done
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	//if not a "read" request  - don't do retries (retires for updates are risky and result could be non-deterministic)
-	return c.httpClient.Do(req)
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -17,15 +18,15 @@ func (c *Client) AbpTerraformUrl(accountId int) string {
 	return fmt.Sprintf("%s/botmanagement/v1/account/%d/terraform", c.config.BaseURLAPI, accountId)
 }
 
-func (c *Client) CreateAbpWebsites(accountId int, account AbpTerraformAccount) (*AbpTerraformAccount, diag.Diagnostics) {
-	return c.RequestAbpWebsitesWithBody(accountId, account, http.MethodPost, CreateAbpWebsites, "Creating", http.StatusCreated)
+func (c *Client) CreateAbpWebsites(ctx context.Context, accountId int, account AbpTerraformAccount) (*AbpTerraformAccount, diag.Diagnostics) {
+	return c.RequestAbpWebsitesWithBody(ctx, accountId, account, http.MethodPost, CreateAbpWebsites, "Creating", http.StatusCreated)
 }
 
-func (c *Client) UpdateAbpWebsites(accountId int, account AbpTerraformAccount) (*AbpTerraformAccount, diag.Diagnostics) {
-	return c.RequestAbpWebsitesWithBody(accountId, account, http.MethodPut, UpdateAbpWebsites, "Updating", http.StatusOK)
+func (c *Client) UpdateAbpWebsites(ctx context.Context, accountId int, account AbpTerraformAccount) (*AbpTerraformAccount, diag.Diagnostics) {
+	return c.RequestAbpWebsitesWithBody(ctx, accountId, account, http.MethodPut, UpdateAbpWebsites, "Updating", http.StatusOK)
 }
 
-func (c *Client) RequestAbpWebsitesWithBody(accountId int, account AbpTerraformAccount, method string, operation string, action string, successStatus int) (*AbpTerraformAccount, diag.Diagnostics) {
+func (c *Client) RequestAbpWebsitesWithBody(ctx context.Context, accountId int, account AbpTerraformAccount, method string, operation string, action string, successStatus int) (*AbpTerraformAccount, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] %s Abp websites Account ID %d\n", action, accountId)
 
@@ -44,7 +45,7 @@ func (c *Client) RequestAbpWebsitesWithBody(accountId int, account AbpTerraformA
 
 	// Post form to Incapsula
 	reqURL := c.AbpTerraformUrl(accountId)
-	resp, err := c.DoJsonRequestWithHeaders(method, reqURL, accountJson, UpdateAbpWebsites)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, method, reqURL, accountJson, UpdateAbpWebsites)
 	if err != nil {
 		diags = append(diags, httpErrorDiagnostic(err, resourceName, accountId, method, action))
 		return nil, diags
@@ -79,15 +80,15 @@ func (c *Client) RequestAbpWebsitesWithBody(accountId int, account AbpTerraformA
 	return &newAbpWebsites, diags
 }
 
-func (c *Client) ReadAbpWebsites(accountId int) (*AbpTerraformAccount, diag.Diagnostics) {
-	return c.RequestAbpWebsites(accountId, false, http.MethodGet, ReadAbpWebsites, "Reading", http.StatusOK)
+func (c *Client) ReadAbpWebsites(ctx context.Context, accountId int) (*AbpTerraformAccount, diag.Diagnostics) {
+	return c.RequestAbpWebsites(ctx, accountId, false, http.MethodGet, ReadAbpWebsites, "Reading", http.StatusOK)
 }
 
-func (c *Client) DeleteAbpWebsites(accountId int, autoPublish bool) (*AbpTerraformAccount, diag.Diagnostics) {
-	return c.RequestAbpWebsites(accountId, autoPublish, http.MethodDelete, DeleteAbpWebsites, "Deleting", http.StatusOK)
+func (c *Client) DeleteAbpWebsites(ctx context.Context, accountId int, autoPublish bool) (*AbpTerraformAccount, diag.Diagnostics) {
+	return c.RequestAbpWebsites(ctx, accountId, autoPublish, http.MethodDelete, DeleteAbpWebsites, "Deleting", http.StatusOK)
 }
 
-func (c *Client) RequestAbpWebsites(accountId int, autoPublish bool, method string, operation string, action string, successStatus int) (*AbpTerraformAccount, diag.Diagnostics) {
+func (c *Client) RequestAbpWebsites(ctx context.Context, accountId int, autoPublish bool, method string, operation string, action string, successStatus int) (*AbpTerraformAccount, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] %s Abp websites Account ID %d\n", action, accountId)
 
@@ -98,7 +99,7 @@ func (c *Client) RequestAbpWebsites(accountId int, autoPublish bool, method stri
 	} else {
 		reqURL = c.AbpTerraformUrl(accountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(method, reqURL, nil, operation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, method, reqURL, nil, operation)
 	if err != nil {
 		diags = append(diags, httpErrorDiagnostic(err, resourceName, accountId, method, action))
 		return nil, diags
@@ -150,11 +151,10 @@ func httpBodyErrorDiagnostic(err error, resourceName string, accountId int, meth
 }
 
 func httpStatusErrorDiagnostic(err error, resourceName string, accountId int, method string, action string, resp *http.Response, responseBody []byte) diag.Diagnostic {
-	return diag.Diagnostic{
-		Severity: diag.Error,
-		Summary:  fmt.Sprintf("Failure %s %s", action, resourceName),
-		Detail:   fmt.Sprintf("Error status code %d from Incapsula service when %s %s for Account ID %d: %s", resp.StatusCode, strings.ToLower(action), resourceName, accountId, string(responseBody)),
-	}
+	return apiErrorDiagnostics(
+		fmt.Sprintf("Failure %s %s", action, resourceName),
+		fmt.Sprintf("Error status code %d from Incapsula service when %s %s for Account ID %d", resp.StatusCode, strings.ToLower(action), resourceName, accountId),
+		resp, responseBody)[0]
 }
 
 func jsonErrorDiagnostic(err error, resourceName string, accountId int, method string, responseBody []byte) diag.Diagnostic {
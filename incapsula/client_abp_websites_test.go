@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -15,7 +16,7 @@ func TestClientAbpWebsitesReadBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.ReadAbpWebsites(accountId)
+	abpWebsitesResponse, diags := client.ReadAbpWebsites(context.Background(), accountId)
 	if len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -36,7 +37,7 @@ func TestClientAbpWebsitesReadBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.ReadAbpWebsites(accountId)
+	abpWebsitesResponse, diags := client.ReadAbpWebsites(context.Background(), accountId)
 	if len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -58,7 +59,7 @@ func TestClientAbpWebsitesReadBadRequest(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.ReadAbpWebsites(accountId)
+	abpWebsitesResponse, diags := client.ReadAbpWebsites(context.Background(), accountId)
 	if len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -105,7 +106,7 @@ func TestClientAbpWebsitesReadOk(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.ReadAbpWebsites(accountId)
+	abpWebsitesResponse, diags := client.ReadAbpWebsites(context.Background(), accountId)
 	if len(diags) != 0 {
 		t.Errorf("Should not have received an error %+v", diags)
 	}
@@ -119,7 +120,7 @@ func TestClientAbpWebsitesCreateBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.CreateAbpWebsites(accountId, AbpTerraformAccount{})
+	abpWebsitesResponse, diags := client.CreateAbpWebsites(context.Background(), accountId, AbpTerraformAccount{})
 	if len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -141,7 +142,7 @@ func TestClientAbpWebsitesCreateBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.CreateAbpWebsites(accountId, AbpTerraformAccount{})
+	abpWebsitesResponse, diags := client.CreateAbpWebsites(context.Background(), accountId, AbpTerraformAccount{})
 	if len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -163,7 +164,7 @@ func TestClientAbpWebsitesCreateBadRequest(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.CreateAbpWebsites(accountId, AbpTerraformAccount{})
+	abpWebsitesResponse, diags := client.CreateAbpWebsites(context.Background(), accountId, AbpTerraformAccount{})
 	if len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -211,7 +212,7 @@ func TestClientAbpWebsitesCreateOk(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 1234
-	abpWebsitesResponse, diags := client.CreateAbpWebsites(accountId, abpWebsites)
+	abpWebsitesResponse, diags := client.CreateAbpWebsites(context.Background(), accountId, abpWebsites)
 	if len(diags) != 0 {
 		t.Errorf("Should not have received an error %+v", diags)
 		return
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -92,7 +93,7 @@ type AccountStatusResponse struct {
 }
 
 // AddAccount adds an account to be managed by Incapsula
-func (c *Client) AddAccount(email, refID, userName, planID, accountName, logLevel string, logsAccountID int, parentID int) (*AccountAddResponse, error) {
+func (c *Client) AddAccount(ctx context.Context, email, refID, userName, planID, accountName, logLevel string, logsAccountID int, parentID int) (*AccountAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula account for email: %s (account ID %d)\n", email, parentID)
 
 	values := url.Values{
@@ -114,7 +115,7 @@ func (c *Client) AddAccount(email, refID, userName, planID, accountName, logLeve
 	}
 
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountAdd)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateAccount)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateAccount)
 	if err != nil {
 		return nil, fmt.Errorf("Error adding account for email %s: %s", email, err)
 	}
@@ -135,20 +136,20 @@ func (c *Client) AddAccount(email, refID, userName, planID, accountName, logLeve
 
 	// Look at the response status code from Incapsula
 	if accountAddResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding account for email %s: %s", email, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when adding account for email %s: %w", email, NewAPIError(resp, responseBody))
 	}
 
 	return &accountAddResponse, nil
 }
 
 // AccountStatus gets the Incapsula managed account's status
-func (c *Client) AccountStatus(accountID int, operation string) (*AccountStatusResponse, error) {
+func (c *Client) AccountStatus(ctx context.Context, accountID int, operation string) (*AccountStatusResponse, error) {
 	log.Printf("[INFO] Getting Incapsula account status for account id: %d\n", accountID)
 
 	// Post form to Incapsula
 	values := url.Values{"account_id": {strconv.Itoa(accountID)}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountStatus)
-	resp, err := c.PostFormWithHeaders(reqURL, values, operation)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, operation)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting account status for account id %d: %s", accountID, err)
 	}
@@ -177,7 +178,7 @@ func (c *Client) AccountStatus(accountID int, operation string) (*AccountStatusR
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return &accountStatusResponse, fmt.Errorf("Error from Incapsula service when getting account status for account id %d: %s", accountID, string(responseBody))
+		return &accountStatusResponse, fmt.Errorf("Error from Incapsula service when getting account status for account id %d: %w", accountID, NewAPIError(resp, responseBody))
 	}
 
 	// Convert inactivity timeout from millis to minutes
@@ -190,7 +191,7 @@ func (c *Client) AccountStatus(accountID int, operation string) (*AccountStatusR
 }
 
 // UpdateAccount will update the specific param/value on the account resource
-func (c *Client) UpdateAccount(accountID, param, value string) (*AccountUpdateResponse, error) {
+func (c *Client) UpdateAccount(ctx context.Context, accountID, param, value string) (*AccountUpdateResponse, error) {
 	log.Printf("[INFO] Updating Incapsula account for accountID: %s. Param: %s. Value: %s\n", accountID, param, value)
 
 	// Convert inactivity timeout from minutes to millis
@@ -208,7 +209,7 @@ func (c *Client) UpdateAccount(accountID, param, value string) (*AccountUpdateRe
 		"value":      {value},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountUpdate)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateAccount)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateAccount)
 	if err != nil {
 		return nil, fmt.Errorf("Error updating param (%s) with value (%s) on account_id: %s: %s", param, value, accountID, err)
 	}
@@ -229,14 +230,14 @@ func (c *Client) UpdateAccount(accountID, param, value string) (*AccountUpdateRe
 
 	// Look at the response status code from Incapsula
 	if accountUpdateResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating account for accountID %s: %s", accountID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when updating account for accountID %s: %w", accountID, NewAPIError(resp, responseBody))
 	}
 
 	return &accountUpdateResponse, nil
 }
 
 // DeleteAccount deletes a account currently managed by Incapsula
-func (c *Client) DeleteAccount(accountID int) error {
+func (c *Client) DeleteAccount(ctx context.Context, accountID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type AccountDeleteResponse struct {
@@ -249,7 +250,7 @@ func (c *Client) DeleteAccount(accountID int) error {
 	// Post form to Incapsula
 	values := url.Values{"account_id": {strconv.Itoa(accountID)}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountDelete)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteAccount)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteAccount)
 	if err != nil {
 		return fmt.Errorf("Error deleting account id: %d: %s", accountID, err)
 	}
@@ -270,7 +271,7 @@ func (c *Client) DeleteAccount(accountID int) error {
 
 	// Look at the response status code from Incapsula
 	if accountDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting account id: %d: %s", accountID, string(responseBody))
+		return fmt.Errorf("Error from Incapsula service when deleting account id: %d: %w", accountID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -22,13 +23,13 @@ type AccountDataStorageRegionResponse struct {
 }
 
 // GetAccountDataStorageRegion gets the default data storage region for sites in the account
-func (c *Client) GetAccountDataStorageRegion(accountID string) (*AccountDataStorageRegionResponse, error) {
+func (c *Client) GetAccountDataStorageRegion(ctx context.Context, accountID string) (*AccountDataStorageRegionResponse, error) {
 	log.Printf("[INFO] Getting default Incapsula data storage region for account: %s\n", accountID)
 
 	// Post form to Incapsula
 	values := url.Values{"account_id": {accountID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountDataStorageRegionGet)
-	resp, err := c.PostFormWithHeaders(reqURL, values, ReadAccountDataStorageRegion)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadAccountDataStorageRegion)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting default data storage region for account id: %s: %s", accountID, err)
 	}
@@ -49,14 +50,14 @@ func (c *Client) GetAccountDataStorageRegion(accountID string) (*AccountDataStor
 
 	// Look at the response status code from Incapsula
 	if accountDataStorageRegionResponse.Res != 0 {
-		return &accountDataStorageRegionResponse, fmt.Errorf("Error from Incapsula service when getting default data storage region for account id: %s: %s", accountID, string(responseBody))
+		return &accountDataStorageRegionResponse, fmt.Errorf("Error from Incapsula service when getting default data storage region for account id: %s: %w", accountID, NewAPIError(resp, responseBody))
 	}
 
 	return &accountDataStorageRegionResponse, nil
 }
 
 // UpdateAccountDataStorageRegion will update the default data storage region on the account
-func (c *Client) UpdateAccountDataStorageRegion(accountID, region string) (*AccountDataStorageRegionResponse, error) {
+func (c *Client) UpdateAccountDataStorageRegion(ctx context.Context, accountID, region string) (*AccountDataStorageRegionResponse, error) {
 	log.Printf("[INFO] Updating Incapsula default data storage region (%s) for accountID: %s\n", region, accountID)
 
 	// Post form to Incapsula
@@ -65,7 +66,7 @@ func (c *Client) UpdateAccountDataStorageRegion(accountID, region string) (*Acco
 		"data_storage_region": {region},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointAccountDataStorageRegionUpdate)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateAccountDataStorageRegion)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateAccountDataStorageRegion)
 	if err != nil {
 		return nil, fmt.Errorf("Error updating data storage region with value (%s) on account_id: %s: %s", region, accountID, err)
 	}
@@ -86,7 +87,7 @@ func (c *Client) UpdateAccountDataStorageRegion(accountID, region string) (*Acco
 
 	// Look at the response status code from Incapsula
 	if accountDataStorageRegionResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating default data storage region for accountID %s: %s", accountID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when updating default data storage region for accountID %s: %w", accountID, NewAPIError(resp, responseBody))
 	}
 
 	return &accountDataStorageRegionResponse, nil
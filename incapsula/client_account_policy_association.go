@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -20,12 +21,12 @@ type AccountPolicyAssociationV3 struct {
 }
 
 // GetAccountPolicyAssociation get the account policy association for the specified_account
-func (c *Client) GetAccountPolicyAssociation(accountId string) (*AccountPolicyAssociationV3, error) {
+func (c *Client) GetAccountPolicyAssociation(ctx context.Context, accountId string) (*AccountPolicyAssociationV3, error) {
 	log.Printf("[INFO] Getting Policy Association for account: %s\n", accountId)
 	//
 	// Get the association
 	reqURL := fmt.Sprintf("%s/policies/v3/accounts/associated-policies?caid=%s", c.config.BaseURLAPI, accountId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadPolicyAccountAssociatiation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadPolicyAccountAssociatiation)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading Policies Assocication for Account ID %s: %s", accountId, err)
 	}
@@ -39,7 +40,7 @@ func (c *Client) GetAccountPolicyAssociation(accountId string) (*AccountPolicyAs
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when reading Policy Association for Account ID %s: %s", resp.StatusCode, accountId, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when reading Policy Association for Account ID %s: %w", resp.StatusCode, accountId, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -49,13 +50,13 @@ func (c *Client) GetAccountPolicyAssociation(accountId string) (*AccountPolicyAs
 		return nil, fmt.Errorf("[ERROR] Error parsing Policies Association JSON response for Account ID %s: %s\nresponse: %s", accountId, err, string(responseBody))
 	}
 	if accountPolicyAssociationV3RequestResponse.Data == nil || len(accountPolicyAssociationV3RequestResponse.Data) == 0 {
-		return nil, fmt.Errorf("[ERROR] got epmty response for Account ID %s\nresponse: %s", accountId, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] got empty response for Account ID %s: %w", accountId, NewAPIError(resp, responseBody))
 	}
 	return &accountPolicyAssociationV3RequestResponse.Data[0], nil
 }
 
 // PatchAccountPolicyAssociation get the account policy association for the specified_account
-func (c *Client) PatchAccountPolicyAssociation(accountId string, availablePolicyIds []int, defaultNonMandatoryPolicyIds []int, wafPolicyIdStr string) (*AccountPolicyAssociationV3, error) {
+func (c *Client) PatchAccountPolicyAssociation(ctx context.Context, accountId string, availablePolicyIds []int, defaultNonMandatoryPolicyIds []int, wafPolicyIdStr string) (*AccountPolicyAssociationV3, error) {
 	log.Printf("[INFO] Setting Policy Association for account: %s, WAF Rules Policy: %s, Default non mandatory non distinct: %v\n", accountId, wafPolicyIdStr, defaultNonMandatoryPolicyIds)
 
 	//Build the policy association request data
@@ -92,7 +93,7 @@ func (c *Client) PatchAccountPolicyAssociation(accountId string, availablePolicy
 		log.Printf("[ERROR] Failed to create body for request %+v", accountPolicyAssociationV3RequestResponse)
 		return nil, err
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPatch, reqURL, byteJSON, UpdatePolicyAccountAssociatiation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPatch, reqURL, byteJSON, UpdatePolicyAccountAssociatiation)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when setting Policies Assocication for Account ID %s with body %+v: %s",
 			accountId, accountPolicyAssociationV3RequestResponse, err)
@@ -107,8 +108,8 @@ func (c *Client) PatchAccountPolicyAssociation(accountId string, availablePolicy
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when setting Policy Association for Account ID %s with body %+v: %s",
-			resp.StatusCode, accountId, accountPolicyAssociationV3RequestResponse, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when setting Policy Association for Account ID %s with body %+v: %w",
+			resp.StatusCode, accountId, accountPolicyAssociationV3RequestResponse, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -117,7 +118,7 @@ func (c *Client) PatchAccountPolicyAssociation(accountId string, availablePolicy
 		return nil, fmt.Errorf("[ERROR] Error parsing Policies Association JSON response for Account ID %s: %s\nresponse: %s", accountId, err, string(responseBody))
 	}
 	if accountPolicyAssociationV3RequestResponse.Data == nil || len(accountPolicyAssociationV3RequestResponse.Data) == 0 {
-		return nil, fmt.Errorf("[ERROR] got epmty response for Account ID %s\nresponse: %s", accountId, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] got empty response for Account ID %s: %w", accountId, NewAPIError(resp, responseBody))
 	}
 	return &accountPolicyAssociationV3RequestResponse.Data[0], nil
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -55,7 +56,7 @@ type RoleDetailsDTO struct {
 }
 
 // AddAccountRole Adds an Account Role to be managed by Incapsula
-func (c *Client) AddAccountRole(requestDTO RoleDetailsCreateDTO) (*RoleDetailsDTO, error) {
+func (c *Client) AddAccountRole(ctx context.Context, requestDTO RoleDetailsCreateDTO) (*RoleDetailsDTO, error) {
 	log.Printf("[INFO] Adding Incapsula account role %s (account ID %d)\n", requestDTO.RoleName, requestDTO.AccountId)
 
 	roleJSON, err := json.Marshal(requestDTO)
@@ -64,7 +65,7 @@ func (c *Client) AddAccountRole(requestDTO RoleDetailsCreateDTO) (*RoleDetailsDT
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURLAPI, endpointRoleAdd)
 	log.Printf("[INFO]  reqURL: %v\n", reqURL)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, roleJSON, CreateAccountRole)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, roleJSON, CreateAccountRole)
 	if err != nil {
 		return nil, fmt.Errorf("Error adding account role %s: %s", requestDTO.RoleName, err)
 	}
@@ -86,19 +87,19 @@ func (c *Client) AddAccountRole(requestDTO RoleDetailsCreateDTO) (*RoleDetailsDT
 
 	// Look at the response status code from Incapsula
 	if roleResponse.ErrorCode != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding account role: %s", string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when adding account role: %w", NewAPIError(resp, responseBody))
 	}
 
 	return &roleResponse, nil
 }
 
 // GetAccountRole - Retrieve the Account Role for a given role ID
-func (c *Client) GetAccountRole(roleId int) (*RoleDetailsDTO, error) {
+func (c *Client) GetAccountRole(ctx context.Context, roleId int) (*RoleDetailsDTO, error) {
 	log.Printf("[INFO] Getting Account Role (Id: %d)\n", roleId)
 
 	// Get request to Incapsula
 	reqURL := fmt.Sprintf("%s/%s/%d", c.config.BaseURLAPI, endpointRoleGet, roleId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadAccountRole)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadAccountRole)
 	if err != nil {
 		return nil, fmt.Errorf("Error executing get Account Role request for role with id %d: %s", roleId, err)
 	}
@@ -121,7 +122,7 @@ func (c *Client) GetAccountRole(roleId int) (*RoleDetailsDTO, error) {
 }
 
 // UpdateAccountRole - Update the Account Role for a given role ID
-func (c *Client) UpdateAccountRole(roleId int, requestDTO RoleDetailsBasicDTO) (*RoleDetailsDTO, error) {
+func (c *Client) UpdateAccountRole(ctx context.Context, roleId int, requestDTO RoleDetailsBasicDTO) (*RoleDetailsDTO, error) {
 	log.Printf("[INFO] Updating Incapsula account role (Id: %d)\n", roleId)
 
 	log.Printf("[INFO]  requestDTO: %+v\n", requestDTO)
@@ -132,7 +133,7 @@ func (c *Client) UpdateAccountRole(roleId int, requestDTO RoleDetailsBasicDTO) (
 	reqURL := fmt.Sprintf("%s/%s/%d", c.config.BaseURLAPI, endpointRoleUpdate, roleId)
 	log.Printf("[INFO]  reqURL: %v\n", reqURL)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, roleJSON, UpdateAccountRole)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, roleJSON, UpdateAccountRole)
 	if err != nil {
 		return nil, fmt.Errorf("Error updating account role with Id %d: %s", roleId, err)
 	}
@@ -154,19 +155,19 @@ func (c *Client) UpdateAccountRole(roleId int, requestDTO RoleDetailsBasicDTO) (
 
 	// Look at the response status code from Incapsula
 	if roleResponse.ErrorCode != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating account role: %s", string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when updating account role: %w", NewAPIError(resp, responseBody))
 	}
 
 	return &roleResponse, nil
 }
 
 // DeleteAccountRole - Delete the Account Role for a given role ID
-func (c *Client) DeleteAccountRole(roleId int) error {
+func (c *Client) DeleteAccountRole(ctx context.Context, roleId int) error {
 	log.Printf("[INFO] Delete Account Role (Id: %d)\n", roleId)
 
 	// Get request to Incapsula
 	reqURL := fmt.Sprintf("%s/%s/%d", c.config.BaseURLAPI, endpointRoleDelete, roleId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteAccountRole)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteAccountRole)
 	if err != nil {
 		return fmt.Errorf("Error executing delete Account Role request for role with id %d: %s", roleId, err)
 	}
@@ -189,12 +190,12 @@ func (c *Client) DeleteAccountRole(roleId int) error {
 }
 
 // GetAccountAbilities - Retrieve the Account Abilities for a given account ID
-func (c *Client) GetAccountAbilities(accountId int) (*[]RoleAbility, error) {
+func (c *Client) GetAccountAbilities(ctx context.Context, accountId int) (*[]RoleAbility, error) {
 	log.Printf("[INFO] Getting Account Abilities for account Id: %d\n", accountId)
 
 	// Get request to Incapsula
 	reqURL := fmt.Sprintf("%s/%s/%d", c.config.BaseURLAPI, endpointAbilitiesGet, accountId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadAccountAbilities)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadAccountAbilities)
 	if err != nil {
 		return nil, fmt.Errorf("Error executing get Account Abilities request for account with id %d: %s", accountId, err)
 	}
@@ -217,12 +218,12 @@ func (c *Client) GetAccountAbilities(accountId int) (*[]RoleAbility, error) {
 }
 
 // GetAccountRoles - Retrieve all the Roles for a given Account ID
-func (c *Client) GetAccountRoles(accountId int) (*[]RoleDetailsDTO, error) {
+func (c *Client) GetAccountRoles(ctx context.Context, accountId int) (*[]RoleDetailsDTO, error) {
 	log.Printf("[INFO] Getting Account Roles (Account Id: %d)\n", accountId)
 
 	// Get request to Incapsula
 	reqURL := fmt.Sprintf("%s/%s?accountId=%d", c.config.BaseURLAPI, endpointAccountRolesGet, accountId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadAccountRoles)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadAccountRoles)
 	if err != nil {
 		return nil, fmt.Errorf("Error executing get Account Roles request for account with id %d: %s", accountId, err)
 	}
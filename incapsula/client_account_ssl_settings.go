@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -47,7 +48,7 @@ type AccountSSLSettingsDTOResponse struct {
 }
 
 // UpdateAccountSSLSettings update account SSL settings
-func (c *Client) UpdateAccountSSLSettings(accountSSLSettingsDTO *AccountSSLSettingsDTO, accountId string) (*AccountSSLSettingsDTOResponse, diag.Diagnostics) {
+func (c *Client) UpdateAccountSSLSettings(ctx context.Context, accountSSLSettingsDTO *AccountSSLSettingsDTO, accountId string) (*AccountSSLSettingsDTOResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] updating account SSL settings to: %v ", accountSSLSettingsDTO)
 
@@ -61,7 +62,7 @@ func (c *Client) UpdateAccountSSLSettings(accountSSLSettingsDTO *AccountSSLSetti
 		})
 		return nil, diags
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, updateUrl, accountSSLSettingsDTOJSON, nil, UpdateAccountSSLSettings)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, updateUrl, accountSSLSettingsDTOJSON, nil, UpdateAccountSSLSettings)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -82,11 +83,10 @@ func (c *Client) UpdateAccountSSLSettings(accountSSLSettingsDTO *AccountSSLSetti
 	}
 	log.Printf("[DEBUG] Imperva update account SSL settings JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on update account SSL settings",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on update account SSL settings",
+			fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var accountSSLSettingsDTOResponse AccountSSLSettingsDTOResponse
@@ -106,12 +106,12 @@ func (c *Client) UpdateAccountSSLSettings(accountSSLSettingsDTO *AccountSSLSetti
 }
 
 // GetAccountSSLSettings gets the Incapsula managed account's status
-func (c *Client) GetAccountSSLSettings(accountId string) (*AccountSSLSettingsDTOResponse, diag.Diagnostics) {
+func (c *Client) GetAccountSSLSettings(ctx context.Context, accountId string) (*AccountSSLSettingsDTOResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] Getting account SSL settings of: %s ", accountId)
 
 	getUrl := getUrl(accountId, c.config.BaseURLAPI)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, getUrl, nil, nil, GetAccountSSLSettings)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, getUrl, nil, nil, GetAccountSSLSettings)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -134,11 +134,10 @@ func (c *Client) GetAccountSSLSettings(accountId string) (*AccountSSLSettingsDTO
 
 	log.Printf("[DEBUG] Imperva get account SSL settings for account %s response: %s\n", accountId, string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on getting account SSL settings",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on getting account SSL settings",
+			fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var accountSSLSettingsDTOResponse AccountSSLSettingsDTOResponse
@@ -156,12 +155,12 @@ func (c *Client) GetAccountSSLSettings(accountId string) (*AccountSSLSettingsDTO
 }
 
 // DeleteAccountSSLSettings gets the Incapsula managed account's status
-func (c *Client) DeleteAccountSSLSettings(accountId string) diag.Diagnostics {
+func (c *Client) DeleteAccountSSLSettings(ctx context.Context, accountId string) diag.Diagnostics {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] Reseting account SSL settings of: %s ", accountId)
 
 	getUrl := getUrl(accountId, c.config.BaseURLAPI)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, getUrl, nil, nil, DeleteAccountSSLSettings)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, getUrl, nil, nil, DeleteAccountSSLSettings)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -173,16 +172,16 @@ func (c *Client) DeleteAccountSSLSettings(accountId string) diag.Diagnostics {
 
 	// Read the body
 	defer resp.Body.Close()
+	responseBody, _ := ioutil.ReadAll(resp.Body)
 
-	log.Printf("[DEBUG] delete account SSL settings ended successfully for account id: %s", accountId)
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on update account SSL settings",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on update account SSL settings",
+			fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
+			resp, responseBody)...)
 		return diags
 	}
+	log.Printf("[DEBUG] delete account SSL settings ended successfully for account id: %s", accountId)
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -13,7 +14,7 @@ func TestClientUpdateAccountSSlSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	dto := AccountSSLSettingsDTO{}
-	updateAccountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(&dto, "")
+	updateAccountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(context.Background(), &dto, "")
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
@@ -40,8 +41,8 @@ func TestClientUpdateAccountSSlSettingsInternalError(t *testing.T) {
 	dto := AccountSSLSettingsDTO{
 		ImpervaCertificate: &imp,
 	}
-	_, diag := client.UpdateAccountSSLSettings(&dto, "")
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500, error") {
+	_, diag := client.UpdateAccountSSLSettings(context.Background(), &dto, "")
+	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500: error") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -64,7 +65,7 @@ func TestClientUpdateAccountSSlSettingsErrorsInBody(t *testing.T) {
 	dto := AccountSSLSettingsDTO{
 		ImpervaCertificate: &imp,
 	}
-	accountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(&dto, "")
+	accountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(context.Background(), &dto, "")
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -91,7 +92,7 @@ func TestClientUpdateAccountSSlSettingsDataInBody(t *testing.T) {
 	dto := AccountSSLSettingsDTO{
 		ImpervaCertificate: &imp,
 	}
-	accountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(&dto, "")
+	accountSSLSettingsResponse, diag := client.UpdateAccountSSLSettings(context.Background(), &dto, "")
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -111,7 +112,7 @@ func TestClientGetAccountSSlSettingsDataInBody(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	accountSSLSettingsResponse, diag := client.GetAccountSSLSettings("")
+	accountSSLSettingsResponse, diag := client.GetAccountSSLSettings(context.Background(), "")
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -132,7 +133,7 @@ func TestClientGetAccountSSlSettingsErrorsInBody(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	accountSSLSettingsResponse, diag := client.GetAccountSSLSettings("")
+	accountSSLSettingsResponse, diag := client.GetAccountSSLSettings(context.Background(), "")
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -152,8 +153,8 @@ func TestClientGetAccountSSlSettingsErrorFromMY(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, diag := client.GetAccountSSLSettings("")
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500, error") {
+	_, diag := client.GetAccountSSLSettings(context.Background(), "")
+	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500: error") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -161,7 +162,7 @@ func TestClientGetAccountSSlSettingsErrorFromMY(t *testing.T) {
 func TestClientGetAccountSSlSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	updateAccountSSLSettingsResponse, diag := client.GetAccountSSLSettings("")
+	updateAccountSSLSettingsResponse, diag := client.GetAccountSSLSettings(context.Background(), "")
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
@@ -173,7 +174,7 @@ func TestClientGetAccountSSlSettingsBadConnection(t *testing.T) {
 func TestClientDeleteAccountSSlSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	diag := client.DeleteAccountSSLSettings("")
+	diag := client.DeleteAccountSSLSettings(context.Background(), "")
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
@@ -190,7 +191,7 @@ func TestClientDeleteAccountSSlSettingsErrorFromMY(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	diag := client.DeleteAccountSSLSettings("")
+	diag := client.DeleteAccountSSLSettings(context.Background(), "")
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500") {
 		t.Errorf("Should have received an error")
 	}
@@ -206,7 +207,7 @@ func TestClientDeleteAccountSSlSettings200FromMY(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	diag := client.DeleteAccountSSLSettings("")
+	diag := client.DeleteAccountSSLSettings(context.Background(), "")
 	if diag != nil || diag.HasError() {
 		t.Errorf("Should not received an error")
 	}
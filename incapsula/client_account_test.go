@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientAddAccountBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	email := "example@example.com"
-	addAccountResponse, err := client.AddAccount(email, "", "", "", "", "", 0, 0)
+	addAccountResponse, err := client.AddAccount(context.Background(), email, "", "", "", "", "", 0, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientAddAccountBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	email := "example@example.com"
-	addAccountResponse, err := client.AddAccount(email, "", "", "", "", "", 0, 0)
+	addAccountResponse, err := client.AddAccount(context.Background(), email, "", "", "", "", "", 0, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientAddAccountInvalidParent(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	email := "example@example.com"
-	addAccountResponse, err := client.AddAccount(email, "", "", "", "", "", 0, 0)
+	addAccountResponse, err := client.AddAccount(context.Background(), email, "", "", "", "", "", 0, 0)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -89,7 +90,7 @@ func TestClientAddAccountValidParent(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	email := "example@example.com"
-	addAccountResponse, err := client.AddAccount(email, "", "", "", "", "", 0, 0)
+	addAccountResponse, err := client.AddAccount(context.Background(), email, "", "", "", "", "", 0, 0)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -112,7 +113,7 @@ func TestClientAccountStatusBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
-	accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := client.AccountStatus(context.Background(), accountID, ReadAccount)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -136,7 +137,7 @@ func TestClientAccountStatusBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := client.AccountStatus(context.Background(), accountID, ReadAccount)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -160,7 +161,7 @@ func TestClientAccountStatusInvalidAccount(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := client.AccountStatus(context.Background(), accountID, ReadAccount)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -184,7 +185,7 @@ func TestClientAccountStatusValidAccount(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := client.AccountStatus(context.Background(), accountID, ReadAccount)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -206,7 +207,7 @@ func TestClientUpdateAccountBadConnection(t *testing.T) {
 	accountID := "42"
 	param := "error_page_template"
 	value := "ABC123"
-	updateAccountResponse, err := client.UpdateAccount(accountID, param, value)
+	updateAccountResponse, err := client.UpdateAccount(context.Background(), accountID, param, value)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -230,7 +231,7 @@ func TestClientUpdateAccountBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "42"
-	updateAccountResponse, err := client.UpdateAccount(accountID, "", "")
+	updateAccountResponse, err := client.UpdateAccount(context.Background(), accountID, "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -254,7 +255,7 @@ func TestClientUpdateAccountInvalidAccount(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "42"
-	updateAccountResponse, err := client.UpdateAccount(accountID, "", "")
+	updateAccountResponse, err := client.UpdateAccount(context.Background(), accountID, "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -278,7 +279,7 @@ func TestClientUpdateAccountValidAccount(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := "42"
-	updateAccountResponse, err := client.UpdateAccount(accountID, "", "")
+	updateAccountResponse, err := client.UpdateAccount(context.Background(), accountID, "", "")
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -301,7 +302,7 @@ func TestClientDeleteAccountBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
-	err := client.DeleteAccount(accountID)
+	err := client.DeleteAccount(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -322,7 +323,7 @@ func TestClientDeleteAccountBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	err := client.DeleteAccount(accountID)
+	err := client.DeleteAccount(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -343,7 +344,7 @@ func TestClientDeleteAccountInvalidAccount(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	err := client.DeleteAccount(accountID)
+	err := client.DeleteAccount(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -364,7 +365,7 @@ func TestClientDeleteAccountValidAccount(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	err := client.DeleteAccount(accountID)
+	err := client.DeleteAccount(context.Background(), accountID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
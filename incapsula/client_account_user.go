@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -53,7 +54,7 @@ type UserUpdateReq struct {
 }
 
 // AddAccountUser adds a user to Incapsula Account
-func (c *Client) AddAccountUser(accountID int, email, firstName, lastName string, roleIds []interface{}) (*UserApisResponse, error) {
+func (c *Client) AddAccountUser(ctx context.Context, accountID int, email, firstName, lastName string, roleIds []interface{}) (*UserApisResponse, error) {
 	log.Printf("[INFO] Adding Incapsula account user for email: %s (account ID %d)\n", email, accountID)
 
 	listRoles := make([]int, len(roleIds))
@@ -70,7 +71,7 @@ func (c *Client) AddAccountUser(accountID int, email, firstName, lastName string
 
 	endpointUserAdd := endpointUserOperationNew
 	operation := CreateAccountUser
-	accountStatusResponse, err := c.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := c.AccountStatus(ctx, accountID, ReadAccount)
 	if accountStatusResponse != nil && accountStatusResponse.AccountType == "Sub Account" {
 		endpointUserAdd = endpointUserOperationNew + "/" + email
 		operation = CreateSubAccountUser
@@ -80,7 +81,7 @@ func (c *Client) AddAccountUser(accountID int, email, firstName, lastName string
 	log.Printf("[INFO] Values: %s\n", userJSON)
 	log.Printf("[INFO] Req: %s\n", reqURL)
 	log.Printf("[INFO] json: %s\n", userJSON)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, userJSON, operation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, userJSON, operation)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error adding user email %s: %s", email, err)
@@ -95,7 +96,7 @@ func (c *Client) AddAccountUser(accountID int, email, firstName, lastName string
 
 	// Look at the response status code from Incapsula
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding User %s: %s", resp.StatusCode, email, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding User %s: %w", resp.StatusCode, email, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -110,12 +111,12 @@ func (c *Client) AddAccountUser(accountID int, email, firstName, lastName string
 }
 
 // GetAccountUser gets the Incapsula user status
-func (c *Client) GetAccountUser(accountID int, email string) (*UserApisResponse, error) {
+func (c *Client) GetAccountUser(ctx context.Context, accountID int, email string) (*UserApisResponse, error) {
 	log.Printf("[INFO] Getting Incapsula user status for email id: %s\n", email)
 
 	// Get to Incapsula
 	reqURL := fmt.Sprintf("%s/%s/%s?caid=%d", c.config.BaseURLAPI, endpointUserOperationNew, email, accountID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadAccountUser)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadAccountUser)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error getting user %s: %s", email, err)
@@ -129,7 +130,7 @@ func (c *Client) GetAccountUser(accountID int, email string) (*UserApisResponse,
 	log.Printf("[DEBUG] Incapsula user status JSON response: %s\n", string(responseBody))
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when getting User %s: %s", resp.StatusCode, email, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when getting User %s: %w", resp.StatusCode, email, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -144,7 +145,7 @@ func (c *Client) GetAccountUser(accountID int, email string) (*UserApisResponse,
 }
 
 // UpdateAccountUser User Roles
-func (c *Client) UpdateAccountUser(accountID int, email string, roleIds []interface{}) (*UserApisUpdateResponse, error) {
+func (c *Client) UpdateAccountUser(ctx context.Context, accountID int, email string, roleIds []interface{}) (*UserApisUpdateResponse, error) {
 	log.Printf("[INFO] Update Incapsula User for email: %s (account ID %d)\n", email, accountID)
 	listRoles := make([]int, len(roleIds))
 	for i, v := range roleIds {
@@ -162,7 +163,7 @@ func (c *Client) UpdateAccountUser(accountID int, email string, roleIds []interf
 
 	log.Printf("[INFO] Req: %s\n", reqURL)
 	log.Printf("[INFO] json: %s\n", userJSON)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPatch, reqURL, userJSON, UpdateAccountUser)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPatch, reqURL, userJSON, UpdateAccountUser)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error updating user email %s: %s", email, err)
@@ -177,7 +178,7 @@ func (c *Client) UpdateAccountUser(accountID int, email string, roleIds []interf
 
 	// Look at the response status code from Incapsula
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating User %s: %s", resp.StatusCode, email, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating User %s: %w", resp.StatusCode, email, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -192,7 +193,7 @@ func (c *Client) UpdateAccountUser(accountID int, email string, roleIds []interf
 }
 
 // DeleteAccountUser deletes a user from Incapsula
-func (c *Client) DeleteAccountUser(accountID int, email string) error {
+func (c *Client) DeleteAccountUser(ctx context.Context, accountID int, email string) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type UserDeleteResponse struct {
@@ -205,7 +206,7 @@ func (c *Client) DeleteAccountUser(accountID int, email string) error {
 	// Delete form to Incapsula
 
 	reqURL := fmt.Sprintf("%s/%s/%s?caid=%d", c.config.BaseURLAPI, endpointUserOperationNew, email, accountID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteAccountUser)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteAccountUser)
 
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when deleting USER: %s %s", email, err)
@@ -219,7 +220,7 @@ func (c *Client) DeleteAccountUser(accountID int, email string) error {
 	log.Printf("[DEBUG] Incapsula delete user JSON response: %s\n", string(responseBody))
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting User %s: %s", resp.StatusCode, email, string(responseBody))
+		return fmt.Errorf("Error status code %d from Incapsula service when deleting User %s: %w", resp.StatusCode, email, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
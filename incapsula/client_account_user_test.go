@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -20,7 +21,7 @@ func TestClientAddUserBadConnection(t *testing.T) {
 
 	roleIds := make([]interface{}, 1)
 	roleIds[0] = 0
-	UserAddResponse, err := client.AddAccountUser(0, email, "", "", roleIds)
+	UserAddResponse, err := client.AddAccountUser(context.Background(), 0, email, "", "", roleIds)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -47,7 +48,7 @@ func TestClientAddUserBadJSON(t *testing.T) {
 	email := "example@example.com"
 	roleIds := make([]interface{}, 1)
 	roleIds[0] = 10
-	UserAddResponse, err := client.AddAccountUser(accountID, email, "f", "l", roleIds)
+	UserAddResponse, err := client.AddAccountUser(context.Background(), accountID, email, "f", "l", roleIds)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -68,7 +69,7 @@ func TestClientUserStatusBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
 	email := "example@example.com"
-	UserStatusResponse, err := client.GetAccountUser(accountID, email)
+	UserStatusResponse, err := client.GetAccountUser(context.Background(), accountID, email)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -93,7 +94,7 @@ func TestClientUserStatusBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	UserStatusResponse, err := client.GetAccountUser(accountID, email)
+	UserStatusResponse, err := client.GetAccountUser(context.Background(), accountID, email)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -114,7 +115,7 @@ func TestClientDeleteUserBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
 	email := "example@example.com"
-	err := client.DeleteAccountUser(accountID, email)
+	err := client.DeleteAccountUser(context.Background(), accountID, email)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -136,7 +137,7 @@ func TestClientDeleteUserBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	err := client.DeleteAccountUser(accountID, email)
+	err := client.DeleteAccountUser(context.Background(), accountID, email)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -156,7 +157,7 @@ func TestClientUpdateUserBadConnection(t *testing.T) {
 	email := "example@example.com"
 	roleIds := make([]interface{}, 1)
 	roleIds[0] = 10
-	updateUserResponse, err := client.UpdateAccountUser(accountID, email, roleIds)
+	updateUserResponse, err := client.UpdateAccountUser(context.Background(), accountID, email, roleIds)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -184,7 +185,7 @@ func TestClientUpdateUserBadJSON(t *testing.T) {
 	roleIds[0] = 10
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	updateUserResponse, err := client.UpdateAccountUser(accountID, email, roleIds)
+	updateUserResponse, err := client.UpdateAccountUser(context.Background(), accountID, email, roleIds)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -2,6 +2,7 @@ package incapsula
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -73,7 +74,7 @@ type ApiSecurityApiConfigDeleteResponse struct {
 	IsError bool   `json:"isError"`
 }
 
-func (c *Client) CreateApiSecurityApiConfig(siteId int64, apiConfigPayload *ApiSecurityApiConfigPostPayload) (*ApiSecurityApiConfigPostResponse, error) {
+func (c *Client) CreateApiSecurityApiConfig(ctx context.Context, siteId int64, apiConfigPayload *ApiSecurityApiConfigPostPayload) (*ApiSecurityApiConfigPostResponse, error) {
 	log.Printf("[INFO] Creating Incapsula API Security API Configuration for Site ID %d\\n", siteId)
 
 	body := &bytes.Buffer{}
@@ -136,7 +137,7 @@ func (c *Client) CreateApiSecurityApiConfig(siteId int64, apiConfigPayload *ApiS
 
 	reqURL := fmt.Sprintf("%s%s%d", c.config.BaseURLAPI, apiConfigUrl, siteId)
 	contentType := writer.FormDataContentType()
-	resp, err := c.DoFormDataRequestWithHeaders(http.MethodPost, reqURL, body.Bytes(), contentType, CreateApiSecApiConfig)
+	resp, err := c.DoFormDataRequestWithHeaders(ctx, http.MethodPost, reqURL, body.Bytes(), contentType, CreateApiSecApiConfig)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error adding API Security API Config for site %d: %s", siteId, err)
 	}
@@ -147,7 +148,7 @@ func (c *Client) CreateApiSecurityApiConfig(siteId int64, apiConfigPayload *ApiS
 	log.Printf("[DEBUG] Incapsula Create Api-Security API Config JSON response: %s\n", string(responseBody))
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service while creating API Security API Config for Site ID %d: %v", resp.StatusCode, siteId, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service while creating API Security API Config for Site ID %d: %w", resp.StatusCode, siteId, NewAPIError(resp, responseBody))
 	}
 	// Dump JSON
 	var apiAddResponse ApiSecurityApiConfigPostResponse
@@ -160,7 +161,7 @@ func (c *Client) CreateApiSecurityApiConfig(siteId int64, apiConfigPayload *ApiS
 }
 
 // UpdateApiSecurityApiConfig updates the Api-Security Api Config
-func (c *Client) UpdateApiSecurityApiConfig(siteId int64, apiId string, apiConfigPayload *ApiSecurityApiConfigPostPayload) (*ApiSecurityApiConfigPostResponse, error) {
+func (c *Client) UpdateApiSecurityApiConfig(ctx context.Context, siteId int64, apiId string, apiConfigPayload *ApiSecurityApiConfigPostPayload) (*ApiSecurityApiConfigPostResponse, error) {
 	log.Printf("[INFO] Updating Incapsula API Security API Configuration for Site ID %d, API Config ID %s\n", siteId, apiId)
 
 	bodyMap := map[string]interface{}{}
@@ -187,7 +188,7 @@ func (c *Client) UpdateApiSecurityApiConfig(siteId int64, apiId string, apiConfi
 
 	reqURL := fmt.Sprintf("%s%s%d/%s", c.config.BaseURLAPI, apiConfigUrl, siteId, apiId)
 
-	resp, err := c.DoFormDataRequestWithHeaders(http.MethodPost, reqURL, body, contentType, CreateMtlsClientToImpervaCertifiate)
+	resp, err := c.DoFormDataRequestWithHeaders(ctx, http.MethodPost, reqURL, body, contentType, CreateMtlsClientToImpervaCertifiate)
 
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error updating API Security API Config for site id %d, API id %s :%s", siteId, apiId, err)
@@ -203,9 +204,9 @@ func (c *Client) UpdateApiSecurityApiConfig(siteId int64, apiId string, apiConfi
 		responseText := string(responseBody)
 
 		if strings.Contains(responseText, "Updating the API was unsuccessful because the new API specification contains fields, as indicated below, that do not match the existing API specification.") {
-			return nil, fmt.Errorf("Error from Incapsula service while updating API Security API for siteId %d, API id %s : %s. \nPlease, run the following terraform command: terraform destroy -target api_security_api_config.your_resource_name\nThen try to apply changes again", siteId, apiId, responseText)
+			return nil, fmt.Errorf("Error from Incapsula service while updating API Security API for siteId %d, API id %s. \nPlease, run the following terraform command: terraform destroy -target api_security_api_config.your_resource_name\nThen try to apply changes again: %w", siteId, apiId, NewAPIError(resp, responseBody))
 		}
-		return nil, fmt.Errorf("Error from Incapsula service while updating API Security API for siteId %d, API id %s : %s", siteId, apiId, responseText)
+		return nil, fmt.Errorf("Error from Incapsula service while updating API Security API for siteId %d, API id %s : %w", siteId, apiId, NewAPIError(resp, responseBody))
 	}
 	// Dump JSON
 	var apiAddResponse ApiSecurityApiConfigPostResponse
@@ -217,11 +218,11 @@ func (c *Client) UpdateApiSecurityApiConfig(siteId int64, apiId string, apiConfi
 }
 
 // GetApiSecurityApiConfig gets the Api-Security Api Config
-func (c *Client) GetApiSecurityApiConfig(siteId int64, apiId int64) (*ApiSecurityApiConfigGetResponse, error) {
+func (c *Client) GetApiSecurityApiConfig(ctx context.Context, siteId int64, apiId int64) (*ApiSecurityApiConfigGetResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Api-Security API Config for Site ID %d, API Config ID %d\n", siteId, apiId)
 
 	url := fmt.Sprintf("%s%s%d/%d", c.config.BaseURLAPI, apiConfigUrl, siteId, apiId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, url, nil, ReadApiSecApiConfig)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, url, nil, ReadApiSecApiConfig)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", apiId, err)
 	}
@@ -233,7 +234,7 @@ func (c *Client) GetApiSecurityApiConfig(siteId int64, apiId int64) (*ApiSecurit
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", resp.StatusCode, apiId, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Api Config for Api ID %d: %w", resp.StatusCode, apiId, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -246,11 +247,11 @@ func (c *Client) GetApiSecurityApiConfig(siteId int64, apiId int64) (*ApiSecurit
 }
 
 // GetApiSecurityApiSwaggerConfig gets the Api-Security  API Config Swagger file content
-func (c *Client) GetApiSecurityApiSwaggerConfig(siteId int64, apiId int64) (*ApiSecurityApiConfigGetFileResponse, error) {
+func (c *Client) GetApiSecurityApiSwaggerConfig(ctx context.Context, siteId int64, apiId int64) (*ApiSecurityApiConfigGetFileResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Api-Security API Swagger Config for Site ID %d, API Config ID %d\n", siteId, apiId)
 
 	url := fmt.Sprintf("%s%sfile/%d/%d", c.config.BaseURLAPI, apiConfigUrl, siteId, apiId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, url, nil, "")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, url, nil, "")
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", apiId, err)
 	}
@@ -262,7 +263,7 @@ func (c *Client) GetApiSecurityApiSwaggerConfig(siteId int64, apiId int64) (*Api
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Api Config for Api ID %d: %s", resp.StatusCode, apiId, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Api Config for Api ID %d: %w", resp.StatusCode, apiId, NewAPIError(resp, responseBody))
 	}
 
 	// Dump JSON
@@ -275,12 +276,12 @@ func (c *Client) GetApiSecurityApiSwaggerConfig(siteId int64, apiId int64) (*Api
 }
 
 // DeleteApiSecurityApiConfig deletes the Api-Security Api + endpoints Config
-func (c *Client) DeleteApiSecurityApiConfig(siteID int64, apiID string) error {
+func (c *Client) DeleteApiSecurityApiConfig(ctx context.Context, siteID int64, apiID string) error {
 	log.Printf("[INFO] Deleting Incapsula API Security API for ID %s\n", apiID)
 
 	// Delete request to Incapsula
 	reqURL := fmt.Sprintf("%s%s%d/%s", c.config.BaseURLAPI, apiConfigUrl, siteID, apiID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteApiSecApiConfig)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteApiSecApiConfig)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting API Secirity API Config with Site ID %d, API ID %s, : %s", siteID, apiID, err)
 	}
@@ -291,7 +292,7 @@ func (c *Client) DeleteApiSecurityApiConfig(siteID int64, apiID string) error {
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service when deleting API Security API Config for Site ID %d, API Config ID %s: %s", resp.StatusCode, siteID, apiID, string(responseBody))
+		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service when deleting API Security API Config for Site ID %d, API Config ID %s: %w", resp.StatusCode, siteID, apiID, NewAPIError(resp, responseBody))
 	}
 	// Dump JSON
 	var apiSecurityApiConfigDeleteResponse ApiSecurityApiConfigDeleteResponse
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestGetApiSecurityApiConfigBadConnection(t *testing.T) {
 	siteID := int64(42)
 	apiID := int64(100)
 
-	apiConfigGetResponse, err := client.GetApiSecurityApiConfig(siteID, apiID)
+	apiConfigGetResponse, err := client.GetApiSecurityApiConfig(context.Background(), siteID, apiID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -49,7 +50,7 @@ func TestGetApiSecurityApiConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.GetApiSecurityApiConfig(siteID, apiConfigID)
+	apiConfigGetResponse, err := client.GetApiSecurityApiConfig(context.Background(), siteID, apiConfigID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -84,7 +85,7 @@ func TestGetApiSecurityApiConfigInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.GetApiSecurityApiConfig(siteID, apiConfigID)
+	apiConfigGetResponse, err := client.GetApiSecurityApiConfig(context.Background(), siteID, apiConfigID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -138,7 +139,7 @@ func TestGetApiSecurityApiConfigValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.GetApiSecurityApiConfig(siteID, apiConfigID)
+	apiConfigGetResponse, err := client.GetApiSecurityApiConfig(context.Background(), siteID, apiConfigID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), apiConfigGetResponse)
@@ -172,7 +173,7 @@ func TestCreateApiSecurityApiConfigBadConnection(t *testing.T) {
 			InvalidParamValueViolationAction: "IGNORE",
 		},
 	}
-	apiConfigGetResponse, err := client.CreateApiSecurityApiConfig(siteID, &payload)
+	apiConfigGetResponse, err := client.CreateApiSecurityApiConfig(context.Background(), siteID, &payload)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -215,7 +216,7 @@ func TestCreateApiSecurityApiConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.CreateApiSecurityApiConfig(siteID, &payload)
+	apiConfigGetResponse, err := client.CreateApiSecurityApiConfig(context.Background(), siteID, &payload)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -263,7 +264,7 @@ func TestCreateApiSecurityApiConfigInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.CreateApiSecurityApiConfig(siteID, &payload)
+	apiConfigGetResponse, err := client.CreateApiSecurityApiConfig(context.Background(), siteID, &payload)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -313,7 +314,7 @@ func TestCreateApiSecurityApiConfigValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.CreateApiSecurityApiConfig(siteID, &payload)
+	apiConfigGetResponse, err := client.CreateApiSecurityApiConfig(context.Background(), siteID, &payload)
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
 	}
@@ -348,7 +349,7 @@ func TestUpdateApiSecurityApiConfigBadConnection(t *testing.T) {
 			InvalidParamValueViolationAction: "IGNORE",
 		},
 	}
-	apiConfigGetResponse, err := client.UpdateApiSecurityApiConfig(siteID, apiConfigID, &payload)
+	apiConfigGetResponse, err := client.UpdateApiSecurityApiConfig(context.Background(), siteID, apiConfigID, &payload)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -392,7 +393,7 @@ func TestUpdateApiSecurityApiConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.UpdateApiSecurityApiConfig(siteID, apiConfigID, &payload)
+	apiConfigGetResponse, err := client.UpdateApiSecurityApiConfig(context.Background(), siteID, apiConfigID, &payload)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -441,7 +442,7 @@ func TestUpdateApiSecurityApiConfigInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.UpdateApiSecurityApiConfig(siteID, apiConfigID, &payload)
+	apiConfigGetResponse, err := client.UpdateApiSecurityApiConfig(context.Background(), siteID, apiConfigID, &payload)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -494,7 +495,7 @@ func TestUpdateApiSecurityApiConfigValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiConfigGetResponse, err := client.UpdateApiSecurityApiConfig(siteID, apiConfigID, &payload)
+	apiConfigGetResponse, err := client.UpdateApiSecurityApiConfig(context.Background(), siteID, apiConfigID, &payload)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
@@ -517,7 +518,7 @@ func TestDeleteApiSecurityApiConfigBadConnection(t *testing.T) {
 	siteID := int64(42)
 	apiConfigID := "100"
 
-	err := client.DeleteApiSecurityApiConfig(siteID, apiConfigID)
+	err := client.DeleteApiSecurityApiConfig(context.Background(), siteID, apiConfigID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -544,7 +545,7 @@ func TestDeleteApiSecurityApiConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteApiSecurityApiConfig(siteID, apiConfigID)
+	err := client.DeleteApiSecurityApiConfig(context.Background(), siteID, apiConfigID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -575,7 +576,7 @@ func TestDeleteApiSecurityApiConfigInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteApiSecurityApiConfig(siteID, apiConfigID)
+	err := client.DeleteApiSecurityApiConfig(context.Background(), siteID, apiConfigID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -606,7 +607,7 @@ func TestDeleteApiSecurityApiConfigValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteApiSecurityApiConfig(siteID, apiConfigID)
+	err := client.DeleteApiSecurityApiConfig(context.Background(), siteID, apiConfigID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -2,6 +2,7 @@ package incapsula
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -40,7 +41,7 @@ type ApiSecurityEndpointConfigPostPayload struct {
 }
 
 // PostApiSecurityEndpointConfig updates an Api-Security Endpoint Config
-func (c *Client) PostApiSecurityEndpointConfig(apiId, endpointId int64, endpointConfigPayload *ApiSecurityEndpointConfigPostPayload) (*ApiSecurityEndpointConfigPostResponse, error) {
+func (c *Client) PostApiSecurityEndpointConfig(ctx context.Context, apiId, endpointId int64, endpointConfigPayload *ApiSecurityEndpointConfigPostPayload) (*ApiSecurityEndpointConfigPostResponse, error) {
 	log.Printf("[INFO] Updating Incapsula API security Enpoint Configuration\n")
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -69,7 +70,7 @@ func (c *Client) PostApiSecurityEndpointConfig(apiId, endpointId int64, endpoint
 	writer.Close()
 	url := fmt.Sprintf("%s%s%d"+"/"+"%d", c.config.BaseURLAPI, endpointConfigUrl, apiId, endpointId)
 	contentType := writer.FormDataContentType()
-	resp, err := c.DoFormDataRequestWithHeaders(http.MethodPost, url, body.Bytes(), contentType, UpdateApiSecEndpointConfig)
+	resp, err := c.DoFormDataRequestWithHeaders(ctx, http.MethodPost, url, body.Bytes(), contentType, UpdateApiSecEndpointConfig)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service while updating Api Security Endpoint Configuration for API Config Id %d, API Config Id %d : %s", apiId, endpointId, err)
 	}
@@ -81,7 +82,7 @@ func (c *Client) PostApiSecurityEndpointConfig(apiId, endpointId int64, endpoint
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service while updating Api Security Endpoint configuration for API Config Id %d, Endpoint Config Id: %d. Error: %s", resp.StatusCode, apiId, endpointId, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service while updating Api Security Endpoint configuration for API Config Id %d, Endpoint Config Id: %d. Error: %w", resp.StatusCode, apiId, endpointId, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -95,10 +96,10 @@ func (c *Client) PostApiSecurityEndpointConfig(apiId, endpointId int64, endpoint
 }
 
 // GetApiSecurityEndpointConfig gets the Api-Security Endpoint Config
-func (c *Client) GetApiSecurityEndpointConfig(apiId int64, endpointId string) (*ApiSecurityEndpointConfigGetResponse, error) {
+func (c *Client) GetApiSecurityEndpointConfig(ctx context.Context, apiId int64, endpointId string) (*ApiSecurityEndpointConfigGetResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Api-Security Endpoint Config on API: %d and Endpoint: %s\n", apiId, endpointId)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, fmt.Sprintf("%s%s%d/%s", c.config.BaseURLAPI, endpointConfigUrl, apiId, endpointId), nil, ReadApiSecEndpointConfig)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, fmt.Sprintf("%s%s%d/%s", c.config.BaseURLAPI, endpointConfigUrl, apiId, endpointId), nil, ReadApiSecEndpointConfig)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service while reading Api-Security Endpoint Config for API ID %d and Endpoint ID %s: %s", apiId, endpointId, err)
 	}
@@ -110,7 +111,7 @@ func (c *Client) GetApiSecurityEndpointConfig(apiId int64, endpointId string) (*
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when reading Api-Security Endpoint Config for API ID %d and Endpoint ID %s: %s", resp.StatusCode, apiId, endpointId, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when reading Api-Security Endpoint Config for API ID %d and Endpoint ID %s: %w", resp.StatusCode, apiId, endpointId, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -124,10 +125,10 @@ func (c *Client) GetApiSecurityEndpointConfig(apiId int64, endpointId string) (*
 }
 
 // GetApiSecurityAllEndpointsConfig gets all the Api-Security Endpoints for API Config ID
-func (c *Client) GetApiSecurityAllEndpointsConfig(apiId int64) (*ApiSecurityEndpointConfigGetAllResponse, error) {
+func (c *Client) GetApiSecurityAllEndpointsConfig(ctx context.Context, apiId int64) (*ApiSecurityEndpointConfigGetAllResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Api-Security all Endpoints Config on API: %d\n", apiId)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, fmt.Sprintf("%s%s%d", c.config.BaseURLAPI, endpointConfigUrl, apiId), nil, ReadApiSecEndpointConfig)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, fmt.Sprintf("%s%s%d", c.config.BaseURLAPI, endpointConfigUrl, apiId), nil, ReadApiSecEndpointConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error from Incapsula service when reading Api-Security all Endpoints Config for API ID %d: %s", apiId, err)
 	}
@@ -140,7 +141,7 @@ func (c *Client) GetApiSecurityAllEndpointsConfig(apiId int64) (*ApiSecurityEndp
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("error status code %d from Incapsula service when reading Api-Security all Endpoints Config for API ID %d: %s", resp.StatusCode, apiId, string(responseBody))
+		return nil, fmt.Errorf("error status code %d from Incapsula service when reading Api-Security all Endpoints Config for API ID %d: %w", resp.StatusCode, apiId, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -19,7 +20,7 @@ func TestGetApiSecurityEndpointConfigBadConnection(t *testing.T) {
 	apiID := int64(100)
 	endpointId := "92"
 	//
-	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(apiID, endpointId)
+	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiID, endpointId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -50,7 +51,7 @@ func TestGetApiSecurityEndpointConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(apiConfigID, endpointId)
+	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -85,7 +86,7 @@ func TestGetApiSecurityEndpointConfigInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(apiConfigID, endpointId)
+	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -129,7 +130,7 @@ func TestGetApiSecurityEndpointConfigValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(apiConfigID, endpointId)
+	apiSecurityEndpointConfigGetResponse, err := client.GetApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId)
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
 	}
@@ -158,7 +159,7 @@ func TestPostApiSecurityEndpointConfigBadConnection(t *testing.T) {
 		SpecificationViolationAction: "BLOCK_REQUEST",
 	}
 
-	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(apiConfigID, endpointId, &payload)
+	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId, &payload)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -198,7 +199,7 @@ func TestPostApiSecurityEndpointConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	//
-	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(apiConfigID, endpointId, &payload)
+	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId, &payload)
 	//
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -247,7 +248,7 @@ func TestPostApiSecurityEndpointConfigInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	//
-	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(apiConfigID, endpointId, &payload)
+	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId, &payload)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -293,7 +294,7 @@ func TestPostApiSecurityEndpointConfigValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	//
-	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(apiConfigID, endpointId, &payload)
+	apiSecurityEndpointConfigPostResponse, err := client.PostApiSecurityEndpointConfig(context.Background(), apiConfigID, endpointId, &payload)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
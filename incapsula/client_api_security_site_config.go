@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -37,11 +38,11 @@ type ApiSecuritySiteConfigPostPayload struct {
 }
 
 // ReadApiSecuritySiteConfig gets the Api-Security Site Config
-func (c *Client) ReadApiSecuritySiteConfig(siteId int64) (*ApiSecuritySiteConfigGetResponse, error) {
+func (c *Client) ReadApiSecuritySiteConfig(ctx context.Context, siteId int64) (*ApiSecuritySiteConfigGetResponse, error) {
 	log.Printf("[INFO] Getting Incapsula Api-Security Site Config: %d\n", siteId)
 
 	// Post form to Incapsula
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet,
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 		fmt.Sprintf("%s%s%d", c.config.BaseURLAPI, siteConfigUrl, siteId),
 		nil,
 		ReadApiSecSiteConfig)
@@ -58,7 +59,7 @@ func (c *Client) ReadApiSecuritySiteConfig(siteId int64) (*ApiSecuritySiteConfig
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Site Config for site ID %d: %s", resp.StatusCode, siteId, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Api-Security Site Config for site ID %d: %w", resp.StatusCode, siteId, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -72,13 +73,13 @@ func (c *Client) ReadApiSecuritySiteConfig(siteId int64) (*ApiSecuritySiteConfig
 }
 
 // UpdateApiSecuritySiteConfig updates an Api-Security Site Config
-func (c *Client) UpdateApiSecuritySiteConfig(siteId int64, siteConfigPayload *ApiSecuritySiteConfigPostPayload) (*ApiSecuritySiteConfigPostResponse, error) {
+func (c *Client) UpdateApiSecuritySiteConfig(ctx context.Context, siteId int64, siteConfigPayload *ApiSecuritySiteConfigPostPayload) (*ApiSecuritySiteConfigPostResponse, error) {
 	siteConfigJSON, err := json.Marshal(siteConfigPayload)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to JSON marshal api security site config: %s", err)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost,
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 		fmt.Sprintf("%s"+siteConfigUrl+"%d", c.config.BaseURLAPI, siteId),
 		siteConfigJSON,
 		UpdateApiSecSiteConfig)
@@ -96,7 +97,7 @@ func (c *Client) UpdateApiSecuritySiteConfig(siteId int64, siteConfigPayload *Ap
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating api-security site configuration: %s", resp.StatusCode, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating api-security site configuration: %w", resp.StatusCode, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
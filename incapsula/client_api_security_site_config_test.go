@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -32,6 +33,7 @@ func TestUpdateApiSecuritySiteConfigBadConnection(t *testing.T) {
 	}
 
 	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+		context.Background(),
 		int64(siteID),
 		&payload)
 
@@ -78,6 +80,7 @@ func TestUpdateApiSecuritySiteConfigBadJSON(t *testing.T) {
 	}
 
 	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+		context.Background(),
 		int64(siteID),
 		&payload)
 
@@ -118,6 +121,7 @@ func TestUpdateApiSecuritySiteConfigInvalidSiteConfig(t *testing.T) {
 	}
 
 	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+		context.Background(),
 		int64(siteID),
 		&payload)
 
@@ -164,6 +168,7 @@ func TestUpdateApiSecuritySiteConfigValidSiteConfig(t *testing.T) {
 	}
 
 	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+		context.Background(),
 		int64(siteID),
 		&payload)
 
@@ -186,7 +191,7 @@ func TestClientReadApiSecuritySiteConfigBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := int64(42)
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteID)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -216,7 +221,7 @@ func TestClientReadApiSecuritySiteConfigBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteID)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -250,7 +255,7 @@ func TestClientReadApiSecuritySiteConfigInvalidSiteConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteID)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -281,7 +286,7 @@ func TestClientReadApiSecuritySiteConfigValidSiteConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteID)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
 	}
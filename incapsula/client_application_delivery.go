@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -73,12 +74,12 @@ type ApplicationDelivery struct {
 	Redirection      Redirection      `json:"redirection"`
 }
 
-func (c *Client) GetApplicationDelivery(siteID int) (*ApplicationDelivery, diag.Diagnostics) {
+func (c *Client) GetApplicationDelivery(ctx context.Context, siteID int) (*ApplicationDelivery, diag.Diagnostics) {
 	log.Printf("[INFO] Getting Incapsula Application Delivery for Site ID %d", siteID)
-	return CrudApplicationDelivery("Read", siteID, http.MethodGet, nil, c)
+	return CrudApplicationDelivery(ctx, "Read", siteID, http.MethodGet, nil, c)
 }
 
-func (c *Client) UpdateApplicationDelivery(siteID int, applicationDelivery *ApplicationDelivery) (*ApplicationDelivery, diag.Diagnostics) {
+func (c *Client) UpdateApplicationDelivery(ctx context.Context, siteID int, applicationDelivery *ApplicationDelivery) (*ApplicationDelivery, diag.Diagnostics) {
 	log.Printf("[INFO] Updating Incapsula Application Delivery for Site ID %d", siteID)
 	var diags diag.Diagnostics
 	applicationDeliveryJSON, err := json.Marshal(applicationDelivery)
@@ -90,15 +91,15 @@ func (c *Client) UpdateApplicationDelivery(siteID int, applicationDelivery *Appl
 		})
 		return nil, diags
 	}
-	return CrudApplicationDelivery("Update", siteID, http.MethodPut, applicationDeliveryJSON, c)
+	return CrudApplicationDelivery(ctx, "Update", siteID, http.MethodPut, applicationDeliveryJSON, c)
 }
 
-func (c *Client) DeleteApplicationDelivery(siteID int) (*ApplicationDelivery, diag.Diagnostics) {
+func (c *Client) DeleteApplicationDelivery(ctx context.Context, siteID int) (*ApplicationDelivery, diag.Diagnostics) {
 	log.Printf("[INFO] Deleting Incapsula Application Delivery for Site ID %d", siteID)
-	return CrudApplicationDelivery("Delete", siteID, http.MethodDelete, nil, c)
+	return CrudApplicationDelivery(ctx, "Delete", siteID, http.MethodDelete, nil, c)
 }
 
-func CrudApplicationDelivery(action string, siteID int, httpMethod string, applicationDeliveyData []byte, c *Client) (*ApplicationDelivery, diag.Diagnostics) {
+func CrudApplicationDelivery(ctx context.Context, action string, siteID int, httpMethod string, applicationDeliveyData []byte, c *Client) (*ApplicationDelivery, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if applicationDeliveyData != nil {
@@ -107,7 +108,7 @@ func CrudApplicationDelivery(action string, siteID int, httpMethod string, appli
 
 	applicationDeliveryUrl := fmt.Sprintf("%s/sites/%d/settings/delivery", c.config.BaseURLRev2, siteID)
 
-	resp, err := c.DoJsonRequestWithHeaders(httpMethod, applicationDeliveryUrl, applicationDeliveyData, strings.ToLower(action)+"_application_delivery")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, httpMethod, applicationDeliveryUrl, applicationDeliveyData, strings.ToLower(action)+"_application_delivery")
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -124,11 +125,10 @@ func CrudApplicationDelivery(action string, siteID int, httpMethod string, appli
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error status code %d from Incapsula service when %s Application Delivery", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing"),
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when %s Application Delivery for Site ID %d: %s", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			fmt.Sprintf("Error status code %d from Incapsula service when %s Application Delivery", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing"),
+			fmt.Sprintf("Error status code %d from Incapsula service when %s Application Delivery for Site ID %d", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID),
+			resp, responseBody)...)
 		return nil, diags
 	}
 
@@ -147,12 +147,12 @@ func CrudApplicationDelivery(action string, siteID int, httpMethod string, appli
 	return &applicationDelivery, nil
 }
 
-func (c *Client) GetErrorPages(siteID int) (*CustomErrorPage, diag.Diagnostics) {
+func (c *Client) GetErrorPages(ctx context.Context, siteID int) (*CustomErrorPage, diag.Diagnostics) {
 	log.Printf("[INFO] Getting Incapsula Error Pages for Site ID %d", siteID)
-	return CrudErrorPages("Read", siteID, http.MethodGet, nil, c)
+	return CrudErrorPages(ctx, "Read", siteID, http.MethodGet, nil, c)
 }
 
-func (c *Client) UpdateErrorPages(siteID int, errorPages *CustomErrorPage) (*CustomErrorPage, diag.Diagnostics) {
+func (c *Client) UpdateErrorPages(ctx context.Context, siteID int, errorPages *CustomErrorPage) (*CustomErrorPage, diag.Diagnostics) {
 	log.Printf("[INFO] Updating Incapsula Application Delivery for Site ID %d", siteID)
 	var diags diag.Diagnostics
 	errorPagesJSON, err := json.Marshal(errorPages)
@@ -164,17 +164,17 @@ func (c *Client) UpdateErrorPages(siteID int, errorPages *CustomErrorPage) (*Cus
 		})
 		return nil, diags
 	}
-	return CrudErrorPages("Update", siteID, http.MethodPut, errorPagesJSON, c)
+	return CrudErrorPages(ctx, "Update", siteID, http.MethodPut, errorPagesJSON, c)
 }
 
-func (c *Client) DeleteErrorPages(siteID int) (*CustomErrorPage, diag.Diagnostics) {
+func (c *Client) DeleteErrorPages(ctx context.Context, siteID int) (*CustomErrorPage, diag.Diagnostics) {
 	log.Printf("[INFO] Deleting Incapsula Application Delivery for Site ID %d", siteID)
 	customErrorPage := CustomErrorPage{}
 	errorPagesJSON, _ := json.Marshal(customErrorPage)
-	return CrudErrorPages("Delete", siteID, http.MethodPut, errorPagesJSON, c)
+	return CrudErrorPages(ctx, "Delete", siteID, http.MethodPut, errorPagesJSON, c)
 }
 
-func CrudErrorPages(action string, siteID int, httpMethod string, errorPagesData []byte, c *Client) (*CustomErrorPage, diag.Diagnostics) {
+func CrudErrorPages(ctx context.Context, action string, siteID int, httpMethod string, errorPagesData []byte, c *Client) (*CustomErrorPage, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	if errorPagesData != nil {
@@ -183,7 +183,7 @@ func CrudErrorPages(action string, siteID int, httpMethod string, errorPagesData
 
 	errorPagesUrl := fmt.Sprintf("%s/sites/%d/settings/delivery/error-pages", c.config.BaseURLRev2, siteID)
 
-	resp, err := c.DoJsonRequestWithHeaders(httpMethod, errorPagesUrl, errorPagesData, strings.ToLower(action)+"_error_pages")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, httpMethod, errorPagesUrl, errorPagesData, strings.ToLower(action)+"_error_pages")
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -200,11 +200,10 @@ func CrudErrorPages(action string, siteID int, httpMethod string, errorPagesData
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error status code %d from Incapsula service when %s Error Pages", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing"),
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when %s Error Pages for Site ID %d: %s", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			fmt.Sprintf("Error status code %d from Incapsula service when %s Error Pages", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing"),
+			fmt.Sprintf("Error status code %d from Incapsula service when %s Error Pages for Site ID %d", resp.StatusCode, strings.TrimSuffix(action, "e")+"ing", siteID),
+			resp, responseBody)...)
 		return nil, diags
 	}
 
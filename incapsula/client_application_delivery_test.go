@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -25,6 +26,7 @@ func TestUpdateApplicationDeliveryBadConnection(t *testing.T) {
 	}
 
 	applicationDeliveryResponse, err := client.UpdateApplicationDelivery(
+		context.Background(),
 		siteID,
 		&applicationDeliveryPayload)
 
@@ -38,6 +40,7 @@ func TestUpdateApplicationDeliveryBadConnection(t *testing.T) {
 	customErrorPagesPayload := CustomErrorPage{}
 
 	errorPages, err := client.UpdateErrorPages(
+		context.Background(),
 		siteID,
 		&customErrorPagesPayload)
 
@@ -76,6 +79,7 @@ func TestUpdateApplicationDeliveryBadJSON(t *testing.T) {
 	}
 
 	applicationDeliveryResponse, err := client.UpdateApplicationDelivery(
+		context.Background(),
 		siteID,
 		&applicationDeliveryPayload)
 
@@ -89,6 +93,7 @@ func TestUpdateApplicationDeliveryBadJSON(t *testing.T) {
 	customErrorPagesPayload := CustomErrorPage{}
 
 	errorPages, err := client.UpdateErrorPages(
+		context.Background(),
 		siteID,
 		&customErrorPagesPayload)
 
@@ -134,6 +139,7 @@ func TestUpdateApplicationDeliveryInvalidConfig(t *testing.T) {
 	payload := ApplicationDelivery{}
 
 	applicationDeliveryResponse, diags := client.UpdateApplicationDelivery(
+		context.Background(),
 		siteID,
 		&payload)
 
@@ -150,6 +156,7 @@ func TestUpdateApplicationDeliveryInvalidConfig(t *testing.T) {
 	customErrorPagesPayload := CustomErrorPage{}
 
 	errorPages, diags := client.UpdateErrorPages(
+		context.Background(),
 		siteID,
 		&customErrorPagesPayload)
 
@@ -237,6 +244,7 @@ func TestUpdateApplicationDeliveryConfig(t *testing.T) {
 	customErrorPagesPayload := CustomErrorPage{}
 
 	applicationDeliveryResponse, diags := client.UpdateApplicationDelivery(
+		context.Background(),
 		siteID,
 		&payload)
 
@@ -248,6 +256,7 @@ func TestUpdateApplicationDeliveryConfig(t *testing.T) {
 	}
 
 	errorPages, diags := client.UpdateErrorPages(
+		context.Background(),
 		siteID,
 		&customErrorPagesPayload)
 	if diags != nil {
@@ -266,7 +275,7 @@ func TestReadApplicationDeliveryBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 42
 
-	applicationDeliveryResponse, err := client.GetApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.GetApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -275,7 +284,7 @@ func TestReadApplicationDeliveryBadConnection(t *testing.T) {
 		t.Errorf("Should have received a nil applicationDeliveryResponse instance")
 	}
 
-	errorPages, err := client.GetErrorPages(siteID)
+	errorPages, err := client.GetErrorPages(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -304,7 +313,7 @@ func TestReadApplicationDeliveryBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, err := client.GetApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.GetApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -313,7 +322,7 @@ func TestReadApplicationDeliveryBadJSON(t *testing.T) {
 		t.Errorf("Should have received a nil applicationDeliveryResponse instance")
 	}
 
-	errorPages, err := client.GetErrorPages(siteID)
+	errorPages, err := client.GetErrorPages(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -355,7 +364,7 @@ func TestReadApplicationDeliveryInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, diags := client.GetApplicationDelivery(siteID)
+	applicationDeliveryResponse, diags := client.GetApplicationDelivery(context.Background(), siteID)
 
 	if diags == nil {
 		t.Errorf("Should have received an error")
@@ -367,7 +376,7 @@ func TestReadApplicationDeliveryInvalidConfig(t *testing.T) {
 		t.Errorf("Should have received a nil applicationDeliveryResponse instance")
 	}
 
-	errorPages, diags := client.GetErrorPages(siteID)
+	errorPages, diags := client.GetErrorPages(context.Background(), siteID)
 
 	if diags == nil {
 		t.Errorf("Should have received an error")
@@ -436,7 +445,7 @@ func TestReadApplicationDeliveryConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, diags := client.GetApplicationDelivery(siteID)
+	applicationDeliveryResponse, diags := client.GetApplicationDelivery(context.Background(), siteID)
 
 	if diags != nil {
 		t.Errorf("Should not have received an error : %s", diags[0].Detail)
@@ -457,7 +466,7 @@ func TestDeleteApplicationDeliveryBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 42
 
-	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -485,7 +494,7 @@ func TestDeleteApplicationDeliveryBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -515,7 +524,7 @@ func TestDeleteApplicationDeliveryInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(siteID)
+	applicationDeliveryResponse, err := client.DeleteApplicationDelivery(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -547,7 +556,7 @@ func TestDeleteApplicationDeliveryConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.DeleteApplicationDelivery(siteID)
+	_, err := client.DeleteApplicationDelivery(context.Background(), siteID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,7 @@ func TestATOSiteAllowlistConfigBadConnection(t *testing.T) {
 	siteId := 42
 	accountId := 55
 
-	ret, _, err := client.GetAtoSiteAllowlist(accountId, siteId)
+	ret, _, err := client.GetAtoSiteAllowlist(context.Background(), accountId, siteId)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -36,7 +37,7 @@ func TestATOSiteAllowlistConfigBadConnection(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.UpdateATOSiteAllowlist(&ATOAllowlistDTO{})
+	err = client.UpdateATOSiteAllowlist(context.Background(), &ATOAllowlistDTO{})
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -72,7 +73,7 @@ func TestATOSiteAllowlistConfigErrorResponse(t *testing.T) {
 	config := &Config{APIID: apiId, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, _, err := client.GetAtoSiteAllowlist(accountId, siteId)
+	ret, _, err := client.GetAtoSiteAllowlist(context.Background(), accountId, siteId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -83,7 +84,7 @@ func TestATOSiteAllowlistConfigErrorResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.UpdateATOSiteAllowlist(&ATOAllowlistDTO{})
+	err = client.UpdateATOSiteAllowlist(context.Background(), &ATOAllowlistDTO{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -115,7 +116,7 @@ func TestATOSiteAllowlistConfigInvalidResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, _, err := client.GetAtoSiteAllowlist(accountId, siteId)
+	ret, _, err := client.GetAtoSiteAllowlist(context.Background(), accountId, siteId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -126,7 +127,7 @@ func TestATOSiteAllowlistConfigInvalidResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.UpdateATOSiteAllowlist(&ATOAllowlistDTO{})
+	err = client.UpdateATOSiteAllowlist(context.Background(), &ATOAllowlistDTO{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -170,7 +171,7 @@ func TestATOSiteAllowlistConfigResponse(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 
 	// Fetch the allowlist for the site
-	response, _, err := client.GetAtoSiteAllowlistWithRetries(accountId, siteId)
+	response, _, err := client.GetAtoSiteAllowlistWithRetries(context.Background(), accountId, siteId)
 
 	// Check for no value edge cases
 	if err != nil {
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -32,7 +33,7 @@ func formNoMitigationConfigurationDTO(accountId, siteId int, endpointId string)
 }
 
 // GetAtoEndpointMitigationConfigurationWithRetries Fetch the mitigation configuration for an endpoint
-func (c *Client) GetAtoEndpointMitigationConfigurationWithRetries(accountId, siteId int, endpointId string) (*ATOEndpointMitigationConfigurationDTO, int, error) {
+func (c *Client) GetAtoEndpointMitigationConfigurationWithRetries(ctx context.Context, accountId, siteId int, endpointId string) (*ATOEndpointMitigationConfigurationDTO, int, error) {
 	// Since the newly created site can take upto 30 seconds to be fully configured, we per.si a simple backoff
 	var backoffSchedule = []time.Duration{
 		5 * time.Second,
@@ -43,17 +44,19 @@ func (c *Client) GetAtoEndpointMitigationConfigurationWithRetries(accountId, sit
 	var lastError error
 
 	for _, backoff := range backoffSchedule {
-		atoEndpointMitigationConfigurationDTO, status, err := c.GetAtoEndpointMitigationConfiguration(accountId, siteId, endpointId)
+		atoEndpointMitigationConfigurationDTO, status, err := c.GetAtoEndpointMitigationConfiguration(ctx, accountId, siteId, endpointId)
 		if err == nil {
 			return atoEndpointMitigationConfigurationDTO, status, nil
 		}
 		lastError = err
-		time.Sleep(backoff)
+		if err := sleepWithContext(ctx, backoff); err != nil {
+			return nil, 0, err
+		}
 	}
 	return nil, 0, lastError
 }
 
-func (c *Client) GetAtoEndpointMitigationConfiguration(accountId, siteId int, endpointId string) (*ATOEndpointMitigationConfigurationDTO, int, error) {
+func (c *Client) GetAtoEndpointMitigationConfiguration(ctx context.Context, accountId, siteId int, endpointId string) (*ATOEndpointMitigationConfigurationDTO, int, error) {
 	log.Printf("[INFO] Getting ATO mitigation configuration for (Site Id: %d)\n", siteId)
 
 	// Get request to ATO
@@ -64,7 +67,7 @@ func (c *Client) GetAtoEndpointMitigationConfiguration(accountId, siteId int, en
 		reqURL = fmt.Sprintf("%s%s/%d%s?caid=%d", c.config.BaseURLAPI, endpointATOSiteBase, siteId, endpointATOMitigation, accountId)
 	}
 	// Adding specific endpoint ID from the API spec at https://docs.imperva.com/bundle/account-takeover/page/account-takeover/ato-api-definition.htm
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, reqURL, nil, map[string]string{"endpointIds": endpointId}, ReadATOSiteMitigationConfigurationOperation)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, map[string]string{"endpointIds": endpointId}, ReadATOSiteMitigationConfigurationOperation)
 	if err != nil {
 		return nil, 0, fmt.Errorf("[Error] Error executing get ATO mitigation configuration request for site with id %d: %s", siteId, err)
 	}
@@ -78,7 +81,7 @@ func (c *Client) GetAtoEndpointMitigationConfiguration(accountId, siteId int, en
 
 	// Check for internal server error
 	if resp.StatusCode != http.StatusOK {
-		return nil, resp.StatusCode, fmt.Errorf("[Error] Error response from server for fetching ATO mitigation configuration for site : %d , endpointId : %s , Error : %s", siteId, endpointId, responseBody)
+		return nil, resp.StatusCode, fmt.Errorf("[Error] Error response from server for fetching ATO mitigation configuration for site : %d , endpointId : %s , Error : %w", siteId, endpointId, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -112,7 +115,7 @@ func (c *Client) GetAtoEndpointMitigationConfiguration(accountId, siteId int, en
 	return &atoEndpointMitigationConfigurationDTO, resp.StatusCode, nil
 }
 
-func (c *Client) UpdateATOEndpointMitigationConfigurationWithRetries(atoSiteMitigationConfigurationDTO *ATOEndpointMitigationConfigurationDTO) error {
+func (c *Client) UpdateATOEndpointMitigationConfigurationWithRetries(ctx context.Context, atoSiteMitigationConfigurationDTO *ATOEndpointMitigationConfigurationDTO) error {
 	// Since the newly created site can take upto 30 seconds to be fully configured, we perform a simple backoff
 	var backoffSchedule = []time.Duration{
 		5 * time.Second,
@@ -123,17 +126,19 @@ func (c *Client) UpdateATOEndpointMitigationConfigurationWithRetries(atoSiteMiti
 	var lastError error
 
 	for _, backoff := range backoffSchedule {
-		err := c.UpdateATOSiteMitigationConfiguration(atoSiteMitigationConfigurationDTO)
+		err := c.UpdateATOSiteMitigationConfiguration(ctx, atoSiteMitigationConfigurationDTO)
 		if err == nil {
 			return nil
 		}
 		lastError = err
-		time.Sleep(backoff)
+		if err := sleepWithContext(ctx, backoff); err != nil {
+			return err
+		}
 	}
 	return lastError
 }
 
-func (c *Client) UpdateATOSiteMitigationConfiguration(atoSiteMitigationConfigurationDTO *ATOEndpointMitigationConfigurationDTO) error {
+func (c *Client) UpdateATOSiteMitigationConfiguration(ctx context.Context, atoSiteMitigationConfigurationDTO *ATOEndpointMitigationConfigurationDTO) error {
 
 	log.Printf("[INFO] Updating ATO mitigation configuration for (Site Id: %d)\n", atoSiteMitigationConfigurationDTO.SiteId)
 
@@ -153,7 +158,7 @@ func (c *Client) UpdateATOSiteMitigationConfiguration(atoSiteMitigationConfigura
 	}
 
 	// Update request to ATO
-	response, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, mitigationConfigurationJSON, UpdateATOSiteMitigationConfigurationOperation)
+	response, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, mitigationConfigurationJSON, UpdateATOSiteMitigationConfigurationOperation)
 
 	// Read the body
 	defer response.Body.Close()
@@ -167,19 +172,19 @@ func (c *Client) UpdateATOSiteMitigationConfiguration(atoSiteMitigationConfigura
 	}
 
 	if response.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("[Error] Error executing update ATO mitigation configuration request for site with status %d: %s", atoSiteMitigationConfigurationDTO.SiteId, response.Status)
+		return fmt.Errorf("[Error] Error status code %d executing update ATO mitigation configuration request for site with id %d: %w", response.StatusCode, atoSiteMitigationConfigurationDTO.SiteId, NewAPIError(response, responseBody))
 	}
 
 	return nil
 
 }
 
-func (c *Client) DisableATOEndpointMitigationConfiguration(accountId, siteId int, endpointId string) error {
+func (c *Client) DisableATOEndpointMitigationConfiguration(ctx context.Context, accountId, siteId int, endpointId string) error {
 	log.Printf("[INFO] Disabling ATO site mitigation configuration for (Site Id: %d)\n", siteId)
 
 	// We are using empty mitigation config array instead of assigning 'NONE' to all risk levels
 	// This has the advantage of resetting config entirely instead of possible enum conversion issues in the future
-	err := c.UpdateATOSiteMitigationConfiguration(formNoMitigationConfigurationDTO(accountId, siteId, endpointId))
+	err := c.UpdateATOSiteMitigationConfiguration(ctx, formNoMitigationConfigurationDTO(accountId, siteId, endpointId))
 
 	// Handle request error
 	if err != nil {
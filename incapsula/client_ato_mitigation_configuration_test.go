@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -20,7 +21,7 @@ func TestATOSiteMitigationConfigurationBadConnection(t *testing.T) {
 	accountId := 55
 	endpointId := "123"
 
-	ret, _, err := client.GetAtoEndpointMitigationConfiguration(accountId, siteId, endpointId)
+	ret, _, err := client.GetAtoEndpointMitigationConfiguration(context.Background(), accountId, siteId, endpointId)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -32,7 +33,7 @@ func TestATOSiteMitigationConfigurationBadConnection(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.UpdateATOSiteMitigationConfiguration(&ATOEndpointMitigationConfigurationDTO{})
+	err = client.UpdateATOSiteMitigationConfiguration(context.Background(), &ATOEndpointMitigationConfigurationDTO{})
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -69,7 +70,7 @@ func TestATOSiteMitigationConfigurationErrorResponse(t *testing.T) {
 	config := &Config{APIID: apiId, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, _, err := client.GetAtoEndpointMitigationConfiguration(accountId, siteId, endpointId)
+	ret, _, err := client.GetAtoEndpointMitigationConfiguration(context.Background(), accountId, siteId, endpointId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -80,7 +81,7 @@ func TestATOSiteMitigationConfigurationErrorResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.UpdateATOSiteMitigationConfiguration(&ATOEndpointMitigationConfigurationDTO{})
+	err = client.UpdateATOSiteMitigationConfiguration(context.Background(), &ATOEndpointMitigationConfigurationDTO{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -113,7 +114,7 @@ func TestATOSiteAMitigationConfigurationInvalidResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, _, err := client.GetAtoEndpointMitigationConfiguration(accountId, siteId, endpointId)
+	ret, _, err := client.GetAtoEndpointMitigationConfiguration(context.Background(), accountId, siteId, endpointId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 		return
@@ -127,7 +128,7 @@ func TestATOSiteAMitigationConfigurationInvalidResponse(t *testing.T) {
 		return
 	}
 
-	err = client.UpdateATOSiteMitigationConfiguration(&ATOEndpointMitigationConfigurationDTO{})
+	err = client.UpdateATOSiteMitigationConfiguration(context.Background(), &ATOEndpointMitigationConfigurationDTO{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 		return
@@ -174,7 +175,7 @@ func TestATOSiteMitigationConfigurationResponse(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 
 	// Fetch the mitigation configuration for the site
-	mitigationConfigurationItem, _, err := client.GetAtoEndpointMitigationConfigurationWithRetries(accountId, siteId, endpointId)
+	mitigationConfigurationItem, _, err := client.GetAtoEndpointMitigationConfigurationWithRetries(context.Background(), accountId, siteId, endpointId)
 
 	// Check for no value edge cases
 	if err != nil {
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -28,7 +29,7 @@ type CacheRuleWithID struct {
 }
 
 // AddCacheRule adds an incap rule to be managed by Incapsula
-func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID, error) {
+func (c *Client) AddCacheRule(ctx context.Context, siteID string, rule *CacheRule) (*CacheRuleWithID, error) {
 	log.Printf("[INFO] Adding Incapsula Cache Rule for Site ID %s\n", siteID)
 
 	ruleJSON, err := json.Marshal(rule)
@@ -41,7 +42,7 @@ func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID,
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache/rules", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, ruleJSON, CreateCacheRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, ruleJSON, CreateCacheRule)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding Cache Rule for Site ID %s: %s", siteID, err)
 	}
@@ -55,7 +56,7 @@ func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID,
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Cache Rule for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Cache Rule for Site ID %s: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -69,12 +70,12 @@ func (c *Client) AddCacheRule(siteID string, rule *CacheRule) (*CacheRuleWithID,
 }
 
 // ReadCacheRule gets the specific Incap Rule
-func (c *Client) ReadCacheRule(siteID string, ruleID int) (*CacheRuleWithID, int, error) {
+func (c *Client) ReadCacheRule(ctx context.Context, siteID string, ruleID int) (*CacheRuleWithID, int, error) {
 	log.Printf("[INFO] Getting Incapsula Cache Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadCacheRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadCacheRule)
 	if err != nil {
 		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -88,7 +89,7 @@ func (c *Client) ReadCacheRule(siteID string, ruleID int) (*CacheRuleWithID, int
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Cache Rule %d for Site ID %s: %w", resp.StatusCode, ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -102,7 +103,7 @@ func (c *Client) ReadCacheRule(siteID string, ruleID int) (*CacheRuleWithID, int
 }
 
 // UpdateCacheRule updates the Incapsula Incap Rule
-func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) error {
+func (c *Client) UpdateCacheRule(ctx context.Context, siteID string, ruleID int, rule *CacheRule) error {
 	log.Printf("[INFO] Updating Incapsula Cache Rule %d for Site ID %s\n", ruleID, siteID)
 
 	ruleJSON, err := json.Marshal(rule)
@@ -112,7 +113,7 @@ func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) err
 
 	// Put request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, ruleJSON, UpdateCacheRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, ruleJSON, UpdateCacheRule)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when updating Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -126,7 +127,7 @@ func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) err
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when updating Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return fmt.Errorf("Error status code %d from Incapsula service when updating Cache Rule %d for Site ID %s: %w", resp.StatusCode, ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -140,7 +141,7 @@ func (c *Client) UpdateCacheRule(siteID string, ruleID int, rule *CacheRule) err
 }
 
 // DeleteCacheRule deletes a site currently managed by Incapsula
-func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
+func (c *Client) DeleteCacheRule(ctx context.Context, siteID string, ruleID int) error {
 	type DeleteCacheRuleResponse struct {
 		Res        int    `json:"res"`
 		ResMessage string `json:"res_message"`
@@ -154,7 +155,7 @@ func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
 
 	// Delete request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteCacheRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteCacheRule)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when deleting Cache Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -169,7 +170,7 @@ func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
 	// Check the response code
 	// Unfortunately, this API endpoint is not RESTful and we return 200's back for failures (instead of 40X - joy)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Cache Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return fmt.Errorf("Error status code %d from Incapsula service when deleting Cache Rule %d for Site ID %s: %w", resp.StatusCode, ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -180,7 +181,7 @@ func (c *Client) DeleteCacheRule(siteID string, ruleID int) error {
 	}
 
 	if deleteCacheRuleResponse.Res != 0 {
-		return fmt.Errorf("Error deleting Cache Rule %d JSON response for Site ID %s: %s\nresponse: %s", ruleID, siteID, err, string(responseBody))
+		return fmt.Errorf("Error from Incapsula service when deleting Cache Rule %d for Site ID %s: %w", ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
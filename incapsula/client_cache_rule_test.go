@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -25,7 +26,7 @@ func TestClientAddCacheRuleBadConnection(t *testing.T) {
 		Enabled: true,
 	}
 
-	addCacheRuleResponse, err := client.AddCacheRule(siteID, &rule)
+	addCacheRuleResponse, err := client.AddCacheRule(context.Background(), siteID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -62,7 +63,7 @@ func TestClientAddCacheRuleBadJSON(t *testing.T) {
 		Enabled: true,
 	}
 
-	addCacheRuleResponse, err := client.AddCacheRule(siteID, &rule)
+	addCacheRuleResponse, err := client.AddCacheRule(context.Background(), siteID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -97,7 +98,7 @@ func TestClientAddCacheRuleInvalidRule(t *testing.T) {
 		Name: "myfirstcoolrule",
 	}
 
-	addCacheRuleResponse, err := client.AddCacheRule(siteID, &rule)
+	addCacheRuleResponse, err := client.AddCacheRule(context.Background(), siteID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -135,7 +136,7 @@ func TestClientAddCacheRuleValidRule(t *testing.T) {
 		Enabled: true,
 	}
 
-	addCacheRuleResponse, err := client.AddCacheRule(siteID, &rule)
+	addCacheRuleResponse, err := client.AddCacheRule(context.Background(), siteID, &rule)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -157,7 +158,7 @@ func TestClientReadCacheRuleBadConnection(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	readCacheRuleResponse, _, err := client.ReadCacheRule(siteID, ruleID)
+	readCacheRuleResponse, _, err := client.ReadCacheRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -188,7 +189,7 @@ func TestClientReadCacheRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readCacheRuleResponse, _, err := client.ReadCacheRule(siteID, ruleID)
+	readCacheRuleResponse, _, err := client.ReadCacheRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -220,7 +221,7 @@ func TestClientReadCacheRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readCacheRuleResponse, statusCode, err := client.ReadCacheRule(siteID, ruleID)
+	readCacheRuleResponse, statusCode, err := client.ReadCacheRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -255,7 +256,7 @@ func TestClientReadCacheRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readCacheRuleResponse, statusCode, err := client.ReadCacheRule(siteID, ruleID)
+	readCacheRuleResponse, statusCode, err := client.ReadCacheRule(context.Background(), siteID, ruleID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -287,7 +288,7 @@ func TestClientUpdateCacheRuleBadConnection(t *testing.T) {
 		Enabled: true,
 	}
 
-	err := client.UpdateCacheRule(siteID, ruleID, &rule)
+	err := client.UpdateCacheRule(context.Background(), siteID, ruleID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -322,7 +323,7 @@ func TestClientUpdateCacheRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateCacheRule(siteID, ruleID, &rule)
+	err := client.UpdateCacheRule(context.Background(), siteID, ruleID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -358,7 +359,7 @@ func TestClientUpdateCacheRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateCacheRule(siteID, ruleID, &rule)
+	err := client.UpdateCacheRule(context.Background(), siteID, ruleID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -394,7 +395,7 @@ func TestClientUpdateCacheRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.UpdateCacheRule(siteID, ruleID, &rule)
+	err := client.UpdateCacheRule(context.Background(), siteID, ruleID, &rule)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -410,7 +411,7 @@ func TestClientDeleteCacheRuleBadConnection(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	err := client.DeleteCacheRule(siteID, ruleID)
+	err := client.DeleteCacheRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -439,11 +440,11 @@ func TestClientDeleteCacheRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteCacheRule(siteID, ruleID)
+	err := client.DeleteCacheRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
-	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error deleting Cache Rule %d JSON response for Site ID %s", ruleID, siteID)) {
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when deleting Cache Rule %d for Site ID %s", ruleID, siteID)) {
 		t.Errorf("Should have received a bad cache rule error, got: %s", err)
 	}
 }
@@ -468,7 +469,7 @@ func TestClientDeleteCacheRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteCacheRule(siteID, ruleID)
+	err := client.DeleteCacheRule(context.Background(), siteID, ruleID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
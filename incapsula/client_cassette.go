@@ -0,0 +1,267 @@
+package incapsula
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+const cassetteModeRecord = "record"
+const cassetteModeReplay = "replay"
+
+const defaultCassetteDir = "testdata/cassettes"
+
+// defaultCassetteName holds the interactions that happen before a test selects its cassette, e.g. the provider configuration
+const defaultCassetteName = "provider"
+
+// Response headers kept in the cassettes, the other ones are not needed to replay the interactions
+var cassetteResponseHeaders = []string{"Content-Type", "Retry-After"}
+
+type cassetteRequest struct {
+	Method    string `json:"method"`
+	URL       string `json:"url"`
+	Operation string `json:"operation,omitempty"`
+	Body      string `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassette struct {
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+// cassetteTransport records the interactions with the Incapsula API into cassette files, or replays them without any network access.
+// The mode is selected with the INCAPSULA_CASSETTE_MODE environment variable (record or replay)
+// and the cassettes are stored in INCAPSULA_CASSETTE_DIR (testdata/cassettes by default).
+// Recorded interactions are sanitized: credentials headers are dropped and sensitive values are redacted
+type cassetteTransport struct {
+	mode string
+	dir  string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	name     string
+	cassette *cassette
+	used     []bool
+	err      error
+}
+
+var cassetteTransportOnce sync.Once
+var sharedCassetteTransport *cassetteTransport
+
+// cassetteTransportFromEnv returns the cassette transport selected by the environment, or nil when cassettes are not used.
+// The transport is shared by all the clients, so that tests can switch cassettes whatever client they use
+func cassetteTransportFromEnv(next http.RoundTripper) *cassetteTransport {
+	cassetteTransportOnce.Do(func() {
+		mode := os.Getenv("INCAPSULA_CASSETTE_MODE")
+		if mode != cassetteModeRecord && mode != cassetteModeReplay {
+			if mode != "" {
+				log.Printf("[WARN] Ignoring unknown INCAPSULA_CASSETTE_MODE %s, expected %s or %s", mode, cassetteModeRecord, cassetteModeReplay)
+			}
+			return
+		}
+		sharedCassetteTransport = &cassetteTransport{mode: mode, dir: cassetteDirFromEnv()}
+		sharedCassetteTransport.load(defaultCassetteName)
+	})
+
+	if sharedCassetteTransport != nil {
+		sharedCassetteTransport.mu.Lock()
+		sharedCassetteTransport.next = next
+		sharedCassetteTransport.mu.Unlock()
+	}
+	return sharedCassetteTransport
+}
+
+var cassetteNameSanitizer = regexp.MustCompile(`[^\w.-]+`)
+
+// cassetteDirFromEnv returns the directory of the cassettes, set by INCAPSULA_CASSETTE_DIR
+func cassetteDirFromEnv() string {
+	if dir := os.Getenv("INCAPSULA_CASSETTE_DIR"); dir != "" {
+		return dir
+	}
+	return defaultCassetteDir
+}
+
+func cassettePath(dir string, name string) string {
+	return filepath.Join(dir, cassetteNameSanitizer.ReplaceAllString(name, "_")+".json")
+}
+
+func (t *cassetteTransport) path(name string) string {
+	return cassettePath(t.dir, name)
+}
+
+// load switches to the given cassette. In replay mode its interactions are read from disk
+func (t *cassetteTransport) load(name string) {
+	t.name = name
+	t.cassette = &cassette{}
+	t.used = nil
+	t.err = nil
+
+	if t.mode != cassetteModeReplay {
+		return
+	}
+	data, err := os.ReadFile(t.path(name))
+	if err != nil {
+		t.err = fmt.Errorf("Error reading cassette %s: %s", name, err)
+		return
+	}
+	if err := json.Unmarshal(data, t.cassette); err != nil {
+		t.err = fmt.Errorf("Error parsing cassette %s: %s", name, err)
+		return
+	}
+	t.used = make([]bool, len(t.cassette.Interactions))
+}
+
+// save writes the recorded interactions of the current cassette. Nothing is written in replay mode
+func (t *cassetteTransport) save() error {
+	if t.mode != cassetteModeRecord || len(t.cassette.Interactions) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.path(t.name), data, 0644)
+}
+
+// Use saves the current cassette and switches to the given one
+func (t *cassetteTransport) Use(name string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	err := t.save()
+	t.load(name)
+	return err
+}
+
+// Eject saves the current cassette and switches back to the default one
+func (t *cassetteTransport) Eject() error {
+	return t.Use(defaultCassetteName)
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	request := cassetteRequest{
+		Method:    req.Method,
+		URL:       req.URL.RequestURI(),
+		Operation: req.Header.Get("x-tf-operation"),
+		Body:      redactBody(body, req.Header.Get("Content-Type")),
+	}
+
+	if t.mode == cassetteModeReplay {
+		return t.replay(req, request)
+	}
+	return t.record(req, request)
+}
+
+func (t *cassetteTransport) record(req *http.Request, request cassetteRequest) (*http.Response, error) {
+	t.mu.Lock()
+	next := t.next
+	t.mu.Unlock()
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	response := cassetteResponse{StatusCode: resp.StatusCode, Headers: map[string]string{}, Body: string(responseBody)}
+	for _, header := range cassetteResponseHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			response.Headers[header] = value
+		}
+	}
+	if redacted, ok := redactJSON(responseBody); ok {
+		response.Body = string(redacted)
+	}
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, &cassetteInteraction{Request: request, Response: response})
+	t.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay serves the first unused interaction of the cassette matching the request.
+// Interactions with the same method, URL and body are preferred, then the ones with the same method and URL,
+// since request bodies may hold generated values
+func (t *cassetteTransport) replay(req *http.Request, request cassetteRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	match := -1
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || interaction.Request.Method != request.Method || interaction.Request.URL != request.URL {
+			continue
+		}
+		if interaction.Request.Body == request.Body {
+			match = i
+			break
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("No interaction recorded in cassette %s for %s %s", t.name, request.Method, request.URL)
+	}
+	t.used[match] = true
+
+	response := t.cassette.Interactions[match].Response
+	header := http.Header{}
+	for key, value := range response.Headers {
+		header.Set(key, value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(response.Body))),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
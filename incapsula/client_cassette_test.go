@@ -0,0 +1,91 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		req.ParseForm()
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write([]byte(`{"res":0,"site_id":` + req.Form.Get("site_id") + `,"private_key":"secret"}`))
+	}))
+
+	recorder := &cassetteTransport{mode: cassetteModeRecord, dir: dir, next: http.DefaultTransport}
+	recorder.load(defaultCassetteName)
+	recorder.Use("TestSites")
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{Transport: recorder}}
+	for _, siteID := range []string{"1", "2"} {
+		resp, err := client.PostFormWithHeaders(context.Background(), server.URL+"/sites/status", url.Values{"site_id": {siteID}, "passphrase": {"hunter2"}}, ReadSite)
+		if err != nil {
+			t.Fatalf("Should not have received an error, got: %s", err)
+		}
+		resp.Body.Close()
+	}
+	if err := recorder.Eject(); err != nil {
+		t.Fatalf("Should have saved the cassette, got: %s", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(recorder.path("TestSites"))
+	if err != nil {
+		t.Fatalf("Should have written the cassette, got: %s", err)
+	}
+	for _, secret := range []string{"bar", "hunter2", `"secret"`} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Should have sanitized %s from the cassette, got: %s", secret, data)
+		}
+	}
+	var recorded cassette
+	json.Unmarshal(data, &recorded)
+	if len(recorded.Interactions) != 2 {
+		t.Fatalf("Should have recorded 2 interactions, got: %d", len(recorded.Interactions))
+	}
+
+	// The server is closed, so every response comes from the cassette
+	player := &cassetteTransport{mode: cassetteModeReplay, dir: dir}
+	player.load(defaultCassetteName)
+	player.Use("TestSites")
+	client.httpClient = &http.Client{Transport: player}
+	for _, siteID := range []string{"2", "1"} {
+		resp, err := client.PostFormWithHeaders(context.Background(), server.URL+"/sites/status", url.Values{"site_id": {siteID}, "passphrase": {"hunter2"}}, ReadSite)
+		if err != nil {
+			t.Fatalf("Should not have received an error, got: %s", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), `"site_id":`+siteID) {
+			t.Errorf("Should have replayed the response of site %s, got: %s", siteID, body)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Should not have reached the server in replay mode, got %d requests", requests)
+	}
+
+	_, err = client.PostFormWithHeaders(context.Background(), server.URL+"/sites/status", url.Values{"site_id": {"1"}}, ReadSite)
+	if err == nil || !strings.Contains(err.Error(), "No interaction recorded in cassette TestSites") {
+		t.Errorf("Should have received a missing interaction error, got: %v", err)
+	}
+}
+
+func TestCassetteReplayMissingCassette(t *testing.T) {
+	player := &cassetteTransport{mode: cassetteModeReplay, dir: t.TempDir()}
+	player.load("TestMissing")
+	client := &Client{config: &Config{APIID: "foo", APIKey: "bar"}, httpClient: &http.Client{Transport: player}}
+	_, err := client.GetWithHeaders(context.Background(), "http://localhost/sites", nil, ReadSite)
+	if err == nil || !strings.Contains(err.Error(), "Error reading cassette TestMissing") {
+		t.Errorf("Should have received a missing cassette error, got: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -42,7 +43,7 @@ type CustomCertificate struct {
 }
 
 // AddCertificate adds a custom SSL certificate to a site in Incapsula
-func (c *Client) AddCertificate(siteID, certificate, privateKey, passphrase, authType, inputHash string) (*CertificateAddResponse, error) {
+func (c *Client) AddCertificate(ctx context.Context, siteID, certificate, privateKey, passphrase, authType, inputHash string) (*CertificateAddResponse, error) {
 
 	log.Printf("[INFO] Adding custom certificate for site_id: %s", siteID)
 
@@ -61,11 +62,9 @@ func (c *Client) AddCertificate(siteID, certificate, privateKey, passphrase, aut
 	if authType != "" {
 		values.Set("auth_type", authType)
 	}
-	log.Printf("AddCertificate certificate\n%v", values)
-	log.Printf("certificate\n%v", certificate)
 	// Post to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateAdd)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateCustomCertificate)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateCustomCertificate)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding custom certificate for site_id %s: %s", siteID, err)
 	}
@@ -86,20 +85,20 @@ func (c *Client) AddCertificate(siteID, certificate, privateKey, passphrase, aut
 
 	// Look at the response status code from Incapsula
 	if certificateAddResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding custom certificate for site_id %s: %s", siteID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when adding custom certificate for site_id %s: %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &certificateAddResponse, nil
 }
 
 // ListCertificates gets the list of custom certificates for a site
-func (c *Client) ListCertificates(siteID, operation string) (*CertificateListResponse, error) {
+func (c *Client) ListCertificates(ctx context.Context, siteID, operation string) (*CertificateListResponse, error) {
 	log.Printf("[INFO] Getting Incapsula site custom certificates (site_id: %s)\n", siteID)
 
 	// Post form to Incapsula
 	values := url.Values{"site_id": {siteID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateList)
-	resp, err := c.PostFormWithHeaders(reqURL, values, operation)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, operation)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting custom certificates for site_id %s: %s", siteID, err)
 	}
@@ -120,14 +119,14 @@ func (c *Client) ListCertificates(siteID, operation string) (*CertificateListRes
 
 	// Look at the response status code from Incapsula
 	if certificateListResponse.Res != 0 {
-		return &certificateListResponse, fmt.Errorf("Error from Incapsula service when getting custom certificates list for site_id %s: %s", siteID, string(responseBody))
+		return &certificateListResponse, fmt.Errorf("Error from Incapsula service when getting custom certificates list for site_id %s: %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &certificateListResponse, nil
 }
 
 // EditCertificate updates the custom certifiacte on an Incapsula site
-func (c *Client) EditCertificate(siteID, certificate, privateKey, passphrase, authType, inputHash string) (*CertificateEditResponse, error) {
+func (c *Client) EditCertificate(ctx context.Context, siteID, certificate, privateKey, passphrase, authType, inputHash string) (*CertificateEditResponse, error) {
 
 	log.Printf("[INFO] Editing custom certificate for Incapsula site_id: %s\n", siteID)
 
@@ -150,7 +149,7 @@ func (c *Client) EditCertificate(siteID, certificate, privateKey, passphrase, au
 
 	// Post to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateEdit)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateCustomCertificate)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateCustomCertificate)
 	if err != nil {
 		return nil, fmt.Errorf("Error editing custom certificate for site_id: %s: %s", siteID, err)
 	}
@@ -171,14 +170,14 @@ func (c *Client) EditCertificate(siteID, certificate, privateKey, passphrase, au
 
 	// Look at the response status code from Incapsula
 	if certificateEditResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when editing custom certificarte for site_id %s: %s", siteID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when editing custom certificarte for site_id %s: %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &certificateEditResponse, nil
 }
 
 // DeleteCertificate deletes a custom certificate for a specific site in Incapsula
-func (c *Client) DeleteCertificate(siteID, authType string) error {
+func (c *Client) DeleteCertificate(ctx context.Context, siteID, authType string) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type CertificateDeleteResponse struct {
@@ -196,7 +195,7 @@ func (c *Client) DeleteCertificate(siteID, authType string) error {
 	}
 
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCertificateDelete)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteCustomCertificate)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteCustomCertificate)
 	if err != nil {
 		return fmt.Errorf("Error deleting custom certificate for site_id: %s %s", siteID, err)
 	}
@@ -230,5 +229,5 @@ func (c *Client) DeleteCertificate(siteID, authType string) error {
 		return nil
 	}
 
-	return fmt.Errorf("Error from Incapsula service when deleting custom certificate for site_id %s %s", siteID, string(responseBody))
+	return fmt.Errorf("Error from Incapsula service when deleting custom certificate for site_id %s %w", siteID, NewAPIError(resp, responseBody))
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -30,7 +31,7 @@ type HsmCertificatePutResponse struct {
 	ResMessage string `json:"res_message"`
 }
 
-func (c *Client) AddHsmCertificate(siteId, inputHash string, hSMDataDTO *HSMDataDTO) (*HsmCertificatePutResponse, error) {
+func (c *Client) AddHsmCertificate(ctx context.Context, siteId, inputHash string, hSMDataDTO *HSMDataDTO) (*HsmCertificatePutResponse, error) {
 	log.Printf("[INFO] Adding HSM certificate for site_id: %s with inputHash: %s ", siteId, inputHash)
 
 	// Put to MY (This API using put, not post)
@@ -39,7 +40,7 @@ func (c *Client) AddHsmCertificate(siteId, inputHash string, hSMDataDTO *HSMData
 		Data: *hSMDataDTO,
 	}
 
-	log.Printf("[INFO] Adding HSM certificate for site_id: %s with inputHash: %s with json: +%v", siteId, inputHash, hSMDataDTO)
+	log.Printf("[INFO] Adding HSM certificate for site_id: %s with inputHash: %s", siteId, inputHash)
 	hSMDataDTOJSON, err := json.Marshal(hsmCustomCertificate)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to JSON marshal HSMDataDTO: %s ", err)
@@ -48,7 +49,7 @@ func (c *Client) AddHsmCertificate(siteId, inputHash string, hSMDataDTO *HSMData
 	var params = map[string]string{}
 	params["input_hash"] = inputHash
 	log.Printf("[DEBUG] Add HSM certificate with params %s and JSON request: %s\n", params, string(hSMDataDTOJSON))
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPut, reqURL, hSMDataDTOJSON, params, CreateHSMCustomCertificate)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPut, reqURL, hSMDataDTOJSON, params, CreateHSMCustomCertificate)
 	if err != nil {
 		return nil, fmt.Errorf("error from Imperva service when adding HSM certificate for site_id %s: %s", siteId, err)
 	}
@@ -67,7 +68,7 @@ func (c *Client) AddHsmCertificate(siteId, inputHash string, hSMDataDTO *HSMData
 	}
 
 	if hsmCertificateAddResponse.Res != 0 {
-		return nil, fmt.Errorf("error adding HSM certificate- res not 0. siteId: %s resposne:%s", siteId, string(responseBody))
+		return nil, fmt.Errorf("error adding HSM certificate- res not 0. siteId: %s: %w", siteId, NewAPIError(resp, responseBody))
 	}
 
 	log.Printf("[DEBUG] Imperva add HSM certificate clent pat ended successfully for site id: %s", siteId)
@@ -80,7 +81,7 @@ func getHsmUrl(siteId string, c *Client) string {
 }
 
 // DeleteHsmCustomCertificate deletes a hsm certificate for a specific site in Imperva
-func (c *Client) DeleteHsmCertificate(siteId string) error {
+func (c *Client) DeleteHsmCertificate(ctx context.Context, siteId string) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type CertificateDeleteResponse struct {
@@ -92,7 +93,7 @@ func (c *Client) DeleteHsmCertificate(siteId string) error {
 
 	// Post form to Incapsula
 	reqURL := getHsmUrl(siteId, c)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, reqURL, nil, nil, DeleteHsmCustomCertificate)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, nil, DeleteHsmCustomCertificate)
 	if err != nil {
 		return fmt.Errorf("error deleting HSM certificate while sending request. siteId: %s %s", siteId, err)
 	}
@@ -101,7 +102,7 @@ func (c *Client) DeleteHsmCertificate(siteId string) error {
 	defer resp.Body.Close()
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Imperva service when deleting hsm certificate for site id %s: %s ", resp.StatusCode, siteId, string(responseBody))
+		return fmt.Errorf("Error status code %d from Imperva service when deleting hsm certificate for site id %s: %w ", resp.StatusCode, siteId, NewAPIError(resp, responseBody))
 	}
 
 	if err != nil {
@@ -119,7 +120,7 @@ func (c *Client) DeleteHsmCertificate(siteId string) error {
 
 	if hsmCertificateDeleteResponse.Res != 0 {
 		log.Printf("[DEBUG] response: %+v", hsmCertificateDeleteResponse)
-		return fmt.Errorf("error deleting HSM certificate- res not 0. siteId: %s resposne:%s", siteId, string(responseBody))
+		return fmt.Errorf("error deleting HSM certificate- res not 0. siteId: %s: %w", siteId, NewAPIError(resp, responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -27,7 +28,7 @@ func TestClientCreateCertificateSigningRequestBadConnection(t *testing.T) {
 	city := "BNE"
 	organization := "Tacos Pty Ltd"
 	organizationUnit := "Sales"
-	certificateSigningRequestCreateResponse, err := client.CreateCertificateSigningRequest(siteID, domain, email, country, state, city, organization, organizationUnit)
+	certificateSigningRequestCreateResponse, err := client.CreateCertificateSigningRequest(context.Background(), siteID, domain, email, country, state, city, organization, organizationUnit)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -60,7 +61,7 @@ func TestClientCreateCertificateSigningRequestBadJSON(t *testing.T) {
 	city := "BNE"
 	organization := "Tacos Pty Ltd"
 	organizationUnit := "Sales"
-	certificateSigningRequestCreateResponse, err := client.CreateCertificateSigningRequest(siteID, domain, email, country, state, city, organization, organizationUnit)
+	certificateSigningRequestCreateResponse, err := client.CreateCertificateSigningRequest(context.Background(), siteID, domain, email, country, state, city, organization, organizationUnit)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
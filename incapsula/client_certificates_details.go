@@ -0,0 +1,72 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strconv"
+)
+
+// Certificate types of the certificates details API
+const (
+	certificateDetailsTypeCustom  = "CUSTOM"
+	certificateDetailsTypeManaged = "ATLAS"
+	certificateHostingTypeHSM     = "HSM"
+)
+
+// CertificateDetailsDTO is a certificate covering a site: a custom certificate, uploaded or stored in an HSM, or an
+// Imperva managed certificate
+type CertificateDetailsDTO struct {
+	Id             int      `json:"id"`
+	Name           string   `json:"name,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	HostingType    string   `json:"hostingType,omitempty"`
+	ExpirationDate int64    `json:"expirationDate,omitempty"`
+	InRenewal      bool     `json:"inRenewal,omitempty"`
+	Sans           []SanDTO `json:"sans,omitempty"`
+}
+
+// CertificatesDetailsResponse contains the certificates covering a site
+type CertificatesDetailsResponse struct {
+	Data   []CertificateDetailsDTO `json:"data"`
+	Errors []APIErrors             `json:"errors"`
+}
+
+// ListSiteCertificatesDetails gets the custom and managed certificates covering a site
+func (c *Client) ListSiteCertificatesDetails(ctx context.Context, siteID int, accountID int) (*CertificatesDetailsResponse, error) {
+	log.Printf("[INFO] Getting the certificates details of site %d\n", siteID)
+
+	queryParams := url.Values{"extSiteId": {strconv.Itoa(siteID)}}
+	if accountID != 0 {
+		queryParams.Set("caid", strconv.Itoa(accountID))
+	}
+	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURLAPI, endpointCertDetails)
+	resp, err := c.GetWithHeaders(ctx, reqURL, queryParams, ReadSiteCertificatesDetails)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the certificates details of site %d: %s", siteID, err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Imperva certificates details JSON response: %s\n", string(responseBody))
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Error status code %d from Imperva service when getting the certificates details of site %d: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
+	}
+
+	// Parse the JSON
+	var certificatesDetailsResponse CertificatesDetailsResponse
+	err = json.Unmarshal(responseBody, &certificatesDetailsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the certificates details JSON response of site %d: %s\nresponse: %s", siteID, err, string(responseBody))
+	}
+
+	return &certificatesDetailsResponse, nil
+}
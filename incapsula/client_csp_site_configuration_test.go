@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -15,7 +16,7 @@ func TestCspSiteConfigBadConnection(t *testing.T) {
 	siteID := 42
 	accountID := 55
 
-	ret, err := client.GetCSPSite(accountID, siteID)
+	ret, err := client.GetCSPSite(context.Background(), accountID, siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -27,7 +28,7 @@ func TestCspSiteConfigBadConnection(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	ret, err = client.UpdateCSPSite(accountID, siteID, &CSPSiteConfig{})
+	ret, err = client.UpdateCSPSite(context.Background(), accountID, siteID, &CSPSiteConfig{})
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -60,7 +61,7 @@ func TestCSPSiteConfigErrorResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, err := client.GetCSPSite(accountID, siteID)
+	ret, err := client.GetCSPSite(context.Background(), accountID, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -71,7 +72,7 @@ func TestCSPSiteConfigErrorResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	ret, err = client.UpdateCSPSite(accountID, siteID, &CSPSiteConfig{})
+	ret, err = client.UpdateCSPSite(context.Background(), accountID, siteID, &CSPSiteConfig{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -103,7 +104,7 @@ func TestCSPSiteConfigInvalidResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, err := client.GetCSPSite(accountID, siteID)
+	ret, err := client.GetCSPSite(context.Background(), accountID, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -114,7 +115,7 @@ func TestCSPSiteConfigInvalidResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	ret, err = client.UpdateCSPSite(accountID, siteID, &CSPSiteConfig{})
+	ret, err = client.UpdateCSPSite(context.Background(), accountID, siteID, &CSPSiteConfig{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -156,7 +157,7 @@ func TestCSPSiteConfigResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	ret, err := client.GetCSPSite(accountID, siteID)
+	ret, err := client.GetCSPSite(context.Background(), accountID, siteID)
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
@@ -176,7 +177,7 @@ func TestCSPSiteConfigResponse(t *testing.T) {
 		t.Errorf("Incorrect value inresponse from GetCSPSite")
 	}
 
-	ret, err = client.UpdateCSPSite(accountID, siteID, &CSPSiteConfig{})
+	ret, err = client.UpdateCSPSite(context.Background(), accountID, siteID, &CSPSiteConfig{})
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -27,7 +28,7 @@ type CSPPreApprovedDomain struct {
 	ReferenceID string `json:"referenceId"`
 }
 
-func (c *Client) getCSPDomainAPI(accountID, siteID int, domain string, APIPath string, ret interface{}) error {
+func (c *Client) getCSPDomainAPI(ctx context.Context, accountID, siteID int, domain string, APIPath string, ret interface{}) error {
 	log.Printf("[INFO] Getting CSP domain %s for domain %s from site ID: %d\n", APIPath, domain, siteID)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
@@ -35,13 +36,13 @@ func (c *Client) getCSPDomainAPI(accountID, siteID int, domain string, APIPath s
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			strings.Trim(fmt.Sprintf("%s%s/%d/domains/%s/%s?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, APIPath, accountID),
 				"/"),
 			nil,
 			ReadCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			strings.Trim(fmt.Sprintf("%s%s/%d/domains/%s/%s", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, APIPath),
 				"/"),
 			nil,
@@ -60,8 +61,8 @@ func (c *Client) getCSPDomainAPI(accountID, siteID int, domain string, APIPath s
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from CSP API when getting domain %s for domain %s from site %d: %s\n",
-			resp.StatusCode, APIPath, domain, siteID, string(responseBody))
+		return fmt.Errorf("Error status code %d from CSP API when getting domain %s for domain %s from site %d: %w\n",
+			resp.StatusCode, APIPath, domain, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -74,15 +75,15 @@ func (c *Client) getCSPDomainAPI(accountID, siteID int, domain string, APIPath s
 	return nil
 }
 
-func (c *Client) getCSPDomainStatus(accountID, siteID int, domain string) (*CSPDomainStatus, error) {
+func (c *Client) getCSPDomainStatus(ctx context.Context, accountID, siteID int, domain string) (*CSPDomainStatus, error) {
 	ret := &CSPDomainStatus{}
-	if err := c.getCSPDomainAPI(accountID, siteID, domain, "status", ret); err != nil {
+	if err := c.getCSPDomainAPI(ctx, accountID, siteID, domain, "status", ret); err != nil {
 		return nil, err
 	}
 	return ret, nil
 }
 
-func (c *Client) updateCSPDomainStatus(accountID, siteID int, domain string, status *CSPDomainStatus) (*CSPDomainStatus, error) {
+func (c *Client) updateCSPDomainStatus(ctx context.Context, accountID, siteID int, domain string, status *CSPDomainStatus) (*CSPDomainStatus, error) {
 	log.Printf("[INFO] Updating CSP domain status for domain %s from site ID: %d to: %v\n", domain, siteID, status)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
@@ -94,12 +95,12 @@ func (c *Client) updateCSPDomainStatus(accountID, siteID int, domain string, sta
 
 	var resp *http.Response
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPut,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPut,
 			fmt.Sprintf("%s%s/%d/domains/%s/status?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			statusJSON,
 			UpdateCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPut,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPut,
 			fmt.Sprintf("%s%s/%d/domains/%s/status", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			statusJSON,
 			UpdateCspSiteDomain)
@@ -117,8 +118,8 @@ func (c *Client) updateCSPDomainStatus(accountID, siteID int, domain string, sta
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from CSP API when updating domain status for domain %s from site %d: %s\n",
-			resp.StatusCode, domain, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from CSP API when updating domain status for domain %s from site %d: %w\n",
+			resp.StatusCode, domain, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -132,15 +133,15 @@ func (c *Client) updateCSPDomainStatus(accountID, siteID int, domain string, sta
 	return st, nil
 }
 
-func (c *Client) getCSPDomainNotes(accountID, siteID int, domain string) ([]CSPDomainNote, error) {
+func (c *Client) getCSPDomainNotes(ctx context.Context, accountID, siteID int, domain string) ([]CSPDomainNote, error) {
 	var ret []CSPDomainNote
-	if err := c.getCSPDomainAPI(accountID, siteID, domain, "notes", &ret); err != nil {
+	if err := c.getCSPDomainAPI(ctx, accountID, siteID, domain, "notes", &ret); err != nil {
 		return nil, err
 	}
 	return ret, nil
 }
 
-func (c *Client) addCSPDomainNote(accountID, siteID int, domain string, note string) error {
+func (c *Client) addCSPDomainNote(ctx context.Context, accountID, siteID int, domain string, note string) error {
 	log.Printf("[INFO] Getting CSP domain notes for domain %s from site ID: %d\n", domain, siteID)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
@@ -148,12 +149,12 @@ func (c *Client) addCSPDomainNote(accountID, siteID int, domain string, note str
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPost,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 			fmt.Sprintf("%s%s/%d/domains/%s/notes?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			[]byte(note),
 			CreateCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPost,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 			fmt.Sprintf("%s%s/%d/domains/%s/notes", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			[]byte(note),
 			CreateCspSiteDomain)
@@ -171,8 +172,8 @@ func (c *Client) addCSPDomainNote(accountID, siteID int, domain string, note str
 
 	// Check the response code
 	if resp.StatusCode != 201 {
-		return fmt.Errorf("Error status code %d from CSP API when getting domain notes for domain %s from site %d: %s\n",
-			resp.StatusCode, domain, siteID, string(responseBody))
+		return fmt.Errorf("Error status code %d from CSP API when getting domain notes for domain %s from site %d: %w\n",
+			resp.StatusCode, domain, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -186,7 +187,7 @@ func (c *Client) addCSPDomainNote(accountID, siteID int, domain string, note str
 	return nil
 }
 
-func (c *Client) deleteCSPDomainNotes(accountID, siteID int, domain string) error {
+func (c *Client) deleteCSPDomainNotes(ctx context.Context, accountID, siteID int, domain string) error {
 	log.Printf("[INFO] Deleting CSP domain notes for domain %s from site ID: %d\n", domain, siteID)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
@@ -194,11 +195,11 @@ func (c *Client) deleteCSPDomainNotes(accountID, siteID int, domain string) erro
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodDelete,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 			fmt.Sprintf("%s%s/%d/domains/%s/notes?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			nil, "")
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodDelete,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 			fmt.Sprintf("%s%s/%d/domains/%s/notes", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			nil, "")
 	}
@@ -208,29 +209,30 @@ func (c *Client) deleteCSPDomainNotes(accountID, siteID int, domain string) erro
 
 	// Read the body
 	defer resp.Body.Close()
+	responseBody, _ := ioutil.ReadAll(resp.Body)
 
 	// Check the response code
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("Error status code %d from CSP API when getting domain notes for domain %s from site %d\n",
-			resp.StatusCode, domain, siteID)
+		return fmt.Errorf("Error status code %d from CSP API when getting domain notes for domain %s from site %d: %w",
+			resp.StatusCode, domain, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
 }
 
-func (c *Client) getCSPPreApprovedDomain(accountID, siteID int, domain string) (*CSPPreApprovedDomain, error) {
+func (c *Client) getCSPPreApprovedDomain(ctx context.Context, accountID, siteID int, domain string) (*CSPPreApprovedDomain, error) {
 	log.Printf("[INFO] Getting CSP pre-approved domain %s from site ID: %d\n", domain, siteID)
 
 	domainRef := base64.RawURLEncoding.EncodeToString([]byte(domain))
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			fmt.Sprintf("%s%s/%d/preapprovedlist/%s?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			nil,
 			ReadCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodGet,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodGet,
 			fmt.Sprintf("%s%s/%d/preapprovedlist/%s", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			nil,
 			ReadCspSiteDomain)
@@ -248,8 +250,8 @@ func (c *Client) getCSPPreApprovedDomain(accountID, siteID int, domain string) (
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from CSP API when getting pre-approved domain %s for site %d: %s\n",
-			resp.StatusCode, domain, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from CSP API when getting pre-approved domain %s for site %d: %w\n",
+			resp.StatusCode, domain, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -263,7 +265,7 @@ func (c *Client) getCSPPreApprovedDomain(accountID, siteID int, domain string) (
 	return &preApprovedDomain, nil
 }
 
-func (c *Client) updateCSPPreApprovedDomain(accountID, siteID int, dom *CSPPreApprovedDomain) (*CSPPreApprovedDomain, error) {
+func (c *Client) updateCSPPreApprovedDomain(ctx context.Context, accountID, siteID int, dom *CSPPreApprovedDomain) (*CSPPreApprovedDomain, error) {
 	log.Printf("[INFO] Updating CSP pre-approved domain for site ID: %d , domain: %v", siteID, dom)
 
 	domJSON, err := json.Marshal(dom)
@@ -273,11 +275,11 @@ func (c *Client) updateCSPPreApprovedDomain(accountID, siteID int, dom *CSPPreAp
 
 	var resp *http.Response
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPost,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 			fmt.Sprintf("%s%s/%d/preapprovedlist?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, accountID),
 			domJSON, UpdateCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodPost,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodPost,
 			fmt.Sprintf("%s%s/%d/preapprovedlist", c.config.BaseURLAPI, CSPSiteApiPath, siteID),
 			domJSON, UpdateCspSiteDomain)
 	}
@@ -294,8 +296,8 @@ func (c *Client) updateCSPPreApprovedDomain(accountID, siteID int, dom *CSPPreAp
 
 	// Check the response code
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("Error status code %d from CSP API when updating pre-approved domain for site %d: %s\n",
-			resp.StatusCode, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from CSP API when updating pre-approved domain for site %d: %w\n",
+			resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -309,18 +311,18 @@ func (c *Client) updateCSPPreApprovedDomain(accountID, siteID int, dom *CSPPreAp
 	return &updatedDom, nil
 }
 
-func (c *Client) deleteCSPPreApprovedDomains(accountID, siteID int, domainRef string) error {
+func (c *Client) deleteCSPPreApprovedDomains(ctx context.Context, accountID, siteID int, domainRef string) error {
 	log.Printf("[INFO] Deleting CSP pre-approved domain %s for site ID: %d\n", domainRef, siteID)
 
 	var resp *http.Response
 	var err error
 	if accountID != 0 {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodDelete,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 			fmt.Sprintf("%s%s/%d/preapprovedlist/%s?caid=%d", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef, accountID),
 			nil,
 			DeleteCspSiteDomain)
 	} else {
-		resp, err = c.DoJsonRequestWithHeaders(http.MethodDelete,
+		resp, err = c.DoJsonRequestWithHeaders(ctx, http.MethodDelete,
 			fmt.Sprintf("%s%s/%d/preapprovedlist/%s", c.config.BaseURLAPI, CSPSiteApiPath, siteID, domainRef),
 			nil,
 			DeleteCspSiteDomain)
@@ -331,11 +333,12 @@ func (c *Client) deleteCSPPreApprovedDomains(accountID, siteID int, domainRef st
 
 	// Read the body
 	defer resp.Body.Close()
+	responseBody, _ := ioutil.ReadAll(resp.Body)
 
 	// Check the response code - no content for DELETE
 	if resp.StatusCode != 204 {
-		return fmt.Errorf("Error status code %d from CSP API when deleting pre-approved domain %s for site ID %d\n",
-			resp.StatusCode, domainRef, siteID)
+		return fmt.Errorf("Error status code %d from CSP API when deleting pre-approved domain %s for site ID %d: %w",
+			resp.StatusCode, domainRef, siteID, NewAPIError(resp, responseBody))
 	}
 	log.Printf("[DEBUG] CSP API Delete Pre-Approved Domain %s was successful\n", domainRef)
 
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -16,7 +17,7 @@ func TestCspSiteDomainBadConnection(t *testing.T) {
 	siteID := 42
 	accountID := 55
 
-	updatedDom, err := client.updateCSPPreApprovedDomain(accountID, siteID, &CSPPreApprovedDomain{})
+	updatedDom, err := client.updateCSPPreApprovedDomain(context.Background(), accountID, siteID, &CSPPreApprovedDomain{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -27,7 +28,7 @@ func TestCspSiteDomainBadConnection(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.deleteCSPPreApprovedDomains(accountID, siteID, "ref")
+	err = client.deleteCSPPreApprovedDomains(context.Background(), accountID, siteID, "ref")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -52,7 +53,7 @@ func TestCspSiteDomainErrorResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updatedDom, err := client.updateCSPPreApprovedDomain(accountID, siteID, &CSPPreApprovedDomain{})
+	updatedDom, err := client.updateCSPPreApprovedDomain(context.Background(), accountID, siteID, &CSPPreApprovedDomain{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -63,7 +64,7 @@ func TestCspSiteDomainErrorResponse(t *testing.T) {
 		t.Errorf("Should have received a nil response")
 	}
 
-	err = client.deleteCSPPreApprovedDomains(accountID, siteID, "ref")
+	err = client.deleteCSPPreApprovedDomains(context.Background(), accountID, siteID, "ref")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -92,7 +93,7 @@ func TestCspSiteDomainInvalidResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updatedDom, err := client.updateCSPPreApprovedDomain(accountID, siteID, &CSPPreApprovedDomain{})
+	updatedDom, err := client.updateCSPPreApprovedDomain(context.Background(), accountID, siteID, &CSPPreApprovedDomain{})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -128,7 +129,7 @@ func TestCSPSiteDomainPreApprovedGetResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	domain, err := client.getCSPPreApprovedDomain(accountID, siteID, "domain.com")
+	domain, err := client.getCSPPreApprovedDomain(context.Background(), accountID, siteID, "domain.com")
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
@@ -167,7 +168,7 @@ func TestCSPSiteDomainPreApprovedUpdateResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	domain, err := client.updateCSPPreApprovedDomain(accountID, siteID, &CSPPreApprovedDomain{})
+	domain, err := client.updateCSPPreApprovedDomain(context.Background(), accountID, siteID, &CSPPreApprovedDomain{})
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
@@ -210,7 +211,7 @@ func TestCSPSiteDomainNotesResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	notes, err := client.getCSPDomainNotes(accountID, siteID, domain)
+	notes, err := client.getCSPDomainNotes(context.Background(), accountID, siteID, domain)
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
@@ -251,7 +252,7 @@ func TestCSPSiteDomainStatusResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	notes, err := client.getCSPDomainStatus(accountID, siteID, domain)
+	notes, err := client.getCSPDomainStatus(context.Background(), accountID, siteID, domain)
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
@@ -282,7 +283,7 @@ func TestCSPSiteDomainStatusEmptyResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	notes, err := client.getCSPDomainStatus(accountID, siteID, domain)
+	notes, err := client.getCSPDomainStatus(context.Background(), accountID, siteID, domain)
 	if err != nil {
 		t.Errorf("Should have not received an error")
 	}
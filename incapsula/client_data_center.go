@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -46,7 +47,7 @@ type DataCenterEditResponse struct {
 }
 
 // AddDataCenter adds an incap rule to be managed by Incapsula
-func (c *Client) AddDataCenter(siteID, name, serverAddress, isContent, isEnabled string) (*DataCenterAddResponse, error) {
+func (c *Client) AddDataCenter(ctx context.Context, siteID, name, serverAddress, isContent, isEnabled string) (*DataCenterAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula data center for siteID: %s\n", siteID)
 
 	// Post form to Incapsula
@@ -58,7 +59,7 @@ func (c *Client) AddDataCenter(siteID, name, serverAddress, isContent, isEnabled
 		"is_enabled":     {isEnabled},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterAdd)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateDataCenter)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateDataCenter)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding data center for siteID %s: %s", siteID, err)
 	}
@@ -89,20 +90,20 @@ func (c *Client) AddDataCenter(siteID, name, serverAddress, isContent, isEnabled
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding data center for siteID %s: %s", siteID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when adding data center for siteID %s: %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &dataCenterAddResponse, nil
 }
 
 // ListDataCenters gets the Incapsula list of data centers
-func (c *Client) ListDataCenters(siteID string) (*DataCenterListResponse, error) {
+func (c *Client) ListDataCenters(ctx context.Context, siteID string) (*DataCenterListResponse, error) {
 	log.Printf("[INFO] Getting Incapsula data centers (site_id: %s)\n", siteID)
 
 	// Post form to Incapsula
 	values := url.Values{"site_id": {siteID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterList)
-	resp, err := c.PostFormWithHeaders(reqURL, values, ReadDataCenter)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadDataCenter)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting data centers for siteID %s: %s", siteID, err)
 	}
@@ -133,14 +134,14 @@ func (c *Client) ListDataCenters(siteID string) (*DataCenterListResponse, error)
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return &dataCenterListResponse, fmt.Errorf("Error from Incapsula service when getting data centers list (site_id: %s): %s", siteID, string(responseBody))
+		return &dataCenterListResponse, fmt.Errorf("Error from Incapsula service when getting data centers list (site_id: %s): %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &dataCenterListResponse, nil
 }
 
 // EditDataCenter edits the Incapsula incap rule
-func (c *Client) EditDataCenter(dcID, name, isContent, isEnabled string) (*DataCenterEditResponse, error) {
+func (c *Client) EditDataCenter(ctx context.Context, dcID, name, isContent, isEnabled string) (*DataCenterEditResponse, error) {
 	log.Printf("[INFO] Editing Incapsula data center for dcID: %s\n", dcID)
 
 	values := url.Values{
@@ -161,7 +162,7 @@ func (c *Client) EditDataCenter(dcID, name, isContent, isEnabled string) (*DataC
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterEdit)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateDataCenter)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateDataCenter)
 	if err != nil {
 		return nil, fmt.Errorf("Error editing data center (%s): %s", dcID, err)
 	}
@@ -196,14 +197,14 @@ func (c *Client) EditDataCenter(dcID, name, isContent, isEnabled string) (*DataC
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when editing data center (%s): %s", dcID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when editing data center (%s): %w", dcID, NewAPIError(resp, responseBody))
 	}
 
 	return &dataCenterEditResponse, nil
 }
 
 // DeleteDataCenter deletes a site currently managed by Incapsula
-func (c *Client) DeleteDataCenter(dcID string) error {
+func (c *Client) DeleteDataCenter(ctx context.Context, dcID string) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type DataCenterDeleteResponse struct {
@@ -216,7 +217,7 @@ func (c *Client) DeleteDataCenter(dcID string) error {
 	// Post form to Incapsula
 	values := url.Values{"dc_id": {dcID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterDelete)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteDataCenter)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteDataCenter)
 	if err != nil {
 		return fmt.Errorf("Error deleting data center (%s): %s", dcID, err)
 	}
@@ -254,5 +255,5 @@ func (c *Client) DeleteDataCenter(dcID string) error {
 		return nil
 	}
 
-	return fmt.Errorf("Error from Incapsula service when deleting data center (%s): %s", dcID, string(responseBody))
+	return fmt.Errorf("Error from Incapsula service when deleting data center (%s): %w", dcID, NewAPIError(resp, responseBody))
 }
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -27,7 +28,7 @@ type DataCenterServerEditResponse struct {
 }
 
 // AddDataCenterServer adds an incap data center server to be managed by Incapsula
-func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string, isEnabled string) (*DataCenterServerAddResponse, error) {
+func (c *Client) AddDataCenterServer(ctx context.Context, dcID, serverAddress, isStandby string, isEnabled string) (*DataCenterServerAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula data center server for dcID: %s\n", dcID)
 
 	bIsEnabled, err := strconv.ParseBool(isEnabled)
@@ -43,7 +44,7 @@ func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string, isEn
 		"is_disabled":    {strconv.FormatBool(!bIsEnabled)},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerAdd)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateDataCenterServer)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateDataCenterServer)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %s", dcID, err)
 	}
@@ -74,14 +75,14 @@ func (c *Client) AddDataCenterServer(dcID, serverAddress, isStandby string, isEn
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %s", dcID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when adding data center server for dcID %s: %w", dcID, NewAPIError(resp, responseBody))
 	}
 
 	return &dataCenterServerAddResponse, nil
 }
 
 // EditDataCenterServer edits the Incapsula data center server
-func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnabled string) (*DataCenterServerEditResponse, error) {
+func (c *Client) EditDataCenterServer(ctx context.Context, serverID, serverAddress, isStandby, isEnabled string) (*DataCenterServerEditResponse, error) {
 	log.Printf("[INFO] Editing Incapsula data center server for serverID: %s\n", serverID)
 
 	// Post form to Incapsula
@@ -92,7 +93,7 @@ func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnab
 		"is_enabled":     {isEnabled},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerEdit)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateDataCenterServer)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateDataCenterServer)
 	if err != nil {
 		return nil, fmt.Errorf("Error editing data center server for serverID: %s: %s", serverID, err)
 	}
@@ -123,14 +124,14 @@ func (c *Client) EditDataCenterServer(serverID, serverAddress, isStandby, isEnab
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when editing data center server for serverID %s: %s", serverID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when editing data center server for serverID %s: %w", serverID, NewAPIError(resp, responseBody))
 	}
 
 	return &dataCenterServerEditResponse, nil
 }
 
 // DeleteDataCenterServer deletes a data center server currently managed by Incapsula
-func (c *Client) DeleteDataCenterServer(serverID string) error {
+func (c *Client) DeleteDataCenterServer(ctx context.Context, serverID string) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type DataCenterServerDeleteResponse struct {
@@ -143,7 +144,7 @@ func (c *Client) DeleteDataCenterServer(serverID string) error {
 	// Post form to Incapsula
 	values := url.Values{"server_id": {serverID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataCenterServerDelete)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteDataCenterServer)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteDataCenterServer)
 	if err != nil {
 		return fmt.Errorf("Error deleting data center server (server_id: %s): %s", serverID, err)
 	}
@@ -177,5 +178,5 @@ func (c *Client) DeleteDataCenterServer(serverID string) error {
 		return nil
 	}
 
-	return fmt.Errorf("Error from Incapsula service when deleting data center server (server_id: %s): %s", serverID, string(responseBody))
+	return fmt.Errorf("Error from Incapsula service when deleting data center server (server_id: %s): %w", serverID, NewAPIError(resp, responseBody))
 }
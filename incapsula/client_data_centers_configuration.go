@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -63,13 +64,13 @@ type DataCentersConfigurationDTO struct {
 }
 
 // AddDataCenter adds an incap rule to be managed by Incapsula
-func (c *Client) PutDataCentersConfiguration(siteID string, requestDTO DataCentersConfigurationDTO) (*DataCentersConfigurationDTO, error) {
+func (c *Client) PutDataCentersConfiguration(ctx context.Context, siteID string, requestDTO DataCentersConfigurationDTO) (*DataCentersConfigurationDTO, error) {
 	log.Printf("[INFO] Updating Incapsula data centers configuration for siteID: %s\n", siteID)
 
 	baseURLv3 := c.config.BaseURL[:len(c.config.BaseURL)-3] + "/v3"
 	dcsJSON, err := json.Marshal(requestDTO)
 	reqURL := fmt.Sprintf("%s/sites/%s/data-centers-configuration", baseURLv3, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, dcsJSON, CreateDataCenterConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, dcsJSON, CreateDataCenterConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("Error executing update Data Centers configuration request for siteID %s: %s", siteID, err)
 	}
@@ -92,13 +93,13 @@ func (c *Client) PutDataCentersConfiguration(siteID string, requestDTO DataCente
 }
 
 // ListDataCenters gets the Incapsula list of data centers
-func (c *Client) GetDataCentersConfiguration(siteID string) (*DataCentersConfigurationDTO, error) {
+func (c *Client) GetDataCentersConfiguration(ctx context.Context, siteID string) (*DataCentersConfigurationDTO, error) {
 	log.Printf("[INFO] Getting Data Centers configuration (site_id: %s)\n", siteID)
 
 	// Get request to Incapsula
 	baseURLv3 := c.config.BaseURL[:len(c.config.BaseURL)-3] + "/v3"
 	reqURL := fmt.Sprintf("%s/sites/%s/data-centers-configuration", baseURLv3, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadDataCenterConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadDataCenterConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("Error executing get Data Centers configuration request for siteID %s: %s", siteID, err)
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestClientPutDataCentersConfigurationBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
 	requestDTO := DataCentersConfigurationDTO{}
-	responseDTO, err := client.PutDataCentersConfiguration(siteID, requestDTO)
+	responseDTO, err := client.PutDataCentersConfiguration(context.Background(), siteID, requestDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -44,7 +45,7 @@ func TestClientPutDataCentersConfigurationBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	requestDTO := DataCentersConfigurationDTO{}
-	responseDTO, err := client.PutDataCentersConfiguration(siteID, requestDTO)
+	responseDTO, err := client.PutDataCentersConfiguration(context.Background(), siteID, requestDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -70,7 +71,7 @@ func TestClientPutDataCenterInvalidDcConfiguration(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	requestDTO := DataCentersConfigurationDTO{}
-	responseDTO, err := client.PutDataCentersConfiguration(siteID, requestDTO)
+	responseDTO, err := client.PutDataCentersConfiguration(context.Background(), siteID, requestDTO)
 	if err != nil {
 		t.Errorf("Should not receive an error. Got: %s", err.Error())
 	}
@@ -97,7 +98,7 @@ func TestClientPutDataCenterValidDcConfiguration(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	requestDTO := DataCentersConfigurationDTO{}
-	responseDTO, err := client.PutDataCentersConfiguration(siteID, requestDTO)
+	responseDTO, err := client.PutDataCentersConfiguration(context.Background(), siteID, requestDTO)
 	if err != nil {
 		t.Errorf("Should not have received an error. Got: %s", err.Error())
 	}
@@ -124,7 +125,7 @@ func TestClientGetDataCentersConfigurationBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
-	responseDTO, err := client.GetDataCentersConfiguration(siteID)
+	responseDTO, err := client.GetDataCentersConfiguration(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -150,7 +151,7 @@ func TestClientGetDataCentersConfigurationBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	responseDTO, err := client.GetDataCentersConfiguration(siteID)
+	responseDTO, err := client.GetDataCentersConfiguration(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -175,7 +176,7 @@ func TestClientGetDataCentersConfigurationInvalidRequest(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	responseDTO, err := client.GetDataCentersConfiguration(siteID)
+	responseDTO, err := client.GetDataCentersConfiguration(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not receive an error. Got: %s", err.Error())
 	}
@@ -201,7 +202,7 @@ func TestClientGetDataCentersConfigurationValidRequest(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	responseDTO, err := client.GetDataCentersConfiguration(siteID)
+	responseDTO, err := client.GetDataCentersConfiguration(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
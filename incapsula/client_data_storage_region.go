@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -22,13 +23,13 @@ type DataStorageRegionResponse struct {
 }
 
 // GetDataStorageRegion gets the data storage region for the site
-func (c *Client) GetDataStorageRegion(siteID string) (*DataStorageRegionResponse, error) {
+func (c *Client) GetDataStorageRegion(ctx context.Context, siteID string) (*DataStorageRegionResponse, error) {
 	log.Printf("[INFO] Getting Incapsula data storage region for site: %s\n", siteID)
 
 	// Post form to Incapsula
 	values := url.Values{"site_id": {siteID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataStorageRegionGet)
-	resp, err := c.PostFormWithHeaders(reqURL, values, ReadDataStorageRegion)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadDataStorageRegion)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting data storage region for site id: %s: %s", siteID, err)
 	}
@@ -49,14 +50,14 @@ func (c *Client) GetDataStorageRegion(siteID string) (*DataStorageRegionResponse
 
 	// Look at the response status code from Incapsula
 	if dataStorageRegionResponse.Res != 0 {
-		return &dataStorageRegionResponse, fmt.Errorf("Error from Incapsula service when getting site data storage region for site id: %s: %s", siteID, string(responseBody))
+		return &dataStorageRegionResponse, fmt.Errorf("Error from Incapsula service when getting site data storage region for site id: %s: %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &dataStorageRegionResponse, nil
 }
 
 // UpdateDataStorageRegion will update the data storage region on the site
-func (c *Client) UpdateDataStorageRegion(siteID, region string) (*DataStorageRegionResponse, error) {
+func (c *Client) UpdateDataStorageRegion(ctx context.Context, siteID, region string) (*DataStorageRegionResponse, error) {
 	log.Printf("[INFO] Updating Incapsula site data storage region (%s) for siteID: %s\n", region, siteID)
 
 	// Post form to Incapsula
@@ -65,7 +66,7 @@ func (c *Client) UpdateDataStorageRegion(siteID, region string) (*DataStorageReg
 		"data_storage_region": {region},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointDataStorageRegionUpdate)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateDataStorageRegion)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateDataStorageRegion)
 	if err != nil {
 		return nil, fmt.Errorf("Error updating data storage region with value (%s) on site_id: %s: %s", region, siteID, err)
 	}
@@ -86,7 +87,7 @@ func (c *Client) UpdateDataStorageRegion(siteID, region string) (*DataStorageReg
 
 	// Look at the response status code from Incapsula
 	if dataStorageRegionResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when updating site data storage region for siteID %s: %s", siteID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when updating site data storage region for siteID %s: %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &dataStorageRegionResponse, nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientGetDataStorageRegionBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "123"
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteID)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -41,7 +42,7 @@ func TestClientGetDataStorageRegionBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "123"
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteID)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientGetDataStorageRegionInvalidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "7289383"
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteID)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -89,7 +90,7 @@ func TestClientGetDataStorageRegionValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "123"
-	dataStorageRegionResponse, err := client.GetDataStorageRegion(siteID)
+	dataStorageRegionResponse, err := client.GetDataStorageRegion(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -113,7 +114,7 @@ func TestClientUpdateDataStorageRegionBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(siteID, region)
+	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(context.Background(), siteID, region)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -138,7 +139,7 @@ func TestClientUpdateDataStorageRegionBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(siteID, region)
+	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(context.Background(), siteID, region)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -163,7 +164,7 @@ func TestClientUpdateDataStorageRegionInvalidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "7293873"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(siteID, region)
+	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(context.Background(), siteID, region)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -188,7 +189,7 @@ func TestClientUpdateDataStorageRegionValidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "7293873"
 	region := "US"
-	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(siteID, region)
+	dataStorageRegionResponse, err := client.UpdateDataStorageRegion(context.Background(), siteID, region)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
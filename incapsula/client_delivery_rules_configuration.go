@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -36,12 +37,12 @@ type DeliveryRuleDto struct {
 	Enabled                 bool   `json:"enabled"`
 }
 
-func (c *Client) ReadDeliveryRuleConfiguration(siteID string, category string) (*DeliveryRulesListDTO, diag.Diagnostics) {
+func (c *Client) ReadDeliveryRuleConfiguration(ctx context.Context, siteID string, category string) (*DeliveryRulesListDTO, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] Getting Delivery rules Type Rule %s for Site ID %s\n", category, siteID)
 
 	reqURL := fmt.Sprintf("%s/sites/%s/delivery-rules-configuration?category=%s", c.config.BaseURLRev3, siteID, category)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadDeliveryRuleConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadDeliveryRuleConfiguration)
 
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -58,11 +59,10 @@ func (c *Client) ReadDeliveryRuleConfiguration(siteID string, category string) (
 	log.Printf("[DEBUG] Incapsula Read Delivery Rules JSON response: %s\n", string(responseBody))
 
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "error status code from Incapsula service when reading delivery rules Catagorie",
-			Detail:   fmt.Sprintf("error status code %d from Incapsula service when reading delivery rules Catagorie %s for Site ID %s: %s", resp.StatusCode, category, siteID, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"error status code from Incapsula service when reading delivery rules Catagorie",
+			fmt.Sprintf("error status code %d from Incapsula service when reading delivery rules Catagorie %s for Site ID %s", resp.StatusCode, category, siteID),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var rulesPriorities DeliveryRulesListDTO
@@ -81,7 +81,7 @@ func (c *Client) ReadDeliveryRuleConfiguration(siteID string, category string) (
 	return &rulesPriorities, nil
 }
 
-func (c *Client) UpdateDeliveryRuleConfiguration(siteID string, category string, rulesList *DeliveryRulesListDTO) (*DeliveryRulesListDTO, diag.Diagnostics) {
+func (c *Client) UpdateDeliveryRuleConfiguration(ctx context.Context, siteID string, category string, rulesList *DeliveryRulesListDTO) (*DeliveryRulesListDTO, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] Updating Delivery rules Type %s for Site ID %s\n", category, siteID)
 	ruleJSON, err := json.Marshal(rulesList)
@@ -98,7 +98,7 @@ func (c *Client) UpdateDeliveryRuleConfiguration(siteID string, category string,
 
 	// Put request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/delivery-rules-configuration?category=%s", c.config.BaseURLRev3, siteID, category)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, ruleJSON, UpdateDeliveryRuleConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, ruleJSON, UpdateDeliveryRuleConfiguration)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -116,11 +116,10 @@ func (c *Client) UpdateDeliveryRuleConfiguration(siteID string, category string,
 
 	// Check the response code
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("error status code %d from Incapsula service", resp.StatusCode),
-			Detail:   fmt.Sprintf("error status code %d from Incapsula service when updating delivery rules category %s for Site ID %s: %s", resp.StatusCode, category, siteID, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			fmt.Sprintf("error status code %d from Incapsula service", resp.StatusCode),
+			fmt.Sprintf("error status code %d from Incapsula service when updating delivery rules category %s for Site ID %s", resp.StatusCode, category, siteID),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var rulesPriorities DeliveryRulesListDTO
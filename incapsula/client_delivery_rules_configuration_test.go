@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -19,7 +20,7 @@ func TestClientADReadIncaRulePriorityBadConnection(t *testing.T) {
 	siteID := "42"
 	category := "Test"
 
-	addIncapRuleResponse, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
+	addIncapRuleResponse, diags := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, category)
 	if diags == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -49,7 +50,7 @@ func TestClientADReadIncaRulePriorityBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
+	readIncapRuleResponse, diags := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, category)
 	if diags == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -81,7 +82,7 @@ func TestClient_ReadIncapRulePrioritiesValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURLRev3: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, err := client.ReadDeliveryRuleConfiguration(siteID, "REDIRECT")
+	readIncapRuleResponse, err := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, "REDIRECT")
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -120,7 +121,7 @@ func TestClientUpdateIncapRulePriorityBadConnection(t *testing.T) {
 		RulesList: rule,
 	}
 
-	updateIncapRuleResponse, diags := client.UpdateDeliveryRuleConfiguration(siteID, category, &rulesList)
+	updateIncapRuleResponse, diags := client.UpdateDeliveryRuleConfiguration(context.Background(), siteID, category, &rulesList)
 	if diags == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -163,7 +164,7 @@ func TestClientUpdateIncapRulePriorityBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURLRev3: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updateIncapRuleResponse, diags := client.UpdateDeliveryRuleConfiguration(siteID, category, &rulesList)
+	updateIncapRuleResponse, diags := client.UpdateDeliveryRuleConfiguration(context.Background(), siteID, category, &rulesList)
 	if diags == nil {
 		t.Errorf("Should have received an error")
 	}
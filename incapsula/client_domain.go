@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -15,7 +16,7 @@ type AddSiteDetails struct {
 	StrictMode bool   `json:"strictMode"`
 }
 
-func (c *Client) GetDomain(siteId string, domainId string) (*SiteDomainDetails, error) {
+func (c *Client) GetDomain(ctx context.Context, siteId string, domainId string) (*SiteDomainDetails, error) {
 
 	if siteId == "" {
 		return nil, fmt.Errorf("[ERROR] site ID was not provided")
@@ -27,7 +28,7 @@ func (c *Client) GetDomain(siteId string, domainId string) (*SiteDomainDetails,
 
 	reqURL := fmt.Sprintf("%s%s%s%s%s", c.config.BaseURLAPI, endpointDomain, siteId, "/domains/", domainId)
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, reqURL, nil, nil, ReadDomain)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, nil, ReadDomain)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading domain details. domain id %s, site id %s: %s", siteId, domainId, err)
 	}
@@ -46,11 +47,11 @@ func (c *Client) GetDomain(siteId string, domainId string) (*SiteDomainDetails,
 	return &siteDomainDetailsResponse, nil
 }
 
-func (c *Client) AddDomainToSite(siteID string, domainName string) (*SiteDomainDetails, error) {
+func (c *Client) AddDomainToSite(ctx context.Context, siteID string, domainName string) (*SiteDomainDetails, error) {
 
 	addDomainDto := AddSiteDetails{domainName, true}
 
-	resp, err := handleAddDomainRequest(c, addDomainDto, siteID)
+	resp, err := handleAddDomainRequest(ctx, c, addDomainDto, siteID)
 
 	if err != nil {
 		return nil, err
@@ -59,9 +60,9 @@ func (c *Client) AddDomainToSite(siteID string, domainName string) (*SiteDomainD
 	return resp, nil
 }
 
-func (c *Client) DeleteDomain(siteID string, domainId string) error {
+func (c *Client) DeleteDomain(ctx context.Context, siteID string, domainId string) error {
 
-	err := handleDeleteDomainRequest(c, siteID, domainId)
+	err := handleDeleteDomainRequest(ctx, c, siteID, domainId)
 
 	if err != nil {
 		return err
@@ -70,7 +71,7 @@ func (c *Client) DeleteDomain(siteID string, domainId string) error {
 	return nil
 }
 
-func handleAddDomainRequest(c *Client, addDomainsDto AddSiteDetails, siteId string) (*SiteDomainDetails, error) {
+func handleAddDomainRequest(ctx context.Context, c *Client, addDomainsDto AddSiteDetails, siteId string) (*SiteDomainDetails, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s", c.config.BaseURLAPI, endpointDomain, siteId, "/domains")
 	body, err := json.Marshal(addDomainsDto)
 
@@ -78,17 +79,18 @@ func handleAddDomainRequest(c *Client, addDomainsDto AddSiteDetails, siteId stri
 		return nil, fmt.Errorf("Failed to parse addDomainsDto: %s ", err)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, body, CreateDomain)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, body, CreateDomain)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when creating domains for site %s: %s", siteId, err)
 	}
 	defer resp.Body.Close()
 
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("[ERROR] Incapsula create domain failed for site: %s \n", siteId)
-		return nil, fmt.Errorf("create request failed: %d", resp.StatusCode)
+		return nil, fmt.Errorf("create request failed: %d: %w", resp.StatusCode, NewAPIError(resp, responseBody))
 	}
-	responseBody, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %s", err)
@@ -102,31 +104,31 @@ func handleAddDomainRequest(c *Client, addDomainsDto AddSiteDetails, siteId stri
 	}
 
 	if siteDomainDetails.Errors != nil && len(siteDomainDetails.Errors) > 0 {
-		return nil, fmt.Errorf("add domain request failed: %s", siteDomainDetails.Errors[0].Detail)
+		return nil, fmt.Errorf("add domain request failed: %s: %w", siteDomainDetails.Errors[0].Detail, NewAPIError(resp, responseBody))
 	}
 	return &siteDomainDetails, nil
 }
 
-func handleDeleteDomainRequest(c *Client, siteId string, domainId string) error {
+func handleDeleteDomainRequest(ctx context.Context, c *Client, siteId string, domainId string) error {
 	reqURL := fmt.Sprintf("%s%s%s%s%s", c.config.BaseURLAPI, endpointDomain, siteId, "/domains/", domainId)
 
 	var params = map[string]string{}
 	params["deleteLastDomain"] = "true"
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, reqURL, nil, params, DeleteDomain)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, params, DeleteDomain)
 
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting domains for site %s: %s", siteId, err)
 	}
 	defer resp.Body.Close()
 
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("[ERROR] Incapsula delete domain failed for site: %s domain: %s \n", siteId, domainId)
-		return fmt.Errorf("delete domain request failed: %d", resp.StatusCode)
+		return fmt.Errorf("delete domain request failed: %d: %w", resp.StatusCode, NewAPIError(resp, responseBody))
 	}
 
-	responseBody, err := ioutil.ReadAll(resp.Body)
-
 	if err != nil {
 		return fmt.Errorf("failed to read response body: %s", err)
 	}
@@ -1,10 +1,13 @@
 package incapsula
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -38,7 +41,7 @@ func TestClientGetDomainForSiteValidCase(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	addDomainResponse, err := client.GetDomain(siteID, domainID)
+	addDomainResponse, err := client.GetDomain(context.Background(), siteID, domainID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -77,7 +80,7 @@ func TestClientAddDomainForSiteValidCase(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	addDomainResponse, err := client.AddDomainToSite(siteID, "a.co")
+	addDomainResponse, err := client.AddDomainToSite(context.Background(), siteID, "a.co")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -124,10 +127,11 @@ func TestClientAddDomainForSiteInvalidCase(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.AddDomainToSite(siteID, "a.co")
+	_, err := client.AddDomainToSite(context.Background(), siteID, "a.co")
 
-	if err.Error() != "add domain request failed: The provided domain is not valid." {
-		t.Errorf("Should have received an error")
+	var apiError *APIError
+	if err == nil || !strings.HasPrefix(err.Error(), "add domain request failed: The provided domain is not valid.") || !errors.As(err, &apiError) {
+		t.Errorf("Should have received an API error, got: %v", err)
 	}
 }
 
@@ -0,0 +1,244 @@
+package incapsula
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-incapsula/incapsula/fakeapi"
+)
+
+////////////////////////////////////////////////////////////////
+// Lifecycle tests against the fake Imperva API
+////////////////////////////////////////////////////////////////
+
+func newFakeAPIClient(t *testing.T) (*Client, *fakeapi.Server) {
+	server := fakeapi.NewServer()
+	t.Cleanup(server.Close)
+
+	config := &Config{
+		APIID:       server.APIID,
+		APIKey:      server.APIKey,
+		BaseURL:     server.BaseURL(),
+		BaseURLRev2: server.BaseURLRev2(),
+		BaseURLRev3: server.BaseURLRev3(),
+		BaseURLAPI:  server.BaseURLAPI(),
+	}
+	return &Client{config: config, httpClient: &http.Client{}}, server
+}
+
+func TestFakeAPIVerify(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+
+	accountStatusResponse, err := client.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if accountStatusResponse.AccountID != server.AccountID {
+		t.Errorf("Account ID doesn't match. Expected %d, got: %d", server.AccountID, accountStatusResponse.AccountID)
+	}
+}
+
+func TestFakeAPIInvalidCredentials(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	client.config.APIKey = "wrong"
+
+	_, err := client.Verify(context.Background())
+	if err == nil {
+		t.Fatal("Should have received an error")
+	}
+
+	_, _, err = client.ReadIncapRule(context.Background(), "1", 1)
+	var apiError *APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Should have received a 401 API error, got: %v", err)
+	}
+}
+
+func TestFakeAPISiteLifecycle(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "www.example.com", "ref-1", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := siteAddResponse.SiteID
+
+	siteStatusResponse, err := client.SiteStatus(ctx, "www.example.com", siteID)
+	if err != nil {
+		t.Fatalf("Should not have received an error reading the site, got: %s", err)
+	}
+	if siteStatusResponse.Domain != "www.example.com" || siteStatusResponse.RefID != "ref-1" || siteStatusResponse.AccountID != server.AccountID {
+		t.Errorf("Unexpected site status: %+v", siteStatusResponse)
+	}
+
+	if _, err := client.UpdateSite(ctx, strconv.Itoa(siteID), "acceleration_level", "aggressive"); err != nil {
+		t.Fatalf("Should not have received an error updating the site, got: %s", err)
+	}
+	siteStatusResponse, err = client.SiteStatus(ctx, "www.example.com", siteID)
+	if err != nil {
+		t.Fatalf("Should not have received an error reading the site, got: %s", err)
+	}
+	if siteStatusResponse.AccelerationLevel != "aggressive" {
+		t.Errorf("Acceleration level should have been updated, got: %s", siteStatusResponse.AccelerationLevel)
+	}
+
+	_, err = client.UpdateSite(ctx, strconv.Itoa(siteID), "unknown_param", "value")
+	var apiError *APIError
+	if !errors.As(err, &apiError) || apiError.Code != "6001" {
+		t.Errorf("Should have received an invalid parameter error, got: %v", err)
+	}
+
+	if err := client.DeleteSite(ctx, "www.example.com", siteID); err != nil {
+		t.Fatalf("Should not have received an error deleting the site, got: %s", err)
+	}
+	_, err = client.SiteStatus(ctx, "www.example.com", siteID)
+	if !errors.As(err, &apiError) || apiError.Code != "9413" {
+		t.Errorf("Should have received an unknown site error after delete, got: %v", err)
+	}
+}
+
+func TestFakeAPIIncapRuleLifecycle(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "rules.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+
+	rule := &IncapRule{Name: "block-admin", Action: "RULE_ACTION_BLOCK", Filter: `URL == "/admin"`, Enabled: true}
+	ruleWithID, err := client.AddIncapRule(ctx, siteID, rule)
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the rule, got: %s", err)
+	}
+
+	readRule, statusCode, err := client.ReadIncapRule(ctx, siteID, ruleWithID.RuleID)
+	if err != nil || statusCode != http.StatusOK {
+		t.Fatalf("Should not have received an error reading the rule, got: %d %s", statusCode, err)
+	}
+	if readRule.Name != rule.Name || readRule.Filter != rule.Filter || !readRule.Enabled {
+		t.Errorf("Unexpected rule: %+v", readRule)
+	}
+
+	rule.Action = "RULE_ACTION_ALERT"
+	rule.Enabled = false
+	if _, err := client.UpdateIncapRule(ctx, siteID, ruleWithID.RuleID, rule); err != nil {
+		t.Fatalf("Should not have received an error updating the rule, got: %s", err)
+	}
+	readRule, _, err = client.ReadIncapRule(ctx, siteID, ruleWithID.RuleID)
+	if err != nil {
+		t.Fatalf("Should not have received an error reading the rule, got: %s", err)
+	}
+	if readRule.Action != "RULE_ACTION_ALERT" || readRule.Enabled {
+		t.Errorf("Rule should have been updated, got: %+v", readRule)
+	}
+
+	if _, err := client.AddIncapRule(ctx, siteID, &IncapRule{Name: "invalid", Action: "BLOCK"}); err == nil {
+		t.Errorf("Should have received an error adding a rule with an invalid action")
+	}
+
+	if err := client.DeleteIncapRule(ctx, siteID, ruleWithID.RuleID); err != nil {
+		t.Fatalf("Should not have received an error deleting the rule, got: %s", err)
+	}
+	_, statusCode, err = client.ReadIncapRule(ctx, siteID, ruleWithID.RuleID)
+	if err == nil || statusCode != http.StatusNotFound {
+		t.Errorf("Should have received a 404 after delete, got: %d %v", statusCode, err)
+	}
+}
+
+func TestFakeAPIPolicyLifecycle(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	policySubmitted := &PolicySubmitted{Name: "allowlist", Enabled: true, PolicyType: "WHITELIST", PolicySettings: []PolicySetting{}}
+	policyExtended, err := client.AddPolicy(ctx, policySubmitted)
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the policy, got: %s", err)
+	}
+	policyID := policyExtended.Value.ID
+
+	policyExtended, err = client.GetPolicy(ctx, strconv.Itoa(policyID), nil)
+	if err != nil {
+		t.Fatalf("Should not have received an error reading the policy, got: %s", err)
+	}
+	if policyExtended.Value.Name != "allowlist" || policyExtended.Value.AccountID != server.AccountID {
+		t.Errorf("Unexpected policy: %+v", policyExtended.Value)
+	}
+
+	policySubmitted.Description = "updated"
+	if _, err := client.UpdatePolicy(ctx, policyID, policySubmitted, nil); err != nil {
+		t.Fatalf("Should not have received an error updating the policy, got: %s", err)
+	}
+	policies, err := client.GetAllPoliciesForAccount(ctx, strconv.Itoa(server.AccountID))
+	if err != nil {
+		t.Fatalf("Should not have received an error listing the policies, got: %s", err)
+	}
+	if len(*policies) != 1 || (*policies)[0].Description != "updated" {
+		t.Errorf("Unexpected policies: %+v", *policies)
+	}
+
+	if err := client.DeletePolicy(ctx, strconv.Itoa(policyID), nil); err != nil {
+		t.Fatalf("Should not have received an error deleting the policy, got: %s", err)
+	}
+	_, err = client.GetPolicy(ctx, strconv.Itoa(policyID), nil)
+	var apiError *APIError
+	if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusNotFound {
+		t.Errorf("Should have received a 404 after delete, got: %v", err)
+	}
+}
+
+func TestFakeAPISiteV3AndCertificateLifecycle(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+	accountID := strconv.Itoa(server.AccountID)
+
+	siteV3Response, diags := client.AddV3Site(ctx, &SiteV3Request{Name: "v3.example.com", SiteType: "CLOUD_WAF"}, accountID)
+	if diags.HasError() {
+		t.Fatalf("Should not have received an error adding the site, got: %v", diags)
+	}
+	site := siteV3Response.Data[0]
+
+	siteV3Response, diags = client.UpdateV3Site(ctx, &SiteV3Request{Id: site.Id, Name: "renamed.example.com"}, accountID)
+	if diags.HasError() || siteV3Response.Data[0].Name != "renamed.example.com" {
+		t.Fatalf("Site should have been renamed, got: %v %v", siteV3Response, diags)
+	}
+
+	certificateResponse, diags := client.RequestSiteCertificate(ctx, site.Id, "DNS", nil)
+	if diags.HasError() {
+		t.Fatalf("Should not have received an error requesting the certificate, got: %v", diags)
+	}
+	sans := certificateResponse.Data[0].CertificatesDetails[0].Sans
+	if len(sans) != 1 || sans[0].SanValue != "v3.example.com" || sans[0].Status != "PENDING_USER_ACTION" {
+		t.Fatalf("Unexpected SANs: %+v", sans)
+	}
+
+	if diags := client.ValidateDomains(ctx, site.Id, sans[0].DomainIds); diags.HasError() {
+		t.Fatalf("Should not have received an error validating the domains, got: %v", diags)
+	}
+	certificateResponse, diags = client.GetSiteCertificateRequestStatus(ctx, site.Id, nil)
+	if diags.HasError() {
+		t.Fatalf("Should not have received an error reading the certificate, got: %v", diags)
+	}
+	if details := certificateResponse.Data[0].CertificatesDetails[0]; details.Status != "ACTIVE" || details.Sans[0].Status != "VALIDATED" {
+		t.Errorf("Certificate should have been validated, got: %+v", details)
+	}
+
+	if _, diags := client.DeleteRequestSiteCertificate(ctx, site.Id, nil); diags.HasError() {
+		t.Fatalf("Should not have received an error deleting the certificate, got: %v", diags)
+	}
+	if _, diags := client.GetSiteCertificateRequestStatus(ctx, site.Id, nil); !diags.HasError() {
+		t.Errorf("Should have received an error reading a deleted certificate request")
+	}
+
+	if _, diags := client.DeleteV3Site(ctx, &SiteV3Request{Id: site.Id}, accountID); diags.HasError() {
+		t.Fatalf("Should not have received an error deleting the site, got: %v", diags)
+	}
+	if _, diags := client.GetV3Site(ctx, &SiteV3Request{Id: site.Id}, accountID); !diags.HasError() {
+		t.Errorf("Should have received an error reading a deleted site")
+	}
+}
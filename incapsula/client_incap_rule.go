@@ -1,13 +1,18 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
+const endpointIncapRuleList = "sites/incapRules/list"
+
 // IncapRule is a struct that encompasses all the properties of an IncapRule
 type IncapRule struct {
 	Name                  string                `json:"name"`
@@ -48,8 +53,23 @@ type IncapRuleWithID struct {
 	RuleID int `json:"rule_id"`
 }
 
+// IncapRuleListResponse contains a page of the incap rules of a site, grouped by category
+type IncapRuleListResponse struct {
+	IncapRules map[string][]IncapRuleListItem `json:"incap_rules"`
+	Res        interface{}                    `json:"res"`
+	ResMessage string                         `json:"res_message"`
+}
+
+// IncapRuleListItem is the summary of an incap rule returned when listing the rules of a site
+type IncapRuleListItem struct {
+	ID     json.Number `json:"id"`
+	Name   string      `json:"name"`
+	Action string      `json:"action"`
+	Filter string      `json:"rule"`
+}
+
 // AddIncapRule adds an incap rule to be managed by Incapsula
-func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID, error) {
+func (c *Client) AddIncapRule(ctx context.Context, siteID string, rule *IncapRule) (*IncapRuleWithID, error) {
 	log.Printf("[INFO] Adding Incapsula Incap Rule for Site ID %s\n", siteID)
 
 	ruleJSON, err := json.Marshal(rule)
@@ -61,7 +81,7 @@ func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID,
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/rules", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, ruleJSON, CreateIncapRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, ruleJSON, CreateIncapRule)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding Incap Rule for Site ID %s: %s", siteID, err)
 	}
@@ -75,7 +95,7 @@ func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID,
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Incap Rule for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Incap Rule for Site ID %s: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -89,12 +109,12 @@ func (c *Client) AddIncapRule(siteID string, rule *IncapRule) (*IncapRuleWithID,
 }
 
 // ReadIncapRule gets the specific Incap Rule
-func (c *Client) ReadIncapRule(siteID string, ruleID int) (*IncapRuleWithID, int, error) {
+func (c *Client) ReadIncapRule(ctx context.Context, siteID string, ruleID int) (*IncapRuleWithID, int, error) {
 	log.Printf("[INFO] Getting Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadIncapRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadIncapRule)
 	if err != nil {
 		return nil, 0, fmt.Errorf("Error from Incapsula service when reading Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -108,7 +128,7 @@ func (c *Client) ReadIncapRule(siteID string, ruleID int) (*IncapRuleWithID, int
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, resp.StatusCode, fmt.Errorf("Error status code %d from Incapsula service when reading Incap Rule %d for Site ID %s: %w", resp.StatusCode, ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -122,7 +142,7 @@ func (c *Client) ReadIncapRule(siteID string, ruleID int) (*IncapRuleWithID, int
 }
 
 // UpdateIncapRule updates the Incapsula Incap Rule
-func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*IncapRuleWithID, error) {
+func (c *Client) UpdateIncapRule(ctx context.Context, siteID string, ruleID int, rule *IncapRule) (*IncapRuleWithID, error) {
 	log.Printf("[INFO] Updating Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	ruleJSON, err := json.Marshal(rule)
@@ -134,7 +154,7 @@ func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*I
 
 	// Put request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, ruleJSON, UpdateIncapRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, ruleJSON, UpdateIncapRule)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when updating Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -148,7 +168,7 @@ func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*I
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Incap Rule %d for Site ID %s: %w", resp.StatusCode, ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -162,12 +182,12 @@ func (c *Client) UpdateIncapRule(siteID string, ruleID int, rule *IncapRule) (*I
 }
 
 // DeleteIncapRule deletes a site currently managed by Incapsula
-func (c *Client) DeleteIncapRule(siteID string, ruleID int) error {
+func (c *Client) DeleteIncapRule(ctx context.Context, siteID string, ruleID int) error {
 	log.Printf("[INFO] Deleting Incapsula Incap Rule %d for Site ID %s\n", ruleID, siteID)
 
 	// Delete request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/rules/%d", c.config.BaseURLRev2, siteID, ruleID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteIncapRule)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteIncapRule)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when deleting Incap Rule %d for Site ID %s: %s", ruleID, siteID, err)
 	}
@@ -181,8 +201,47 @@ func (c *Client) DeleteIncapRule(siteID string, ruleID int) error {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Incap Rule %d for Site ID %s: %s", resp.StatusCode, ruleID, siteID, string(responseBody))
+		return fmt.Errorf("Error status code %d from Incapsula service when deleting Incap Rule %d for Site ID %s: %w", resp.StatusCode, ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
 }
+
+// ListIncapRules gets a page of the incap rules of a site. Pages are numbered from 0
+func (c *Client) ListIncapRules(ctx context.Context, siteID string, pageSize int, pageNum int) (*IncapRuleListResponse, error) {
+	log.Printf("[INFO] Listing Incapsula Incap Rules for Site ID %s (page %d)\n", siteID, pageNum)
+
+	values := url.Values{
+		"site_id":             {siteID},
+		"include_ad_rules":    {"no"},
+		"include_incap_rules": {"yes"},
+		"page_size":           {strconv.Itoa(pageSize)},
+		"page_num":            {strconv.Itoa(pageNum)},
+	}
+	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointIncapRuleList)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadIncapRulesAll)
+	if err != nil {
+		return nil, fmt.Errorf("Error from Incapsula service when listing Incap Rules for Site ID %s: %s", siteID, err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula List Incap Rules JSON response: %s\n", string(responseBody))
+
+	// Parse the JSON
+	var incapRuleListResponse IncapRuleListResponse
+	err = json.Unmarshal([]byte(responseBody), &incapRuleListResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Incap Rules JSON response for Site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
+	}
+
+	// Look at the response status code from Incapsula
+	if fmt.Sprint(incapRuleListResponse.Res) != "0" {
+		return nil, fmt.Errorf("Error from Incapsula service when listing Incap Rules for Site ID %s: %w", siteID, NewAPIError(resp, responseBody))
+	}
+
+	return &incapRuleListResponse, nil
+}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -25,7 +26,7 @@ func TestClientAddIncapRuleBadConnection(t *testing.T) {
 		Enabled: true,
 	}
 
-	addIncapRuleResponse, err := client.AddIncapRule(siteID, &rule)
+	addIncapRuleResponse, err := client.AddIncapRule(context.Background(), siteID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -62,7 +63,7 @@ func TestClientAddIncapRuleBadJSON(t *testing.T) {
 		Enabled: true,
 	}
 
-	addIncapRuleResponse, err := client.AddIncapRule(siteID, &rule)
+	addIncapRuleResponse, err := client.AddIncapRule(context.Background(), siteID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -97,7 +98,7 @@ func TestClientAddIncapRuleInvalidRule(t *testing.T) {
 		Name: "some_name",
 	}
 
-	addIncapRuleResponse, err := client.AddIncapRule(siteID, &rule)
+	addIncapRuleResponse, err := client.AddIncapRule(context.Background(), siteID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -135,7 +136,7 @@ func TestClientAddIncapRuleValidRule(t *testing.T) {
 		Enabled: true,
 	}
 
-	addIncapRuleResponse, err := client.AddIncapRule(siteID, &rule)
+	addIncapRuleResponse, err := client.AddIncapRule(context.Background(), siteID, &rule)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -160,7 +161,7 @@ func TestClientReadIncapRuleBadConnection(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	readIncapRuleResponse, _, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, _, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -191,7 +192,7 @@ func TestClientReadIncapRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, _, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, _, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -223,7 +224,7 @@ func TestClientReadIncapRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, statusCode, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -258,7 +259,7 @@ func TestClientReadIncapRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, statusCode, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -296,7 +297,7 @@ func TestClientReadIncapRuleValidDisabledRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readIncapRuleResponse, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+	readIncapRuleResponse, statusCode, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -331,7 +332,7 @@ func TestClientUpdateIncapRuleBadConnection(t *testing.T) {
 		Enabled: true,
 	}
 
-	updateIncapRuleResponse, err := client.UpdateIncapRule(siteID, ruleID, &rule)
+	updateIncapRuleResponse, err := client.UpdateIncapRule(context.Background(), siteID, ruleID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -369,7 +370,7 @@ func TestClientUpdateIncapRuleBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updateIncapRuleResponse, err := client.UpdateIncapRule(siteID, ruleID, &rule)
+	updateIncapRuleResponse, err := client.UpdateIncapRule(context.Background(), siteID, ruleID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -408,7 +409,7 @@ func TestClientUpdateIncapRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updateIncapRuleResponse, err := client.UpdateIncapRule(siteID, ruleID, &rule)
+	updateIncapRuleResponse, err := client.UpdateIncapRule(context.Background(), siteID, ruleID, &rule)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -447,7 +448,7 @@ func TestClientUpdateIncapRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	updateIncapRuleResponse, err := client.UpdateIncapRule(siteID, ruleID, &rule)
+	updateIncapRuleResponse, err := client.UpdateIncapRule(context.Background(), siteID, ruleID, &rule)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -472,7 +473,7 @@ func TestClientDeleteIncapRuleBadConnection(t *testing.T) {
 	siteID := "42"
 	ruleID := 62
 
-	err := client.DeleteIncapRule(siteID, ruleID)
+	err := client.DeleteIncapRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -500,7 +501,7 @@ func TestClientDeleteIncapRuleInvalidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteIncapRule(siteID, ruleID)
+	err := client.DeleteIncapRule(context.Background(), siteID, ruleID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -528,7 +529,7 @@ func TestClientDeleteIncapRuleValidRule(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteIncapRule(siteID, ruleID)
+	err := client.DeleteIncapRule(context.Background(), siteID, ruleID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
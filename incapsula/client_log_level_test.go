@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -19,7 +20,7 @@ func TestClientUpdateLogLevelBadConnection(t *testing.T) {
 	siteID := "42"
 	logLevel := "full"
 	logsAccountId := "123"
-	err := client.UpdateLogLevel(siteID, logLevel, logsAccountId)
+	err := client.UpdateLogLevel(context.Background(), siteID, logLevel, logsAccountId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -42,7 +43,7 @@ func TestClientUpdateLogLevelBadJSON(t *testing.T) {
 	siteID := "42"
 	logLevel := "full"
 	logsAccountId := "123"
-	err := client.UpdateLogLevel(siteID, logLevel, logsAccountId)
+	err := client.UpdateLogLevel(context.Background(), siteID, logLevel, logsAccountId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -65,7 +66,7 @@ func TestClientUpdateLogLevelInvalidSite(t *testing.T) {
 	siteID := "42"
 	logLevel := "full"
 	logsAccountId := "123"
-	err := client.UpdateLogLevel(siteID, logLevel, logsAccountId)
+	err := client.UpdateLogLevel(context.Background(), siteID, logLevel, logsAccountId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -88,7 +89,7 @@ func TestClientUpdateLogLevelValidSite(t *testing.T) {
 	siteID := "42"
 	logLevel := "full"
 	logsAccountId := "123"
-	err := client.UpdateLogLevel(siteID, logLevel, logsAccountId)
+	err := client.UpdateLogLevel(context.Background(), siteID, logLevel, logsAccountId)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
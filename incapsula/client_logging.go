@@ -0,0 +1,236 @@
+package incapsula
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const redactedValue = "REDACTED"
+
+// sensitiveKeyFragments are matched against lower case key names stripped of '_' and '-',
+// so that private_key, privateKey and X-Private-Key are all caught
+var sensitiveKeyFragments = []string{"password", "passphrase", "kickstartpass", "secret", "privatekey", "apikey", "accesskey", "token", "authorization", "cookie"}
+
+// sensitiveKeys are matched as a whole, since they are too short or common to be matched as fragments
+var sensitiveKeys = map[string]bool{"key": true, "certificate": true, "pfx": true}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+}
+
+// isSensitiveKey reports whether the value of a JSON key, form field or header must not be logged
+func isSensitiveKey(key string) bool {
+	normalized := normalizeKey(key)
+	if sensitiveKeys[normalized] {
+		return true
+	}
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(normalized, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON replaces the values of sensitive keys at any depth of a JSON document
+func redactJSON(data []byte) ([]byte, bool) {
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, false
+	}
+	redacted, err := json.Marshal(redactJSONValue(document))
+	if err != nil {
+		return nil, false
+	}
+	return redacted, true
+}
+
+func redactJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isSensitiveKey(key) && nested != nil {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactJSONValue(nested)
+			}
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactJSONValue(nested)
+		}
+	}
+	return value
+}
+
+// redactForm returns a copy of the form values where the sensitive fields are redacted
+func redactForm(values url.Values) url.Values {
+	redacted := url.Values{}
+	for key, fieldValues := range values {
+		if isSensitiveKey(key) {
+			redacted[key] = []string{redactedValue}
+		} else {
+			redacted[key] = fieldValues
+		}
+	}
+	return redacted
+}
+
+// redactHeaders returns a copy of the headers where the sensitive ones are redacted
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for key := range redacted {
+		if isSensitiveKey(key) {
+			redacted[key] = []string{redactedValue}
+		}
+	}
+	return redacted
+}
+
+// redactBody returns a loggable representation of a request or response body
+func redactBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		return fmt.Sprintf("[%d bytes of %s omitted]", len(body), mediaType)
+	case mediaType == contentTypeApplicationUrlEncoded:
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			return redactForm(values).Encode()
+		}
+	}
+
+	if redacted, ok := redactJSON(body); ok {
+		return string(redacted)
+	}
+	return redactText(string(body))
+}
+
+func formatHeaders(header http.Header) string {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	formatted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		formatted = append(formatted, fmt.Sprintf("%s: %s", key, strings.Join(header[key], ", ")))
+	}
+	return strings.Join(formatted, "; ")
+}
+
+var (
+	// "key": "value"
+	jsonFieldPattern = regexp.MustCompile(`"([\w-]+)"(\s*:\s*)"((?:[^"\\]|\\.)*)"`)
+	// key=value, as in form bodies and query strings
+	formFieldPattern = regexp.MustCompile(`(^|[?&\s])([\w-]+)=([^&\s]*)`)
+	// Key:value, as in structs printed with %+v or headers printed with %v
+	structFieldPattern = regexp.MustCompile(`([\w-]+)(: ?)(\[[^\]{}]*\]|[^\s{}\[\],;]+)`)
+)
+
+// redactText redacts sensitive values in free text, such as log messages built from request or response bodies
+func redactText(text string) string {
+	text = jsonFieldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := jsonFieldPattern.FindStringSubmatch(match)
+		if !isSensitiveKey(groups[1]) {
+			return match
+		}
+		return fmt.Sprintf(`"%s"%s"%s"`, groups[1], groups[2], redactedValue)
+	})
+	text = formFieldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := formFieldPattern.FindStringSubmatch(match)
+		if !isSensitiveKey(groups[2]) {
+			return match
+		}
+		return fmt.Sprintf("%s%s=%s", groups[1], groups[2], redactedValue)
+	})
+	text = structFieldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := structFieldPattern.FindStringSubmatch(match)
+		if !isSensitiveKey(groups[1]) || strings.HasPrefix(groups[3], `"`) {
+			return match
+		}
+		return fmt.Sprintf("%s%s%s", groups[1], groups[2], redactedValue)
+	})
+	return text
+}
+
+// logMetadataOnly is set once any provider configuration asks to log only request metadata
+var logMetadataOnly atomic.Bool
+
+var installLogRedactionOnce sync.Once
+
+// redactingWriter filters the provider logs: sensitive values are redacted from every line,
+// and in metadata only mode DEBUG and TRACE lines are dropped whatever the TF_LOG level is
+type redactingWriter struct {
+	out io.Writer
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	line := string(p)
+	if logMetadataOnly.Load() && (strings.Contains(line, "[DEBUG]") || strings.Contains(line, "[TRACE]")) {
+		return len(p), nil
+	}
+	if _, err := io.WriteString(w.out, redactText(line)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// installLogRedaction routes the standard logger through the redacting writer.
+// Many client methods dump request and response bodies, this makes sure none of them leaks secrets
+func installLogRedaction(metadataOnly bool) {
+	if metadataOnly {
+		logMetadataOnly.Store(true)
+	}
+	installLogRedactionOnce.Do(func() {
+		log.SetOutput(&redactingWriter{out: log.Writer()})
+	})
+}
+
+// logRequest logs an API request with its headers and body redacted
+func (c *Client) logRequest(req *http.Request, operation string, attempt int) {
+	if logMetadataOnly.Load() {
+		return
+	}
+
+	var body []byte
+	if req.GetBody != nil {
+		if reader, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(reader)
+			reader.Close()
+		}
+	}
+
+	requestURL := *req.URL
+	requestURL.RawQuery = redactForm(req.URL.Query()).Encode()
+	log.Printf("[DEBUG] Incapsula API request %s %s (operation: %s, attempt: %d)\nheaders: %s\nbody: %s", req.Method, requestURL.String(), operation, attempt, formatHeaders(redactHeaders(req.Header)), redactBody(body, req.Header.Get("Content-Type")))
+}
+
+// logResponse logs the metadata of an API response: status code and duration, never the body
+func (c *Client) logResponse(req *http.Request, operation string, attempt int, resp *http.Response, err error, duration time.Duration) {
+	requestURL := *req.URL
+	requestURL.RawQuery = redactForm(req.URL.Query()).Encode()
+	if err != nil {
+		log.Printf("[INFO] Incapsula API %s %s (operation: %s, attempt: %d) failed after %s: %s", req.Method, requestURL.String(), operation, attempt, duration, err)
+		return
+	}
+	log.Printf("[INFO] Incapsula API %s %s (operation: %s, attempt: %d) returned %d in %s", req.Method, requestURL.String(), operation, attempt, resp.StatusCode, duration)
+}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,7 +10,7 @@ import (
 	"strings"
 )
 
-func (c *Client) GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID int, accountId string) (*ClientCaCertificateWithSites, bool, error) {
+func (c *Client) GetSiteMtlsClientToImpervaCertificateAssociation(ctx context.Context, siteID, certificateID int, accountId string) (*ClientCaCertificateWithSites, bool, error) {
 	log.Printf("[INFO] Getting Site to mutual TLS Imperva to Origin Certificate association for Site ID %d", siteID)
 	reqURL := fmt.Sprintf("%s/certificate-manager/v2/sites/%d/client-certificates", c.config.BaseURLAPI, siteID)
 
@@ -17,7 +18,7 @@ func (c *Client) GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certif
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadMtlsClientToImpervaCertifiateSiteAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadMtlsClientToImpervaCertifiateSiteAssociation)
 	if err != nil {
 		return nil, true, fmt.Errorf("[ERROR] Error getting Site to mutual TLS Client to Imperva Certificate association for Site ID %d, certificate ID %d\n%v", siteID, certificateID, err)
 	}
@@ -31,7 +32,7 @@ func (c *Client) GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certif
 		return nil, false, nil
 	}
 	if resp.StatusCode != 200 {
-		return nil, true, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching Incapsula Site to mutual TLS Client to Imperva Certificate association for Site ID %d\n: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, true, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching Incapsula Site to mutual TLS Client to Imperva Certificate association for Site ID %d\n: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Dump JSON
@@ -61,7 +62,7 @@ func (c *Client) GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certif
 	}
 }
 
-func (c *Client) CreateSiteMtlsClientToImpervaCertificateAssociation(certificateID, siteID int, accountId string) error {
+func (c *Client) CreateSiteMtlsClientToImpervaCertificateAssociation(ctx context.Context, certificateID, siteID int, accountId string) error {
 	log.Printf("[INFO] Updating Site to mutual TLS Client to Imperva Certificate Association certificate ID %d for Site ID %d", certificateID, siteID)
 	reqURL := fmt.Sprintf("%s/certificate-manager/v2/sites/%d/client-certificates/%d", c.config.BaseURLAPI, siteID, certificateID)
 
@@ -69,7 +70,7 @@ func (c *Client) CreateSiteMtlsClientToImpervaCertificateAssociation(certificate
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, nil, CreateMtlsClientToImpervaCertifiateSiteAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, nil, CreateMtlsClientToImpervaCertifiateSiteAssociation)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error creating Incapsula Site to mutual TLS Client to Imperva Certificate Association for certificate ID %d, Site ID %d\n%s", certificateID, siteID, err)
 	}
@@ -80,12 +81,12 @@ func (c *Client) CreateSiteMtlsClientToImpervaCertificateAssociation(certificate
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on creating Site to mutual TLS Client to Imperva Certificate Association for Site ID %d, Certificate ID %d:\n%s", resp.StatusCode, siteID, certificateID, string(responseBody))
+		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on creating Site to mutual TLS Client to Imperva Certificate Association for Site ID %d, Certificate ID %d:\n%w", resp.StatusCode, siteID, certificateID, NewAPIError(resp, responseBody))
 	}
 	return nil
 }
 
-func (c *Client) DeleteSiteMtlsClientToImpervaCertificateAssociation(certificateID, siteID int, accountId string) error {
+func (c *Client) DeleteSiteMtlsClientToImpervaCertificateAssociation(ctx context.Context, certificateID, siteID int, accountId string) error {
 	log.Printf("[INFO] Unassigning Site to mutual TLS Client to Imperva Certificate Association certificate ID %d for Site ID %d", certificateID, siteID)
 	reqURL := fmt.Sprintf("%s/certificate-manager/v2/sites/%d/client-certificates/%d", c.config.BaseURLAPI, siteID, certificateID)
 
@@ -93,7 +94,7 @@ func (c *Client) DeleteSiteMtlsClientToImpervaCertificateAssociation(certificate
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteMtlsClientToImpervaCertifiateSiteAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteMtlsClientToImpervaCertifiateSiteAssociation)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error deleting Incapsula Site to mutual TLS Client to Imperva Certificate Association certificate ID %d for Site ID %d\n%s", certificateID, siteID, err)
 	}
@@ -103,7 +104,7 @@ func (c *Client) DeleteSiteMtlsClientToImpervaCertificateAssociation(certificate
 	log.Printf("[DEBUG] Incapsula delete Site to mutual TLS Client to Imperva Certificate Association certificate ID %d for Site ID %d JSON response: %s\n", certificateID, siteID, string(responseBody))
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on deleting site to mutual TLS Client to Imperva Certificate Association for certificate ID %d for Site ID %d\n%s", resp.StatusCode, certificateID, siteID, string(responseBody))
+		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on deleting site to mutual TLS Client to Imperva Certificate Association for certificate ID %d for Site ID %d\n%w", resp.StatusCode, certificateID, siteID, NewAPIError(resp, responseBody))
 	}
 	return nil
 }
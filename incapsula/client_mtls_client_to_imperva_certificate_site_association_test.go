@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -19,7 +20,7 @@ func TestClientGetSiteMtlsClientToImpervaCertificateAssociationBadConnection(t *
 	siteID := 42
 	certificateID := 100
 
-	_, _, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID, "")
+	_, _, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(context.Background(), siteID, certificateID, "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -49,7 +50,7 @@ func TestClientGetSiteMtlsClientToImpervaCertificateAssociationBadJSON(t *testin
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	clientCaCertificateWithSites, _, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID, "")
+	clientCaCertificateWithSites, _, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(context.Background(), siteID, certificateID, "")
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -94,7 +95,7 @@ func TestClientGetSiteMtlsClientToImpervaCertificateAssociationInvalidConfig(t *
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	clientCaCertificateWithSites, _, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID, "")
+	clientCaCertificateWithSites, _, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(context.Background(), siteID, certificateID, "")
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -143,7 +144,7 @@ func TestClientGetSiteMtlsClientToImpervaCertificateAssociationValidConfig(t *te
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	clientCaCertificateWithSites, _, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID, "")
+	clientCaCertificateWithSites, _, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(context.Background(), siteID, certificateID, "")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -163,7 +164,7 @@ func TestClientCreateSiteMtlsClientToImpervaCertificateAssociationBadConnection(
 	siteID := 42
 	certificateID := 100
 
-	err := client.CreateSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID, "")
+	err := client.CreateSiteMtlsClientToImpervaCertificateAssociation(context.Background(), siteID, certificateID, "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -191,7 +192,7 @@ func TestClientCreateSiteMtlsClientToImpervaCertificateAssociationInvalidConfig(
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.CreateSiteMtlsClientToImpervaCertificateAssociation(certificateID, siteID, "")
+	err := client.CreateSiteMtlsClientToImpervaCertificateAssociation(context.Background(), certificateID, siteID, "")
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -220,7 +221,7 @@ func TestClientCreateSiteMtlsClientToImpervaCertificateAssociationValidConfig(t
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.CreateSiteMtlsClientToImpervaCertificateAssociation(certificateID, siteID, "")
+	err := client.CreateSiteMtlsClientToImpervaCertificateAssociation(context.Background(), certificateID, siteID, "")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -237,7 +238,7 @@ func TestClientDeleteSiteMtlsClientToImpervaCertificateAssociationBadConnection(
 	siteID := 42
 	certificateID := 100
 
-	err := client.DeleteSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID, "")
+	err := client.DeleteSiteMtlsClientToImpervaCertificateAssociation(context.Background(), siteID, certificateID, "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -265,7 +266,7 @@ func TestClientDeleteSiteMtlsClientToImpervaCertificateAssociationInvalidConfig(
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteSiteMtlsClientToImpervaCertificateAssociation(certificateID, siteID, "")
+	err := client.DeleteSiteMtlsClientToImpervaCertificateAssociation(context.Background(), certificateID, siteID, "")
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -294,7 +295,7 @@ func TestClientDeleteSiteMtlsClientToImpervaCertificateAssociationValidConfig(t
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteSiteMtlsClientToImpervaCertificateAssociation(certificateID, siteID, "")
+	err := client.DeleteSiteMtlsClientToImpervaCertificateAssociation(context.Background(), certificateID, siteID, "")
 
 	if err != nil {
 		t.Errorf("Should not have received an error on delete Client to Imperva Certificate Association ")
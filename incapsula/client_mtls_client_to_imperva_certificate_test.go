@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestGetClientCaCertificateBadConnection(t *testing.T) {
 	certificateID := "42"
 	accountID := "100"
 
-	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(accountID, certificateID)
+	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -49,7 +50,7 @@ func TestGetClientCaCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(accountID, certificateID)
+	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -92,7 +93,7 @@ func TestGetClientCaCertificateInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(accountID, certificateID)
+	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -130,7 +131,7 @@ func TestGetClientCaCertificateValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(accountID, certificateID)
+	mTLSClientToImpervaCertificateResponse, _, err := client.GetClientCaCertificate(context.Background(), accountID, certificateID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), mTLSClientToImpervaCertificateResponse)
@@ -153,7 +154,7 @@ func TestAddClientCaCertificateBadConnection(t *testing.T) {
 	accountID := "100"
 	certificate := []byte{}
 
-	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(certificate, accountID, certificateName)
+	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(context.Background(), certificate, accountID, certificateName)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -186,7 +187,7 @@ func TestAddClientCaCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(certificate, accountID, certificateName)
+	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(context.Background(), certificate, accountID, certificateName)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -231,7 +232,7 @@ func TestAddClientCaCertificateInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(certificate, accountID, certificateName)
+	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(context.Background(), certificate, accountID, certificateName)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -271,7 +272,7 @@ func TestAddClientCaCertificateValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(certificate, accountID, certificateName)
+	mTLSClientToImpervaCertificateResponse, err := client.AddClientCaCertificate(context.Background(), certificate, accountID, certificateName)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), mTLSClientToImpervaCertificateResponse)
@@ -293,7 +294,7 @@ func TestDeleteClientCaCertificateBadConnection(t *testing.T) {
 	certificateID := "42"
 	accountID := "100"
 
-	err := client.DeleteClientCaCertificate(accountID, certificateID)
+	err := client.DeleteClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -325,7 +326,7 @@ func TestDeleteClientCaCertificateInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteClientCaCertificate(accountID, certificateID)
+	err := client.DeleteClientCaCertificate(context.Background(), accountID, certificateID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -355,7 +356,7 @@ func TestDeleteClientCaCertificateValidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteClientCaCertificate(accountID, certificateID)
+	err := client.DeleteClientCaCertificate(context.Background(), accountID, certificateID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %v\n", err.Error())
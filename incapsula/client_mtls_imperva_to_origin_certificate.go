@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -32,32 +33,32 @@ type MTLSCertificateResponse struct {
 	Data []MTLSCertificate `json:"data"`
 }
 
-func (c *Client) AddMTLSCertificate(certificate, privateKey []byte, passphrase, certificateName, inputHash, accountID string) (*MTLSCertificate, error) {
+func (c *Client) AddMTLSCertificate(ctx context.Context, certificate, privateKey []byte, passphrase, certificateName, inputHash, accountID string) (*MTLSCertificate, error) {
 	log.Printf("[INFO] Adding mutual TLS Imperva to Origin Certificate")
 	reqURL := fmt.Sprintf("%s%s", c.config.BaseURLAPI, endpointMTLSCertificate)
 	if accountID != "" {
 		reqURL = fmt.Sprintf("%s%s?caid=%s", c.config.BaseURLAPI, endpointMTLSCertificate, accountID)
 	}
-	return c.editMTLSCertificate(http.MethodPost, reqURL, certificate, privateKey, passphrase, certificateName, inputHash, "Create", CreateMtlsImpervaToOriginCertifiate)
+	return c.editMTLSCertificate(ctx, http.MethodPost, reqURL, certificate, privateKey, passphrase, certificateName, inputHash, "Create", CreateMtlsImpervaToOriginCertifiate)
 }
 
-func (c *Client) UpdateMTLSCertificate(certificateID string, certificate, privateKey []byte, passphrase, certificateName, inputHash, accountID string) (*MTLSCertificate, error) {
+func (c *Client) UpdateMTLSCertificate(ctx context.Context, certificateID string, certificate, privateKey []byte, passphrase, certificateName, inputHash, accountID string) (*MTLSCertificate, error) {
 	log.Printf("[INFO] Updating mutual TLS Imperva to Origin Certificate with ID %s", certificateID)
 	reqURL := fmt.Sprintf("%s%s/%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID)
 	if accountID != "" {
 		reqURL = fmt.Sprintf("%s%s/%s?caid=%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, accountID)
 	}
-	return c.editMTLSCertificate(http.MethodPut, reqURL, certificate, privateKey, passphrase, certificateName, inputHash, "Update", UpdateMtlsImpervaToOriginCertifiate)
+	return c.editMTLSCertificate(ctx, http.MethodPut, reqURL, certificate, privateKey, passphrase, certificateName, inputHash, "Update", UpdateMtlsImpervaToOriginCertifiate)
 }
 
-func (c *Client) GetMTLSCertificate(certificateID, accountID string) (*MTLSCertificate, error) {
+func (c *Client) GetMTLSCertificate(ctx context.Context, certificateID, accountID string) (*MTLSCertificate, error) {
 	log.Printf("[INFO] Reading mutual TLS Imperva to Origin Certificate with ID %s", certificateID)
 	//todo refactor !! move to separate method
 	reqURL := fmt.Sprintf("%s%s/%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID)
 	if accountID != "" {
 		reqURL = fmt.Sprintf("%s%s/%s?caid=%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, accountID)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadMtlsImpervaToOriginCertifiate)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadMtlsImpervaToOriginCertifiate)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading mTLS Imperva to Origin Certificate ID %s: %s", certificateID, err)
 	}
@@ -69,7 +70,7 @@ func (c *Client) GetMTLSCertificate(certificateID, accountID string) (*MTLSCerti
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching mutual TLS Imperva to Origin certificate ID %s\n: %s\n%s", resp.StatusCode, certificateID, err, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching mutual TLS Imperva to Origin certificate ID %s\n: %s\n%w", resp.StatusCode, certificateID, err, NewAPIError(resp, responseBody))
 	}
 	// Dump JSON
 	var mtlsCertificate MTLSCertificateResponse
@@ -84,7 +85,7 @@ func (c *Client) GetMTLSCertificate(certificateID, accountID string) (*MTLSCerti
 	}
 
 }
-func (c *Client) editMTLSCertificate(hhtpMethod, reqURL string, certificate, privateKey []byte, passphrase, certificateName, inputHash, action, operation string) (*MTLSCertificate, error) {
+func (c *Client) editMTLSCertificate(ctx context.Context, hhtpMethod, reqURL string, certificate, privateKey []byte, passphrase, certificateName, inputHash, action, operation string) (*MTLSCertificate, error) {
 	bodyMap := map[string]interface{}{}
 	bodyMap["certificateFile"] = []byte(certificate)
 
@@ -104,7 +105,7 @@ func (c *Client) editMTLSCertificate(hhtpMethod, reqURL string, certificate, pri
 	}
 
 	bodyNew, contentTypeNew := c.CreateFormDataBody(bodyMap)
-	resp, err := c.DoFormDataRequestWithHeaders(hhtpMethod, reqURL, bodyNew, contentTypeNew, operation)
+	resp, err := c.DoFormDataRequestWithHeaders(ctx, hhtpMethod, reqURL, bodyNew, contentTypeNew, operation)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error while %s mTLS Imperva to Origin Certificate: %s", action, err)
 	}
@@ -116,7 +117,7 @@ func (c *Client) editMTLSCertificate(hhtpMethod, reqURL string, certificate, pri
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on %s mutual TLS Imperva to Origin certificate: %s", resp.StatusCode, action, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on %s mutual TLS Imperva to Origin certificate: %w", resp.StatusCode, action, NewAPIError(resp, responseBody))
 	}
 
 	// Dump JSON
@@ -132,25 +133,25 @@ func (c *Client) editMTLSCertificate(hhtpMethod, reqURL string, certificate, pri
 	}
 
 }
-func (c *Client) DeleteMTLSCertificate(certificateID, accountID string) error {
+func (c *Client) DeleteMTLSCertificate(ctx context.Context, certificateID, accountID string) error {
 	log.Printf("[INFO] Deleting mTLS certificate with ID %s", certificateID)
 
 	reqURL := fmt.Sprintf("%s%s/%s", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID)
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteMtlsImpervaToOriginCertifiate)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteMtlsImpervaToOriginCertifiate)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error from Incapsula service when deleting mTLS Imperva to Origin Certificate ID %s: %s", certificateID, err)
 	}
 
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on deleting mutual TLS Imperva to Origin certificate ID %s\n: %s", resp.StatusCode, certificateID, err)
+		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on deleting mutual TLS Imperva to Origin certificate ID %s\n: %w", resp.StatusCode, certificateID, NewAPIError(resp, responseBody))
 	}
 
-	// Read the body
-	defer resp.Body.Close()
-	_, err = ioutil.ReadAll(resp.Body)
-
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error deleting mTLS Imperva to Origin Certificate: %s", err)
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	//"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,13 +9,13 @@ import (
 	"net/http"
 )
 
-func (c *Client) GetSiteMtlsCertificateAssociation(certificateID, siteID int, accountId string) (bool, error) {
+func (c *Client) GetSiteMtlsCertificateAssociation(ctx context.Context, certificateID, siteID int, accountId string) (bool, error) {
 	log.Printf("[INFO] Getting Site to mutual TLS Imperva to Origin Certificate association for Site ID %d", siteID)
 	reqURL := fmt.Sprintf("%s%s/%d/associated-sites/%d", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, siteID)
 	if accountId != "" {
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, "ReadSiteMtlsImpervaToOriginCertifiateAssociation")
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, "ReadSiteMtlsImpervaToOriginCertifiateAssociation")
 	if err != nil {
 		return false, fmt.Errorf("[ERROR] Error getting Site to mutual TLS Imperva to Origin Certificate association for Site ID %d: %s", siteID, err)
 	}
@@ -29,17 +30,17 @@ func (c *Client) GetSiteMtlsCertificateAssociation(certificateID, siteID int, ac
 	} else if resp.StatusCode == 200 {
 		return true, err
 	} else {
-		return false, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching Incapsula Site to mutual TLS Imperva to Origin certificate association for Site ID %d\n: %s", resp.StatusCode, siteID, string(responseBody))
+		return false, fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching Incapsula Site to mutual TLS Imperva to Origin certificate association for Site ID %d\n: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 }
 
-func (c *Client) CreateSiteMtlsCertificateAssociation(certificateID, siteID int, accountId string) error {
+func (c *Client) CreateSiteMtlsCertificateAssociation(ctx context.Context, certificateID, siteID int, accountId string) error {
 	log.Printf("[INFO] Updating Site to mutual TLS Imperva to Origin Certificate association for certificate ID %d, Site ID %d", certificateID, siteID)
 	reqURL := fmt.Sprintf("%s%s/%d/associated-sites/%d", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, siteID)
 	if accountId != "" {
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, nil, CreateSiteMtlsImpervaToOriginCertifiateAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, nil, CreateSiteMtlsImpervaToOriginCertifiateAssociation)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error creating Incapsula Site to Imperva to Origin mutual TLS Certificate Association for certificate ID %d, Site ID %d\n%s", certificateID, siteID, err)
 	}
@@ -50,18 +51,18 @@ func (c *Client) CreateSiteMtlsCertificateAssociation(certificateID, siteID int,
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on creating Incapsula Site to mutual TLS Imperva to Origin certificate Association for Site ID %d, Certificate ID %d:\n%s", resp.StatusCode, siteID, certificateID, string(responseBody))
+		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on creating Incapsula Site to mutual TLS Imperva to Origin certificate Association for Site ID %d, Certificate ID %d:\n%w", resp.StatusCode, siteID, certificateID, NewAPIError(resp, responseBody))
 	}
 	return nil
 }
 
-func (c *Client) DeleteSiteMtlsCertificateAssociation(certificateID, siteID int, accountId string) error {
+func (c *Client) DeleteSiteMtlsCertificateAssociation(ctx context.Context, certificateID, siteID int, accountId string) error {
 	log.Printf("[INFO] Unassigning Site to mutual TLS Imperva to Origin Certificate association for certificate ID %d, Site ID %d", certificateID, siteID)
 	reqURL := fmt.Sprintf("%s%s/%d/associated-sites/%d", c.config.BaseURLAPI, endpointMTLSCertificate, certificateID, siteID)
 	if accountId != "" {
 		reqURL = fmt.Sprintf("%s?caid=%s", reqURL, accountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteSiteMtlsImpervaToOriginCertifiateAssociation)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteSiteMtlsImpervaToOriginCertifiateAssociation)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error deleting Incapsula Site to Imperva to Origin mutual TLS Certificate Association for certificate ID %d for Site ID %d\n%s", certificateID, siteID, err)
 	}
@@ -72,7 +73,7 @@ func (c *Client) DeleteSiteMtlsCertificateAssociation(certificateID, siteID int,
 
 	// Check the response code
 	if resp.StatusCode != 200 && resp.StatusCode != 404 {
-		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching site to mutual TLS Imperva to Origin certificate Association for certificate ID %d for Site ID %d\n%s", resp.StatusCode, certificateID, siteID, string(responseBody))
+		return fmt.Errorf("[ERROR] Error status code %d from Incapsula service on fetching site to mutual TLS Imperva to Origin certificate Association for certificate ID %d for Site ID %d\n%w", resp.StatusCode, certificateID, siteID, NewAPIError(resp, responseBody))
 	}
 	return nil
 }
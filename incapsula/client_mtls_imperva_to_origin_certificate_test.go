@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestGetMTLSCertificateBadConnection(t *testing.T) {
 	certificateID := "42"
 	accountID := "777"
 
-	mTLSCertificateResponse, err := client.GetMTLSCertificate(certificateID, accountID)
+	mTLSCertificateResponse, err := client.GetMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -50,7 +51,7 @@ func TestGetMTLSCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.GetMTLSCertificate(certificateID, accountID)
+	mTLSCertificateResponse, err := client.GetMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -94,7 +95,7 @@ func TestGetMTLSCertificateInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.GetMTLSCertificate(certificateID, accountID)
+	mTLSCertificateResponse, err := client.GetMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -149,7 +150,7 @@ func TestGetMTLSCertificateValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.GetMTLSCertificate(certificateID, accountID)
+	mTLSCertificateResponse, err := client.GetMTLSCertificate(context.Background(), certificateID, accountID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), mTLSCertificateResponse)
@@ -175,7 +176,7 @@ func TestEditMTLSCertificateBadConnection(t *testing.T) {
 	inputHash := "inputHash"
 	accountID := "777"
 
-	mTLSCertificateResponse, err := client.AddMTLSCertificate(certificate, privateKey, passphrase, certificateName, inputHash, accountID)
+	mTLSCertificateResponse, err := client.AddMTLSCertificate(context.Background(), certificate, privateKey, passphrase, certificateName, inputHash, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -212,7 +213,7 @@ func TestEditMTLSCertificateBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.UpdateMTLSCertificate(certificateID, certificate, privateKey, passphrase, certificateName, inputHash, accountID)
+	mTLSCertificateResponse, err := client.UpdateMTLSCertificate(context.Background(), certificateID, certificate, privateKey, passphrase, certificateName, inputHash, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -259,7 +260,7 @@ func TestEditMTLSCertificateApiConfig(t *testing.T) {
 	certificateName := "certificateName"
 	inputHash := "inputHash"
 
-	mTLSCertificateResponse, err := client.AddMTLSCertificate(certificate, privateKey, passphrase, certificateName, inputHash, "")
+	mTLSCertificateResponse, err := client.AddMTLSCertificate(context.Background(), certificate, privateKey, passphrase, certificateName, inputHash, "")
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -320,7 +321,7 @@ func TestEditMTLSCertificateValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	mTLSCertificateResponse, err := client.AddMTLSCertificate(certificate, privateKey, passphrase, certificateName, inputHash, accountID)
+	mTLSCertificateResponse, err := client.AddMTLSCertificate(context.Background(), certificate, privateKey, passphrase, certificateName, inputHash, accountID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s\n, %v", err.Error(), mTLSCertificateResponse)
@@ -342,7 +343,7 @@ func TestDeleteMTLSCertificateBadConnection(t *testing.T) {
 	certificateID := "42"
 	accountID := "777"
 
-	err := client.DeleteMTLSCertificate(certificateID, accountID)
+	err := client.DeleteMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -383,7 +384,7 @@ func TestDeleteMTLSCertificateInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteMTLSCertificate(certificateID, accountID)
+	err := client.DeleteMTLSCertificate(context.Background(), certificateID, accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -413,7 +414,7 @@ func TestDeleteMTLSCertificateValidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	err := client.DeleteMTLSCertificate(certificateID, accountID)
+	err := client.DeleteMTLSCertificate(context.Background(), certificateID, accountID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %v\n", err.Error())
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -52,7 +53,7 @@ type NotificationPolicy struct {
 	Data NotificationPolicyFullDto `json:"data"`
 }
 
-func (c *Client) AddNotificationCenterPolicy(notificationPolicyFullDto *NotificationPolicyFullDto) (*NotificationPolicy, error) {
+func (c *Client) AddNotificationCenterPolicy(ctx context.Context, notificationPolicyFullDto *NotificationPolicyFullDto) (*NotificationPolicy, error) {
 	notificationPolicy := NotificationPolicy{
 		Data: *notificationPolicyFullDto,
 	}
@@ -65,7 +66,7 @@ func (c *Client) AddNotificationCenterPolicy(notificationPolicyFullDto *Notifica
 	}
 
 	log.Printf("[DEBUG] Add NotificationCenterPolicy with params %s and JSON request: %s\n", params, string(policyJSON))
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, reqURL, policyJSON, params, CreateNotificationCenterPolicy)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, reqURL, policyJSON, params, CreateNotificationCenterPolicy)
 	log.Printf("[DEBUG] client_notification_center_policy Post rest response:\n%+v", resp)
 	if err != nil {
 		return nil, fmt.Errorf("Error from NotificationCenter service when adding policy: %s ", err)
@@ -75,7 +76,7 @@ func (c *Client) AddNotificationCenterPolicy(notificationPolicyFullDto *Notifica
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] Add NotificationCenterPolicy JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when adding policy: %s ", resp.StatusCode, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when adding policy: %w ", resp.StatusCode, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -89,7 +90,7 @@ func (c *Client) AddNotificationCenterPolicy(notificationPolicyFullDto *Notifica
 
 }
 
-func (c *Client) UpdateNotificationCenterPolicy(notificationPolicyFullDto *NotificationPolicyFullDto) (*NotificationPolicy, error) {
+func (c *Client) UpdateNotificationCenterPolicy(ctx context.Context, notificationPolicyFullDto *NotificationPolicyFullDto) (*NotificationPolicy, error) {
 	notificationPolicy := NotificationPolicy{
 		Data: *notificationPolicyFullDto,
 	}
@@ -102,7 +103,7 @@ func (c *Client) UpdateNotificationCenterPolicy(notificationPolicyFullDto *Notif
 	params := GetRequestParamsWithCaid(notificationPolicyFullDto.AccountId)
 
 	log.Printf("[DEBUG] Update NotificationCenterPolicy JSON request: %s\n", string(policyJSON))
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPut, reqURL, policyJSON, params, UpdateNotificationCenterPolicy)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPut, reqURL, policyJSON, params, UpdateNotificationCenterPolicy)
 	log.Printf("[DEBUG] client_notification_center_policy Put rest response:\n%+v", resp)
 	if err != nil {
 		return nil, fmt.Errorf("Error from NotificationCenter service when updateing policy: %s ", err)
@@ -112,7 +113,7 @@ func (c *Client) UpdateNotificationCenterPolicy(notificationPolicyFullDto *Notif
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] Update NotificationCenterPolicy JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when updateing policy: %s ", resp.StatusCode, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when updateing policy: %w ", resp.StatusCode, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -125,11 +126,11 @@ func (c *Client) UpdateNotificationCenterPolicy(notificationPolicyFullDto *Notif
 	return &policy, nil
 }
 
-func (c *Client) DeleteNotificationCenterPolicy(policyId int, accountId int) error {
+func (c *Client) DeleteNotificationCenterPolicy(ctx context.Context, policyId int, accountId int) error {
 	log.Printf("[INFO] Deleting NotificationCenterPolicy with ID %d ", policyId)
 	requestUrl := getRequestUrlWithId(c, policyId)
 	params := GetRequestParamsWithCaid(accountId)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, requestUrl, nil, params, DeleteNotificationCenterPolicy)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, requestUrl, nil, params, DeleteNotificationCenterPolicy)
 	log.Printf("[DEBUG] client_notification_center_policy Delete rest response:\n%+v", resp)
 	if err != nil {
 		return fmt.Errorf("Error from NotificationCenterPolicy service when deleting Policy with Id %d: %s ", policyId, err)
@@ -139,7 +140,7 @@ func (c *Client) DeleteNotificationCenterPolicy(policyId int, accountId int) err
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] NotificationCenter Delete policy JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from NotificationCenter service when deleting policy with Id %d: %s ", resp.StatusCode, policyId, string(responseBody))
+		return fmt.Errorf("Error status code %d from NotificationCenter service when deleting policy with Id %d: %w ", resp.StatusCode, policyId, NewAPIError(resp, responseBody))
 	}
 
 	return nil
@@ -157,13 +158,13 @@ func getRequestUrlWithId(c *Client, policyId int) string {
 	return requestUrl
 }
 
-func (c *Client) GetNotificationCenterPolicy(policyId int, accountId int) (*NotificationPolicy, error) {
+func (c *Client) GetNotificationCenterPolicy(ctx context.Context, policyId int, accountId int) (*NotificationPolicy, error) {
 	log.Printf("[INFO] Getting  NotificationCenterPolicy with policyId: %d and accountId: %d", policyId, accountId)
 	requestUrl := getRequestUrlWithId(c, policyId)
 	log.Printf("[INFO]  requestUrl:%s", requestUrl)
 
 	params := GetRequestParamsWithCaid(accountId)
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, requestUrl, nil, params, ReadNotificationCenterPolicy)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, requestUrl, nil, params, ReadNotificationCenterPolicy)
 	log.Printf("[DEBUG] client_notification_center_policy Get rest response:\n%+v", resp)
 	if err != nil {
 		return nil, fmt.Errorf("Error from NotificationCenter service when reading policy with Id %d: %s ", policyId, err)
@@ -173,7 +174,7 @@ func (c *Client) GetNotificationCenterPolicy(policyId int, accountId int) (*Noti
 	responseBody, err := ioutil.ReadAll(resp.Body)
 	log.Printf("[DEBUG] NotificationCenter Read policy JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when reading policy for ID %d: %s ", resp.StatusCode, policyId, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from NotificationCenter service when reading policy for ID %d: %w ", resp.StatusCode, policyId, NewAPIError(resp, responseBody))
 	}
 
 	var notificationCenterPolicy NotificationPolicy
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -18,13 +19,13 @@ type SetOriginPOPResponse struct {
 }
 
 // SetOriginPOP sets the origin POP for given data center
-func (c *Client) SetOriginPOP(dcID int, originPOP string) error {
+func (c *Client) SetOriginPOP(ctx context.Context, dcID int, originPOP string) error {
 	reqURL := fmt.Sprintf("%s/sites/datacenter/origin-pop/modify?dc_id=%d", c.config.BaseURL, dcID)
 	if originPOP != "" {
 		reqURL = fmt.Sprintf("%s&origin_pop=%s", reqURL, originPOP)
 	}
 	// Post request to Incapsula
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, nil, UpdateOriginPop)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, nil, UpdateOriginPop)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when setting origin POP: %s for data center: %d: %s", originPOP, dcID, err)
 	}
@@ -45,7 +46,7 @@ func (c *Client) SetOriginPOP(dcID int, originPOP string) error {
 
 	// Look at the response status code from Incapsula
 	if originPOPResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when updating origin POP: %s for data center: %d: %s", originPOP, dcID, string(responseBody))
+		return fmt.Errorf("Error from Incapsula service when updating origin POP: %s for data center: %d: %w", originPOP, dcID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -53,12 +54,12 @@ type PerformanceSettings struct {
 }
 
 // GetPerformanceSettings gets the site performance settings
-func (c *Client) GetPerformanceSettings(siteID string) (*PerformanceSettings, error) {
+func (c *Client) GetPerformanceSettings(ctx context.Context, siteID string) (*PerformanceSettings, error) {
 	log.Printf("[INFO] Getting Incapsula Performance Settings for Site ID %s\n", siteID)
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadSitePerformance)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadSitePerformance)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when reading Incap Performance Settings for Site ID %s: %s", siteID, err)
 	}
@@ -72,7 +73,7 @@ func (c *Client) GetPerformanceSettings(siteID string) (*PerformanceSettings, er
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Incap Performance Settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Incap Performance Settings for Site ID %s: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -86,7 +87,7 @@ func (c *Client) GetPerformanceSettings(siteID string) (*PerformanceSettings, er
 }
 
 // UpdatePerformanceSettings updates the site performance settings
-func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *PerformanceSettings) (*PerformanceSettings, error) {
+func (c *Client) UpdatePerformanceSettings(ctx context.Context, siteID string, performanceSettings *PerformanceSettings) (*PerformanceSettings, error) {
 	log.Printf("[INFO] Updating Incapsula Performance Settings for Site ID %s\n", siteID)
 
 	performanceSettingsJSON, err := json.Marshal(performanceSettings)
@@ -104,7 +105,7 @@ func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *P
 	}
 
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/cache", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithCustomHeaders(http.MethodPut, reqURL, performanceSettingsJSON, headers, UpdateSitePerformance)
+	resp, err := c.DoJsonRequestWithCustomHeaders(ctx, http.MethodPut, reqURL, performanceSettingsJSON, headers, UpdateSitePerformance)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when updating Incap Performance Settings for Site ID %s: %s", siteID, err)
 	}
@@ -118,7 +119,7 @@ func (c *Client) UpdatePerformanceSettings(siteID string, performanceSettings *P
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Incap Performance Settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Incap Performance Settings for Site ID %s: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestClientGetPerformanceSettingsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "123"
-	performanceSettings, err := client.GetPerformanceSettings(siteID)
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -47,7 +48,7 @@ func TestClientGetPerformanceSettingsBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	performanceSettings, err := client.GetPerformanceSettings(siteID)
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -78,7 +79,7 @@ func TestClientGetPerformanceSettingsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	performanceSettings, err := client.GetPerformanceSettings(siteID)
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -108,7 +109,7 @@ func TestClientGetPerformanceSettingsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	performanceSettings, err := client.GetPerformanceSettings(siteID)
+	performanceSettings, err := client.GetPerformanceSettings(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -130,7 +131,7 @@ func TestClientUpdatePerformanceSettingsSettingsBadConnection(t *testing.T) {
 	siteID := "123"
 	performanceSettings := PerformanceSettings{}
 	performanceSettings.Mode.HTTPS = "include_all_resources"
-	_, err := client.UpdatePerformanceSettings(siteID, &performanceSettings)
+	_, err := client.UpdatePerformanceSettings(context.Background(), siteID, &performanceSettings)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -160,7 +161,7 @@ func TestClientUpdatePerformanceSettingsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.UpdatePerformanceSettings(siteID, &performanceSettings)
+	_, err := client.UpdatePerformanceSettings(context.Background(), siteID, &performanceSettings)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -189,7 +190,7 @@ func TestClientUpdatePerformanceSettingsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.UpdatePerformanceSettings(siteID, &performanceSettings)
+	_, err := client.UpdatePerformanceSettings(context.Background(), siteID, &performanceSettings)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
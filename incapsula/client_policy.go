@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -49,32 +50,47 @@ type DefaultPolicyConfig struct {
 
 // PolicySetting is a struct that encompasses all the properties of a policy setting
 type PolicySetting struct {
-	SettingsAction    string `json:"settingsAction"`
-	PolicySettingType string `json:"policySettingType"`
-	Data              struct {
-		Geo *struct {
-			Countries  []string `json:"countries,omitempty"`
-			Continents []string `json:"continents,omitempty"`
-		} `json:"geo,omitempty"`
-		Ips  []string `json:"ips,omitempty"`
-		Urls []struct {
-			Pattern string `json:"pattern,omitempty"`
-			URL     string `json:"url,omitempty"`
-		} `json:"urls,omitempty"`
-		HeaderValue string `json:"headerValue,omitempty"`
-	} `json:"data"`
-	PolicyDataExceptions []struct {
-		Data []struct {
-			ValidateExceptionData bool     `json:"validateExceptionData,omitempty"`
-			ExceptionType         string   `json:"exceptionType,omitempty"`
-			Values                []string `json:"values,omitempty"`
-		} `json:"data,omitempty"`
-		Comment string `json:"comment,omitempty"`
-	} `json:"policyDataExceptions,omitempty"`
+	SettingsAction       string                `json:"settingsAction"`
+	PolicySettingType    string                `json:"policySettingType"`
+	Data                 PolicySettingData     `json:"data"`
+	PolicyDataExceptions []PolicyDataException `json:"policyDataExceptions,omitempty"`
+}
+
+// PolicySettingData is the data a policy setting applies to: geo locations, IPs, URLs or a header value
+type PolicySettingData struct {
+	Geo         *PolicySettingGeo  `json:"geo,omitempty"`
+	Ips         []string           `json:"ips,omitempty"`
+	Urls        []PolicySettingURL `json:"urls,omitempty"`
+	HeaderValue string             `json:"headerValue,omitempty"`
+}
+
+// PolicySettingGeo is the geo data of a policy setting
+type PolicySettingGeo struct {
+	Countries  []string `json:"countries,omitempty"`
+	Continents []string `json:"continents,omitempty"`
+}
+
+// PolicySettingURL is a URL of a policy setting, with the pattern it is matched with
+type PolicySettingURL struct {
+	Pattern string `json:"pattern,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// PolicyDataException is an exception to a policy setting
+type PolicyDataException struct {
+	Data    []PolicyDataExceptionData `json:"data,omitempty"`
+	Comment string                    `json:"comment,omitempty"`
+}
+
+// PolicyDataExceptionData is a condition of a policy setting exception
+type PolicyDataExceptionData struct {
+	ValidateExceptionData bool     `json:"validateExceptionData,omitempty"`
+	ExceptionType         string   `json:"exceptionType,omitempty"`
+	Values                []string `json:"values,omitempty"`
 }
 
 // AddPolicy adds a policy to be managed by Incapsula
-func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, error) {
+func (c *Client) AddPolicy(ctx context.Context, policySubmitted *PolicySubmitted) (*PolicyExtended, error) {
 	log.Printf("[INFO] Adding Incapsula Policy\n")
 
 	policyJSON, err := json.Marshal(policySubmitted)
@@ -88,7 +104,7 @@ func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, e
 	if policySubmitted.AccountID != 0 {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, policySubmitted.AccountID)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, policyJSON, CreatePolicy)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, policyJSON, CreatePolicy)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when adding Policy: %s", err)
 	}
@@ -102,7 +118,7 @@ func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, e
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Policy: %s", resp.StatusCode, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when adding Policy: %w", resp.StatusCode, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -116,7 +132,7 @@ func (c *Client) AddPolicy(policySubmitted *PolicySubmitted) (*PolicyExtended, e
 }
 
 // GetPolicy gets the policy
-func (c *Client) GetPolicy(policyID string, currentAccountId *int) (*PolicyExtended, error) {
+func (c *Client) GetPolicy(ctx context.Context, policyID string, currentAccountId *int) (*PolicyExtended, error) {
 	log.Printf("[INFO] Getting Incapsula Policy: %s\n", policyID)
 
 	// Post form to Incapsula
@@ -125,7 +141,7 @@ func (c *Client) GetPolicy(policyID string, currentAccountId *int) (*PolicyExten
 		reqURL = fmt.Sprintf("%s&caid=%d", reqURL, *currentAccountId)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadPolicy)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadPolicy)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when reading Policy for ID %s: %s", policyID, err)
 	}
@@ -139,7 +155,7 @@ func (c *Client) GetPolicy(policyID string, currentAccountId *int) (*PolicyExten
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Policy for ID %s: %s", resp.StatusCode, policyID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading Policy for ID %s: %w", resp.StatusCode, policyID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -153,7 +169,7 @@ func (c *Client) GetPolicy(policyID string, currentAccountId *int) (*PolicyExten
 }
 
 // UpdatePolicy updates the Incapsula Policy
-func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted, currentAccountId *int) (*PolicyExtended, error) {
+func (c *Client) UpdatePolicy(ctx context.Context, policyID int, policySubmitted *PolicySubmitted, currentAccountId *int) (*PolicyExtended, error) {
 	log.Printf("[INFO] Updating Incapsula Policy with ID %d\n", policyID)
 
 	policyJSON, err := json.Marshal(policySubmitted)
@@ -167,7 +183,7 @@ func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted, cu
 	if currentAccountId != nil {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, *currentAccountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, policyJSON, UpdatePolicy)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, policyJSON, UpdatePolicy)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when updating Policy: %s", err)
 	}
@@ -181,7 +197,7 @@ func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted, cu
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Policy with ID %d: %s", resp.StatusCode, policyID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when updating Policy with ID %d: %w", resp.StatusCode, policyID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -195,7 +211,7 @@ func (c *Client) UpdatePolicy(policyID int, policySubmitted *PolicySubmitted, cu
 }
 
 // DeletePolicy deletes a policy currently managed by Incapsula
-func (c *Client) DeletePolicy(policyID string, currentAccountId *int) error {
+func (c *Client) DeletePolicy(ctx context.Context, policyID string, currentAccountId *int) error {
 	log.Printf("[INFO] Deleting Incapsula Policy for ID %s\n", policyID)
 
 	// Delete request to Incapsula
@@ -203,7 +219,7 @@ func (c *Client) DeletePolicy(policyID string, currentAccountId *int) error {
 	if currentAccountId != nil {
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, *currentAccountId)
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeletePolicy)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeletePolicy)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when deleting Policy with ID %s: %s", policyID, err)
 	}
@@ -217,19 +233,19 @@ func (c *Client) DeletePolicy(policyID string, currentAccountId *int) error {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when deleting Policy with ID %s: %s", resp.StatusCode, policyID, string(responseBody))
+		return fmt.Errorf("Error status code %d from Incapsula service when deleting Policy with ID %s: %w", resp.StatusCode, policyID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
 }
 
 // GetAllPoliciesForAccount gets all policies for specific account
-func (c *Client) GetAllPoliciesForAccount(accountId string) (*[]Policy, error) {
+func (c *Client) GetAllPoliciesForAccount(ctx context.Context, accountId string) (*[]Policy, error) {
 	log.Printf("[INFO] Getting All Incapsula Policies for account: %s\n", accountId)
 	//
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/policies/v2/policies?caid=%s&extended=true", c.config.BaseURLAPI, accountId)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadPoliciesAll)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadPoliciesAll)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading All Policies for Account ID %s: %s", accountId, err)
 	}
@@ -243,7 +259,7 @@ func (c *Client) GetAllPoliciesForAccount(accountId string) (*[]Policy, error) {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when reading All Policies for Account ID %s: %s", resp.StatusCode, accountId, string(responseBody))
+		return nil, fmt.Errorf("[ERROR] Error status code %d from Incapsula service when reading All Policies for Account ID %s: %w", resp.StatusCode, accountId, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
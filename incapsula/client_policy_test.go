@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ func TestGetAllPoliciesForAccountBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountId := "92"
 
-	getAllPolicieGetResponse, err := client.GetAllPoliciesForAccount(accountId)
+	getAllPolicieGetResponse, err := client.GetAllPoliciesForAccount(context.Background(), accountId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -47,7 +48,7 @@ func TestGetAllPoliciesForAccountBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	getAllPolicieGetResponse, err := client.GetAllPoliciesForAccount(accountId)
+	getAllPolicieGetResponse, err := client.GetAllPoliciesForAccount(context.Background(), accountId)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -82,7 +83,7 @@ func TestGetAllPoliciesForAccountInvalidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	getAllPolicieGetResponse, err := client.GetAllPoliciesForAccount(accountId)
+	getAllPolicieGetResponse, err := client.GetAllPoliciesForAccount(context.Background(), accountId)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -141,7 +142,7 @@ func TestGetAllPoliciesForAccountValidApiConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	getAllPolicieGetResponse, err := client.GetAllPoliciesForAccount(accountId)
+	getAllPolicieGetResponse, err := client.GetAllPoliciesForAccount(context.Background(), accountId)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
@@ -0,0 +1,163 @@
+package incapsula
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket allowing a steady rate of requests with bursts of up to burst requests
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	burst := math.Max(1, math.Ceil(requestsPerSecond))
+	return &rateLimiter{rate: requestsPerSecond, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a token is available or the context is done. A nil limiter never blocks
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// concurrencyLimiter caps the number of requests in flight
+type concurrencyLimiter chan struct{}
+
+func newConcurrencyLimiter(maxConcurrent int) concurrencyLimiter {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	return make(concurrencyLimiter, maxConcurrent)
+}
+
+// acquire blocks until a slot is free or the context is done. A nil limiter never blocks
+func (l concurrencyLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l concurrencyLimiter) release() {
+	if l == nil {
+		return
+	}
+	<-l
+}
+
+// requestLimiter combines the rate and concurrency budgets shared by all resources.
+// Write requests consume both the global budget and the write budget, read requests the global budget and the read budget
+type requestLimiter struct {
+	rate             *rateLimiter
+	concurrency      concurrencyLimiter
+	writeRate        *rateLimiter
+	writeConcurrency concurrencyLimiter
+	readRate         *rateLimiter
+	readConcurrency  concurrencyLimiter
+}
+
+func newRequestLimiter(config *Config) *requestLimiter {
+	return &requestLimiter{
+		rate:             newRateLimiter(config.MaxRequestsPerSecond),
+		concurrency:      newConcurrencyLimiter(config.MaxConcurrentRequests),
+		writeRate:        newRateLimiter(config.MaxWriteRequestsPerSecond),
+		writeConcurrency: newConcurrencyLimiter(config.MaxConcurrentWriteRequests),
+		readRate:         newRateLimiter(config.MaxReadRequestsPerSecond),
+		readConcurrency:  newConcurrencyLimiter(config.MaxConcurrentReadRequests),
+	}
+}
+
+// acquire waits for the budget of a single request and returns the function releasing its concurrency slots,
+// which may be called several times. A nil limiter does not limit anything
+func (l *requestLimiter) acquire(ctx context.Context, isRead bool) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	kindRate, kindConcurrency := l.writeRate, l.writeConcurrency
+	if isRead {
+		kindRate, kindConcurrency = l.readRate, l.readConcurrency
+	}
+
+	if err := kindRate.wait(ctx); err != nil {
+		return nil, err
+	}
+	if err := l.rate.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := kindConcurrency.acquire(ctx); err != nil {
+		return nil, err
+	}
+	if err := l.concurrency.acquire(ctx); err != nil {
+		kindConcurrency.release()
+		return nil, err
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.concurrency.release()
+			kindConcurrency.release()
+		})
+	}, nil
+}
+
+// releasingBody holds the concurrency slots of a request until its response body is read to the end or closed,
+// as the response is still being received until then
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.release()
+	}
+	return n, err
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
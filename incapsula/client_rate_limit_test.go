@@ -0,0 +1,152 @@
+package incapsula
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterNilDoesNotBlock(t *testing.T) {
+	var limiter *rateLimiter
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+	if newRateLimiter(0) != nil {
+		t.Errorf("Should not have created a limiter for a rate of 0")
+	}
+}
+
+func TestRateLimiterThrottles(t *testing.T) {
+	limiter := newRateLimiter(20)
+	start := time.Now()
+	// The first 20 requests are served from the initial burst, the next 10 take about half a second
+	for i := 0; i < 30; i++ {
+		if err := limiter.wait(context.Background()); err != nil {
+			t.Fatalf("Should not have received an error, got: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Should have throttled requests, took: %s", elapsed)
+	}
+}
+
+func TestRateLimiterCancelled(t *testing.T) {
+	limiter := newRateLimiter(0.001)
+	limiter.wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx); err == nil {
+		t.Errorf("Should have received an error once the context is done")
+	}
+}
+
+func TestClientMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", MaxConcurrentRequests: 4, MaxConcurrentWriteRequests: 2}
+	client := &Client{config: config, httpClient: &http.Client{}, limiter: newRequestLimiter(config)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodPost, server.URL, []byte(`{}`), CreateIncapRule)
+			if err != nil {
+				t.Errorf("Should not have received an error, got: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("Should have had at most 2 write requests in flight, got: %d", maxInFlight)
+	}
+}
+
+func TestClientMaxConcurrentReadRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", MaxConcurrentRequests: 4, MaxConcurrentReadRequests: 2}
+	client := &Client{config: config, httpClient: &http.Client{}, limiter: newRequestLimiter(config)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodGet, server.URL, nil, ReadIncapRule)
+			if err != nil {
+				t.Errorf("Should not have received an error, got: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("Should have had at most 2 read requests in flight, got: %d", maxInFlight)
+	}
+}
+
+func TestClientConcurrentRequestReleasedOnBodyClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", MaxConcurrentRequests: 1}
+	client := &Client{config: config, httpClient: &http.Client{}, limiter: newRequestLimiter(config)}
+
+	resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodGet, server.URL, nil, ReadIncapRule)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+
+	// The response of the first request is not closed yet, so the second request can't be sent
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.DoJsonRequestWithHeaders(ctx, http.MethodGet, server.URL, nil, ReadIncapRule); err == nil {
+		t.Errorf("Should have waited for the response of the first request to be closed")
+	}
+
+	resp.Body.Close()
+	resp, err = client.DoJsonRequestWithHeaders(context.Background(), http.MethodGet, server.URL, nil, ReadIncapRule)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp.Body.Close()
+}
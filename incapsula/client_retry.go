@@ -0,0 +1,211 @@
+package incapsula
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const defaultRetryMaxAttempts = 5
+const defaultRetryMinBackoff = 1 * time.Second
+const defaultRetryMaxBackoff = 30 * time.Second
+
+var defaultRetryStatusCodes = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryPolicy describes when and how often a request to the Incapsula API is retried
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one
+	MaxAttempts int
+
+	// Lower and upper bounds of the exponential backoff between attempts
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// HTTP status codes on which read requests are retried, none when empty.
+	// Mutating requests are only retried on 429 (if listed here) since the server did not process them
+	StatusCodes []int
+}
+
+// retryPolicy returns the retry policy of the client, falling back to the defaults for unset values
+func (c *Client) retryPolicy() *RetryPolicy {
+	policy := &RetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		MinBackoff:  defaultRetryMinBackoff,
+		MaxBackoff:  defaultRetryMaxBackoff,
+		StatusCodes: defaultRetryStatusCodes,
+	}
+
+	if c.config == nil {
+		return policy
+	}
+	if c.config.RetryMaxAttempts > 0 {
+		policy.MaxAttempts = c.config.RetryMaxAttempts
+	}
+	if c.config.RetryMinBackoff > 0 {
+		policy.MinBackoff = c.config.RetryMinBackoff
+	}
+	if c.config.RetryMaxBackoff > 0 {
+		policy.MaxBackoff = c.config.RetryMaxBackoff
+	}
+	if policy.MaxBackoff < policy.MinBackoff {
+		policy.MaxBackoff = policy.MinBackoff
+	}
+	// An empty list, unlike an unset one, disables the retries on status codes
+	if c.config.RetryStatusCodes != nil {
+		policy.StatusCodes = c.config.RetryStatusCodes
+	}
+
+	return policy
+}
+
+// isReadRequest reports whether the request does not change anything on the server side.
+// Some v1 endpoints are read using POST, those are identified by their "read" operation name
+func isReadRequest(req *http.Request) bool {
+	operation := req.Header.Get("x-tf-operation")
+	return req.Method == http.MethodGet || (req.Method == http.MethodPost && strings.HasPrefix(strings.ToLower(operation), "read"))
+}
+
+// shouldRetryStatusCode reports whether a response with the given status code should be retried
+func (p *RetryPolicy) shouldRetryStatusCode(statusCode int, isRead bool) bool {
+	if !isRead && statusCode != http.StatusTooManyRequests {
+		return false
+	}
+	for _, code := range p.StatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetryError reports whether a transport error should be retried.
+// A refused connection means the request never reached the server, so it is safe to retry any request.
+// A reset connection may happen after the server got the request, so only reads are retried
+func (p *RetryPolicy) shouldRetryError(err error, isRead bool) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	return isRead && errors.Is(err, syscall.ECONNRESET)
+}
+
+// backoff returns the delay before the given retry (starting from 1), using exponential backoff with jitter.
+// A Retry-After header sent by the server takes precedence, and isn't bounded by the maximum backoff: retrying sooner
+// would be rejected again. The deadline of the request bounds it instead, see executeRequest
+func (p *RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return retryAfter
+		}
+	}
+
+	delay := float64(p.MinBackoff) * math.Pow(2, float64(retry-1))
+	if delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	// Equal jitter: keep half of the delay and randomize the other half
+	half := delay / 2
+	return time.Duration(half + rand.Float64()*half)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, given either in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func (c *Client) executeRequest(req *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy()
+	isRead := isReadRequest(req)
+	operation := req.Header.Get("x-tf-operation")
+	c.applyAccountID(req)
+
+	if c.credentials != nil {
+		apiID, apiKey, err := c.credentials.credentials(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("x-api-id", apiID)
+		req.Header.Set("x-api-key", apiKey)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("Error preparing request body for retry: %s", err)
+			}
+			req.Body = body
+		}
+
+		release, err := c.limiter.acquire(req.Context(), isRead)
+		if err != nil {
+			return nil, err
+		}
+		c.logRequest(req, operation, attempt)
+		start := time.Now()
+		resp, err := c.httpClient.Do(req)
+		c.logResponse(req, operation, attempt, resp, err, time.Since(start))
+		// A request stays in flight until its response body is read to the end or closed
+		if err != nil || resp.Body == nil {
+			release()
+		} else {
+			resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+		}
+
+		retry := false
+		if err != nil {
+			retry = policy.shouldRetryError(err, isRead)
+		} else {
+			retry = policy.shouldRetryStatusCode(resp.StatusCode, isRead)
+		}
+
+		if !retry || attempt >= policy.MaxAttempts || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		// Waiting past the deadline of the request would only turn the response into a context error
+		delay := policy.backoff(attempt, resp)
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < delay {
+			log.Printf("[WARN] Not retrying %s (attempt %d/%d): the retry in %s would be after the deadline of the request", operation, attempt, policy.MaxAttempts, delay)
+			return resp, err
+		}
+		if err != nil {
+			log.Printf("[WARN] Error from Incapsula service on %s (attempt %d/%d): %s, retrying in %s", operation, attempt, policy.MaxAttempts, err, delay)
+		} else {
+			log.Printf("[WARN] Error status code %d from Incapsula service on %s (attempt %d/%d), retrying in %s", resp.StatusCode, operation, attempt, policy.MaxAttempts, delay)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
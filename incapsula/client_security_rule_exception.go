@@ -1,12 +1,14 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // Endpoints (unexported consts)
@@ -38,7 +40,7 @@ type SecurityRuleExceptionCreateResponse struct {
 }
 
 // AddSecurityRuleException adds a security rule exception
-func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urls, userAgents, parameters string) (*SecurityRuleExceptionCreateResponse, error) {
+func (c *Client) AddSecurityRuleException(ctx context.Context, siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urls, userAgents, parameters string) (*SecurityRuleExceptionCreateResponse, error) {
 	// Base URL values
 	values := url.Values{
 		"site_id":           {strconv.Itoa(siteID)},
@@ -77,7 +79,7 @@ func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, cl
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateSecurityRuleException)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateSecurityRuleException)
 	if err != nil {
 		return nil, fmt.Errorf("Error configuring security rule exception rule_id (%s) for site_id (%d)", ruleID, siteID)
 	}
@@ -98,14 +100,14 @@ func (c *Client) AddSecurityRuleException(siteID int, ruleID, clientAppTypes, cl
 
 	// Look at the response status code from Incapsula
 	if securityRuleExceptionCreateResponse.Res != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %w", ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &securityRuleExceptionCreateResponse, nil
 }
 
 // EditSecurityRuleException edits a security rule exception
-func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urls, userAgents, parameters, whitelistID string) (*SiteStatusResponse, error) {
+func (c *Client) EditSecurityRuleException(ctx context.Context, siteID int, ruleID, clientAppTypes, clientApps, countries, continents, ips, urls, userAgents, parameters, whitelistID string) (*SiteStatusResponse, error) {
 	// Base URL values
 	values := url.Values{
 		"site_id":      {strconv.Itoa(siteID)},
@@ -144,7 +146,7 @@ func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, c
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateSecurityRuleException)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateSecurityRuleException)
 	if err != nil {
 		return nil, fmt.Errorf("Error configuring security rule exception rule_id (%s) for site_id (%d)", ruleID, siteID)
 	}
@@ -175,20 +177,20 @@ func (c *Client) EditSecurityRuleException(siteID int, ruleID, clientAppTypes, c
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return nil, fmt.Errorf("Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when adding security rule exception for rule_id (%s) and site_id (%d): %w", ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &siteStatusResponse, nil
 }
 
 // ListSecurityRuleExceptions gets the site status including the list of exceptions for security rules
-func (c *Client) ListSecurityRuleExceptions(siteID, ruleID string) (*SiteStatusResponse, error) {
+func (c *Client) ListSecurityRuleExceptions(ctx context.Context, siteID, ruleID string) (*SiteStatusResponse, error) {
 	log.Printf("[INFO] Getting Incapsula security rule exeptions for rule_id (%s) on site_id (%s)\n", ruleID, siteID)
 
 	// Post form to Incapsula
 	values := url.Values{"site_id": {siteID}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionList)
-	resp, err := c.PostFormWithHeaders(reqURL, values, ReadSecurityRuleException)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadSecurityRuleException)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting security rule exceptions for rule_id (%s) on siteID (%s): %s", ruleID, siteID, err)
 	}
@@ -219,14 +221,14 @@ func (c *Client) ListSecurityRuleExceptions(siteID, ruleID string) (*SiteStatusR
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return &siteStatusResponse, fmt.Errorf("Error from Incapsula service when getting security rule exceptions (site_id: %s): %s", siteID, string(responseBody))
+		return &siteStatusResponse, fmt.Errorf("Error from Incapsula service when getting security rule exceptions (site_id: %s): %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &siteStatusResponse, nil
 }
 
 // DeleteSecurityRuleException deletes a security rule exception
-func (c *Client) DeleteSecurityRuleException(siteID int, ruleID, whitelistID string) error {
+func (c *Client) DeleteSecurityRuleException(ctx context.Context, siteID int, ruleID, whitelistID string) error {
 	type ExceptionDeleteResponse struct {
 		Res        int    `json:"res"`
 		ResMessage string `json:"res_message"`
@@ -243,7 +245,7 @@ func (c *Client) DeleteSecurityRuleException(siteID int, ruleID, whitelistID str
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointExceptionConfigure)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteSecurityRuleException)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteSecurityRuleException)
 	if err != nil {
 		return fmt.Errorf("Error deleting security rule exception whitelist_id (%s) for rule_id (%s) for site_id (%d)", whitelistID, ruleID, siteID)
 	}
@@ -264,8 +266,145 @@ func (c *Client) DeleteSecurityRuleException(siteID int, ruleID, whitelistID str
 
 	// Look at the response status code from Incapsula
 	if exceptionDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting security rule exception for rule_id (%s) and site_id (%d): %s", ruleID, siteID, string(responseBody))
+		return fmt.Errorf("Error from Incapsula service when deleting security rule exception for rule_id (%s) and site_id (%d): %w", ruleID, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
 }
+
+// SecurityRuleExceptionData is an exception of a security rule, with the values of each exception parameter.
+// WhitelistID is empty for exceptions that don't exist yet
+type SecurityRuleExceptionData struct {
+	WhitelistID    string
+	ClientAppTypes []string
+	ClientApps     []string
+	Countries      []string
+	Continents     []string
+	Ips            []string
+	Urls           []string
+	UserAgents     []string
+	Parameters     []string
+}
+
+// params returns the values of the exception by parameter name, as named in
+// securityRuleExceptionParamMapping. Parameters without values are left out
+func (e *SecurityRuleExceptionData) params() map[string][]string {
+	params := map[string][]string{}
+	for param, values := range map[string][]string{
+		"client_app_types": e.ClientAppTypes,
+		"client_apps":      e.ClientApps,
+		"countries":        e.Countries,
+		"continents":       e.Continents,
+		"ips":              e.Ips,
+		"urls":             e.Urls,
+		"user_agents":      e.UserAgents,
+		"parameters":       e.Parameters,
+	} {
+		if len(values) > 0 {
+			params[param] = values
+		}
+	}
+	return params
+}
+
+// AddSecurityRuleExceptionData adds an exception to a security rule and returns its whitelist ID
+func (c *Client) AddSecurityRuleExceptionData(ctx context.Context, siteID int, ruleID string, exception *SecurityRuleExceptionData) (string, error) {
+	response, err := c.AddSecurityRuleException(
+		ctx,
+		siteID,
+		ruleID,
+		strings.Join(exception.ClientAppTypes, ","),
+		strings.Join(exception.ClientApps, ","),
+		strings.Join(exception.Countries, ","),
+		strings.Join(exception.Continents, ","),
+		strings.Join(exception.Ips, ","),
+		strings.Join(exception.Urls, ","),
+		strings.Join(exception.UserAgents, ","),
+		strings.Join(exception.Parameters, ","),
+	)
+	if err != nil {
+		return "", err
+	}
+	return response.ExceptionID, nil
+}
+
+// EditSecurityRuleExceptionData replaces the values of the parameters set in an existing exception of a security rule.
+// The parameters without values are left unchanged
+func (c *Client) EditSecurityRuleExceptionData(ctx context.Context, siteID int, ruleID string, exception *SecurityRuleExceptionData) error {
+	_, err := c.EditSecurityRuleException(
+		ctx,
+		siteID,
+		ruleID,
+		strings.Join(exception.ClientAppTypes, ","),
+		strings.Join(exception.ClientApps, ","),
+		strings.Join(exception.Countries, ","),
+		strings.Join(exception.Continents, ","),
+		strings.Join(exception.Ips, ","),
+		strings.Join(exception.Urls, ","),
+		strings.Join(exception.UserAgents, ","),
+		strings.Join(exception.Parameters, ","),
+		exception.WhitelistID,
+	)
+	return err
+}
+
+// securityRuleExceptionsFromSiteStatus returns the exceptions of a security rule listed in the site status
+func securityRuleExceptionsFromSiteStatus(siteStatusResponse *SiteStatusResponse, ruleID string) []SecurityRuleExceptionData {
+	var exceptions []SecurityRuleExceptionData
+	for _, rule := range siteStatusResponse.Security.Waf.Rules {
+		if rule.ID != ruleID {
+			continue
+		}
+		for _, entry := range rule.Exceptions {
+			exception := SecurityRuleExceptionData{WhitelistID: strconv.Itoa(entry.ID)}
+			for _, value := range entry.Values {
+				var urls []string
+				for _, url := range value.Urls {
+					urls = append(urls, url.Value)
+				}
+				exception.setValues(value.ID, value.Ips, urls, value.Geo.Countries, value.Geo.Continents, value.ClientApps, value.ClientAppTypes, value.Parameters, value.UserAgents)
+			}
+			exceptions = append(exceptions, exception)
+		}
+	}
+	for _, rule := range siteStatusResponse.Security.Acls.Rules {
+		if rule.ID != ruleID {
+			continue
+		}
+		for _, entry := range rule.Exceptions {
+			exception := SecurityRuleExceptionData{WhitelistID: strconv.Itoa(entry.ID)}
+			for _, value := range entry.Values {
+				var urls []string
+				for _, url := range value.Urls {
+					urls = append(urls, url.Value)
+				}
+				exception.setValues(value.ID, value.Ips, urls, value.Geo.Countries, value.Geo.Continents, value.ClientApps, value.ClientAppTypes, value.Parameters, value.UserAgents)
+			}
+			exceptions = append(exceptions, exception)
+		}
+	}
+	return exceptions
+}
+
+// setValues sets the values of an exception type of the site status. The WAF and ACL rules list their exceptions
+// with different structs, hence the values passed one by one
+func (e *SecurityRuleExceptionData) setValues(exceptionType string, ips, urls, countries, continents, clientApps, clientAppTypes, parameters, userAgents []string) {
+	switch exceptionType {
+	case exceptionTypeUrl:
+		e.Urls = urls
+	case exceptionTypeCountry:
+		e.Countries = countries
+	case exceptionTypeContinent:
+		e.Continents = continents
+	case exceptionTypeClientAppId:
+		e.ClientApps = clientApps
+	case exceptionTypeClientAppType:
+		e.ClientAppTypes = clientAppTypes
+	case exceptionTypeHttpParameter:
+		e.Parameters = parameters
+	case exceptionTypeIp:
+		e.Ips = ips
+	case exceptionTypeUserAgent:
+		e.UserAgents = userAgents
+	}
+}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -19,7 +20,7 @@ type ShortRenewalCycleConfiguration struct {
 	ShortRenewalCycle bool `json:"shortRenewalCycle"`
 }
 
-func (c *Client) GetShortRenewalCycleConfiguration(siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
+func (c *Client) GetShortRenewalCycleConfiguration(ctx context.Context, siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
 	if siteId == "" {
 		fmt.Errorf("[ERROR] site id was not provided")
 	}
@@ -31,7 +32,7 @@ func (c *Client) GetShortRenewalCycleConfiguration(siteId string, caid string) (
 	} else {
 		params["caid"] = caid
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, reqURL, nil, params, ReadShortRenewalCycleConfiguration)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, params, ReadShortRenewalCycleConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] error from Incapsula service when reading short renewal cycle configuration %s: %s", siteId, err)
 	}
@@ -49,7 +50,7 @@ func (c *Client) GetShortRenewalCycleConfiguration(siteId string, caid string) (
 	return &shortRenewalCycleConfigurationResponse, nil
 }
 
-func (c *Client) EnableShortRenewalCycleConfiguration(siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
+func (c *Client) EnableShortRenewalCycleConfiguration(ctx context.Context, siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
 	if siteId == "" {
 		fmt.Errorf("[ERROR] site id was not provided")
 	}
@@ -61,7 +62,7 @@ func (c *Client) EnableShortRenewalCycleConfiguration(siteId string, caid string
 	} else {
 		params["caid"] = caid
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, reqURL, nil, params, CreateShortRenewalCycleConfiguration)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, reqURL, nil, params, CreateShortRenewalCycleConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] error from Incapsula service when enabling short renewal cycle configuration %s: %s", siteId, err)
 	}
@@ -79,7 +80,7 @@ func (c *Client) EnableShortRenewalCycleConfiguration(siteId string, caid string
 	return &shortRenewalCycleConfigurationResponse, nil
 }
 
-func (c *Client) DeleteShortRenewalCycleConfiguration(siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
+func (c *Client) DeleteShortRenewalCycleConfiguration(ctx context.Context, siteId string, caid string) (*ShortRenewalCycleConfigurationDto, error) {
 	if siteId == "" {
 		fmt.Errorf("[ERROR] site id was not provided")
 	}
@@ -91,7 +92,7 @@ func (c *Client) DeleteShortRenewalCycleConfiguration(siteId string, caid string
 	} else {
 		params["caid"] = caid
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, reqURL, nil, params, DeleteShortRenewalCycleConfiguration)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, params, DeleteShortRenewalCycleConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] error from Incapsula service when deleting short renewal cycle configuration. site id: %s\n: %s", siteId, err)
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -27,7 +28,7 @@ func TestClient_GetShortRenewalCycleConfigurationConfiguration(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	response, err := client.GetShortRenewalCycleConfiguration(siteId, "")
+	response, err := client.GetShortRenewalCycleConfiguration(context.Background(), siteId, "")
 	if err != nil {
 		t.Errorf("unexcpected error: %s", err)
 	}
@@ -54,7 +55,7 @@ func TestClient_EnableShortRenewalCycleConfigurationConfiguration(t *testing.T)
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	response, err := client.EnableShortRenewalCycleConfiguration(siteId, "")
+	response, err := client.EnableShortRenewalCycleConfiguration(context.Background(), siteId, "")
 	if err != nil {
 		t.Errorf("unexcpected error: %s", err)
 	}
@@ -81,7 +82,7 @@ func TestDeleteShortRenewalCycleConfiguration(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	response, err := client.DeleteShortRenewalCycleConfiguration(siteId, "")
+	response, err := client.DeleteShortRenewalCycleConfiguration(context.Background(), siteId, "")
 	if err != nil {
 		t.Errorf("unexcpected error: %s", err)
 	}
@@ -115,7 +116,7 @@ func TestDeleteShortRenewalCycleConfigurationError(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	shortRenewalCycleConfigurationDto, err := client.DeleteShortRenewalCycleConfiguration(siteId, "")
+	shortRenewalCycleConfigurationDto, err := client.DeleteShortRenewalCycleConfiguration(context.Background(), siteId, "")
 	if err != nil {
 		t.Errorf("expected to get error")
 	}
@@ -150,7 +151,7 @@ func TestEnableShortRenewalCycleConfigurationError(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	shortRenewalCycleConfigurationDto, err := client.EnableShortRenewalCycleConfiguration(siteId, "")
+	shortRenewalCycleConfigurationDto, err := client.EnableShortRenewalCycleConfiguration(context.Background(), siteId, "")
 	if err != nil {
 		t.Errorf("unexcpected error: %s", err)
 	}
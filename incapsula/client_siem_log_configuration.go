@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,32 +31,38 @@ type SiemLogConfiguration struct {
 	Data []SiemLogConfigurationData `json:"data"`
 }
 
-func (c *Client) CreateSiemLogConfiguration(siemLogConfiguration *SiemLogConfiguration) (*SiemLogConfiguration, *int, error) {
+func (c *Client) CreateSiemLogConfiguration(ctx context.Context, siemLogConfiguration *SiemLogConfiguration) (*SiemLogConfiguration, *int, error) {
 	logConfigurationJSON, err := json.Marshal(siemLogConfiguration)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to produce JSON from SiemLogConfiguration: %s", err)
 	}
 	reqURL := fmt.Sprintf("%s/%s/", c.config.BaseURLAPI, endpointSiemLogConfiguration)
-	return siemLogConfigurationRequestWithResponse(c, CreateSiemLogConfiguration, http.MethodPost, reqURL, logConfigurationJSON, siemLogConfiguration.Data[0].AssetID, 201)
+	return siemLogConfigurationRequestWithResponse(ctx, c, CreateSiemLogConfiguration, http.MethodPost, reqURL, logConfigurationJSON, siemLogConfiguration.Data[0].AssetID, 201)
 }
 
-func (c *Client) ReadSiemLogConfiguration(ID string, accountId string) (*SiemLogConfiguration, *int, error) {
+func (c *Client) ReadSiemLogConfiguration(ctx context.Context, ID string, accountId string) (*SiemLogConfiguration, *int, error) {
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemLogConfiguration, ID)
-	return siemLogConfigurationRequestWithResponse(c, ReadSiemLogConfiguration, http.MethodGet, reqURL, nil, accountId, 200)
+	return siemLogConfigurationRequestWithResponse(ctx, c, ReadSiemLogConfiguration, http.MethodGet, reqURL, nil, accountId, 200)
 }
 
-func (c *Client) UpdateSiemLogConfiguration(siemLogConfiguration *SiemLogConfiguration) (*SiemLogConfiguration, *int, error) {
+func (c *Client) ListSiemLogConfigurations(ctx context.Context, accountId string) (*SiemLogConfiguration, error) {
+	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURLAPI, endpointSiemLogConfiguration)
+	response, _, err := siemLogConfigurationRequestWithResponse(ctx, c, ReadSiemLogConfigurationsAll, http.MethodGet, reqURL, nil, accountId, 200)
+	return response, err
+}
+
+func (c *Client) UpdateSiemLogConfiguration(ctx context.Context, siemLogConfiguration *SiemLogConfiguration) (*SiemLogConfiguration, *int, error) {
 	siemLogConfigurationJSON, err := json.Marshal(siemLogConfiguration)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to produce JSON from SiemLogConfigurationWithID: %s", err)
 	}
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemLogConfiguration, siemLogConfiguration.Data[0].ID)
-	return siemLogConfigurationRequestWithResponse(c, UpdateSiemLogConfiguration, http.MethodPut, reqURL, siemLogConfigurationJSON, siemLogConfiguration.Data[0].AssetID, 200)
+	return siemLogConfigurationRequestWithResponse(ctx, c, UpdateSiemLogConfiguration, http.MethodPut, reqURL, siemLogConfigurationJSON, siemLogConfiguration.Data[0].AssetID, 200)
 }
 
-func (c *Client) DeleteSiemLogConfiguration(ID string, accountId string) (*int, error) {
+func (c *Client) DeleteSiemLogConfiguration(ctx context.Context, ID string, accountId string) (*int, error) {
 	reqURL := fmt.Sprintf("%s/%s/%s", c.config.BaseURLAPI, endpointSiemLogConfiguration, ID)
-	_, _, responseStatusCode, err := siemLogConfigurationRequest(c, DeleteSiemLogConfiguration, http.MethodDelete, reqURL, nil, accountId, 200)
+	_, _, responseStatusCode, err := siemLogConfigurationRequest(ctx, c, DeleteSiemLogConfiguration, http.MethodDelete, reqURL, nil, accountId, 200)
 	return responseStatusCode, err
 }
 
@@ -65,7 +72,7 @@ func dSiemLogConfigurationResponseClose(c io.Closer) {
 	}
 }
 
-func siemLogConfigurationRequest(c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*string, *[]byte, *int, error) {
+func siemLogConfigurationRequest(ctx context.Context, c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*string, *[]byte, *int, error) {
 	log.Printf("[INFO] Executing operation %s on SIEM log configuration with data: %s", operation, data)
 
 	var params = map[string]string{}
@@ -74,7 +81,7 @@ func siemLogConfigurationRequest(c *Client, operation string, method string, req
 		params["caid"] = accountIdStr
 	}
 
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(method, reqURL, data, params, operation)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, method, reqURL, data, params, operation)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("error from Incapsula service when executing %s operation on SIEM log configuration: %s", operation, err)
 	}
@@ -89,15 +96,15 @@ func siemLogConfigurationRequest(c *Client, operation string, method string, req
 	log.Printf("[DEBUG] Incapsula returned response: %s\nfor %s operation on SIEM log configuration", body, operation)
 
 	if resp.StatusCode != expectedSuccessStatusCode {
-		return nil, nil, &resp.StatusCode, fmt.Errorf("received failure response for operation: %s on SIEM log configuration\nstatus code: %d\nbody: %s",
-			operation, resp.StatusCode, body)
+		return nil, nil, &resp.StatusCode, fmt.Errorf("received failure response for operation: %s on SIEM log configuration\nstatus code: %d\nbody: %w",
+			operation, resp.StatusCode, NewAPIError(resp, responseBody))
 	}
 
 	return &body, &responseBody, &resp.StatusCode, nil
 }
 
-func siemLogConfigurationRequestWithResponse(c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*SiemLogConfiguration, *int, error) {
-	body, responseBody, responseStatusCode, err := siemLogConfigurationRequest(c, operation, method, reqURL, data, accountIdStr, expectedSuccessStatusCode)
+func siemLogConfigurationRequestWithResponse(ctx context.Context, c *Client, operation string, method string, reqURL string, data []byte, accountIdStr string, expectedSuccessStatusCode int) (*SiemLogConfiguration, *int, error) {
+	body, responseBody, responseStatusCode, err := siemLogConfigurationRequest(ctx, c, operation, method, reqURL, data, accountIdStr, expectedSuccessStatusCode)
 	if responseBody == nil {
 		return nil, responseStatusCode, err
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -23,7 +24,7 @@ func TestClientCreateSiemLogConfigurationBadConfig(t *testing.T) {
 	}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 
-	siemLogConfiguration, _, err := client.CreateSiemLogConfiguration(&SiemLogConfiguration{Data: []SiemLogConfigurationData{{
+	siemLogConfiguration, _, err := client.CreateSiemLogConfiguration(context.Background(), &SiemLogConfiguration{Data: []SiemLogConfigurationData{{
 		AssetID:           RandomNumbersExcludingZeroString(10),
 		ConfigurationName: RandomLetterAndNumberString(20),
 		Provider:          RandomCapitalLetterString(6),
@@ -66,7 +67,7 @@ func TestClientCreateSiemLogConfigurationBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemLogConfiguration, _, err := client.CreateSiemLogConfiguration(&SiemLogConfiguration{Data: []SiemLogConfigurationData{{
+	siemLogConfiguration, _, err := client.CreateSiemLogConfiguration(context.Background(), &SiemLogConfiguration{Data: []SiemLogConfigurationData{{
 		AssetID:           assetId,
 		ConfigurationName: RandomLetterAndNumberString(20),
 		Provider:          RandomCapitalLetterString(6),
@@ -128,7 +129,7 @@ func TestClientCreateSiemLogConfigurationBadRequest(t *testing.T) {
 		config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 		client := &Client{config: config, httpClient: &http.Client{}}
 
-		siemLogConfiguration, _, err := client.CreateSiemLogConfiguration(&SiemLogConfiguration{Data: []SiemLogConfigurationData{{
+		siemLogConfiguration, _, err := client.CreateSiemLogConfiguration(context.Background(), &SiemLogConfiguration{Data: []SiemLogConfigurationData{{
 			AssetID:           assetId,
 			ConfigurationName: RandomLetterAndNumberString(20),
 			Provider:          RandomCapitalLetterString(6),
@@ -200,7 +201,7 @@ func TestClientCreateValidSiemLogConfiguration(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemLogConfiguration, _, err := client.CreateSiemLogConfiguration(&SiemLogConfiguration{Data: []SiemLogConfigurationData{{
+	siemLogConfiguration, _, err := client.CreateSiemLogConfiguration(context.Background(), &SiemLogConfiguration{Data: []SiemLogConfigurationData{{
 		AssetID:           assetId,
 		ConfigurationName: configurationName,
 		Provider:          provider,
@@ -268,7 +269,7 @@ func TestClientReadExistingSiemLogConfiguration(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemLogConfiguration, _, err := client.ReadSiemLogConfiguration(ID, "")
+	siemLogConfiguration, _, err := client.ReadSiemLogConfiguration(context.Background(), ID, "")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -316,7 +317,7 @@ func TestClientReadNonExistingSiemLogConfiguration(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siemLogConfiguration, _, err := client.ReadSiemLogConfiguration(ID, "")
+	siemLogConfiguration, _, err := client.ReadSiemLogConfiguration(context.Background(), ID, "")
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -391,7 +392,7 @@ func TestClientUpdateExistingSiemLogConfiguration(t *testing.T) {
 		ConnectionId:      connectionId,
 	}
 
-	siemLogConfiguration, _, err := client.UpdateSiemLogConfiguration(&SiemLogConfiguration{Data: []SiemLogConfigurationData{sent}})
+	siemLogConfiguration, _, err := client.UpdateSiemLogConfiguration(context.Background(), &SiemLogConfiguration{Data: []SiemLogConfigurationData{sent}})
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
@@ -496,6 +497,6 @@ func ClientDeleteSiemLogConfigurationBase(t *testing.T, ID string, responseStatu
 
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.DeleteSiemLogConfiguration(ID, "")
+	_, err := client.DeleteSiemLogConfiguration(context.Background(), ID, "")
 	return err
 }
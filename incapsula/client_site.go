@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -13,6 +14,7 @@ const endpointSiteAdd = "sites/add"
 const endpointSiteStatus = "sites/status"
 const endpointSiteUpdate = "sites/configure"
 const endpointSiteDelete = "sites/delete"
+const endpointSiteList = "sites/list"
 const endpointCertDetails = "certificates-ui/v3/certificates"
 
 // SiteAddResponse contains the relevant site information when adding an Incapsula managed site
@@ -27,6 +29,13 @@ type SiteUpdateResponse struct {
 	Res    int `json:"res"`
 }
 
+// SiteListResponse contains a page of the sites of an account
+type SiteListResponse struct {
+	Sites      []SiteStatusResponse `json:"sites"`
+	Res        interface{}          `json:"res"`
+	ResMessage string               `json:"res_message"`
+}
+
 // SiteStatusDNSValidationData is DNS related validation data (HTML is a map[string][]string)
 type SiteStatusDNSValidationData struct {
 	DNSRecordName string   `json:"dns_record_name"`
@@ -227,7 +236,7 @@ type Response struct {
 }
 
 // AddSite adds a site to be managed by Incapsula
-func (c *Client) AddSite(domain, refID, sendSiteSetupEmails, siteIP, forceSSL string, accountID int, nakedDomainSan bool, wildcarSan bool, logsAccountId string) (*SiteAddResponse, error) {
+func (c *Client) AddSite(ctx context.Context, domain, refID, sendSiteSetupEmails, siteIP, forceSSL string, accountID int, nakedDomainSan bool, wildcarSan bool, logsAccountId string) (*SiteAddResponse, error) {
 	log.Printf("[INFO] Adding Incapsula site for domain: %s (account ID %d)\n", domain, accountID)
 
 	values := url.Values{
@@ -246,7 +255,7 @@ func (c *Client) AddSite(domain, refID, sendSiteSetupEmails, siteIP, forceSSL st
 	}
 
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteAdd)
-	resp, err := c.PostFormWithHeaders(reqURL, values, CreateSite)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, CreateSite)
 	if err != nil {
 		return nil, fmt.Errorf("Error adding site for domain %s: %s", domain, err)
 	}
@@ -267,20 +276,20 @@ func (c *Client) AddSite(domain, refID, sendSiteSetupEmails, siteIP, forceSSL st
 
 	// Look at the response status code from Incapsula
 	if siteAddResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when adding site for domain %s: %s", domain, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when adding site for domain %s: %w", domain, NewAPIError(resp, responseBody))
 	}
 
 	return &siteAddResponse, nil
 }
 
 // SiteStatus gets the Incapsula managed site's status
-func (c *Client) SiteStatus(domain string, siteID int) (*SiteStatusResponse, error) {
+func (c *Client) SiteStatus(ctx context.Context, domain string, siteID int) (*SiteStatusResponse, error) {
 	log.Printf("[INFO] Getting Incapsula site status for domain: %s (site id: %d)\n", domain, siteID)
 
 	// Post form to Incapsula
 	values := url.Values{"site_id": {strconv.Itoa(siteID)}}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteStatus)
-	resp, err := c.PostFormWithHeaders(reqURL, values, ReadSite)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadSite)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting site status for domain %s (site id: %d): %s", domain, siteID, err)
 	}
@@ -309,14 +318,14 @@ func (c *Client) SiteStatus(domain string, siteID int) (*SiteStatusResponse, err
 
 	// Look at the response status code from Incapsula
 	if resString != "0" {
-		return &siteStatusResponse, fmt.Errorf("Error from Incapsula service when getting site status for domain %s (site id: %d): %s", domain, siteID, string(responseBody))
+		return &siteStatusResponse, fmt.Errorf("Error from Incapsula service when getting site status for domain %s (site id: %d): %w", domain, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &siteStatusResponse, nil
 }
 
 // UpdateSite will update the specific param/value on the site resource
-func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, error) {
+func (c *Client) UpdateSite(ctx context.Context, siteID, param, value string) (*SiteUpdateResponse, error) {
 	log.Printf("[INFO] Updating Incapsula site for siteID: %s\n", siteID)
 
 	// Post form to Incapsula
@@ -326,7 +335,7 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 		"value":   {value},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteUpdate)
-	resp, err := c.PostFormWithHeaders(reqURL, values, UpdateSite)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, UpdateSite)
 	if err != nil {
 		return nil, fmt.Errorf("Error updating param (%s) with value (%s) on site_id: %s: %s", param, value, siteID, err)
 	}
@@ -353,7 +362,7 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 			queryParams := url.Values{}
 			queryParams.Add("extSiteId", siteID)
 
-			resp, err := c.GetWithHeaders(reqURL, queryParams, UpdateSite)
+			resp, err := c.GetWithHeaders(ctx, reqURL, queryParams, UpdateSite)
 
 			if err != nil {
 				return nil, fmt.Errorf("Error checking certificate on site_id: %s: %s", siteID, err)
@@ -383,14 +392,14 @@ func (c *Client) UpdateSite(siteID, param, value string) (*SiteUpdateResponse, e
 				}
 			}
 		}
-		return nil, fmt.Errorf("Error from Incapsula service when updating site for siteID %s: %s", siteID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when updating site for siteID %s: %w", siteID, NewAPIError(resp, responseBody))
 	}
 
 	return &siteUpdateResponse, nil
 }
 
 // DeleteSite deletes a site currently managed by Incapsula
-func (c *Client) DeleteSite(domain string, siteID int) error {
+func (c *Client) DeleteSite(ctx context.Context, domain string, siteID int) error {
 	// Specifically shaded this struct, no need to share across funcs or export
 	// We only care about the response code and possibly the message
 	type SiteDeleteResponse struct {
@@ -406,7 +415,7 @@ func (c *Client) DeleteSite(domain string, siteID int) error {
 		"ignore_grace_period": {strconv.FormatBool(true)},
 	}
 	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteDelete)
-	resp, err := c.PostFormWithHeaders(reqURL, values, DeleteSite)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, DeleteSite)
 	if err != nil {
 		return fmt.Errorf("Error deleting site for domain %s (site id: %d): %s", domain, siteID, err)
 	}
@@ -427,8 +436,47 @@ func (c *Client) DeleteSite(domain string, siteID int) error {
 
 	// Look at the response status code from Incapsula
 	if siteDeleteResponse.Res != 0 {
-		return fmt.Errorf("Error from Incapsula service when deleting site for domain %s (site id: %d): %s", domain, siteID, string(responseBody))
+		return fmt.Errorf("Error from Incapsula service when deleting site for domain %s (site id: %d): %w", domain, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
 }
+
+// ListSites gets a page of the sites of the account. Pages are numbered from 0
+func (c *Client) ListSites(ctx context.Context, accountID int, pageSize int, pageNum int) (*SiteListResponse, error) {
+	log.Printf("[INFO] Listing Incapsula sites (account ID %d, page %d)\n", accountID, pageNum)
+
+	values := url.Values{
+		"page_size": {strconv.Itoa(pageSize)},
+		"page_num":  {strconv.Itoa(pageNum)},
+	}
+	if accountID != 0 {
+		values.Set("account_id", strconv.Itoa(accountID))
+	}
+	reqURL := fmt.Sprintf("%s/%s", c.config.BaseURL, endpointSiteList)
+	resp, err := c.PostFormWithHeaders(ctx, reqURL, values, ReadSitesAll)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing sites (account ID %d): %s", accountID, err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula list sites JSON response: %s\n", string(responseBody))
+
+	// Parse the JSON
+	var siteListResponse SiteListResponse
+	err = json.Unmarshal([]byte(responseBody), &siteListResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing list sites JSON response (account ID %d): %s", accountID, err)
+	}
+
+	// Look at the response status code from Incapsula
+	if fmt.Sprint(siteListResponse.Res) != "0" {
+		return nil, fmt.Errorf("Error from Incapsula service when listing sites (account ID %d): %w", accountID, NewAPIError(resp, responseBody))
+	}
+
+	return &siteListResponse, nil
+}
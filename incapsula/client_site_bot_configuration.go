@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -26,7 +27,7 @@ type BotsConfigurationDTO struct {
 }
 
 // UpdateBotAccessControlConfiguration - Update the Bot Access Control configuration for a given website
-func (c *Client) UpdateBotAccessControlConfiguration(siteID string, requestDTO BotsConfigurationDTO) (*BotsConfigurationDTO, error) {
+func (c *Client) UpdateBotAccessControlConfiguration(ctx context.Context, siteID string, requestDTO BotsConfigurationDTO) (*BotsConfigurationDTO, error) {
 	log.Printf("[INFO] Updating the Bot Access Control configuration for siteID: %s\n", siteID)
 
 	log.Printf("[INFO]  requestDTO: %+v\n", requestDTO)
@@ -36,7 +37,7 @@ func (c *Client) UpdateBotAccessControlConfiguration(siteID string, requestDTO B
 	log.Printf("[INFO]  botsJSON: %v\n", string(botsJSON))
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/botConfiguration", baseURLv3, siteID)
 	log.Printf("[INFO]  reqURL: %v\n", reqURL)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, botsJSON, CreateBotConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, botsJSON, CreateBotConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("Error executing update Bot Access Control configuration request for siteID %s: %s", siteID, err)
 	}
@@ -59,13 +60,13 @@ func (c *Client) UpdateBotAccessControlConfiguration(siteID string, requestDTO B
 }
 
 // GetBotAccessControlConfiguration - Retrieve the Bot Access Control configuration for a given website
-func (c *Client) GetBotAccessControlConfiguration(siteID string) (*BotsConfigurationDTO, error) {
+func (c *Client) GetBotAccessControlConfiguration(ctx context.Context, siteID string) (*BotsConfigurationDTO, error) {
 	log.Printf("[INFO] Getting Bot Access Control configuration (site_id: %s)\n", siteID)
 
 	// Get request to Incapsula
 	baseURLv3 := c.config.BaseURL[:len(c.config.BaseURL)-3] + "/v3"
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/botConfiguration", baseURLv3, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadBotConfiguration)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadBotConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("Error executing get Bot Access Control configuration request for siteID %s: %s", siteID, err)
 	}
@@ -88,13 +89,13 @@ func (c *Client) GetBotAccessControlConfiguration(siteID string) (*BotsConfigura
 }
 
 // GetClientApplicationsMetadata - Retrieve the Bot Access Control Metadata
-func (c *Client) GetClientApplicationsMetadata() (*ClientApps, error) {
+func (c *Client) GetClientApplicationsMetadata(ctx context.Context) (*ClientApps, error) {
 	log.Printf("[INFO] Getting Client Applications Metadata\n")
 
 	// Get request to Incapsula
 	baseURLIntegration := strings.Replace(c.config.BaseURL, "/prov/", "/integration/", 1)
 	reqURL := fmt.Sprintf("%s/clapps", baseURLIntegration)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, nil, ReadClientApplications)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, nil, ReadClientApplications)
 	if err != nil {
 		return nil, fmt.Errorf("Error executing get Bot Access Control Metadata request %s", err)
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ func TestClientUpdateBotAccessControlConfigurationBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
 	requestDTO := BotsConfigurationDTO{}
-	responseDTO, err := client.UpdateBotAccessControlConfiguration(siteID, requestDTO)
+	responseDTO, err := client.UpdateBotAccessControlConfiguration(context.Background(), siteID, requestDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -44,7 +45,7 @@ func TestClientUpdateBotAccessControlConfigurationBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	requestDTO := BotsConfigurationDTO{}
-	responseDTO, err := client.UpdateBotAccessControlConfiguration(siteID, requestDTO)
+	responseDTO, err := client.UpdateBotAccessControlConfiguration(context.Background(), siteID, requestDTO)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -70,7 +71,7 @@ func TestClientUpdateBotAccessControlInvalidBotConfiguration(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	requestDTO := BotsConfigurationDTO{}
-	responseDTO, err := client.UpdateBotAccessControlConfiguration(siteID, requestDTO)
+	responseDTO, err := client.UpdateBotAccessControlConfiguration(context.Background(), siteID, requestDTO)
 	if err != nil {
 		t.Errorf("Should not receive an error. Got: %s", err.Error())
 	}
@@ -97,7 +98,7 @@ func TestClientUpdateBotAccessControlValidBotConfiguration(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	requestDTO := BotsConfigurationDTO{}
-	responseDTO, err := client.UpdateBotAccessControlConfiguration(siteID, requestDTO)
+	responseDTO, err := client.UpdateBotAccessControlConfiguration(context.Background(), siteID, requestDTO)
 	if err != nil {
 		t.Errorf("Should not have received an error. Got: %s", err.Error())
 	}
@@ -122,7 +123,7 @@ func TestClientGetBotAccessControlConfigurationBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := "42"
-	responseDTO, err := client.GetBotAccessControlConfiguration(siteID)
+	responseDTO, err := client.GetBotAccessControlConfiguration(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -148,7 +149,7 @@ func TestClientGetBotAccessControlConfigurationBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	responseDTO, err := client.GetBotAccessControlConfiguration(siteID)
+	responseDTO, err := client.GetBotAccessControlConfiguration(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -173,7 +174,7 @@ func TestClientGetBotAccessControlConfigurationInvalidRequest(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	responseDTO, err := client.GetBotAccessControlConfiguration(siteID)
+	responseDTO, err := client.GetBotAccessControlConfiguration(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not receive an error. Got: %s", err.Error())
 	}
@@ -199,7 +200,7 @@ func TestClientGetBotAccessControlConfigurationValidRequest(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL + "/api/prov/v1"}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	responseDTO, err := client.GetBotAccessControlConfiguration(siteID)
+	responseDTO, err := client.GetBotAccessControlConfiguration(context.Background(), siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -53,7 +54,7 @@ type SiteCertificateV3Response struct {
 }
 
 // RequestSiteCertificate request site certificate
-func (c *Client) RequestSiteCertificate(siteId int, validationMethod string, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
+func (c *Client) RequestSiteCertificate(ctx context.Context, siteId int, validationMethod string, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] request site certificate to: %d ", siteId)
 	siteCertificateDTO := SiteCertificateDTO{}
@@ -63,7 +64,7 @@ func (c *Client) RequestSiteCertificate(siteId int, validationMethod string, acc
 	if accountId != nil {
 		url = fmt.Sprintf("%s?caid=%d", url, *accountId)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, url, []byte(siteCertificateDTOJSON), nil, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, url, []byte(siteCertificateDTOJSON), nil, RequestSiteCert)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -84,11 +85,10 @@ func (c *Client) RequestSiteCertificate(siteId int, validationMethod string, acc
 	}
 	log.Printf("[DEBUG] Imperva request site certificate JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on request site certificate",
+			fmt.Sprintf("Failed to read response for site id %d, got response status %d", siteId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var siteCertificateV3Response SiteCertificateV3Response
@@ -108,7 +108,7 @@ func (c *Client) RequestSiteCertificate(siteId int, validationMethod string, acc
 }
 
 // DeleteRequestSiteCertificate deletes a site certificate request
-func (c *Client) DeleteRequestSiteCertificate(siteId int, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
+func (c *Client) DeleteRequestSiteCertificate(ctx context.Context, siteId int, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] deleting request site certificate %d", siteId)
 
@@ -116,7 +116,7 @@ func (c *Client) DeleteRequestSiteCertificate(siteId int, accountId *int) (*Site
 	if accountId != nil {
 		url = fmt.Sprintf("%s?caid=%d", url, *accountId)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, url, []byte("{}"), nil, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, url, []byte("{}"), nil, RequestSiteCert)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -137,11 +137,10 @@ func (c *Client) DeleteRequestSiteCertificate(siteId int, accountId *int) (*Site
 	}
 	log.Printf("[DEBUG] Imperva delete request site certificate JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on delete request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on delete request site certificate",
+			fmt.Sprintf("Failed to read response for site id %d, got response status %d", siteId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var siteCertificateV3Response SiteCertificateV3Response
@@ -161,7 +160,7 @@ func (c *Client) DeleteRequestSiteCertificate(siteId int, accountId *int) (*Site
 }
 
 // GetSiteCertificateRequestStatus get site cert request
-func (c *Client) GetSiteCertificateRequestStatus(siteId int, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
+func (c *Client) GetSiteCertificateRequestStatus(ctx context.Context, siteId int, accountId *int) (*SiteCertificateV3Response, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] get request site certificate status %d", siteId)
 
@@ -169,7 +168,7 @@ func (c *Client) GetSiteCertificateRequestStatus(siteId int, accountId *int) (*S
 	if accountId != nil {
 		url = fmt.Sprintf("%s?caid=%d", url, *accountId)
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, url, nil, nil, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, url, nil, nil, RequestSiteCert)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -190,11 +189,10 @@ func (c *Client) GetSiteCertificateRequestStatus(siteId int, accountId *int) (*S
 	}
 	log.Printf("[DEBUG] Imperva get request site certificate JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on get request site certificate",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on get request site certificate",
+			fmt.Sprintf("Failed to read response for site id %d, got response status %d", siteId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var siteCertificateV3Response SiteCertificateV3Response
@@ -214,7 +212,7 @@ func (c *Client) GetSiteCertificateRequestStatus(siteId int, accountId *int) (*S
 }
 
 // ValidateDomains SSL validation of domains
-func (c *Client) ValidateDomains(siteId int, domainIds []int) diag.Diagnostics {
+func (c *Client) ValidateDomains(ctx context.Context, siteId int, domainIds []int) diag.Diagnostics {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] request ssl validation to: %v of site %d", domainIds, siteId)
 
@@ -228,7 +226,7 @@ func (c *Client) ValidateDomains(siteId int, domainIds []int) diag.Diagnostics {
 		})
 		return diags
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, url, domainJson, nil, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, url, domainJson, nil, RequestSiteCert)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -237,15 +235,15 @@ func (c *Client) ValidateDomains(siteId int, domainIds []int) diag.Diagnostics {
 		})
 		return diags
 	}
+	defer resp.Body.Close()
 	log.Printf("[DEBUG] Imperva ssl validation response: %d\n", resp.StatusCode)
 	if resp.StatusCode != 201 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to request ssl validation",
-			Detail:   fmt.Sprintf("Failed to request ssl validation for site id %d and domains %v, got response status %d", siteId, domainIds, resp.StatusCode),
-		})
+		responseBody, _ := ioutil.ReadAll(resp.Body)
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to request ssl validation",
+			fmt.Sprintf("Failed to request ssl validation for site id %d and domains %v, got response status %d", siteId, domainIds, resp.StatusCode),
+			resp, responseBody)...)
 		return diags
 	}
-	defer resp.Body.Close()
 	return diags
 }
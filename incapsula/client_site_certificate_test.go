@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,7 +13,7 @@ import (
 func TestClientRequestSiteCertificateBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	requestSiteCertificateResponse, diag := client.RequestSiteCertificate(123, "DNS", nil)
+	requestSiteCertificateResponse, diag := client.RequestSiteCertificate(context.Background(), 123, "DNS", nil)
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
@@ -32,8 +33,8 @@ func TestClientRequestSiteCertificateInternalError(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, diag := client.RequestSiteCertificate(123, "DNS", nil)
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500, error") {
+	_, diag := client.RequestSiteCertificate(context.Background(), 123, "DNS", nil)
+	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500: error") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -49,7 +50,7 @@ func TestClientRequestSiteCertificateErrorsInBody(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	accountSSLSettingsResponse, diag := client.RequestSiteCertificate(123, "DNS", nil)
+	accountSSLSettingsResponse, diag := client.RequestSiteCertificate(context.Background(), 123, "DNS", nil)
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -69,7 +70,7 @@ func TestClientRequestSiteCertificateDataInBody(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	requestSiteCertificateResponse, diag := client.RequestSiteCertificate(123, "DNS", nil)
+	requestSiteCertificateResponse, diag := client.RequestSiteCertificate(context.Background(), 123, "DNS", nil)
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -90,7 +91,7 @@ func TestClientRequestSiteCertificateWithAccountId(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 111
-	response, diag := client.RequestSiteCertificate(123, "DNS", &accountId)
+	response, diag := client.RequestSiteCertificate(context.Background(), 123, "DNS", &accountId)
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -110,7 +111,7 @@ func TestClientGetSiteCertificateRequestStatusDataInBody(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	siteCertificateResponse, diag := client.GetSiteCertificateRequestStatus(123, nil)
+	siteCertificateResponse, diag := client.GetSiteCertificateRequestStatus(context.Background(), 123, nil)
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -131,7 +132,7 @@ func TestClientGetSiteCertificateRequestStatusErrorsInBody(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteCertificateResponse, diag := client.GetSiteCertificateRequestStatus(123, nil)
+	siteCertificateResponse, diag := client.GetSiteCertificateRequestStatus(context.Background(), 123, nil)
 	if diag != nil {
 		t.Errorf("Should not received an error")
 	}
@@ -151,8 +152,8 @@ func TestClientGetSiteCertificateRequestStatusError500(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, diag := client.GetSiteCertificateRequestStatus(123, nil)
-	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500, error") {
+	_, diag := client.GetSiteCertificateRequestStatus(context.Background(), 123, nil)
+	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500: error") {
 		t.Errorf("Should have received an error")
 	}
 }
@@ -160,7 +161,7 @@ func TestClientGetSiteCertificateRequestStatusError500(t *testing.T) {
 func TestClientGetSiteCertificateRequestStatusBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	updateAccountSSLSettingsResponse, diag := client.GetSiteCertificateRequestStatus(123, nil)
+	updateAccountSSLSettingsResponse, diag := client.GetSiteCertificateRequestStatus(context.Background(), 123, nil)
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
@@ -172,7 +173,7 @@ func TestClientGetSiteCertificateRequestStatusBadConnection(t *testing.T) {
 func TestClientDeleteRequestSiteCertificateBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	_, diag := client.DeleteRequestSiteCertificate(123, nil)
+	_, diag := client.DeleteRequestSiteCertificate(context.Background(), 123, nil)
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
@@ -189,7 +190,7 @@ func TestClientDeleteRequestSiteCertificateError500(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, diag := client.DeleteRequestSiteCertificate(123, nil)
+	_, diag := client.DeleteRequestSiteCertificate(context.Background(), 123, nil)
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500") {
 		t.Errorf("Should have received an error")
 	}
@@ -207,7 +208,7 @@ func TestClientDeleteRequestSiteCertificateWithAccountId(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountId := 111
-	response, diag := client.DeleteRequestSiteCertificate(123, &accountId)
+	response, diag := client.DeleteRequestSiteCertificate(context.Background(), 123, &accountId)
 	if diag != nil || diag.HasError() {
 		t.Errorf("Should not received an error")
 	}
@@ -219,7 +220,7 @@ func TestClientDeleteRequestSiteCertificateWithAccountId(t *testing.T) {
 func TestClientValidateDomainBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: "http://badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	diag := client.ValidateDomains(123, []int{222})
+	diag := client.ValidateDomains(context.Background(), 123, []int{222})
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "Timeout exceeded while awaiting") {
 		t.Errorf("Should have received an time out error")
 	}
@@ -235,7 +236,7 @@ func TestClientValidateDomainError500(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	diag := client.ValidateDomains(123, []int{222})
+	diag := client.ValidateDomains(context.Background(), 123, []int{222})
 	if diag == nil || !diag.HasError() || !strings.Contains(diag[0].Detail, "got response status 500") {
 		t.Errorf("Should have received an error")
 	}
@@ -252,7 +253,7 @@ func TestClientValidateDomain(t *testing.T) {
 	defer server.Close()
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	deleteSiteCertificateResponse, diag := client.DeleteRequestSiteCertificate(123, nil)
+	deleteSiteCertificateResponse, diag := client.DeleteRequestSiteCertificate(context.Background(), 123, nil)
 	if diag != nil || diag.HasError() {
 		t.Errorf("Should not received an error")
 	}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -80,7 +81,7 @@ type ApiErrorResponse struct {
 	Source ApiErrorSource `json:"source"`
 }
 
-func (c *Client) GetWebsiteDomains(siteId string) (*SiteDomainDetailsDto, error) {
+func (c *Client) GetWebsiteDomains(ctx context.Context, siteId string) (*SiteDomainDetailsDto, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s", c.config.BaseURLAPI, endpointDomainManagement, siteId, "/domains")
 	if siteId == "" {
 		fmt.Errorf("[ERROR] site ID was not provided")
@@ -88,7 +89,7 @@ func (c *Client) GetWebsiteDomains(siteId string) (*SiteDomainDetailsDto, error)
 	var params = map[string]string{}
 	params["pageSize"] = "-1"
 	params["excludeAutoDiscovered"] = "true"
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, reqURL, nil, params, ReadDomain)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, params, ReadDomain)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when reading domain configuration details %s: %s", siteId, err)
 	}
@@ -106,8 +107,8 @@ func (c *Client) GetWebsiteDomains(siteId string) (*SiteDomainDetailsDto, error)
 	return &siteDomainDetailsResponse, nil
 }
 
-func (c *Client) BulkUpdateDomainsToSite(siteID string, siteDomainDetails []SiteDomainDetails) error {
-	err := verifyDomainsAmountBelowMaxAllowed(c, siteID, siteDomainDetails)
+func (c *Client) BulkUpdateDomainsToSite(ctx context.Context, siteID string, siteDomainDetails []SiteDomainDetails) error {
+	err := verifyDomainsAmountBelowMaxAllowed(ctx, c, siteID, siteDomainDetails)
 	if err != nil {
 		return err
 	}
@@ -120,13 +121,14 @@ func (c *Client) BulkUpdateDomainsToSite(siteID string, siteDomainDetails []Site
 	}
 
 	addBulkDomainsDto := BulkAddDomainsDto{Data: domainNames}
-	resp, err := handleAddBulkRequest(c, addBulkDomainsDto, siteID)
+	resp, err := handleAddBulkRequest(ctx, c, addBulkDomainsDto, siteID)
 	if err != nil {
 		return err
 	}
 
-	for i := 0; i < 15; i++ {
-		asyncResponseDataDto, err := checkForAsyncRequestStatus(c, siteID, resp.Data[0].Handler)
+	// Poll until the request completes, bounded by the deadline of the context
+	for i := 0; ; i++ {
+		asyncResponseDataDto, err := checkForAsyncRequestStatus(ctx, c, siteID, resp.Data[0].Handler)
 		if err != nil {
 			return err
 		}
@@ -135,20 +137,20 @@ func (c *Client) BulkUpdateDomainsToSite(siteID string, siteDomainDetails []Site
 		log.Printf("iteration %d: update domains for site status: %s", i, status)
 		switch status {
 		case "IN_PROGRESS":
-			time.Sleep(10 * time.Second)
+			if err := sleepWithContext(ctx, 10*time.Second); err != nil {
+				return fmt.Errorf("async request status for siteId %s reached timeout: %s", siteID, err)
+			}
 		case "FAILED":
 			return fmt.Errorf("async update domains for siteId %s returned FAILED status", siteID)
 		case "COMPLETED_SUCCESSFULLY":
 			return nil
 		}
 	}
-
-	return fmt.Errorf("async request status for siteId %s reached timeout", siteID)
 }
 
-func checkForAsyncRequestStatus(c *Client, siteId string, requestUuid string) (*AsyncResponseDetailsDto, error) {
+func checkForAsyncRequestStatus(ctx context.Context, c *Client, siteId string, requestUuid string) (*AsyncResponseDetailsDto, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s%s", c.config.BaseURLAPI, endpointDomainManagement, siteId, "/domains/status/", requestUuid)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, UpdateDomain)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, UpdateDomain)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when update domains for siteId %s: %s", siteId, err)
 	}
@@ -163,20 +165,20 @@ func checkForAsyncRequestStatus(c *Client, siteId string, requestUuid string) (*
 	}
 
 	if asyncResponseDetailsDto.Errors != nil && len(asyncResponseDetailsDto.Errors) > 0 {
-		return nil, fmt.Errorf("got error when trying to get async update domains requst for siteId %s: %s", siteId, asyncResponseDetailsDto.Errors[0].Detail)
+		return nil, fmt.Errorf("got error when trying to get async update domains requst for siteId %s: %s: %w", siteId, asyncResponseDetailsDto.Errors[0].Detail, NewAPIError(resp, responseBody))
 	}
 
 	return &asyncResponseDetailsDto, nil
 }
 
-func handleAddBulkRequest(c *Client, bulkAddDomainsDto BulkAddDomainsDto, siteId string) (*AsyncResponseDetailsDto, error) {
+func handleAddBulkRequest(ctx context.Context, c *Client, bulkAddDomainsDto BulkAddDomainsDto, siteId string) (*AsyncResponseDetailsDto, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s", c.config.BaseURLAPI, endpointDomainManagement, siteId, "/domains")
 	body, err := json.Marshal(bulkAddDomainsDto)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to parse bulkAddDomainsDto: %s ", err)
 	}
 
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, body, UpdateDomain)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, body, UpdateDomain)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when updating domains for site %s: %s", siteId, err)
 	}
@@ -192,13 +194,13 @@ func handleAddBulkRequest(c *Client, bulkAddDomainsDto BulkAddDomainsDto, siteId
 	}
 
 	if asyncResponseDetailsDto.Errors != nil && len(asyncResponseDetailsDto.Errors) > 0 {
-		return nil, fmt.Errorf("update domains async request failed: %s", asyncResponseDetailsDto.Errors[0].Detail)
+		return nil, fmt.Errorf("update domains async request failed: %s: %w", asyncResponseDetailsDto.Errors[0].Detail, NewAPIError(resp, responseBody))
 	}
 	return &asyncResponseDetailsDto, nil
 }
 
-func verifyDomainsAmountBelowMaxAllowed(c *Client, siteId string, siteDomainDetails []SiteDomainDetails) error {
-	siteExtraDetails, err := GetSiteExtraDetails(c, siteId)
+func verifyDomainsAmountBelowMaxAllowed(ctx context.Context, c *Client, siteId string, siteDomainDetails []SiteDomainDetails) error {
+	siteExtraDetails, err := GetSiteExtraDetails(ctx, c, siteId)
 	if err != nil {
 		return err
 	}
@@ -214,9 +216,9 @@ func verifyDomainsAmountBelowMaxAllowed(c *Client, siteId string, siteDomainDeta
 	return nil
 }
 
-func GetSiteExtraDetails(c *Client, siteID string) (*SiteDomainsExtraDetailsDto, error) {
+func GetSiteExtraDetails(ctx context.Context, c *Client, siteID string) (*SiteDomainsExtraDetailsDto, error) {
 	reqURL := fmt.Sprintf("%s%s%s%s", c.config.BaseURLAPI, endpointDomainManagement, siteID, "/domains/extraDetails")
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadDomainExtraDetails)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadDomainExtraDetails)
 	if err != nil {
 		return nil, fmt.Errorf("[ERROR] Error from Incapsula service when geting site domains extra details %s: %s", siteID, err)
 	}
@@ -232,7 +234,7 @@ func GetSiteExtraDetails(c *Client, siteID string) (*SiteDomainsExtraDetailsDto,
 		return nil, fmt.Errorf("[ERROR] Error parsing add domains to site JSON response for site ID %s: %s\nresponse: %s", siteID, err, string(responseBody))
 	}
 	if siteExtraDetailsResponse.Errors != nil && len(siteExtraDetailsResponse.Errors) > 0 {
-		return nil, fmt.Errorf("got error when trying to get site extra details: %s", siteExtraDetailsResponse.Errors[0].Detail)
+		return nil, fmt.Errorf("got error when trying to get site extra details: %s: %w", siteExtraDetailsResponse.Errors[0].Detail, NewAPIError(resp, responseBody))
 	}
 	if len(siteExtraDetailsResponse.Data) > 0 {
 		return &siteExtraDetailsResponse.Data[0], nil
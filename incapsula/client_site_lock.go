@@ -0,0 +1,81 @@
+package incapsula
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// keyedMutex is a registry of mutexes identified by a key. Locking a key only blocks the holders of the same key,
+// and the mutex of a key is released from the registry once nobody holds or waits for it
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	// lock holds a token while the key is locked, a channel so that waiting can be cancelled
+	lock chan struct{}
+	// refs counts the holder and the waiters of the key
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: map[string]*keyedMutexEntry{}}
+}
+
+// lock blocks until the key is locked or the context is done, and returns the function unlocking it.
+// A nil registry never blocks
+func (m *keyedMutex) lock(ctx context.Context, key string) (func(), error) {
+	if m == nil {
+		return func() {}, nil
+	}
+
+	m.mu.Lock()
+	entry, ok := m.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{lock: make(chan struct{}, 1)}
+		m.locks[key] = entry
+	}
+	entry.refs++
+	m.mu.Unlock()
+
+	select {
+	case entry.lock <- struct{}{}:
+		var once sync.Once
+		return func() {
+			once.Do(func() {
+				<-entry.lock
+				m.release(key, entry)
+			})
+		}, nil
+	case <-ctx.Done():
+		m.release(key, entry)
+		return nil, ctx.Err()
+	}
+}
+
+func (m *keyedMutex) release(key string, entry *keyedMutexEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry.refs--
+	if entry.refs == 0 {
+		delete(m.locks, key)
+	}
+}
+
+// lockSiteWrites serializes the changes a resource type makes to a site, when the serialization of this type is
+// enabled. It returns the function releasing the site. Changes to different sites still run in parallel
+func (c *Client) lockSiteWrites(ctx context.Context, resourceType string, siteID string) (func(), error) {
+	if siteID == "" || siteID == "0" || !c.config.serializesSiteWrites(resourceType) {
+		return func() {}, nil
+	}
+
+	log.Printf("[DEBUG] Waiting for the writes to site %s to be serialized (%s)\n", siteID, resourceType)
+	unlock, err := c.siteLocks.lock(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Locked site %s for writing (%s)\n", siteID, resourceType)
+	return unlock, nil
+}
@@ -0,0 +1,190 @@
+package incapsula
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestKeyedMutexNilDoesNotBlock(t *testing.T) {
+	var locks *keyedMutex
+	unlock, err := locks.lock(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	unlock()
+}
+
+// maxHolders runs concurrent holders of the keys and returns the maximum number of holders of the same key
+func maxHolders(t *testing.T, locks *keyedMutex, keys []string) map[string]int32 {
+	holders := map[string]*int32{}
+	maxima := map[string]*int32{}
+	for _, key := range keys {
+		holders[key] = new(int32)
+		maxima[key] = new(int32)
+	}
+
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				unlock, err := locks.lock(context.Background(), key)
+				if err != nil {
+					t.Errorf("Should not have received an error, got: %s", err)
+					return
+				}
+				defer unlock()
+				current := atomic.AddInt32(holders[key], 1)
+				for {
+					observed := atomic.LoadInt32(maxima[key])
+					if current <= observed || atomic.CompareAndSwapInt32(maxima[key], observed, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(holders[key], -1)
+			}(key)
+		}
+	}
+	wg.Wait()
+
+	result := map[string]int32{}
+	for key, maximum := range maxima {
+		result[key] = *maximum
+	}
+	return result
+}
+
+func TestKeyedMutexSerializesSameKey(t *testing.T) {
+	locks := newKeyedMutex()
+	for key, maximum := range maxHolders(t, locks, []string{"1", "2"}) {
+		if maximum != 1 {
+			t.Errorf("Should have serialized the holders of key %s, got %d at the same time", key, maximum)
+		}
+	}
+	if len(locks.locks) != 0 {
+		t.Errorf("Should have released the keys, got: %v", locks.locks)
+	}
+}
+
+func TestKeyedMutexDifferentKeysInParallel(t *testing.T) {
+	locks := newKeyedMutex()
+	unlock, err := locks.lock(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	other, err := locks.lock(ctx, "2")
+	if err != nil {
+		t.Fatalf("Should have locked another key while the first one is held, got: %s", err)
+	}
+	other()
+}
+
+func TestKeyedMutexCancelled(t *testing.T) {
+	locks := newKeyedMutex()
+	unlock, err := locks.lock(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := locks.lock(ctx, "1"); err == nil {
+		t.Errorf("Should have received an error once the context is done")
+	}
+
+	unlock()
+	unlock()
+	if len(locks.locks) != 0 {
+		t.Errorf("Should have released the key, got: %v", locks.locks)
+	}
+}
+
+func TestSiteWriteSerializationConfig(t *testing.T) {
+	config := &Config{SiteWriteSerialization: map[string]bool{"incapsula_incap_rule": false}}
+	if config.serializesSiteWrites("incapsula_incap_rule") {
+		t.Errorf("Should not have serialized a disabled resource type")
+	}
+	if !config.serializesSiteWrites("incapsula_cache_rule") {
+		t.Errorf("Should have serialized a resource type enabled by default")
+	}
+	if config.serializesSiteWrites("incapsula_ssl_validation") {
+		t.Errorf("Should not have serialized a resource type disabled by default")
+	}
+	if config.serializesSiteWrites("incapsula_policy") {
+		t.Errorf("Should not have serialized a resource type not changing a site")
+	}
+
+	diags := validateSiteWriteSerialization(map[string]interface{}{"incapsula_cache_rule": false, "incapsula_policy": true}, cty.GetAttrPath("site_write_serialization"))
+	if len(diags) != 1 || diags[0].Summary != "incapsula_policy does not change the configuration of a site" {
+		t.Errorf("Should have rejected the resource type not changing a site, got: %v", diags)
+	}
+}
+
+func TestSiteWriteSerializationResourceTypes(t *testing.T) {
+	resources := Provider().ResourcesMap
+	for _, resourceType := range siteWriteSerializationResourceTypes() {
+		resource, ok := resources[resourceType]
+		if !ok {
+			t.Errorf("Should have found the resource type %s", resourceType)
+			continue
+		}
+		if _, ok := resource.Schema["site_id"]; !ok && resourceType != "incapsula_site" {
+			t.Errorf("Should have found the site_id argument of %s", resourceType)
+		}
+	}
+}
+
+func TestSiteWriteLockSerializesWrites(t *testing.T) {
+	var holders, maximum int32
+	write := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		current := atomic.AddInt32(&holders, 1)
+		for {
+			observed := atomic.LoadInt32(&maximum)
+			if current <= observed || atomic.CompareAndSwapInt32(&maximum, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&holders, -1)
+		return nil
+	}
+	run := func(client *Client) int32 {
+		holders, maximum = 0, 0
+		wrapped := withSiteWriteLock("incapsula_cache_rule", write)
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d := resourceCacheRule().TestResourceData()
+				d.Set("site_id", "1234")
+				wrapped(context.Background(), d, client)
+			}()
+		}
+		wg.Wait()
+		return maximum
+	}
+
+	client := &Client{config: &Config{}, siteLocks: newKeyedMutex()}
+	if maximum := run(client); maximum != 1 {
+		t.Errorf("Should have serialized the writes to the same site, got %d at the same time", maximum)
+	}
+
+	client.config.SiteWriteSerialization = map[string]bool{"incapsula_cache_rule": false}
+	if maximum := run(client); maximum < 2 {
+		t.Errorf("Should have run the writes in parallel once the serialization is disabled, got %d at the same time", maximum)
+	}
+}
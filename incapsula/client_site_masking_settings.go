@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -15,12 +16,12 @@ type MaskingSettings struct {
 }
 
 // GetMaskingSettings gets the site masking settings
-func (c *Client) GetMaskingSettings(siteID string) (*MaskingSettings, error) {
+func (c *Client) GetMaskingSettings(ctx context.Context, siteID string) (*MaskingSettings, error) {
 	log.Printf("[INFO] Getting Incapsula Masking Settings for Site ID %s\n", siteID)
 
 	// Post form to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/masking", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadSiteMasking)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadSiteMasking)
 	if err != nil {
 		return nil, fmt.Errorf("Error from Incapsula service when reading masking settings for Site ID %s: %s", siteID, err)
 	}
@@ -34,7 +35,7 @@ func (c *Client) GetMaskingSettings(siteID string) (*MaskingSettings, error) {
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading masking settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, fmt.Errorf("Error status code %d from Incapsula service when reading masking settings for Site ID %s: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -48,7 +49,7 @@ func (c *Client) GetMaskingSettings(siteID string) (*MaskingSettings, error) {
 }
 
 // UpdateMaskingSettings updates the site masking settings
-func (c *Client) UpdateMaskingSettings(siteID string, maskingSettings *MaskingSettings) error {
+func (c *Client) UpdateMaskingSettings(ctx context.Context, siteID string, maskingSettings *MaskingSettings) error {
 	log.Printf("[INFO] Updating Incapsula masking settings for Site ID %s\n", siteID)
 
 	maskingSettingsJSON, err := json.Marshal(maskingSettings)
@@ -58,7 +59,7 @@ func (c *Client) UpdateMaskingSettings(siteID string, maskingSettings *MaskingSe
 
 	// Put request to Incapsula
 	reqURL := fmt.Sprintf("%s/sites/%s/settings/masking", c.config.BaseURLRev2, siteID)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, maskingSettingsJSON, UpdateSiteMasking)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, maskingSettingsJSON, UpdateSiteMasking)
 	if err != nil {
 		return fmt.Errorf("Error from Incapsula service when updating masking settings for Site ID %s: %s", siteID, err)
 	}
@@ -72,7 +73,7 @@ func (c *Client) UpdateMaskingSettings(siteID string, maskingSettings *MaskingSe
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Error status code %d from Incapsula service when updating masking settings for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody))
+		return fmt.Errorf("Error status code %d from Incapsula service when updating masking settings for Site ID %s: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	return nil
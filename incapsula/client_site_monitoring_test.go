@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -25,6 +26,7 @@ func TestUpdateSiteMonitoringBadConnection(t *testing.T) {
 	}
 
 	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+		context.Background(),
 		siteID,
 		&payload)
 
@@ -65,6 +67,7 @@ func TestUpdateSiteMonitoringBadJSON(t *testing.T) {
 	}
 
 	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+		context.Background(),
 		siteID,
 		&payload)
 
@@ -112,6 +115,7 @@ func TestUpdateSiteMonitoringInvalidConfig(t *testing.T) {
 	payload := SiteMonitoring{}
 
 	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+		context.Background(),
 		siteID,
 		&payload)
 
@@ -159,6 +163,7 @@ func TestUpdateSiteMonitoringNoSubscription(t *testing.T) {
 	payload := SiteMonitoring{}
 
 	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+		context.Background(),
 		siteID,
 		&payload)
 
@@ -229,6 +234,7 @@ func TestUpdateSiteMonitoringConfig(t *testing.T) {
 	}
 
 	siteMonitoringResponse, err := client.UpdateSiteMonitoring(
+		context.Background(),
 		siteID,
 		&payload)
 
@@ -251,7 +257,7 @@ func TestReadSiteMonitoringBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 42
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -282,7 +288,7 @@ func TestUpdatReadSiteMonitoringBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -326,7 +332,7 @@ func TestReadSiteMonitoringInvalidConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -370,7 +376,7 @@ func TestReadSiteMonitoringNoSubscription(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -431,7 +437,7 @@ func TestReadSiteMonitoringConfig(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(context.Background(), siteID)
 
 	if err != nil {
 		t.Errorf("Should not have received an error : %s", err.Error())
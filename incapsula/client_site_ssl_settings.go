@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -34,7 +35,7 @@ type SSLSettingsResponse struct {
 	Data []SSLSettingsDTO `json:"data"`
 }
 
-func (c *Client) UpdateSiteSSLSettings(siteID int, accountID int, mySSLSettings SSLSettingsResponse) (*SSLSettingsResponse, error) {
+func (c *Client) UpdateSiteSSLSettings(ctx context.Context, siteID int, accountID int, mySSLSettings SSLSettingsResponse) (*SSLSettingsResponse, error) {
 	log.Printf("[INFO] Updating Incapsula Site SSL settings for Site ID %d\n", siteID)
 
 	requestJSON, err := json.Marshal(mySSLSettings)
@@ -49,7 +50,7 @@ func (c *Client) UpdateSiteSSLSettings(siteID int, accountID int, mySSLSettings
 	}
 	log.Printf("[INFO] SSL Settings request json looks like this %s\n", requestJSON)
 	log.Printf("[INFO] SSL Settings request URL looks like this %s\n", reqURL)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPatch, reqURL, requestJSON, UpdateSiteSSLSettings)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPatch, reqURL, requestJSON, UpdateSiteSSLSettings)
 	if err != nil {
 		return nil, fmt.Errorf("error from Incapsula service when updating Site SSL settings %s for Site ID %d: %s", requestJSON, siteID, err)
 	}
@@ -63,7 +64,7 @@ func (c *Client) UpdateSiteSSLSettings(siteID int, accountID int, mySSLSettings
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("error status code %d from Incapsula service when updating Site SSL settings %s for Site ID %d: %s", resp.StatusCode, requestJSON, siteID, string(responseBody))
+		return nil, fmt.Errorf("error status code %d from Incapsula service when updating Site SSL settings %s for Site ID %d: %w", resp.StatusCode, requestJSON, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -76,7 +77,7 @@ func (c *Client) UpdateSiteSSLSettings(siteID int, accountID int, mySSLSettings
 	return &sslSettingsResponse, nil
 }
 
-func (c *Client) ReadSiteSSLSettings(siteID int, accountID int) (*SSLSettingsResponse, int, error) {
+func (c *Client) ReadSiteSSLSettings(ctx context.Context, siteID int, accountID int) (*SSLSettingsResponse, int, error) {
 	log.Printf("[INFO] Getting Incapsula Incap SSL settings for Site ID %d\n", siteID)
 
 	// Get form to Incapsula
@@ -85,7 +86,7 @@ func (c *Client) ReadSiteSSLSettings(siteID int, accountID int) (*SSLSettingsRes
 		reqURL = fmt.Sprintf("%s?caid=%d", reqURL, accountID)
 	}
 	log.Printf("[INFO] SSL Settings request URL looks like this %s\n", reqURL)
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadSiteSSLSettings)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadSiteSSLSettings)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error from Incapsula service when reading SSL Settings for Site ID %d: %s", siteID, err)
 	}
@@ -99,7 +100,7 @@ func (c *Client) ReadSiteSSLSettings(siteID int, accountID int) (*SSLSettingsRes
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		return nil, resp.StatusCode, fmt.Errorf("error status code %d from Incapsula service when reading SSL settings for Site ID %d: %s", resp.StatusCode, siteID, string(responseBody))
+		return nil, resp.StatusCode, fmt.Errorf("error status code %d from Incapsula service when reading SSL settings for Site ID %d: %w", resp.StatusCode, siteID, NewAPIError(resp, responseBody))
 	}
 
 	// Parse the JSON
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -16,7 +17,7 @@ func TestUpdateSiteSSLSettingsHandleBadConnection(t *testing.T) {
 	sslSettingsDTO := getUpdateSiteSSLSettingsDTO()
 
 	// act
-	var res, err = client.UpdateSiteSSLSettings(123, 1234, sslSettingsDTO)
+	var res, err = client.UpdateSiteSSLSettings(context.Background(), 123, 1234, sslSettingsDTO)
 
 	// assert
 	if err == nil {
@@ -54,7 +55,7 @@ func TestUpdateSiteSSLSettingsHandleResponseCodeNotSuccess(t *testing.T) {
 	var dto = getUpdateSiteSSLSettingsDTO()
 
 	// act
-	_, err := client.UpdateSiteSSLSettings(siteID, accountID, dto)
+	_, err := client.UpdateSiteSSLSettings(context.Background(), siteID, accountID, dto)
 
 	// assert
 	if err == nil {
@@ -92,7 +93,7 @@ func TestUpdateSiteSSLSettingsHandleInvalidResponseBody(t *testing.T) {
 	var dto = getUpdateSiteSSLSettingsDTO()
 
 	// act
-	_, err := client.UpdateSiteSSLSettings(siteID, accountID, dto)
+	_, err := client.UpdateSiteSSLSettings(context.Background(), siteID, accountID, dto)
 
 	// assert
 	if err == nil {
@@ -130,7 +131,7 @@ func TestUpdateSiteSSLSettingsSuccess(t *testing.T) {
 	var dto = getUpdateSiteSSLSettingsDTO()
 
 	// act
-	_, err := client.UpdateSiteSSLSettings(siteID, accountID, dto)
+	_, err := client.UpdateSiteSSLSettings(context.Background(), siteID, accountID, dto)
 
 	// assert
 	if err != nil {
@@ -144,7 +145,7 @@ func TestReadSiteSSLSettingsHandleRequestError(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 
 	// act
-	var res, statusCode, err = client.ReadSiteSSLSettings(123, 1234)
+	var res, statusCode, err = client.ReadSiteSSLSettings(context.Background(), 123, 1234)
 
 	// assert
 	if err == nil {
@@ -185,7 +186,7 @@ func TestReadSiteSSLSettingsHandleResponseCodeNotSuccess(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 
 	// act
-	_, statusCode, err := client.ReadSiteSSLSettings(siteID, accountID)
+	_, statusCode, err := client.ReadSiteSSLSettings(context.Background(), siteID, accountID)
 
 	// assert
 	if err == nil {
@@ -222,7 +223,7 @@ func TestReadSiteSSLSettingsHandleInvalidResponseBody(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 
 	// act
-	_, _, err := client.ReadSiteSSLSettings(siteID, accountID)
+	_, _, err := client.ReadSiteSSLSettings(context.Background(), siteID, accountID)
 
 	// assert
 	if err == nil {
@@ -259,7 +260,7 @@ func TestReadSiteSSLSettingsSuccess(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 
 	// act
-	_, statusCode, err := client.ReadSiteSSLSettings(siteID, accountID)
+	_, statusCode, err := client.ReadSiteSSLSettings(context.Background(), siteID, accountID)
 
 	// assert
 	if err != nil {
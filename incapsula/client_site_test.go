@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,7 +19,7 @@ func TestClientAddSiteBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	domain := "foo.com"
-	addSiteResponse, err := client.AddSite(domain, "", "", "", "", 0, false, false, "")
+	addSiteResponse, err := client.AddSite(context.Background(), domain, "", "", "", "", 0, false, false, "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -42,7 +43,7 @@ func TestClientAddSiteBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
-	addSiteResponse, err := client.AddSite(domain, "", "", "", "", 0, false, false, "")
+	addSiteResponse, err := client.AddSite(context.Background(), domain, "", "", "", "", 0, false, false, "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -66,7 +67,7 @@ func TestClientAddSiteInvalidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
-	addSiteResponse, err := client.AddSite(domain, "", "", "", "", 0, false, false, "")
+	addSiteResponse, err := client.AddSite(context.Background(), domain, "", "", "", "", 0, false, false, "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -90,7 +91,7 @@ func TestClientAddSiteValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
-	addSiteResponse, err := client.AddSite(domain, "", "", "", "", 0, false, false, "")
+	addSiteResponse, err := client.AddSite(context.Background(), domain, "", "", "", "", 0, false, false, "")
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -114,7 +115,7 @@ func TestClientSiteStatusBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	domain := "foo.com"
 	siteID := 123
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -139,7 +140,7 @@ func TestClientSiteStatusBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -164,7 +165,7 @@ func TestClientSiteStatusInvalidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -189,7 +190,7 @@ func TestClientSiteStatusValidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	siteStatusResponse, err := client.SiteStatus(domain, siteID)
+	siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -214,7 +215,7 @@ func TestClientUpdateSiteBadConnection(t *testing.T) {
 	siteID := "42"
 	param := "active"
 	value := "bypass"
-	updateSiteResponse, err := client.UpdateSite(siteID, param, value)
+	updateSiteResponse, err := client.UpdateSite(context.Background(), siteID, param, value)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -238,7 +239,7 @@ func TestClientUpdateSiteBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	updateSiteResponse, err := client.UpdateSite(siteID, "", "")
+	updateSiteResponse, err := client.UpdateSite(context.Background(), siteID, "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -262,7 +263,7 @@ func TestClientUpdateSiteInvalidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	updateSiteResponse, err := client.UpdateSite(siteID, "", "")
+	updateSiteResponse, err := client.UpdateSite(context.Background(), siteID, "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -286,7 +287,7 @@ func TestClientUpdateSiteValidSite(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	siteID := "42"
-	addSiteResponse, err := client.UpdateSite(siteID, "", "")
+	addSiteResponse, err := client.UpdateSite(context.Background(), siteID, "", "")
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -310,7 +311,7 @@ func TestClientDeleteSiteBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	domain := "foo.com"
 	siteID := 123
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(context.Background(), domain, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -332,7 +333,7 @@ func TestClientDeleteSiteBadJSON(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(context.Background(), domain, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -354,7 +355,7 @@ func TestClientDeleteSiteInvalidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(context.Background(), domain, siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -376,7 +377,7 @@ func TestClientDeleteSiteValidSite(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 	domain := "foo.com"
 	siteID := 123
-	err := client.DeleteSite(domain, siteID)
+	err := client.DeleteSite(context.Background(), domain, siteID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -427,7 +428,7 @@ func TestClientUpdateSiteWithExistingCertificate(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{}}
 
 	siteID := "111"
-	addSiteResponse, err := client.UpdateSite(siteID, "", "")
+	addSiteResponse, err := client.UpdateSite(context.Background(), siteID, "", "")
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -435,7 +436,7 @@ func TestClientUpdateSiteWithExistingCertificate(t *testing.T) {
 		t.Errorf("Response code doesn't match")
 	}
 
-	updateSiteResponse, err := client.UpdateSite(siteID, "domain_validation", "DNS")
+	updateSiteResponse, err := client.UpdateSite(context.Background(), siteID, "domain_validation", "DNS")
 
 	if err != nil {
 		t.Errorf("Should not have received an error")
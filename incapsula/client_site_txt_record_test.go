@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -18,7 +19,7 @@ func TestClientGetTXTRecordsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 123
-	txtRecords, err := client.ReadTXTRecords(siteID)
+	txtRecords, err := client.ReadTXTRecords(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -48,7 +49,7 @@ func TestClientGetTXTRecordsBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	txtSettings, err := client.ReadTXTRecords(siteID)
+	txtSettings, err := client.ReadTXTRecords(context.Background(), siteID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -79,7 +80,7 @@ func TestClientGetTXTRecordsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	txtSettings, err := client.ReadTXTRecords(siteID)
+	txtSettings, err := client.ReadTXTRecords(context.Background(), siteID)
 	log.Print(err)
 	if err == nil {
 		t.Errorf("Should have received an error")
@@ -110,7 +111,7 @@ func TestClientGetTXTRecordsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	txtSettings, err := client.ReadTXTRecords(siteID)
+	txtSettings, err := client.ReadTXTRecords(context.Background(), siteID)
 	log.Printf("Test Results: SiteID=%d, "+
 		"txt_record_value_one=%s, "+
 		"txt_record_value_two=%s, "+
@@ -155,7 +156,7 @@ func TestClientUpdateTXTRecordsBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	siteID := 123
-	_, err := client.UpdateTXTRecord(siteID, "test1", "test2", "test3", "test4", "test5")
+	_, err := client.UpdateTXTRecord(context.Background(), siteID, "test1", "test2", "test3", "test4", "test5")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -183,7 +184,7 @@ func TestClientUpdateTXTRecordsInvalidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	_, err := client.UpdateTXTRecord(siteID, "", "test2", "test3", "test4", "test5")
+	_, err := client.UpdateTXTRecord(context.Background(), siteID, "", "test2", "test3", "test4", "test5")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -211,7 +212,7 @@ func TestClientUpdateTXTRecordsValidSite(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	txtResponse, err := client.UpdateTXTRecord(siteID, "test1", "test2", "test3", "test4", "test5")
+	txtResponse, err := client.UpdateTXTRecord(context.Background(), siteID, "test1", "test2", "test3", "test4", "test5")
 	log.Print(err)
 	if err != nil {
 		t.Errorf("Should not have received an error")
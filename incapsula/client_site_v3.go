@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -30,7 +31,7 @@ type SiteV3Response struct {
 }
 
 // AddV3Site adds a v3 site to be managed by Incapsula
-func (c *Client) AddV3Site(siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
+func (c *Client) AddV3Site(ctx context.Context, siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] adding v3 site to: %v ", siteV3Request)
 
@@ -44,7 +45,7 @@ func (c *Client) AddV3Site(siteV3Request *SiteV3Request, accountId string) (*Sit
 		})
 		return nil, diags
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPost, updateUrl, siteV3RequestJson, nil, AddV3Site)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPost, updateUrl, siteV3RequestJson, nil, AddV3Site)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -65,11 +66,10 @@ func (c *Client) AddV3Site(siteV3Request *SiteV3Request, accountId string) (*Sit
 	}
 	log.Printf("[DEBUG] Imperva add v3 site JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on add v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on add v3 site",
+			fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var siteV3Response SiteV3Response
@@ -89,7 +89,7 @@ func (c *Client) AddV3Site(siteV3Request *SiteV3Request, accountId string) (*Sit
 }
 
 // UpdateV3Site update a v3 site currently managed by Incapsula
-func (c *Client) UpdateV3Site(siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
+func (c *Client) UpdateV3Site(ctx context.Context, siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] updating v3 site %d to: %v ", siteV3Request.Id, siteV3Request)
 
@@ -103,7 +103,7 @@ func (c *Client) UpdateV3Site(siteV3Request *SiteV3Request, accountId string) (*
 		})
 		return nil, diags
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodPatch, updateUrl, siteV3RequestJson, nil, UpdateV3Site)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodPatch, updateUrl, siteV3RequestJson, nil, UpdateV3Site)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -124,11 +124,10 @@ func (c *Client) UpdateV3Site(siteV3Request *SiteV3Request, accountId string) (*
 	}
 	log.Printf("[DEBUG] Imperva update v3 site JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on update v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on update v3 site",
+			fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var siteV3Response SiteV3Response
@@ -148,7 +147,7 @@ func (c *Client) UpdateV3Site(siteV3Request *SiteV3Request, accountId string) (*
 }
 
 // DeleteV3Site deletes a site currently managed by Incapsula
-func (c *Client) DeleteV3Site(siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
+func (c *Client) DeleteV3Site(ctx context.Context, siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] deleting v3 site %d to: %v ", siteV3Request.Id, siteV3Request)
 
@@ -162,7 +161,7 @@ func (c *Client) DeleteV3Site(siteV3Request *SiteV3Request, accountId string) (*
 		})
 		return nil, diags
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodDelete, updateUrl, siteV3RequestJson, nil, UpdateV3Site)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodDelete, updateUrl, siteV3RequestJson, nil, UpdateV3Site)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -183,11 +182,10 @@ func (c *Client) DeleteV3Site(siteV3Request *SiteV3Request, accountId string) (*
 	}
 	log.Printf("[DEBUG] Imperva delete v3 site JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on delete v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on delete v3 site",
+			fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var siteV3Response SiteV3Response
@@ -207,7 +205,7 @@ func (c *Client) DeleteV3Site(siteV3Request *SiteV3Request, accountId string) (*
 }
 
 // GetV3Site deletes a site currently managed by Incapsula
-func (c *Client) GetV3Site(siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
+func (c *Client) GetV3Site(ctx context.Context, siteV3Request *SiteV3Request, accountId string) (*SiteV3Response, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] getting v3 site %d to: %v ", siteV3Request.Id, siteV3Request)
 
@@ -221,7 +219,7 @@ func (c *Client) GetV3Site(siteV3Request *SiteV3Request, accountId string) (*Sit
 		})
 		return nil, diags
 	}
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, updateUrl, siteV3RequestJson, map[string]string{"caid": accountId}, UpdateV3Site)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, updateUrl, siteV3RequestJson, map[string]string{"caid": accountId}, UpdateV3Site)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -242,11 +240,10 @@ func (c *Client) GetV3Site(siteV3Request *SiteV3Request, accountId string) (*Sit
 	}
 	log.Printf("[DEBUG] Imperva get v3 site JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on get v3 site",
-			Detail:   fmt.Sprintf("Failed to read response for account id %s, got response status %d, %s", accountId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on get v3 site",
+			fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var siteV3Response SiteV3Response
@@ -265,6 +262,56 @@ func (c *Client) GetV3Site(siteV3Request *SiteV3Request, accountId string) (*Sit
 	return &siteV3Response, nil
 }
 
+// ListV3Sites gets a page of the sites of the v3 site management API. Pages are numbered from 0
+func (c *Client) ListV3Sites(ctx context.Context, accountId string, page int, size int) (*SiteV3Response, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	log.Printf("[INFO] listing v3 sites of account %s, page %d", accountId, page)
+
+	params := map[string]string{"page": strconv.Itoa(page), "size": strconv.Itoa(size)}
+	if accountId != "" {
+		params["caid"] = accountId
+	}
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, c.config.BaseURLAPI+endpointSiteV3, nil, params, ReadV3SitesAll)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error response from Imperva service on list v3 sites",
+			Detail:   fmt.Sprintf("Failed to list v3 sites account id %s, %s", accountId, err.Error()),
+		})
+		return nil, diags
+	}
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to read response from Imperva service on list v3 sites",
+			Detail:   fmt.Sprintf("Failed to read response for account id %s, %s", accountId, err.Error()),
+		})
+		return nil, diags
+	}
+	log.Printf("[DEBUG] Imperva list v3 sites JSON response: %s\n", string(responseBody))
+	if resp.StatusCode != 200 {
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on list v3 sites",
+			fmt.Sprintf("Failed to read response for account id %s, got response status %d", accountId, resp.StatusCode),
+			resp, responseBody)...)
+		return nil, diags
+	}
+	var siteV3Response SiteV3Response
+	err = json.Unmarshal(responseBody, &siteV3Response)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failed to parse list v3 sites response",
+			Detail:   fmt.Sprintf("Failed to parse list v3 sites JSON response for account %s, %s", accountId, err.Error()),
+		})
+		return nil, diags
+	}
+
+	return &siteV3Response, nil
+}
+
 func getSiteV3Url(accountId string, siteId string, baseUrl string) string {
 	url := fmt.Sprintf("%s%s%s", baseUrl, endpointSiteV3, siteId)
 	if accountId != "" {
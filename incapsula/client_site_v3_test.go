@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -29,7 +30,7 @@ func TestAddV3SiteWithNameAndType(t *testing.T) {
 	siteV3Request.Name = "de3affdrere.inddcapcwafteam.net"
 
 	siteV3Request.SiteType = "CLOUD_WAF"
-	siteV3Response, diags := client.AddV3Site(&siteV3Request, accountID)
+	siteV3Response, diags := client.AddV3Site(context.Background(), &siteV3Request, accountID)
 
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, diags)
@@ -86,7 +87,7 @@ func TestUpdateV3SiteWithName(t *testing.T) {
 	siteV3Request := SiteV3Request{}
 	siteV3Request.Name = "de3affdrere.inddcapcwafteam.net"
 	siteV3Request.Id = 111
-	siteV3Response, diags := client.UpdateV3Site(&siteV3Request, accountID)
+	siteV3Response, diags := client.UpdateV3Site(context.Background(), &siteV3Request, accountID)
 
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, diags)
@@ -118,7 +119,7 @@ func TestDeleteV3Site(t *testing.T) {
 	siteV3Request.SiteType = "CLOUD_WAF"
 	siteV3Request.Id = 1234
 
-	siteV3Response, diags := client.DeleteV3Site(&siteV3Request, accountID)
+	siteV3Response, diags := client.DeleteV3Site(context.Background(), &siteV3Request, accountID)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed to delete v3 site of Account ID: %s, %v\n", accountID, diags)
 	} else if siteV3Response.Errors != nil {
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -35,7 +36,7 @@ type SSLInstructionsResponse struct {
 }
 
 // GetSiteSSLInstructions request site ssl instructions
-func (c *Client) GetSiteSSLInstructions(siteId int) (*SSLInstructionsResponse, diag.Diagnostics) {
+func (c *Client) GetSiteSSLInstructions(ctx context.Context, siteId int) (*SSLInstructionsResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] request site SSL instructions to: %d ", siteId)
 
@@ -43,7 +44,7 @@ func (c *Client) GetSiteSSLInstructions(siteId int) (*SSLInstructionsResponse, d
 	var params = map[string]string{}
 	params["extSiteId"] = strconv.Itoa(siteId)
 	params["certificateType"] = "MANAGED"
-	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(http.MethodGet, url, []byte("{}"), params, RequestSiteCert)
+	resp, err := c.DoJsonAndQueryParamsRequestWithHeaders(ctx, http.MethodGet, url, []byte("{}"), params, RequestSiteCert)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -64,11 +65,10 @@ func (c *Client) GetSiteSSLInstructions(siteId int) (*SSLInstructionsResponse, d
 	}
 	log.Printf("[DEBUG] Imperva request site SSL instructions JSON response: %s\n", string(responseBody))
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failed to read response from Imperva service on request site SSL instructions",
-			Detail:   fmt.Sprintf("Failed to read response for site id %d, got response status %d, %s", siteId, resp.StatusCode, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failed to read response from Imperva service on request site SSL instructions",
+			fmt.Sprintf("Failed to read response for site id %d, got response status %d", siteId, resp.StatusCode),
+			resp, responseBody)...)
 		return nil, diags
 	}
 	var sSLInstructionsResponse SSLInstructionsResponse
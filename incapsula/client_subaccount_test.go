@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -16,7 +17,7 @@ import (
 func TestClientAddSubAccountBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	subAccountAddResponse, err := client.AddSubAccount(&SubAccountPayload{"", "", "", 0, 0})
+	subAccountAddResponse, err := client.AddSubAccount(context.Background(), &SubAccountPayload{"", "", "", 0, 0})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -39,7 +40,7 @@ func TestClientAddSubAccountBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	subAccountAddResponse, err := client.AddSubAccount(&SubAccountPayload{"testsubaccount", "", "", 0, 0})
+	subAccountAddResponse, err := client.AddSubAccount(context.Background(), &SubAccountPayload{"testsubaccount", "", "", 0, 0})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -62,7 +63,7 @@ func TestClientAddSubAccountInvalidParent(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	subAccountAddResponse, err := client.AddSubAccount(&SubAccountPayload{"", "", "", 0, 0})
+	subAccountAddResponse, err := client.AddSubAccount(context.Background(), &SubAccountPayload{"", "", "", 0, 0})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -82,7 +83,7 @@ func TestClientDeleteSubAccountBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	subAccountID := 123
-	err := client.DeleteSubAccount(subAccountID)
+	err := client.DeleteSubAccount(context.Background(), subAccountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -103,7 +104,7 @@ func TestClientDeleteSubAccountBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	subAccountID := 123
-	err := client.DeleteSubAccount(subAccountID)
+	err := client.DeleteSubAccount(context.Background(), subAccountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -124,7 +125,7 @@ func TestClientDeleteSubAccountInvalidAccount(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	subAccountID := 123
-	err := client.DeleteSubAccount(subAccountID)
+	err := client.DeleteSubAccount(context.Background(), subAccountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -145,7 +146,7 @@ func TestClientDeleteSubAccountValidAccount(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	subAccountID := 123
-	err := client.DeleteSubAccount(subAccountID)
+	err := client.DeleteSubAccount(context.Background(), subAccountID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -1,9 +1,12 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -15,7 +18,7 @@ import (
 func TestClientVerifyBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -35,7 +38,7 @@ func TestClientVerifyBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -55,7 +58,7 @@ func TestClientVerifyInvalidAccount(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -75,8 +78,285 @@ func TestClientVerifyValidAccount(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	_, err := client.Verify()
+	_, err := client.Verify(context.Background())
 	if err != nil {
 		t.Errorf("Should not have received an error, got: %s", err)
 	}
 }
+
+// //////////////////////////////////////////////////////////////
+// Retry Tests
+// //////////////////////////////////////////////////////////////
+func TestClientRetryReadOnServiceUnavailable(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts < 3 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMinBackoff: time.Millisecond, RetryMaxBackoff: time.Millisecond}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.GetWithHeaders(context.Background(), server.URL, nil, ReadSite)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Should have received status code 200, got: %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("Should have made 3 attempts, got: %d", attempts)
+	}
+}
+
+func TestClientRetryStopsAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMaxAttempts: 2, RetryMinBackoff: time.Millisecond, RetryMaxBackoff: time.Millisecond}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.GetWithHeaders(context.Background(), server.URL, nil, ReadSite)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Should have received status code 502, got: %d", resp.StatusCode)
+	}
+	if attempts != 2 {
+		t.Errorf("Should have made 2 attempts, got: %d", attempts)
+	}
+}
+
+func TestClientNoRetryWriteOnServiceUnavailable(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMinBackoff: time.Millisecond, RetryMaxBackoff: time.Millisecond}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	_, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodPost, server.URL, []byte(`{}`), CreateIncapRule)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Should have made a single attempt, got: %d", attempts)
+	}
+}
+
+func TestClientRetryWriteOnTooManyRequests(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"name":"rule"}` {
+			t.Errorf("Should have received the full body on attempt %d, got: %s", attempts, string(body))
+		}
+		if attempts == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMinBackoff: time.Millisecond, RetryMaxBackoff: time.Millisecond}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	resp, err := client.DoJsonRequestWithHeaders(context.Background(), http.MethodPost, server.URL, []byte(`{"name":"rule"}`), CreateIncapRule)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Should have received status code 200, got: %d", resp.StatusCode)
+	}
+	if attempts != 2 {
+		t.Errorf("Should have made 2 attempts, got: %d", attempts)
+	}
+}
+
+func TestClientRetryAfterBeyondMaxBackoff(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts == 1 {
+			rw.Header().Set("Retry-After", "1")
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMinBackoff: time.Millisecond, RetryMaxBackoff: time.Millisecond}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	start := time.Now()
+	resp, err := client.GetWithHeaders(context.Background(), server.URL, nil, ReadSite)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Should have received status code 200, got: %v %v", resp, err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Should have waited for the Retry-After of the server, got: %s", elapsed)
+	}
+}
+
+func TestClientRetryAfterPastDeadline(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.Header().Set("Retry-After", "60")
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMinBackoff: time.Millisecond, RetryMaxBackoff: time.Millisecond}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := client.GetWithHeaders(ctx, server.URL, nil, ReadSite)
+	if err != nil {
+		t.Fatalf("Should have returned the response instead of waiting for the deadline, got: %s", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || attempts != 1 {
+		t.Errorf("Should have returned the first response, got status code %d after %d attempts", resp.StatusCode, attempts)
+	}
+}
+
+func TestClientRetryEmptyStatusCodes(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", RetryMinBackoff: time.Millisecond, RetryMaxBackoff: time.Millisecond, RetryStatusCodes: []int{}}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	if _, err := client.GetWithHeaders(context.Background(), server.URL, nil, ReadSite); err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Should not have retried with an empty list of status codes, got %d attempts", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if delay, ok := parseRetryAfter("7"); !ok || delay != 7*time.Second {
+		t.Errorf("Should have parsed 7 seconds, got: %s (%t)", delay, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("Should not have parsed an invalid value")
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if delay, ok := parseRetryAfter(date); !ok || delay <= 0 {
+		t.Errorf("Should have parsed an HTTP date, got: %s (%t)", delay, ok)
+	}
+}
+
+// //////////////////////////////////////////////////////////////
+// Account ID Tests
+// //////////////////////////////////////////////////////////////
+func accountIDTestServer(caids *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		*caids = append(*caids, req.URL.Query().Get("caid"))
+		rw.Write([]byte(`{"res":0}`))
+	}))
+}
+
+func TestClientDefaultAccountID(t *testing.T) {
+	caids := []string{}
+	server := accountIDTestServer(&caids)
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", DefaultAccountID: 42}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	client.GetWithHeaders(context.Background(), server.URL+"/sites/1/rules", nil, "read_rule")
+	client.GetWithHeaders(context.Background(), server.URL+"/sites/1/rules?caid=7", nil, "read_rule")
+	client.GetWithHeaders(contextWithAccountID(context.Background(), 8), server.URL+"/sites/1/rules", nil, "read_rule")
+	client.GetWithHeaders(contextWithAccountID(context.Background(), 0), server.URL+"/sites/1/rules", nil, "read_rule")
+	client.PostFormWithHeaders(context.Background(), server.URL+"/sites/add", url.Values{"account_id": {"9"}}, "create_site")
+	client.PostFormWithHeaders(context.Background(), server.URL+"/sites/dataCenters/add", url.Values{"site_id": {"1"}}, "create_data_center")
+	client.DoJsonRequestWithHeaders(context.Background(), http.MethodPost, server.URL+"/sites", []byte(`{"name":"site","accountId":9}`), "create_site_v3")
+	client.DoJsonRequestWithHeaders(context.Background(), http.MethodPost, server.URL+"/sites/1/rules", []byte(`{"name":"rule"}`), "create_rule")
+
+	expected := []string{"42", "7", "8", "", "", "42", "", "42"}
+	if strings.Join(caids, ",") != strings.Join(expected, ",") {
+		t.Errorf("Should have sent caid %v, got: %v", expected, caids)
+	}
+}
+
+func TestClientNoDefaultAccountID(t *testing.T) {
+	caids := []string{}
+	server := accountIDTestServer(&caids)
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar"}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	client.GetWithHeaders(context.Background(), server.URL+"/sites/1/rules", nil, "read_rule")
+	client.GetWithHeaders(contextWithAccountID(context.Background(), 8), server.URL+"/sites/1/rules", nil, "read_rule")
+
+	expected := []string{"", "8"}
+	if strings.Join(caids, ",") != strings.Join(expected, ",") {
+		t.Errorf("Should have sent caid %v, got: %v", expected, caids)
+	}
+}
+
+func TestClientDefaultAccountIDAccountEndpoints(t *testing.T) {
+	caids := []string{}
+	server := accountIDTestServer(&caids)
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", DefaultAccountID: 42}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	client.PostFormWithHeaders(context.Background(), server.URL+"/accounts/add", url.Values{"email": {"a@example.com"}}, "create_account")
+	client.PostFormWithHeaders(context.Background(), server.URL+"/subaccounts/add", url.Values{"sub_account_name": {"sub"}}, "create_subaccount")
+	client.DoJsonRequestWithHeaders(context.Background(), http.MethodPost, server.URL+"/user-management/v1/users", []byte(`{"email":"a@example.com"}`), "create_user")
+	client.GetWithHeaders(context.Background(), server.URL+"/policies/v3/accounts/associated-policies", nil, "read_account_policy")
+
+	expected := []string{"", "", "", ""}
+	if strings.Join(caids, ",") != strings.Join(expected, ",") {
+		t.Errorf("Should not have sent caid to the account endpoints, got: %v", caids)
+	}
+}
+
+func TestJSONHasAccountID(t *testing.T) {
+	for body, expected := range map[string]bool{
+		`{"accountId":9}`: true,
+		`{"data":[{"attributes":{"account_id":9}}]}`: true,
+		`{"data":{"caid":"9"}}`:                      true,
+		`{"accountId":0,"name":"site"}`:              false,
+		`{"name":"site"}`:                            false,
+		`not json`:                                   false,
+	} {
+		if jsonHasAccountID([]byte(body)) != expected {
+			t.Errorf("Should have returned %t for %s", expected, body)
+		}
+	}
+}
+
+func TestClientVerifyIgnoresDefaultAccountID(t *testing.T) {
+	caids := []string{}
+	server := accountIDTestServer(&caids)
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, DefaultAccountID: 42}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	client.Verify(context.Background())
+
+	if len(caids) != 1 || caids[0] != "" {
+		t.Errorf("Should have verified the credentials without caid, got: %v", caids)
+	}
+}
@@ -0,0 +1,134 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	proxied := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		proxied = req.URL.String()
+		rw.WriteHeader(200)
+	}))
+	defer proxy.Close()
+
+	client, err := newHTTPClient(&Config{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp, err := client.Get("http://api.imperva.example/account")
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp.Body.Close()
+	if proxied != "http://api.imperva.example/account" {
+		t.Errorf("Should have sent the request through the proxy, got: %s", proxied)
+	}
+}
+
+func TestNewHTTPClientBadProxyURL(t *testing.T) {
+	_, err := newHTTPClient(&Config{ProxyURL: "proxy.example.com"})
+	if err == nil || !strings.HasPrefix(err.Error(), "Error parsing proxy_url") {
+		t.Errorf("Should have received a proxy_url error, got: %v", err)
+	}
+}
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	client, err := newHTTPClient(&Config{})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Errorf("Should not have trusted the test server without the CA bundle")
+	}
+
+	caBundleFile := filepath.Join(t.TempDir(), "ca.pem")
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caBundleFile, caBundle, 0600); err != nil {
+		t.Fatalf("Failed to write the CA bundle: %s", err)
+	}
+	client, err = newHTTPClient(&Config{CABundleFile: caBundleFile})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Should have trusted the test server with the CA bundle, got: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewHTTPClientInvalidCABundle(t *testing.T) {
+	caBundleFile := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(caBundleFile, []byte("not a certificate"), 0600)
+	_, err := newHTTPClient(&Config{CABundleFile: caBundleFile})
+	if err == nil || !strings.Contains(err.Error(), "no PEM certificate found") {
+		t.Errorf("Should have received a ca_bundle_file error, got: %v", err)
+	}
+
+	_, err = newHTTPClient(&Config{CABundleFile: filepath.Join(t.TempDir(), "missing.pem")})
+	if err == nil || !strings.HasPrefix(err.Error(), "Error reading ca_bundle_file") {
+		t.Errorf("Should have received a ca_bundle_file error, got: %v", err)
+	}
+}
+
+func TestNewHTTPClientInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	client, err := newHTTPClient(&Config{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Should have skipped the verification, got: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewHTTPClientClientCertificateWithoutKey(t *testing.T) {
+	_, err := newHTTPClient(&Config{ClientCertFile: "cert.pem"})
+	if err == nil || err.Error() != "client_cert_file and client_key_file must be set together" {
+		t.Errorf("Should have received a client certificate error, got: %v", err)
+	}
+
+	_, err = newHTTPClient(&Config{ClientCertFile: "missing.pem", ClientKeyFile: "missing.key"})
+	if err == nil || !strings.HasPrefix(err.Error(), "Error loading client certificate") {
+		t.Errorf("Should have received a client certificate error, got: %v", err)
+	}
+}
+
+func TestNewHTTPClientRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client, err := newHTTPClient(&Config{RequestTimeout: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Errorf("Should have timed out")
+	}
+}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -18,6 +19,7 @@ const endpointTestCreateS3 = "accounts/testS3Connection"
 const endpointTestCreateSFTP = "accounts/testSftpConnection"
 const endpointWAFLogsActivate = "waf-log-setup/activate"
 const endpointWAFLogsChangeStatus = "waf-log-setup/change/status"
+const endpointReadSiemStorage = "accounts/getSiemStorage"
 
 const saveOnSuccess = true
 
@@ -36,6 +38,20 @@ type WAFLogSetupResponseActivate struct {
 	ConfigID   int    `json:"logs_collector_config_id"`
 }
 
+// WAFLogSetupReadResponse contains the SIEM storage settings of an account.
+// The SFTP password and the S3 secret key are never returned
+type WAFLogSetupReadResponse struct {
+	Res                   interface{} `json:"res"`
+	ResMessage            string      `json:"res_message"`
+	StorageType           string      `json:"storage_type"`
+	Status                string      `json:"status"`
+	S3BucketName          string      `json:"s3_bucket_name"`
+	S3AccessKey           string      `json:"s3_access_key"`
+	SftpHost              string      `json:"sftp_host"`
+	SftpUserName          string      `json:"sftp_user_name"`
+	SftpDestinationFolder string      `json:"sftp_destination_folder"`
+}
+
 // WAFLogSetupPayload contains the S3/SFTP payload for Incapsula WAF Log Setup creation
 type WAFLogSetupPayload struct {
 	AccountID         int    `json:"account_id,omitempty"`
@@ -49,7 +65,7 @@ type WAFLogSetupPayload struct {
 	DestinationFolder string `json:"sftp_destination_folder,omitempty"`
 }
 
-func (c *Client) activateAndUpdateStatus(wafLogSetupPayload *WAFLogSetupPayload) error {
+func (c *Client) activateAndUpdateStatus(ctx context.Context, wafLogSetupPayload *WAFLogSetupPayload) error {
 
 	//******************************************
 	//Activate WAF Logs
@@ -59,7 +75,7 @@ func (c *Client) activateAndUpdateStatus(wafLogSetupPayload *WAFLogSetupPayload)
 		"account_id": {strconv.Itoa(wafLogSetupPayload.AccountID)},
 	}
 
-	respActivate, errActivate := c.PostFormWithHeaders(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointWAFLogsActivate), valuesActivate, ActivateWAFLogSetup)
+	respActivate, errActivate := c.PostFormWithHeaders(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointWAFLogsActivate), valuesActivate, ActivateWAFLogSetup)
 	if errActivate != nil {
 		return fmt.Errorf("Error activating WAF Log Setup for account  %d: %s", wafLogSetupPayload.AccountID, errActivate)
 	}
@@ -99,7 +115,7 @@ func (c *Client) activateAndUpdateStatus(wafLogSetupPayload *WAFLogSetupPayload)
 		"logs_config_new_status": {logsConfigNewStatus},
 	}
 
-	respStatus, errStatus := c.PostFormWithHeaders(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointWAFLogsChangeStatus), valuesStatus, UpdateStatusWAFLogSetup)
+	respStatus, errStatus := c.PostFormWithHeaders(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointWAFLogsChangeStatus), valuesStatus, UpdateStatusWAFLogSetup)
 	if errActivate != nil {
 		return fmt.Errorf("Error changing WAF Log Setup status for account  %d: %s", wafLogSetupPayload.AccountID, errStatus)
 	}
@@ -121,15 +137,14 @@ func (c *Client) activateAndUpdateStatus(wafLogSetupPayload *WAFLogSetupPayload)
 }
 
 // AddWAFLogSetupS3 adds a WAFLogSetup to be managed by Incapsula
-func (c *Client) AddWAFLogSetupS3(wafLogSetupPayload *WAFLogSetupPayload) (*WAFLogSetupResponse, error) {
+func (c *Client) AddWAFLogSetupS3(ctx context.Context, wafLogSetupPayload *WAFLogSetupPayload) (*WAFLogSetupResponse, error) {
 	log.Printf("[INFO] Adding Incapsula WAF S3 Log Setup for account: %d\n", wafLogSetupPayload.AccountID)
 	log.Printf("[DEBUG] accountId %d\n", wafLogSetupPayload.AccountID)
 	log.Printf("[DEBUG] enabled %s\n", strconv.FormatBool(wafLogSetupPayload.Enabled))
-	log.Printf("[DEBUG] accessKey %s\n", wafLogSetupPayload.AccessKey)
 	log.Printf("[DEBUG] bucketName %s\n", wafLogSetupPayload.BucketName)
 	//log.Printf("[DEBUG] secretKey %s\n", wafLogSetupPayload.SecretKey)
 
-	err := c.activateAndUpdateStatus(wafLogSetupPayload)
+	err := c.activateAndUpdateStatus(ctx, wafLogSetupPayload)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +168,7 @@ func (c *Client) AddWAFLogSetupS3(wafLogSetupPayload *WAFLogSetupPayload) (*WAFL
 		values["save_on_success"][0] = fmt.Sprint(saveOnSuccess)
 		endpoint = endpointTestCreateS3
 	}
-	resp, err := c.PostFormWithHeaders(fmt.Sprintf("%s/%s", c.config.BaseURL, endpoint), values, CreateWAFLogSetup)
+	resp, err := c.PostFormWithHeaders(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpoint), values, CreateWAFLogSetup)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating S3 WAF Log Setup for account  %d: %s", wafLogSetupPayload.AccountID, err)
 	}
@@ -174,17 +189,17 @@ func (c *Client) AddWAFLogSetupS3(wafLogSetupPayload *WAFLogSetupPayload) (*WAFL
 
 	// Look at the response status code from Incapsula
 	if wafLogSetupResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when creating S3 WAF Log Setup for account %d: %s", wafLogSetupPayload.AccountID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when creating S3 WAF Log Setup for account %d: %w", wafLogSetupPayload.AccountID, NewAPIError(resp, responseBody))
 	}
 
 	return &wafLogSetupResponse, nil
 }
 
 // AddWAFLogSetupSFTP adds a WAFLogSetup to be managed by Incapsula
-func (c *Client) AddWAFLogSetupSFTP(wafLogSetupPayload *WAFLogSetupPayload) (*WAFLogSetupResponse, error) {
+func (c *Client) AddWAFLogSetupSFTP(ctx context.Context, wafLogSetupPayload *WAFLogSetupPayload) (*WAFLogSetupResponse, error) {
 	log.Printf("[INFO] Adding Incapsula WAF S3 Log Setup for account: %d\n", wafLogSetupPayload.AccountID)
 
-	err := c.activateAndUpdateStatus(wafLogSetupPayload)
+	err := c.activateAndUpdateStatus(ctx, wafLogSetupPayload)
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +227,7 @@ func (c *Client) AddWAFLogSetupSFTP(wafLogSetupPayload *WAFLogSetupPayload) (*WA
 		endpoint = endpointTestCreateSFTP
 	}
 
-	resp, err := c.PostFormWithHeaders(fmt.Sprintf("%s/%s", c.config.BaseURL, endpoint), values, CreateWAFLogSetup)
+	resp, err := c.PostFormWithHeaders(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpoint), values, CreateWAFLogSetup)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating SFTP WAF Log Setup for account  %d: %s", wafLogSetupPayload.AccountID, err)
 	}
@@ -233,19 +248,19 @@ func (c *Client) AddWAFLogSetupSFTP(wafLogSetupPayload *WAFLogSetupPayload) (*WA
 
 	// Look at the response status code from Incapsula
 	if wafLogSetupResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when creating SFTP WAF Log Setup for account %d: %s", wafLogSetupPayload.AccountID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when creating SFTP WAF Log Setup for account %d: %w", wafLogSetupPayload.AccountID, NewAPIError(resp, responseBody))
 	}
 
 	return &wafLogSetupResponse, nil
 }
 
 // AddWAFLogSetupDefault turns WAF Log Setup to Default with enablement option ACTIVE/SUSPENDED
-func (c *Client) AddWAFLogSetupDefault(wafLogSetupPayload *WAFLogSetupPayload) (*WAFLogSetupResponse, error) {
+func (c *Client) AddWAFLogSetupDefault(ctx context.Context, wafLogSetupPayload *WAFLogSetupPayload) (*WAFLogSetupResponse, error) {
 	log.Printf("[INFO] Adding Incapsula WAF Default Log Setup for account: %d\n", wafLogSetupPayload.AccountID)
 	log.Printf("[DEBUG] accountId %d\n", wafLogSetupPayload.AccountID)
 	log.Printf("[DEBUG] enabled %s\n", strconv.FormatBool(wafLogSetupPayload.Enabled))
 
-	err := c.activateAndUpdateStatus(wafLogSetupPayload)
+	err := c.activateAndUpdateStatus(ctx, wafLogSetupPayload)
 	if err != nil {
 		return nil, err
 	}
@@ -254,18 +269,18 @@ func (c *Client) AddWAFLogSetupDefault(wafLogSetupPayload *WAFLogSetupPayload) (
 	//Setup Connection WAF Logs
 	//************************************
 
-	return c.RestoreWAFLogSetupDefault(wafLogSetupPayload.AccountID)
+	return c.RestoreWAFLogSetupDefault(ctx, wafLogSetupPayload.AccountID)
 }
 
 // RestoreWAFLogSetupDefault restores WAF Log Setup to Default for a given account ID
-func (c *Client) RestoreWAFLogSetupDefault(accountID int) (*WAFLogSetupResponse, error) {
+func (c *Client) RestoreWAFLogSetupDefault(ctx context.Context, accountID int) (*WAFLogSetupResponse, error) {
 	log.Printf("[INFO] Restoring Incapsula WAF Log Setup to default for account: %d\n", accountID)
 
 	values := url.Values{
 		"account_id": {strconv.Itoa(accountID)},
 	}
 
-	resp, err := c.PostFormWithHeaders(fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCreateDefault), values, DeleteWAFLogSetup)
+	resp, err := c.PostFormWithHeaders(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointCreateDefault), values, DeleteWAFLogSetup)
 	if err != nil {
 		return nil, fmt.Errorf("Error restoring WAF Log Setup to default for account  %d: %s", accountID, err)
 	}
@@ -286,8 +301,43 @@ func (c *Client) RestoreWAFLogSetupDefault(accountID int) (*WAFLogSetupResponse,
 
 	// Look at the response status code from Incapsula
 	if wafLogSetupResponse.Res != 0 {
-		return nil, fmt.Errorf("Error from Incapsula service when creating default WAF Log Setup for account %d: %s", accountID, string(responseBody))
+		return nil, fmt.Errorf("Error from Incapsula service when creating default WAF Log Setup for account %d: %w", accountID, NewAPIError(resp, responseBody))
 	}
 
 	return &wafLogSetupResponse, nil
 }
+
+// ReadWAFLogSetup gets the SIEM storage settings of an account
+func (c *Client) ReadWAFLogSetup(ctx context.Context, accountID int) (*WAFLogSetupReadResponse, error) {
+	log.Printf("[INFO] Reading Incapsula WAF Log Setup for account: %d\n", accountID)
+
+	values := url.Values{
+		"account_id": {strconv.Itoa(accountID)},
+	}
+
+	resp, err := c.PostFormWithHeaders(ctx, fmt.Sprintf("%s/%s", c.config.BaseURL, endpointReadSiemStorage), values, ReadWAFLogSetup)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading WAF Log Setup for account %d: %s", accountID, err)
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula read WAF Log Setup JSON response: %s\n", string(responseBody))
+
+	// Parse the JSON
+	var wafLogSetupReadResponse WAFLogSetupReadResponse
+	err = json.Unmarshal([]byte(responseBody), &wafLogSetupReadResponse)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing read WAF Log Setup JSON response for account %d: %s", accountID, err)
+	}
+
+	// Look at the response status code from Incapsula
+	if fmt.Sprint(wafLogSetupReadResponse.Res) != "0" {
+		return nil, fmt.Errorf("Error from Incapsula service when reading WAF Log Setup for account %d: %w", accountID, NewAPIError(resp, responseBody))
+	}
+
+	return &wafLogSetupReadResponse, nil
+}
@@ -1,12 +1,16 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 //////////////////////////////////////////////////////////////
@@ -16,7 +20,7 @@ import (
 func TestClientWAFLogS3SetupBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupS3(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupS3(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -31,7 +35,7 @@ func TestClientWAFLogS3SetupBadConnection(t *testing.T) {
 func TestClientWAFLogSFTPSetupBadConnection(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -51,7 +55,7 @@ func TestClientCreateWAFLogSetupS3BadJSONActivate(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupS3(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupS3(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -75,7 +79,7 @@ func TestClientCreateWAFLogSetupS3BadJSONEnable(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupS3(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupS3(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -101,7 +105,7 @@ func TestClientCreateWAFLogSetupS3BadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupS3(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupS3(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -121,7 +125,7 @@ func TestClientCreateWAFLogSetupSFTPBadJSONActivate(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -145,7 +149,7 @@ func TestClientCreateWAFLogSetupSFTPBadJSONEnable(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -171,7 +175,7 @@ func TestClientCreateWAFLogSetupSFTPBadJSON(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -197,7 +201,7 @@ func TestClientAddWAFLogSetupS3Invalid(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupS3(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupS3(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -220,7 +224,7 @@ func TestClientAddWAFLogSetupS3Valid(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupS3(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupS3(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -243,7 +247,7 @@ func TestClientAddWAFLogSetupSFTPInvalid(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -266,7 +270,7 @@ func TestClientAddWAFLogSetupSFTPValid(t *testing.T) {
 
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
-	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(&WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
+	wafLogSetupResponse, err := client.AddWAFLogSetupSFTP(context.Background(), &WAFLogSetupPayload{0, true, "", "", "", "", "", "", ""})
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -284,7 +288,7 @@ func TestClientDeleteWAFLogSetupBadConnection(t *testing.T) {
 	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
 	accountID := 123
 
-	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(accountID)
+	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -311,7 +315,7 @@ func TestClientDeleteWAFLogSetupBadJSON(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(accountID)
+	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -338,7 +342,7 @@ func TestClientDeleteWAFLogSetupInvalid(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(accountID)
+	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(context.Background(), accountID)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -362,7 +366,7 @@ func TestClientDeleteWAFLogSetupValid(t *testing.T) {
 	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 	accountID := 123
-	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(accountID)
+	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(context.Background(), accountID)
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -370,3 +374,147 @@ func TestClientDeleteWAFLogSetupValid(t *testing.T) {
 		t.Errorf("Should have received a wafLogSetupResponse instance")
 	}
 }
+
+////////////////////////////////////////////////////////////////
+/// 	ReadWAFLogSetup Tests
+////////////////////////////////////////////////////////////////
+
+func TestClientReadWAFLogSetupBadConnection(t *testing.T) {
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: "badness.incapsula.com"}
+	client := &Client{config: config, httpClient: &http.Client{Timeout: time.Millisecond * 1}}
+	wafLogSetupReadResponse, err := client.ReadWAFLogSetup(context.Background(), 123)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error reading WAF Log Setup")) {
+		t.Errorf("Should have received a client error, got: %s", err)
+	}
+	if wafLogSetupReadResponse != nil {
+		t.Errorf("Should have received a nil wafLogSetupReadResponse instance")
+	}
+}
+
+func TestClientReadWAFLogSetupBadJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	wafLogSetupReadResponse, err := client.ReadWAFLogSetup(context.Background(), 123)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error parsing read WAF Log Setup")) {
+		t.Errorf("Should have received a JSON parse error, got: %s", err)
+	}
+	if wafLogSetupReadResponse != nil {
+		t.Errorf("Should have received a nil wafLogSetupReadResponse instance")
+	}
+}
+
+func TestClientReadWAFLogSetupInvalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":9403,"res_message":"Unknown/unauthorized account_id"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	wafLogSetupReadResponse, err := client.ReadWAFLogSetup(context.Background(), 123)
+	if err == nil {
+		t.Errorf("Should have received an error")
+	}
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("Error from Incapsula service when reading WAF Log Setup")) {
+		t.Errorf("Should have received a bad incapsula response error, got: %s", err)
+	}
+	if wafLogSetupReadResponse != nil {
+		t.Errorf("Should have received a nil wafLogSetupReadResponse instance")
+	}
+}
+
+func TestClientReadWAFLogSetupValid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != fmt.Sprintf("/%s", endpointReadSiemStorage) {
+			t.Errorf("Should have have hit /%s endpoint. Got: %s", endpointReadSiemStorage, req.URL.String())
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK","storage_type":"SFTP","status":"SUSPENDED","sftp_host":"dummyhost","sftp_user_name":"sampleuser","sftp_destination_folder":"/home/user_name/log_folder"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	wafLogSetupReadResponse, err := client.ReadWAFLogSetup(context.Background(), 123)
+	if err != nil {
+		t.Errorf("Should not have received an error, got: %s", err)
+	}
+	if wafLogSetupReadResponse == nil {
+		t.Fatalf("Should have received a wafLogSetupReadResponse instance")
+	}
+	if wafLogSetupReadResponse.SftpHost != "dummyhost" || wafLogSetupReadResponse.Status != "SUSPENDED" {
+		t.Errorf("Unexpected WAF Log Setup: %+v", wafLogSetupReadResponse)
+	}
+}
+
+func TestResourceWAFLogSetupReadKeepsStateOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":1,"res_message":"Unexpected error"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	d := schema.TestResourceDataRaw(t, resourceWAFLogSetup().Schema, map[string]interface{}{
+		"account_id":              123,
+		"enabled":                 false,
+		"sftp_host":               "dummyhost",
+		"sftp_user_name":          "sampleuser",
+		"sftp_password":           "secret",
+		"sftp_destination_folder": "/home/user_name/log_folder",
+	})
+	d.SetId("123")
+
+	diags := resourceWAFLogSetupRead(context.Background(), d, client)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("Should have received a warning, got: %v", diags)
+	}
+	if d.Id() != "123" || d.Get("sftp_host") != "dummyhost" || d.Get("sftp_password") != "secret" || d.Get("enabled") != false {
+		t.Errorf("Should have kept the values of the state, got: %v %v %v", d.Get("sftp_host"), d.Get("sftp_password"), d.Get("enabled"))
+	}
+}
+
+func TestResourceWAFLogSetupReadAccountNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":9403,"res_message":"Unknown/unauthorized account_id"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	d := schema.TestResourceDataRaw(t, resourceWAFLogSetup().Schema, map[string]interface{}{"account_id": 123})
+	d.SetId("123")
+
+	if diags := resourceWAFLogSetupRead(context.Background(), d, client); len(diags) != 0 {
+		t.Errorf("Should not have received diagnostics, got: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("Should have removed the resource from the state, got ID: %s", d.Id())
+	}
+}
+
+func TestResourceWAFLogSetupImportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"res":1,"res_message":"Unexpected error"}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+	d := schema.TestResourceDataRaw(t, resourceWAFLogSetup().Schema, map[string]interface{}{})
+	d.SetId("123")
+
+	if _, err := resourceWAFLogSetup().Importer.StateContext(context.Background(), d, client); err == nil {
+		t.Errorf("Should have received an error")
+	}
+}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -22,7 +23,7 @@ func TestClientConfigureWAFSecurityRuleBadConnection(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	securityRuleAction := "badRuleAction"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -50,7 +51,7 @@ func TestClientConfigureWAFSecurityRuleBadJSON(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	securityRuleAction := "badRuleAction"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -78,7 +79,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRuleID(t *testing.T) {
 	siteID := 1234
 	ruleID := "bad_rule_id"
 	securityRuleAction := "bad_rule_action"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -106,7 +107,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRuleAction(t *testing.T) {
 	siteID := 1234
 	ruleID := "api.threats.backdoor"
 	securityRuleAction := "bad_rule_action"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -137,7 +138,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRule_activationMode(t *testing.T)
 	ddosTrafficThreshold := "123"
 	unknownClientsChallenge := "none"
 	blockNonEssentialBots := "false"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, "", activationMode, ddosTrafficThreshold, unknownClientsChallenge, blockNonEssentialBots, "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "", activationMode, ddosTrafficThreshold, unknownClientsChallenge, blockNonEssentialBots, "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -168,7 +169,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRule_ddosThreshold(t *testing.T) {
 	ddosTrafficThreshold := "123"
 	unknownClientsChallenge := "none"
 	blockNonEssentialBots := "false"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, "", activationMode, ddosTrafficThreshold, unknownClientsChallenge, blockNonEssentialBots, "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "", activationMode, ddosTrafficThreshold, unknownClientsChallenge, blockNonEssentialBots, "", "")
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -197,7 +198,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRule_blockBadBots(t *testing.T) {
 	ruleID := botAccessControlRuleID
 	challengeSuspectedBots := "true"
 	blockBadBots := "123"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, "", "", "", "", "", blockBadBots, challengeSuspectedBots)
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "", "", "", "", "", blockBadBots, challengeSuspectedBots)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -226,7 +227,7 @@ func TestClientConfigureWAFSecurityRuleInvalidRule_challengeSuspectedBots(t *tes
 	ruleID := botAccessControlRuleID
 	challengeSuspectedBots := "123"
 	blockBadBots := "true"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, "", "", "", "", "", blockBadBots, challengeSuspectedBots)
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, "", "", "", "", "", blockBadBots, challengeSuspectedBots)
 	if err == nil {
 		t.Errorf("Should have received an error")
 	}
@@ -258,7 +259,7 @@ func TestClientConfigureWAFSecurityRuleValidRule(t *testing.T) {
 	siteID := 1234
 	ruleID := backdoorRuleID
 	securityRuleAction := "api.threats.action.quarantine_url"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -283,7 +284,7 @@ func TestClientConfigureWAFSecurityRuleResultCodeStringValidRule(t *testing.T) {
 	siteID := 1234
 	ruleID := backdoorRuleID
 	securityRuleAction := "api.threats.action.quarantine_url"
-	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
+	configureWAFSecurityRuleResponse, err := client.ConfigureWAFSecurityRule(context.Background(), siteID, ruleID, securityRuleAction, "", "", "", "", "", "")
 	if err != nil {
 		t.Errorf("Should not have received an error")
 	}
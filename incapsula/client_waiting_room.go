@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -41,7 +42,7 @@ type WaitingRoomDTOResponse struct {
 	Errors []APIErrors      `json:"errors"`
 }
 
-func (c *Client) CreateWaitingRoom(accountId string, siteID string, waitingRoom *WaitingRoomDTO) (*WaitingRoomDTOResponse, diag.Diagnostics) {
+func (c *Client) CreateWaitingRoom(ctx context.Context, accountId string, siteID string, waitingRoom *WaitingRoomDTO) (*WaitingRoomDTOResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] Creating Waiting Room for Site ID %s\n", siteID)
 
@@ -63,7 +64,7 @@ func (c *Client) CreateWaitingRoom(accountId string, siteID string, waitingRoom
 	if accountId != "" {
 		reqURL += "?caid=" + accountId
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPost, reqURL, waitingRoomJSON, CreateWaitingRoom)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPost, reqURL, waitingRoomJSON, CreateWaitingRoom)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -82,11 +83,10 @@ func (c *Client) CreateWaitingRoom(accountId string, siteID string, waitingRoom
 
 	// Check the response code
 	if resp.StatusCode != 201 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Creating Waiting Room",
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when creating Waiting Room for Site ID %s: %s", resp.StatusCode, siteID, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failure Creating Waiting Room",
+			fmt.Sprintf("Error status code %d from Incapsula service when creating Waiting Room for Site ID %s", resp.StatusCode, siteID),
+			resp, responseBody)...)
 	}
 
 	// Parse the JSON
@@ -104,7 +104,7 @@ func (c *Client) CreateWaitingRoom(accountId string, siteID string, waitingRoom
 	return &newWaitingRoom, diags
 }
 
-func (c *Client) ReadWaitingRoom(accountId string, siteID string, waitingRoomID int64) (*WaitingRoomDTOResponse, diag.Diagnostics) {
+func (c *Client) ReadWaitingRoom(ctx context.Context, accountId string, siteID string, waitingRoomID int64) (*WaitingRoomDTOResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] Getting Incapsula Waiting Room %d for Site ID %s\n", waitingRoomID, siteID)
 
@@ -113,7 +113,7 @@ func (c *Client) ReadWaitingRoom(accountId string, siteID string, waitingRoomID
 	if accountId != "" {
 		reqURL += "?caid=" + accountId
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodGet, reqURL, nil, ReadWaitingRoom)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadWaitingRoom)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -132,11 +132,10 @@ func (c *Client) ReadWaitingRoom(accountId string, siteID string, waitingRoomID
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Reading Waiting Room",
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when reading Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failure Reading Waiting Room",
+			fmt.Sprintf("Error status code %d from Incapsula service when reading Waiting Room %d for Site ID %s", resp.StatusCode, waitingRoomID, siteID),
+			resp, responseBody)...)
 	}
 
 	// Parse the JSON
@@ -154,7 +153,7 @@ func (c *Client) ReadWaitingRoom(accountId string, siteID string, waitingRoomID
 	return &waitingRoom, diags
 }
 
-func (c *Client) UpdateWaitingRoom(accountId string, siteID string, waitingRoomID int64, waitingRoom *WaitingRoomDTO) (*WaitingRoomDTOResponse, diag.Diagnostics) {
+func (c *Client) UpdateWaitingRoom(ctx context.Context, accountId string, siteID string, waitingRoomID int64, waitingRoom *WaitingRoomDTO) (*WaitingRoomDTOResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] Updating Incapsula Waiting Room %d for Site ID %s\n", waitingRoomID, siteID)
 
@@ -173,7 +172,7 @@ func (c *Client) UpdateWaitingRoom(accountId string, siteID string, waitingRoomI
 	if accountId != "" {
 		reqURL += "?caid=" + accountId
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodPut, reqURL, waitingRoomJSON, UpdateWaitingRoom)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodPut, reqURL, waitingRoomJSON, UpdateWaitingRoom)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -192,11 +191,10 @@ func (c *Client) UpdateWaitingRoom(accountId string, siteID string, waitingRoomI
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Updating Waiting Room",
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when updating Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failure Updating Waiting Room",
+			fmt.Sprintf("Error status code %d from Incapsula service when updating Waiting Room %d for Site ID %s", resp.StatusCode, waitingRoomID, siteID),
+			resp, responseBody)...)
 	}
 
 	// Parse the JSON
@@ -214,7 +212,7 @@ func (c *Client) UpdateWaitingRoom(accountId string, siteID string, waitingRoomI
 	return &updatedWaitingRoom, diags
 }
 
-func (c *Client) DeleteWaitingRoom(accountId string, siteID string, waitingRoomID int64) (*WaitingRoomDTOResponse, diag.Diagnostics) {
+func (c *Client) DeleteWaitingRoom(ctx context.Context, accountId string, siteID string, waitingRoomID int64) (*WaitingRoomDTOResponse, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	log.Printf("[INFO] Deleting Incapsula Waiting Room %d for Site ID %s\n", waitingRoomID, siteID)
 
@@ -223,7 +221,7 @@ func (c *Client) DeleteWaitingRoom(accountId string, siteID string, waitingRoomI
 	if accountId != "" {
 		reqURL += "?caid=" + accountId
 	}
-	resp, err := c.DoJsonRequestWithHeaders(http.MethodDelete, reqURL, nil, DeleteWaitingRoom)
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodDelete, reqURL, nil, DeleteWaitingRoom)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -242,11 +240,10 @@ func (c *Client) DeleteWaitingRoom(accountId string, siteID string, waitingRoomI
 
 	// Check the response code
 	if resp.StatusCode != 200 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Failure Deleting Waiting Room",
-			Detail:   fmt.Sprintf("Error status code %d from Incapsula service when deleting Waiting Room %d for Site ID %s: %s", resp.StatusCode, waitingRoomID, siteID, string(responseBody)),
-		})
+		diags = append(diags, apiErrorDiagnostics(
+			"Failure Deleting Waiting Room",
+			fmt.Sprintf("Error status code %d from Incapsula service when deleting Waiting Room %d for Site ID %s", resp.StatusCode, waitingRoomID, siteID),
+			resp, responseBody)...)
 	}
 
 	// Parse the JSON
@@ -263,3 +260,53 @@ func (c *Client) DeleteWaitingRoom(accountId string, siteID string, waitingRoomI
 
 	return &waitingRoom, diags
 }
+
+// ListWaitingRooms gets all the waiting rooms of a site
+func (c *Client) ListWaitingRooms(ctx context.Context, accountId string, siteID string) (*WaitingRoomDTOResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	log.Printf("[INFO] Listing Incapsula Waiting Rooms for Site ID %s\n", siteID)
+
+	reqURL := fmt.Sprintf("%s/waiting-room-settings/v3/sites/%s/waiting-rooms", c.config.BaseURLAPI, siteID)
+	if accountId != "" {
+		reqURL += "?caid=" + accountId
+	}
+	resp, err := c.DoJsonRequestWithHeaders(ctx, http.MethodGet, reqURL, nil, ReadWaitingRoomsAll)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failure sending Waiting Rooms list request",
+			Detail:   fmt.Sprintf("Error from Incapsula service when listing Waiting Rooms for Site ID %s: %s", siteID, err.Error()),
+		})
+		return nil, diags
+	}
+
+	// Read the body
+	defer resp.Body.Close()
+	responseBody, err := ioutil.ReadAll(resp.Body)
+
+	// Dump JSON
+	log.Printf("[DEBUG] Incapsula List Waiting Rooms JSON response: %s\n", string(responseBody))
+
+	// Check the response code
+	if resp.StatusCode != 200 {
+		diags = append(diags, apiErrorDiagnostics(
+			"Failure Listing Waiting Rooms",
+			fmt.Sprintf("Error status code %d from Incapsula service when listing Waiting Rooms for Site ID %s", resp.StatusCode, siteID),
+			resp, responseBody)...)
+		return nil, diags
+	}
+
+	// Parse the JSON
+	var waitingRooms WaitingRoomDTOResponse
+	err = json.Unmarshal([]byte(responseBody), &waitingRooms)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Failure parsing Waiting Rooms list response",
+			Detail:   fmt.Sprintf("Error parsing Waiting Rooms JSON response for Site ID %s: %s\nresponse: %s", siteID, err.Error(), string(responseBody)),
+		})
+		return nil, diags
+	}
+
+	return &waitingRooms, diags
+}
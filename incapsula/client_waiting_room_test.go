@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -28,7 +29,7 @@ func TestClientCreateWaitingRoomBadConnection(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
+	createWaitingRoomResponse, diags := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -71,7 +72,7 @@ func TestClientCreateWaitingRoomBadJSON(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
+	createWaitingRoomResponse, diags := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -113,7 +114,7 @@ func TestClientCreateWaitingRoomBadStatusCodeWithEmptyBody(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
+	createWaitingRoomResponse, diags := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -156,7 +157,7 @@ func TestClientCreateWaitingRoomBadStatusCode(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
+	createWaitingRoomResponse, diags := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -198,7 +199,7 @@ func TestClientCreateWaitingRoomEmptyBody(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
+	createWaitingRoomResponse, diags := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -241,7 +242,7 @@ func TestClientCreateWaitingRoomInvalidJSONValue(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
+	createWaitingRoomResponse, diags := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -285,7 +286,7 @@ func TestClientCreateWaitingRoomValidResponse(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	createWaitingRoomResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
+	createWaitingRoomResponse, diags := client.CreateWaitingRoom(context.Background(), accountId, siteID, &waitingRoom)
 	if diags != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -318,7 +319,7 @@ func TestClientReadWaitingRoomBadConnection(t *testing.T) {
 	accountId := "1234"
 	waitingRoomID := int64(1)
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+	readWaitingRoomResponse, diags := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -350,7 +351,7 @@ func TestClientReadWaitingRoomBadJSON(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+	readWaitingRoomResponse, diags := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -382,7 +383,7 @@ func TestClientReadWaitingRoomBadStatusCodeWithEmptyBody(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+	readWaitingRoomResponse, diags := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -415,7 +416,7 @@ func TestClientReadWaitingRoomBadStatusCode(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+	readWaitingRoomResponse, diags := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -447,7 +448,7 @@ func TestClientReadWaitingRoomEmptyBody(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+	readWaitingRoomResponse, diags := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -480,7 +481,7 @@ func TestClientReadWaitingRoomValidResponse(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	readWaitingRoomResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+	readWaitingRoomResponse, diags := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -524,7 +525,7 @@ func TestClientUpdateWaitingRoomBadConnection(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
+	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -567,7 +568,7 @@ func TestClientUpdateWaitingRoomBadJSON(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
+	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -610,7 +611,7 @@ func TestClientUpdateWaitingRoomBadStatusCodeWithEmptyBody(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
+	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -654,7 +655,7 @@ func TestClientUpdateWaitingRoomBadStatusCode(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
+	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -697,7 +698,7 @@ func TestClientUpdateWaitingRoomEmptyBody(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
+	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -741,7 +742,7 @@ func TestClientUpdateWaitingRoomValidResponse(t *testing.T) {
 		ThresholdSettings:      thresholdSettings,
 	}
 
-	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
+	updateWaitingRoomResponse, diags := client.UpdateWaitingRoom(context.Background(), accountId, siteID, waitingRoomID, &waitingRoom)
 	if diags != nil {
 		t.Errorf("Should not have received an error")
 	}
@@ -774,7 +775,7 @@ func TestClientDeleteWaitingRoomBadConnection(t *testing.T) {
 	accountId := "1234"
 	waitingRoomID := int64(1)
 
-	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
+	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received a error")
 	}
@@ -806,7 +807,7 @@ func TestClientDeleteWaitingRoomBadStatusCodeWithEmptyBody(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
+	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -839,7 +840,7 @@ func TestClientDeleteWaitingRoomBadStatusCode(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
+	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags == nil || len(diags) == 0 {
 		t.Errorf("Should have received an error")
 	}
@@ -872,7 +873,7 @@ func TestClientDeleteWaitingRoomValidWaitingRoom(t *testing.T) {
 	config := &Config{APIID: apiID, APIKey: apiKey, BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLAPI: server.URL}
 	client := &Client{config: config, httpClient: &http.Client{}}
 
-	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
+	deleteWaitingRoomResponse, diags := client.DeleteWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 	if diags != nil {
 		t.Errorf("Should not have received an error")
 	}
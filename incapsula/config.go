@@ -1,9 +1,11 @@
 package incapsula
 
 import (
+	"context"
 	"errors"
 	"log"
 	"strings"
+	"time"
 )
 
 // Config represents the configuration required for the Incapsula Client
@@ -14,6 +16,12 @@ type Config struct {
 	// API Key
 	APIKey string
 
+	// Command printing the API credentials as JSON, run when no API key is configured
+	CredentialProcess string
+
+	// Default account ID of the selected credentials profile
+	DefaultAccountID int
+
 	// Base URL (no trailing slash)
 	// This endpoint is unlikely to change in the near future
 	BaseURL string
@@ -32,6 +40,64 @@ type Config struct {
 	// API V2
 	// Same as revision 2 but with a different subdomain
 	BaseURLAPI string
+
+	// Maximum number of attempts for a request, including the first one
+	RetryMaxAttempts int
+
+	// Bounds of the exponential backoff between attempts
+	RetryMinBackoff time.Duration
+	RetryMaxBackoff time.Duration
+
+	// HTTP status codes on which requests are retried
+	RetryStatusCodes []int
+
+	// Request budget shared by all resources, zero means unlimited
+	MaxRequestsPerSecond  float64
+	MaxConcurrentRequests int
+
+	// Additional request budget for requests that change resources, zero means unlimited
+	MaxWriteRequestsPerSecond  float64
+	MaxConcurrentWriteRequests int
+
+	// Additional request budget for requests that only read resources, zero means unlimited
+	MaxReadRequestsPerSecond  float64
+	MaxConcurrentReadRequests int
+
+	// Log only the metadata of the API requests (method, URL, status code and duration), whatever the TF_LOG level is
+	LogMetadataOnly bool
+
+	// Proxy used to reach the API, the HTTPS_PROXY environment variable is used when empty
+	ProxyURL string
+
+	// PEM file of the certificate authorities trusted in addition to the system ones
+	CABundleFile string
+
+	// Skip the verification of the API server certificate, for testing only
+	InsecureSkipVerify bool
+
+	// PEM files of the client certificate and key presented to the API or the proxy
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// Timeout of a single HTTP request, zero means no timeout
+	RequestTimeout time.Duration
+
+	// Timeout of establishing the connection, including the TLS handshake
+	ConnectTimeout time.Duration
+
+	// Overrides of the default serialization of the writes to a site, by resource type
+	SiteWriteSerialization map[string]bool
+}
+
+// serializesSiteWrites tells whether the writes of a resource type to the same site are serialized
+func (c *Config) serializesSiteWrites(resourceType string) bool {
+	if c == nil {
+		return false
+	}
+	if enabled, ok := c.SiteWriteSerialization[resourceType]; ok {
+		return enabled
+	}
+	return siteWriteSerializationDefaults[resourceType]
 }
 
 var missingAPIIDMessage = "API Identifier (api_id) must be provided"
@@ -42,9 +108,21 @@ var missingBaseURLRev3Message = "Base URL Revision 3 must be provided"
 var missingBaseURLAPIMessage = "Base URL API must be provided"
 
 // Client configures and returns a fully initialized Incapsula Client
-func (c *Config) Client() (interface{}, error) {
+func (c *Config) Client(ctx context.Context) (interface{}, error) {
 	log.Println("[INFO] Checking API credentials for client instantiation")
 
+	// Fetch the credentials from the credential process, the client runs it again when they expire
+	var credentials *credentialProcess
+	if c.CredentialProcess != "" && strings.TrimSpace(c.APIKey) == "" {
+		credentials = newCredentialProcess(c.CredentialProcess, c.APIID)
+		apiID, apiKey, err := credentials.credentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.APIID = apiID
+		c.APIKey = apiKey
+	}
+
 	// Check API Identifier
 	if strings.TrimSpace(c.APIID) == "" {
 		return nil, errors.New(missingAPIIDMessage)
@@ -76,10 +154,14 @@ func (c *Config) Client() (interface{}, error) {
 	}
 
 	// Create client
-	client := NewClient(c)
+	client, err := NewClient(c)
+	if err != nil {
+		return nil, err
+	}
+	client.credentials = credentials
 
 	// Verify client credentials
-	accountStatusResponse, err := client.Verify()
+	accountStatusResponse, err := client.Verify(ctx)
 	client.accountStatus = accountStatusResponse
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,7 +10,7 @@ import (
 
 func TestMissingCredentials(t *testing.T) {
 	config := Config{}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -20,7 +21,7 @@ func TestMissingCredentials(t *testing.T) {
 
 func TestMissingAPIID(t *testing.T) {
 	config := Config{APIID: "", APIKey: "foo"}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -31,7 +32,7 @@ func TestMissingAPIID(t *testing.T) {
 
 func TestMissingAPIKey(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -42,7 +43,7 @@ func TestMissingAPIKey(t *testing.T) {
 
 func TestMissingBaseURL(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -53,7 +54,7 @@ func TestMissingBaseURL(t *testing.T) {
 
 func TestMissingBaseURLRev2(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: "foobar.com", BaseURLRev2: "", BaseURLRev3: "foobar.com"}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -64,7 +65,7 @@ func TestMissingBaseURLRev2(t *testing.T) {
 
 func TestMissingBaseURLRev3(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: "foobar.com", BaseURLRev2: "foobar.com", BaseURLRev3: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -75,7 +76,7 @@ func TestMissingBaseURLRev3(t *testing.T) {
 
 func TestMissingBaseURLAPI(t *testing.T) {
 	config := Config{APIID: "foo", APIKey: "bar", BaseURL: "foobar.com", BaseURLRev2: "foobar.com", BaseURLRev3: "foobar.com", BaseURLAPI: ""}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -94,7 +95,7 @@ func TestInvalidCredentials(t *testing.T) {
 	defer server.Close()
 
 	config := Config{APIID: "bad", APIKey: "bad", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err == nil {
 		t.Errorf("Should have received an error, got a client: %q", client)
 	}
@@ -113,7 +114,7 @@ func TestValidCredentials(t *testing.T) {
 	defer server.Close()
 
 	config := Config{APIID: "good", APIKey: "good", BaseURL: server.URL, BaseURLRev2: server.URL, BaseURLRev3: server.URL, BaseURLAPI: server.URL}
-	client, err := config.Client()
+	client, err := config.Client(context.Background())
 	if err != nil {
 		t.Errorf("Should not have received an error, got: %s", err)
 	}
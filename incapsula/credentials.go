@@ -0,0 +1,238 @@
+package incapsula
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultCredentialsFile = "~/.incapsula/credentials"
+const defaultProfile = "default"
+
+// credentialProcessRefreshWindow is how long before their expiration the credentials of a credential process are refreshed
+const credentialProcessRefreshWindow = 1 * time.Minute
+
+// credentialsProfile is a named profile of the shared credentials file
+type credentialsProfile struct {
+	APIID             string
+	APIKey            string
+	BaseURL           string
+	BaseURLRev2       string
+	BaseURLRev3       string
+	BaseURLAPI        string
+	AccountID         int
+	CredentialProcess string
+}
+
+// parseCredentialsFile parses an INI file made of [profile] sections holding "key = value" lines.
+// Lines starting with # or ; are comments. Sections may also be written [profile name]
+func parseCredentialsFile(r io.Reader) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var section map[string]string
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid section header %s", lineNumber, line)
+			}
+			name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line[1:len(line)-1]), "profile "))
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = map[string]string{}
+			}
+			section = profiles[name]
+			continue
+		}
+
+		separator := strings.IndexAny(line, "=:")
+		if separator < 0 {
+			return nil, fmt.Errorf("line %d: expected key = value, got %s", lineNumber, line)
+		}
+		if section == nil {
+			return nil, fmt.Errorf("line %d: key outside of a profile section", lineNumber)
+		}
+		key := strings.TrimSpace(line[:separator])
+		value := strings.TrimSpace(line[separator+1:])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		section[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// loadCredentialsProfile reads the given profile of the shared credentials file.
+// A missing file or profile is only an error when the profile was explicitly requested, otherwise nil is returned
+func loadCredentialsProfile(path string, name string, explicit bool) (*credentialsProfile, error) {
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error locating the credentials file: %s", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Error reading the credentials file %s: %s", path, err)
+	}
+	defer file.Close()
+
+	profiles, err := parseCredentialsFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the credentials file %s: %s", path, err)
+	}
+
+	values, ok := profiles[name]
+	if !ok {
+		if !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Profile %s not found in the credentials file %s", name, path)
+	}
+
+	profile := &credentialsProfile{}
+	for key, value := range values {
+		switch key {
+		case "api_id":
+			profile.APIID = value
+		case "api_key":
+			profile.APIKey = value
+		case "base_url":
+			profile.BaseURL = value
+		case "base_url_rev_2":
+			profile.BaseURLRev2 = value
+		case "base_url_rev_3":
+			profile.BaseURLRev3 = value
+		case "base_url_api":
+			profile.BaseURLAPI = value
+		case "account_id":
+			accountID, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing account_id of profile %s: %s", name, err)
+			}
+			profile.AccountID = accountID
+		case "credential_process":
+			profile.CredentialProcess = value
+		default:
+			return nil, fmt.Errorf("Unknown key %s in profile %s of the credentials file %s", key, name, path)
+		}
+	}
+
+	return profile, nil
+}
+
+// applyCredentialsProfile fills in the settings of the configuration that are still empty with the ones of the profile
+func applyCredentialsProfile(config *Config, profile *credentialsProfile) {
+	config.APIID = firstNonEmpty(config.APIID, profile.APIID)
+	config.APIKey = firstNonEmpty(config.APIKey, profile.APIKey)
+	config.BaseURL = firstNonEmpty(config.BaseURL, profile.BaseURL)
+	config.BaseURLRev2 = firstNonEmpty(config.BaseURLRev2, profile.BaseURLRev2)
+	config.BaseURLRev3 = firstNonEmpty(config.BaseURLRev3, profile.BaseURLRev3)
+	config.BaseURLAPI = firstNonEmpty(config.BaseURLAPI, profile.BaseURLAPI)
+	config.CredentialProcess = firstNonEmpty(config.CredentialProcess, profile.CredentialProcess)
+	if config.DefaultAccountID == 0 {
+		config.DefaultAccountID = profile.AccountID
+	}
+}
+
+// credentialProcessOutput is the JSON document printed by a credential process on its standard output
+type credentialProcessOutput struct {
+	APIID      string     `json:"api_id"`
+	APIKey     string     `json:"api_key"`
+	Expiration *time.Time `json:"expiration"`
+}
+
+// credentialProcess runs an external command to fetch the API credentials, and runs it again when they expire
+type credentialProcess struct {
+	command string
+
+	mu         sync.Mutex
+	apiID      string
+	apiKey     string
+	expiration time.Time
+}
+
+func newCredentialProcess(command string, apiID string) *credentialProcess {
+	return &credentialProcess{command: command, apiID: apiID}
+}
+
+// credentials returns the cached credentials, running the command when there are none yet or they are about to expire
+func (p *credentialProcess) credentials(ctx context.Context) (string, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.apiKey != "" && (p.expiration.IsZero() || time.Until(p.expiration) > credentialProcessRefreshWindow) {
+		return p.apiID, p.apiKey, nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", p.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", p.command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", "", fmt.Errorf("Error running credential_process: %s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return "", "", fmt.Errorf("Error parsing credential_process output: %s", err)
+	}
+	if output.APIKey == "" {
+		return "", "", errors.New("Error parsing credential_process output: api_key is missing")
+	}
+
+	if output.APIID != "" {
+		p.apiID = output.APIID
+	}
+	if p.apiID == "" {
+		return "", "", errors.New("Error parsing credential_process output: api_id is missing")
+	}
+	p.apiKey = output.APIKey
+	p.expiration = time.Time{}
+	if output.Expiration != nil {
+		p.expiration = *output.Expiration
+	}
+
+	return p.apiID, p.apiKey, nil
+}
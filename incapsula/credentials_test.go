@@ -0,0 +1,169 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testCredentialsFile = `# Imperva accounts
+[default]
+api_id = 1
+api_key = default-key
+
+[profile customer]
+api_id = 2
+api_key = "customer-key"
+account_id = 42
+base_url_api = https://api.example.com
+
+; keys are fetched on demand
+[short-lived]
+api_id = 3
+credential_process = echo '{"api_key": "short-lived-key"}'
+`
+
+func writeTestCredentialsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write the credentials file: %s", err)
+	}
+	return path
+}
+
+func TestParseCredentialsFile(t *testing.T) {
+	profiles, err := parseCredentialsFile(strings.NewReader(testCredentialsFile))
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(profiles) != 3 {
+		t.Errorf("Should have parsed 3 profiles, got: %d", len(profiles))
+	}
+	if profiles["customer"]["api_key"] != "customer-key" {
+		t.Errorf("Should have unquoted the API key, got: %s", profiles["customer"]["api_key"])
+	}
+	if profiles["short-lived"]["credential_process"] != `echo '{"api_key": "short-lived-key"}'` {
+		t.Errorf("Should have kept the credential process command, got: %s", profiles["short-lived"]["credential_process"])
+	}
+}
+
+func TestParseCredentialsFileInvalid(t *testing.T) {
+	for _, content := range []string{"api_id = 1\n", "[default\napi_id = 1\n", "[default]\napi_id\n", "[]\n"} {
+		if _, err := parseCredentialsFile(strings.NewReader(content)); err == nil {
+			t.Errorf("Should have received an error for %q", content)
+		}
+	}
+}
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	path := writeTestCredentialsFile(t, testCredentialsFile)
+
+	profile, err := loadCredentialsProfile(path, "customer", true)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if profile.APIID != "2" || profile.APIKey != "customer-key" || profile.AccountID != 42 || profile.BaseURLAPI != "https://api.example.com" {
+		t.Errorf("Should have loaded the customer profile, got: %+v", profile)
+	}
+
+	if _, err := loadCredentialsProfile(path, "missing", true); err == nil || !strings.HasPrefix(err.Error(), "Profile missing not found") {
+		t.Errorf("Should have received a missing profile error, got: %v", err)
+	}
+	if profile, err := loadCredentialsProfile(path, "missing", false); profile != nil || err != nil {
+		t.Errorf("Should have ignored the missing profile, got: %v, %v", profile, err)
+	}
+
+	missingFile := filepath.Join(t.TempDir(), "credentials")
+	if profile, err := loadCredentialsProfile(missingFile, defaultProfile, false); profile != nil || err != nil {
+		t.Errorf("Should have ignored the missing file, got: %v, %v", profile, err)
+	}
+	if _, err := loadCredentialsProfile(missingFile, "customer", true); err == nil {
+		t.Errorf("Should have received a missing file error")
+	}
+}
+
+func TestLoadCredentialsProfileUnknownKey(t *testing.T) {
+	path := writeTestCredentialsFile(t, "[default]\napi_secret = 1\n")
+	if _, err := loadCredentialsProfile(path, defaultProfile, false); err == nil || !strings.HasPrefix(err.Error(), "Unknown key api_secret") {
+		t.Errorf("Should have received an unknown key error, got: %v", err)
+	}
+}
+
+func TestApplyCredentialsProfile(t *testing.T) {
+	config := &Config{APIKey: "argument-key"}
+	applyCredentialsProfile(config, &credentialsProfile{APIID: "2", APIKey: "profile-key", AccountID: 42, BaseURL: "https://v1.example.com"})
+	if config.APIKey != "argument-key" {
+		t.Errorf("Should have kept the API key of the arguments, got: %s", config.APIKey)
+	}
+	if config.APIID != "2" || config.DefaultAccountID != 42 || config.BaseURL != "https://v1.example.com" {
+		t.Errorf("Should have filled in the empty settings, got: %+v", config)
+	}
+}
+
+func TestCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command requires a POSIX shell")
+	}
+
+	counter := filepath.Join(t.TempDir(), "counter")
+	expiration := time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339)
+	command := fmt.Sprintf(`echo run >> %s; echo '{"api_id": "7", "api_key": "key-'$(wc -l < %s | tr -d ' ')'", "expiration": "%s"}'`, counter, counter, expiration)
+	process := newCredentialProcess(command, "")
+
+	apiID, apiKey, err := process.credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if apiID != "7" || apiKey != "key-1" {
+		t.Errorf("Should have parsed the credentials, got: %s, %s", apiID, apiKey)
+	}
+
+	// The credentials expire within the refresh window, so the command runs again
+	_, apiKey, err = process.credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if apiKey != "key-2" {
+		t.Errorf("Should have refreshed the credentials, got: %s", apiKey)
+	}
+}
+
+func TestCredentialProcessCached(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command requires a POSIX shell")
+	}
+
+	process := newCredentialProcess(`echo '{"api_key": "key"}'`, "7")
+	if _, _, err := process.credentials(context.Background()); err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	process.command = "exit 1"
+	apiID, apiKey, err := process.credentials(context.Background())
+	if err != nil || apiID != "7" || apiKey != "key" {
+		t.Errorf("Should have used the cached credentials, got: %s, %s, %v", apiID, apiKey, err)
+	}
+}
+
+func TestCredentialProcessErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command requires a POSIX shell")
+	}
+
+	cases := map[string]string{
+		"echo denied >&2; exit 1":      "Error running credential_process",
+		"echo not json":                "Error parsing credential_process output",
+		`echo '{"api_id": "7"}'`:       "api_key is missing",
+		`echo '{"api_key": "secret"}'`: "api_id is missing",
+	}
+	for command, expected := range cases {
+		_, _, err := newCredentialProcess(command, "").credentials(context.Background())
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Should have received an error containing %q for %q, got: %v", expected, command, err)
+		}
+	}
+}
@@ -64,7 +64,7 @@ func dataSourceAccountPermissionsRead(ctx context.Context, d *schema.ResourceDat
 	client := m.(*Client)
 
 	accountId := d.Get("account_id").(int)
-	responseDTO, err := client.GetAccountAbilities(accountId)
+	responseDTO, err := client.GetAccountAbilities(ctx, accountId)
 	if err != nil {
 		return diag.Errorf("Error getting Account Permissions: %s", err)
 	}
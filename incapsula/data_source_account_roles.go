@@ -52,7 +52,7 @@ func dataSourceAccountRolesRead(ctx context.Context, d *schema.ResourceData, m i
 	client := m.(*Client)
 
 	accountId := d.Get("account_id").(int)
-	responseDTO, err := client.GetAccountRoles(accountId)
+	responseDTO, err := client.GetAccountRoles(ctx, accountId)
 	if err != nil {
 		return diag.Errorf("Error getting Account Roles: %s", err)
 	}
@@ -0,0 +1,274 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Certificate types exposed by the incapsula_certificates data source
+const (
+	certificateTypeCustom  = "custom"
+	certificateTypeManaged = "managed"
+	certificateTypeHSM     = "hsm"
+)
+
+func dataSourceCertificates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCertificatesRead,
+		Description: "Provides the custom, Imperva managed and HSM certificates of the sites of an account. All filter arguments are optional. When specified, a logical AND operator is assumed.",
+
+		Schema: map[string]*schema.Schema{
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to list the certificates of. Defaults to the provider default_account_id, or to the account of the API credentials.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"type": {
+				Description:  "Filter by the type of the certificates. Possible values: custom, managed, hsm.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{certificateTypeCustom, certificateTypeManaged, certificateTypeHSM}, false),
+			},
+			"expiring_within_days": {
+				Description:  "Filter the certificates expiring within the given number of days, including the expired ones.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			// Computed Attributes
+			"ids": {
+				Description: "The numeric identifiers of the certificates.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"certificates": {
+				Description: "The certificates, the first to expire first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Numeric identifier of the certificate.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the certificate.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of the certificate: custom, managed or hsm.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The status of the certificate, as returned by the API. For example: ACTIVE, IN_PROCESS.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"hostnames": {
+							Description: "The hostnames covered by the certificate.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"expiration_date": {
+							Description: "The expiration date of the certificate, in RFC 3339 format. Empty when the certificate isn't issued yet.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"in_renewal": {
+							Description: "Whether the certificate is being renewed.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"site_ids": {
+							Description: "The numeric identifiers of the sites the certificate is bound to.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// accountCertificate is a certificate of the incapsula_certificates data source, with its type and the sites it is
+// bound to
+type accountCertificate struct {
+	details         CertificateDetailsDTO
+	certificateType string
+	siteIDs         []int
+}
+
+func dataSourceCertificatesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	accountID := d.Get("account_id").(int)
+	if accountID == 0 {
+		accountID = client.lookupAccountID()
+	}
+
+	certificates, err := listAccountCertificates(ctx, client, accountID)
+	if err != nil {
+		return diagnosticsFromError(d, err)
+	}
+
+	certificateType := d.Get("type").(string)
+	var expiringBefore time.Time
+	if days, ok := d.GetOk("expiring_within_days"); ok {
+		expiringBefore = time.Now().AddDate(0, 0, days.(int))
+	}
+
+	ids := []int{}
+	certificateList := []map[string]interface{}{}
+	for _, certificate := range certificates {
+		if certificateType != "" && certificate.certificateType != certificateType {
+			continue
+		}
+		if !expiringBefore.IsZero() && (certificate.details.ExpirationDate == 0 || time.UnixMilli(certificate.details.ExpirationDate).After(expiringBefore)) {
+			continue
+		}
+		ids = append(ids, certificate.details.Id)
+		certificateList = append(certificateList, flattenAccountCertificate(certificate))
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%d/%s/%d", accountID, certificateType, d.Get("expiring_within_days")))))
+	d.Set("ids", ids)
+	d.Set("certificates", certificateList)
+
+	return nil
+}
+
+// listAccountCertificates gets the certificates covering each site of the account, one site after the other, and merges
+// the certificates bound to several sites. The certificates are sorted by expiration date, the ones without expiration
+// date last
+func listAccountCertificates(ctx context.Context, client *Client, accountID int) ([]*accountCertificate, error) {
+	sites, err := client.listAllSites(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var certificates []*accountCertificate
+	certificatesByID := map[int]*accountCertificate{}
+	for _, site := range sites {
+		certificatesDetailsResponse, err := client.ListSiteCertificatesDetails(ctx, site.SiteID, accountID)
+		if err != nil {
+			return nil, err
+		}
+		managedIDs, err := siteManagedCertificateIDs(ctx, client, site.SiteID, accountID, certificatesDetailsResponse.Data)
+		if err != nil {
+			return nil, err
+		}
+		for _, details := range certificatesDetailsResponse.Data {
+			certificate, ok := certificatesByID[details.Id]
+			if !ok {
+				certificate = &accountCertificate{details: details, certificateType: certificateTypeFromDetails(details, managedIDs)}
+				certificatesByID[details.Id] = certificate
+				certificates = append(certificates, certificate)
+			}
+			certificate.siteIDs = append(certificate.siteIDs, site.SiteID)
+		}
+	}
+
+	sort.SliceStable(certificates, func(i, j int) bool {
+		expirationI, expirationJ := certificates[i].details.ExpirationDate, certificates[j].details.ExpirationDate
+		if expirationI == 0 || expirationJ == 0 {
+			return expirationJ == 0 && expirationI != 0
+		}
+		return expirationI < expirationJ
+	})
+	return certificates, nil
+}
+
+// siteManagedCertificateIDs returns the IDs of the managed certificates of a site, read from the managed certificate
+// API, when the certificates details API returns a certificate of a type it doesn't document. It is nil otherwise,
+// sparing a request per site
+func siteManagedCertificateIDs(ctx context.Context, client *Client, siteID int, accountID int, certificatesDetails []CertificateDetailsDTO) (map[int]bool, error) {
+	documented := true
+	for _, details := range certificatesDetails {
+		if details.Type != certificateDetailsTypeCustom && details.Type != certificateDetailsTypeManaged {
+			log.Printf("[WARN] Certificate %d of site %d has the undocumented type %q, checking the managed certificate of the site", details.Id, siteID, details.Type)
+			documented = false
+		}
+	}
+	if documented {
+		return nil, nil
+	}
+
+	var caid *int
+	if accountID != 0 {
+		caid = &accountID
+	}
+	siteCertificateV3Response, diags := client.GetSiteCertificateRequestStatus(ctx, siteID, caid)
+	if diags.HasError() {
+		return nil, fmt.Errorf("Error getting the managed certificate of site %d: %s", siteID, diagnosticsSummary(diags))
+	}
+	managedIDs := map[int]bool{}
+	for _, siteCertificate := range siteCertificateV3Response.Data {
+		for _, certificate := range siteCertificate.CertificatesDetails {
+			managedIDs[certificate.Id] = true
+		}
+	}
+	return managedIDs, nil
+}
+
+// certificateTypeFromDetails maps the type and hosting type of the API to the certificate types of the data source.
+// The certificates details API documents the types CUSTOM and ATLAS, the Imperva managed certificates, and the hosting
+// types IMPERVA and HSM of the custom certificates. A certificate of another type is managed when it is one of the
+// managedIDs of the managed certificate API, custom otherwise
+func certificateTypeFromDetails(details CertificateDetailsDTO, managedIDs map[int]bool) string {
+	switch {
+	case details.Type == certificateDetailsTypeManaged || managedIDs[details.Id]:
+		return certificateTypeManaged
+	case details.HostingType == certificateHostingTypeHSM:
+		return certificateTypeHSM
+	default:
+		return certificateTypeCustom
+	}
+}
+
+func flattenAccountCertificate(certificate *accountCertificate) map[string]interface{} {
+	hostnames := []string{}
+	seen := map[string]bool{}
+	for _, san := range certificate.details.Sans {
+		if san.SanValue != "" && !seen[san.SanValue] {
+			seen[san.SanValue] = true
+			hostnames = append(hostnames, san.SanValue)
+		}
+	}
+	sort.Strings(hostnames)
+
+	expirationDate := ""
+	if certificate.details.ExpirationDate != 0 {
+		expirationDate = time.UnixMilli(certificate.details.ExpirationDate).UTC().Format(time.RFC3339)
+	}
+
+	siteIDs := append([]int{}, certificate.siteIDs...)
+	sort.Ints(siteIDs)
+
+	return map[string]interface{}{
+		"id":              certificate.details.Id,
+		"name":            certificate.details.Name,
+		"type":            certificate.certificateType,
+		"status":          certificate.details.Status,
+		"hostnames":       hostnames,
+		"expiration_date": expirationDate,
+		"in_renewal":      certificate.details.InRenewal,
+		"site_ids":        siteIDs,
+	}
+}
@@ -0,0 +1,139 @@
+package incapsula
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceCertificatesReadFakeAPI(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	var siteIDs []int
+	for _, domain := range []string{"www.example.com", "shop.example.com"} {
+		siteAddResponse, err := client.AddSite(ctx, domain, "", "false", "", "false", 0, false, false, "")
+		if err != nil {
+			t.Fatalf("Should not have received an error adding the site, got: %s", err)
+		}
+		siteIDs = append(siteIDs, siteAddResponse.SiteID)
+	}
+
+	custom := newTestCertificate(t, "www.example.com", nil, time.Now().AddDate(0, 0, 10))
+	if _, err := client.AddCertificate(ctx, strconv.Itoa(siteIDs[0]), base64.StdEncoding.EncodeToString([]byte(custom.pem)), "", "", "", ""); err != nil {
+		t.Fatalf("Should not have received an error adding the custom certificate, got: %s", err)
+	}
+	hsm := newTestCertificate(t, "shop.example.com", nil, time.Now().AddDate(1, 0, 0))
+	if _, err := client.AddHsmCertificate(ctx, strconv.Itoa(siteIDs[1]), "", &HSMDataDTO{Certificate: base64.StdEncoding.EncodeToString([]byte(hsm.pem))}); err != nil {
+		t.Fatalf("Should not have received an error adding the HSM certificate, got: %s", err)
+	}
+	if _, diags := client.RequestSiteCertificate(ctx, siteIDs[1], "CNAME", nil); diags.HasError() {
+		t.Fatalf("Should not have received an error requesting the managed certificate, got: %v", diags)
+	}
+
+	read := func(config map[string]interface{}) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, dataSourceCertificates().Schema, config)
+		if diags := dataSourceCertificatesRead(ctx, d, client); diags.HasError() {
+			t.Fatalf("Should not have received an error, got: %v", diags)
+		}
+		return d
+	}
+
+	d := read(map[string]interface{}{})
+	if count := d.Get("certificates.#"); count != 3 {
+		t.Fatalf("Should have listed the 3 certificates, got: %v", d.Get("certificates"))
+	}
+	// The certificates are sorted by expiration date
+	expectedTypes := []string{certificateTypeCustom, certificateTypeHSM, certificateTypeManaged}
+	for i, expectedType := range expectedTypes {
+		if certificateType := d.Get("certificates." + strconv.Itoa(i) + ".type"); certificateType != expectedType {
+			t.Errorf("Unexpected type of certificate %d, expected: %s, got: %v", i, expectedType, certificateType)
+		}
+	}
+	if hostnames := d.Get("certificates.0.hostnames").([]interface{}); len(hostnames) != 1 || hostnames[0] != "www.example.com" {
+		t.Errorf("Unexpected hostnames of the custom certificate: %v", hostnames)
+	}
+	if boundSiteIDs := d.Get("certificates.0.site_ids").([]interface{}); len(boundSiteIDs) != 1 || boundSiteIDs[0] != siteIDs[0] {
+		t.Errorf("Unexpected sites of the custom certificate: %v", boundSiteIDs)
+	}
+	if expirationDate := d.Get("certificates.0.expiration_date"); expirationDate != custom.certificate.NotAfter.UTC().Format(time.RFC3339) {
+		t.Errorf("Unexpected expiration date of the custom certificate: %v", expirationDate)
+	}
+
+	d = read(map[string]interface{}{"expiring_within_days": 30})
+	if ids := d.Get("ids").([]interface{}); len(ids) != 1 || d.Get("certificates.0.type") != certificateTypeCustom {
+		t.Errorf("Should have only listed the custom certificate expiring within 30 days, got: %v", d.Get("certificates"))
+	}
+
+	d = read(map[string]interface{}{"type": certificateTypeManaged})
+	if ids := d.Get("ids").([]interface{}); len(ids) != 1 || d.Get("certificates.0.status") != "IN_PROCESS" {
+		t.Errorf("Should have only listed the managed certificate, got: %v", d.Get("certificates"))
+	}
+}
+
+func TestCertificateTypeFromDetails(t *testing.T) {
+	managedIDs := map[int]bool{4: true}
+	cases := []struct {
+		details  CertificateDetailsDTO
+		expected string
+	}{
+		{CertificateDetailsDTO{Id: 1, Type: certificateDetailsTypeManaged}, certificateTypeManaged},
+		{CertificateDetailsDTO{Id: 2, Type: certificateDetailsTypeCustom, HostingType: certificateHostingTypeHSM}, certificateTypeHSM},
+		{CertificateDetailsDTO{Id: 3, Type: certificateDetailsTypeCustom, HostingType: "IMPERVA"}, certificateTypeCustom},
+		{CertificateDetailsDTO{Id: 4, Type: "MANAGED"}, certificateTypeManaged},
+		{CertificateDetailsDTO{Id: 5, Type: "UNKNOWN"}, certificateTypeCustom},
+	}
+	for _, c := range cases {
+		if certificateType := certificateTypeFromDetails(c.details, managedIDs); certificateType != c.expected {
+			t.Errorf("Unexpected type for %+v, expected: %s, got: %s", c.details, c.expected, certificateType)
+		}
+	}
+}
+
+func TestListAccountCertificatesUndocumentedType(t *testing.T) {
+	managedRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/" + endpointSiteList:
+			rw.Write([]byte(`{"res":0,"sites":[{"site_id":42},{"site_id":43}]}`))
+		case "/" + endpointCertDetails:
+			if req.URL.Query().Get("extSiteId") == "42" {
+				rw.Write([]byte(`{"data":[{"id":1,"type":"MANAGED"},{"id":2,"type":"CUSTOM","hostingType":"IMPERVA"}]}`))
+			} else {
+				rw.Write([]byte(`{"data":[{"id":3,"type":"ATLAS"}]}`))
+			}
+		case endpointSiteCertV3BasePath + "42" + endpointSiteCertV3Suffix:
+			managedRequests++
+			rw.Write([]byte(`{"data":[{"siteId":42,"certificateDetails":[{"id":1}]}]}`))
+		default:
+			t.Errorf("Unexpected request: %s", req.URL.String())
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL, BaseURLAPI: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	certificates, err := listAccountCertificates(context.Background(), client, 0)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	types := map[int]string{}
+	for _, certificate := range certificates {
+		types[certificate.details.Id] = certificate.certificateType
+	}
+	expected := map[int]string{1: certificateTypeManaged, 2: certificateTypeCustom, 3: certificateTypeManaged}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("Unexpected types, expected: %v, got: %v", expected, types)
+	}
+	if managedRequests != 1 {
+		t.Errorf("Should have only checked the managed certificate of the site with the undocumented type, got %d requests", managedRequests)
+	}
+}
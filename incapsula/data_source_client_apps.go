@@ -75,7 +75,7 @@ func UniqueStrings(stringSlices ...[]string) []string {
 func dataSourceClientAppsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	responseDTO, err := client.GetClientApplicationsMetadata()
+	responseDTO, err := client.GetClientApplicationsMetadata(ctx)
 	if err != nil {
 		return diag.Errorf("Error getting Client Applications: %s", err)
 	}
@@ -0,0 +1,89 @@
+package incapsula
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceSiteSkippedAttributes are the arguments of incapsula_site that are only sent when the site is created or updated, and never read
+var dataSourceSiteSkippedAttributes = map[string]bool{
+	"ref_id":                  true,
+	"deprecated":              true,
+	"send_site_setup_emails":  true,
+	"force_ssl":               true,
+	"logs_account_id":         true,
+	"domain_validation":       true,
+	"approver":                true,
+	"ignore_ssl":              true,
+	"domain_redirect_to_full": true,
+	"remove_ssl":              true,
+}
+
+func dataSourceSite() *schema.Resource {
+	siteSchema := map[string]*schema.Schema{
+		"site_id": {
+			Description:  "Numeric identifier of the site to look up.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"site_id", "domain"},
+		},
+		"domain": {
+			Description:  "The domain of the site to look up, in the provider default_account_id, or in the account of the API credentials.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"site_id", "domain"},
+		},
+	}
+
+	// The attributes are the ones of the incapsula_site resource, all of them computed
+	for key, attribute := range resourceSite().Schema {
+		if _, ok := siteSchema[key]; ok || dataSourceSiteSkippedAttributes[key] {
+			continue
+		}
+		siteSchema[key] = &schema.Schema{
+			Description: attribute.Description,
+			Type:        attribute.Type,
+			Elem:        attribute.Elem,
+			Sensitive:   attribute.Sensitive,
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceSiteRead,
+		Description: "Provides the properties of a single site, looked up by site_id or domain.",
+		Schema:      siteSchema,
+	}
+}
+
+func dataSourceSiteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	siteID, ok := d.GetOk("site_id")
+	if !ok {
+		lookedUpSiteID, err := client.lookupSiteID(ctx, d.Get("domain").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		siteID = lookedUpSiteID
+	}
+	if _, err := strconv.Atoi(siteID.(string)); err != nil {
+		return diag.Errorf("site_id must be a numeric identifier, got: %s", siteID)
+	}
+
+	d.SetId(siteID.(string))
+	if diags := readSite(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		return diag.Errorf("Site %s was not found", siteID)
+	}
+	d.Set("site_id", d.Id())
+
+	return nil
+}
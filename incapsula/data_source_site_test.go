@@ -0,0 +1,55 @@
+package incapsula
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSiteReadFakeAPI(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "lookup.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+
+	for _, config := range []map[string]interface{}{{"domain": "lookup.example.com"}, {"site_id": siteID}} {
+		d := schema.TestResourceDataRaw(t, dataSourceSite().Schema, config)
+		if diags := dataSourceSiteRead(ctx, d, client); diags.HasError() {
+			t.Fatalf("Should not have received an error reading %v, got: %v", config, diags)
+		}
+		if d.Id() != siteID || d.Get("site_id") != siteID || d.Get("domain") != "lookup.example.com" {
+			t.Errorf("Unexpected site %s for %v", d.Id(), config)
+		}
+		if d.Get("account_id") != server.AccountID || d.Get("dns_cname_record_value") == "" || d.Get("original_data_center_id") != siteAddResponse.SiteID {
+			t.Errorf("Unexpected attributes: account %v, CNAME %v, data center %v", d.Get("account_id"), d.Get("dns_cname_record_value"), d.Get("original_data_center_id"))
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceSite().Schema, map[string]interface{}{"domain": "missing.example.com"})
+	if diags := dataSourceSiteRead(ctx, d, client); !diags.HasError() {
+		t.Errorf("Should have received an error for an unknown domain")
+	}
+}
+
+func TestDataSourceSiteSchema(t *testing.T) {
+	siteSchema := dataSourceSite().Schema
+	for key, attribute := range siteSchema {
+		if !attribute.Computed {
+			t.Errorf("Attribute %s should be computed", key)
+		}
+	}
+	for key := range dataSourceSiteSkippedAttributes {
+		if _, ok := siteSchema[key]; ok {
+			t.Errorf("Write only attribute %s should not be exposed", key)
+		}
+	}
+	if _, ok := siteSchema["domain_verification"]; !ok {
+		t.Errorf("Attribute domain_verification should be exposed")
+	}
+}
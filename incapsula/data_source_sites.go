@@ -0,0 +1,227 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSites() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSitesRead,
+		Description: "Provides the sites of an account. All filter arguments are optional. When specified, a logical AND operator is assumed.",
+
+		Schema: map[string]*schema.Schema{
+			// Optional Arguments
+			"account_id": {
+				Description: "Numeric identifier of the account to list the sites of. Defaults to the provider default_account_id, or to the account of the API credentials.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"site_type": {
+				Description:  "The API the sites are listed with. `v1` lists the sites of the APIv1, `v3` the sites of the v3 site management API. Default: v1.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "v1",
+				ValidateFunc: validation.StringInSlice([]string{"v1", "v3"}, false),
+			},
+			"domain_regex": {
+				Description:  "Filter by a regular expression the domain of the sites must match. For example: `\\.shop\\.example\\.com$`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"active": {
+				Description:  "Filter by the status of the sites. Possible values: active, bypass.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"active", "bypass"}, false),
+			},
+
+			// Computed Attributes
+			"ids": {
+				Description: "The numeric identifiers of the sites.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"domains": {
+				Description: "The domains of the sites.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sites": {
+				Description: "The sites, in the order of the API.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Numeric identifier of the site.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "The domain of the site.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"account_id": {
+							Description: "Numeric identifier of the account of the site.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"active": {
+							Description: "The status of the site: active or bypass.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cnames": {
+							Description: "The CNAME records the DNS of the site must point to.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"creation_date": {
+							Description: "The creation date of the site, in RFC 3339 format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// siteSummary is a site of the v1 or v3 lists, as exposed by the incapsula_sites data source
+type siteSummary struct {
+	id           int
+	domain       string
+	accountID    int
+	active       string
+	cnames       []string
+	creationTime int64
+}
+
+func dataSourceSitesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	accountID := d.Get("account_id").(int)
+	if accountID == 0 {
+		accountID = client.lookupAccountID()
+	}
+
+	var sites []siteSummary
+	var err error
+	if d.Get("site_type").(string) == "v3" {
+		sites, err = listV3SiteSummaries(ctx, client, accountID)
+	} else {
+		sites, err = listSiteSummaries(ctx, client, accountID)
+	}
+	if err != nil {
+		return diagnosticsFromError(d, err)
+	}
+
+	domainRegex := regexp.MustCompile(d.Get("domain_regex").(string))
+	active := d.Get("active").(string)
+
+	ids := []int{}
+	domains := []string{}
+	siteList := []map[string]interface{}{}
+	for _, site := range sites {
+		if !domainRegex.MatchString(site.domain) || (active != "" && site.active != active) {
+			continue
+		}
+		creationDate := ""
+		if site.creationTime != 0 {
+			creationDate = time.UnixMilli(site.creationTime).UTC().Format(time.RFC3339)
+		}
+		ids = append(ids, site.id)
+		domains = append(domains, site.domain)
+		siteList = append(siteList, map[string]interface{}{
+			"id":            site.id,
+			"domain":        site.domain,
+			"account_id":    site.accountID,
+			"active":        site.active,
+			"cnames":        site.cnames,
+			"creation_date": creationDate,
+		})
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%d/%s/%s/%s", accountID, d.Get("site_type"), d.Get("domain_regex"), active))))
+	d.Set("ids", ids)
+	d.Set("domains", domains)
+	d.Set("sites", siteList)
+
+	return nil
+}
+
+func listSiteSummaries(ctx context.Context, client *Client, accountID int) ([]siteSummary, error) {
+	sites, err := client.listAllSites(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]siteSummary, len(sites))
+	for i, site := range sites {
+		cnames := []string{}
+		for _, record := range site.DNS {
+			if record.SetTypeTo == "CNAME" {
+				cnames = append(cnames, record.SetDataTo...)
+			}
+		}
+		summaries[i] = siteSummary{
+			id:           site.SiteID,
+			domain:       site.Domain,
+			accountID:    site.AccountID,
+			active:       site.Active,
+			cnames:       cnames,
+			creationTime: site.SiteCreationDate,
+		}
+	}
+	return summaries, nil
+}
+
+func listV3SiteSummaries(ctx context.Context, client *Client, accountID int) ([]siteSummary, error) {
+	caid := ""
+	if accountID != 0 {
+		caid = strconv.Itoa(accountID)
+	}
+
+	var summaries []siteSummary
+	for page := 0; ; page++ {
+		siteV3Response, diags := client.ListV3Sites(ctx, caid, page, lookupPageSize)
+		if diags.HasError() {
+			return nil, fmt.Errorf("Error listing v3 sites: %s", diagnosticsSummary(diags))
+		}
+		for _, site := range siteV3Response.Data {
+			active := "bypass"
+			if site.Active {
+				active = "active"
+			}
+			cnames := []string{}
+			if site.Cname != "" {
+				cnames = append(cnames, site.Cname)
+			}
+			summaries = append(summaries, siteSummary{
+				id:           site.Id,
+				domain:       site.Name,
+				accountID:    site.AccountId,
+				active:       active,
+				cnames:       cnames,
+				creationTime: site.CreationTime,
+			})
+		}
+		if len(siteV3Response.Data) < lookupPageSize {
+			return summaries, nil
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package incapsula
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSitesReadFakeAPI(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	for _, domain := range []string{"a.shop.example.com", "b.shop.example.com", "www.example.com"} {
+		if _, err := client.AddSite(ctx, domain, "", "false", "", "false", 0, false, false, ""); err != nil {
+			t.Fatalf("Should not have received an error adding the site, got: %s", err)
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceSites().Schema, map[string]interface{}{
+		"domain_regex": `\.shop\.example\.com$`,
+		"active":       "active",
+	})
+	if diags := dataSourceSitesRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %v", diags)
+	}
+
+	domains := d.Get("domains").([]interface{})
+	if len(domains) != 2 || domains[0] != "a.shop.example.com" || domains[1] != "b.shop.example.com" {
+		t.Errorf("Unexpected domains: %v", domains)
+	}
+	if d.Get("sites.0.account_id") != server.AccountID || d.Get("sites.0.cnames.#") != 1 || d.Get("sites.0.creation_date") == "" {
+		t.Errorf("Unexpected site: %v", d.Get("sites.0"))
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceSites().Schema, map[string]interface{}{
+		"site_type": "v3",
+		"active":    "bypass",
+	})
+	if diags := dataSourceSitesRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %v", diags)
+	}
+	if ids := d.Get("ids").([]interface{}); len(ids) != 0 {
+		t.Errorf("Should not have found bypassed sites, got: %v", ids)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -68,44 +70,283 @@ func dataSourceSSLInstructions() *schema.Resource {
 						},
 					}},
 			},
+			"wait_until_available": {
+				Description: "Wait until the instructions of all the domain_ids are generated, bounded by the read timeout. Default: false.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"records": {
+				Description: "The DNS records to create, one per FQDN and record type, sorted by FQDN. The maps by FQDN are derived from them.",
+				Computed:    true,
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fqdn": {
+							Description: "The fully qualified domain name of the record, without trailing dot.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of the record, e.g. CNAME or TXT.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"values": {
+							Description: "The values of the record.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"ttl": {
+							Description: "The suggested TTL of the record, in seconds.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"validation_method": {
+							Description: "The validation method of the SANs the record validates, e.g. CNAME or DNS.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"domain_ids": {
+							Description: "The domains validated by the record.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+			"record_types": {
+				Description: "The type of the record of each FQDN of records.",
+				Computed:    true,
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"record_values": {
+				Description: "The values of the record of each FQDN of records, separated by commas.",
+				Computed:    true,
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"record_ttls": {
+				Description: "The suggested TTL of the record of each FQDN of records, in seconds.",
+				Computed:    true,
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"record_validation_methods": {
+				Description: "The validation method of the record of each FQDN of records.",
+				Computed:    true,
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
+// sslInstructionRecordTTL is the suggested TTL of the validation records, short enough for a fixed record to be picked
+// up quickly by the validation
+const sslInstructionRecordTTL = 300
+
+// sslInstructionsBackoffSchedule are the delays between the polls of wait_until_available, the last one is repeated
+// until the timeout
+var sslInstructionsBackoffSchedule = []time.Duration{
+	5 * time.Second,
+	10 * time.Second,
+	20 * time.Second,
+	30 * time.Second,
+}
+
+// sslInstructionsCertificatePolls bounds the polls of the managed certificate until it covers the domains of the site,
+// sslInstructionsCertificatePollInterval apart
+const (
+	sslInstructionsCertificatePolls        = 20
+	sslInstructionsCertificatePollInterval = 10 * time.Second
+)
+
+// sslInstructionsSleep waits between the polls, replaced by the tests
+var sslInstructionsSleep = sleepWithContext
+
 func dataSourceSSLInstructionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := d.Get("site_id").(string)
 	atoi, _ := strconv.Atoi(siteId)
-	waitForInstructions(client, atoi)
-	sSLInstructionsResponse, err := client.GetSiteSSLInstructions(atoi)
-	if err != nil {
-		return diag.Errorf("Error request site SSL instructions: %v", err)
+	if err := waitForInstructions(ctx, client, atoi); err != nil {
+		return diag.Errorf("Error waiting for the managed certificate of site %s: %v", siteId, err)
+	}
+	var domainIds []int
+	for _, domainId := range d.Get("domain_ids").(*schema.Set).List() {
+		id, _ := strconv.Atoi(domainId.(string))
+		domainIds = append(domainIds, id)
 	}
-	if sSLInstructionsResponse.Data != nil && len(sSLInstructionsResponse.Data) > 0 {
-		populateInstructionsFromDTO(d, sSLInstructionsResponse.Data)
+	var missing []int
+	for attempt := 0; ; attempt++ {
+		sSLInstructionsResponse, err := client.GetSiteSSLInstructions(ctx, atoi)
+		if err != nil && attempt > 0 && ctx.Err() != nil {
+			return diag.Errorf("the SSL instructions of domains %v of site %s were not generated before the timeout: %s", missing, siteId, ctx.Err())
+		}
+		if err != nil {
+			return diag.Errorf("Error request site SSL instructions: %v", err)
+		}
+		missing = domainsWithoutInstructions(sSLInstructionsResponse.Data, domainIds)
+		if !d.Get("wait_until_available").(bool) || len(missing) == 0 {
+			if sSLInstructionsResponse.Data != nil && len(sSLInstructionsResponse.Data) > 0 {
+				populateInstructionsFromDTO(d, sSLInstructionsResponse.Data)
+			}
+			records := flattenSSLInstructionRecords(sSLInstructionsResponse.Data)
+			recordMaps, diags := sslInstructionRecordsByFQDN(records)
+			d.Set("records", records)
+			for attribute, value := range recordMaps {
+				d.Set(attribute, value)
+			}
+			d.SetId(siteId)
+			return diags
+		}
+
+		backoff := sslInstructionsBackoffSchedule[len(sslInstructionsBackoffSchedule)-1]
+		if attempt < len(sslInstructionsBackoffSchedule) {
+			backoff = sslInstructionsBackoffSchedule[attempt]
+		}
+		if err := sslInstructionsSleep(ctx, backoff); err != nil {
+			return diag.Errorf("the SSL instructions of domains %v of site %s were not generated before the timeout: %s", missing, siteId, err)
+		}
 	}
-	d.SetId(siteId)
-	return nil
 }
 
-func waitForInstructions(client *Client, siteId int) diag.Diagnostics {
-	siteDomainDetailsDto, err := client.GetWebsiteDomains(strconv.Itoa(siteId))
-	if err != nil {
-		return diag.Errorf("Error getting site %d domains: %v", siteId, err)
-	}
-	if siteDomainDetailsDto.Data != nil && len(siteDomainDetailsDto.Data) > 0 {
-		for i := 0; i < 20; i++ {
-			siteCertificateV3Response, _ := client.GetSiteCertificateRequestStatus(siteId, nil)
-			b := siteCertificateV3Response != nil && siteCertificateV3Response.Data != nil && len(siteCertificateV3Response.Data) > 0
-			b = b && siteCertificateV3Response.Data[0].CertificatesDetails != nil && validateSans(siteCertificateV3Response.Data[0], siteDomainDetailsDto.Data)
-			if b {
-				return nil
+// domainsWithoutInstructions returns the domains that aren't related to any of the instructions
+func domainsWithoutInstructions(instructionsDTO []InstructionsDTO, domainIds []int) []int {
+	covered := map[int]bool{}
+	for _, instruction := range instructionsDTO {
+		for _, san := range instruction.RelatedSansDetails {
+			for _, domainId := range san.DomainIds {
+				covered[domainId] = true
 			}
-			time.Sleep(10 * time.Second)
 		}
-		panic("managed certificate was not created as expected")
 	}
-	return nil
+	missing := []int{}
+	for _, domainId := range domainIds {
+		if !covered[domainId] {
+			missing = append(missing, domainId)
+		}
+	}
+	sort.Ints(missing)
+	return missing
+}
+
+// flattenSSLInstructionRecords merges the instructions into one record per FQDN and record type, so that the records
+// keep the same order whatever the order of the domains
+func flattenSSLInstructionRecords(instructionsDTO []InstructionsDTO) []interface{} {
+	type record struct {
+		fqdn             string
+		recordType       string
+		validationMethod string
+		values           []string
+		domainIds        []int
+	}
+	records := map[string]*record{}
+	for _, instruction := range instructionsDTO {
+		fqdn := strings.ToLower(strings.TrimSuffix(instruction.Domain, "."))
+		if fqdn == "" {
+			continue
+		}
+		key := fqdn + "/" + instruction.RecordType
+		r, ok := records[key]
+		if !ok {
+			r = &record{fqdn: fqdn, recordType: instruction.RecordType, validationMethod: instruction.ValidationMethod}
+			records[key] = r
+		}
+		if !slices.Contains(r.values, instruction.VerificationCode) {
+			r.values = append(r.values, instruction.VerificationCode)
+		}
+		for _, san := range instruction.RelatedSansDetails {
+			for _, domainId := range san.DomainIds {
+				if !slices.Contains(r.domainIds, domainId) {
+					r.domainIds = append(r.domainIds, domainId)
+				}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		r := records[key]
+		sort.Strings(r.values)
+		sort.Ints(r.domainIds)
+		result = append(result, map[string]interface{}{
+			"fqdn":              r.fqdn,
+			"type":              r.recordType,
+			"values":            r.values,
+			"ttl":               sslInstructionRecordTTL,
+			"validation_method": r.validationMethod,
+			"domain_ids":        r.domainIds,
+		})
+	}
+	return result
+}
+
+// sslInstructionRecordsByFQDN returns the maps by FQDN of the fields of the records, keyed by attribute name. An FQDN
+// holding records of several types is kept with the first of its types in alphabetical order, and reported as a warning
+func sslInstructionRecordsByFQDN(records []interface{}) (map[string]map[string]interface{}, diag.Diagnostics) {
+	recordMaps := map[string]map[string]interface{}{
+		"record_types":              {},
+		"record_values":             {},
+		"record_ttls":               {},
+		"record_validation_methods": {},
+	}
+	var diags diag.Diagnostics
+	for _, record := range records {
+		r := record.(map[string]interface{})
+		fqdn := r["fqdn"].(string)
+		if recordType, ok := recordMaps["record_types"][fqdn]; ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Several SSL validation records for %s", fqdn),
+				Detail: fmt.Sprintf("%s has a %s record and a %s record. The maps by FQDN only hold the %s record, use records to create both.",
+					fqdn, recordType, r["type"], recordType),
+			})
+			continue
+		}
+		recordMaps["record_types"][fqdn] = r["type"]
+		recordMaps["record_values"][fqdn] = strings.Join(r["values"].([]string), ",")
+		recordMaps["record_ttls"][fqdn] = r["ttl"]
+		recordMaps["record_validation_methods"][fqdn] = r["validation_method"]
+	}
+	return recordMaps, diags
+}
+
+// waitForInstructions polls the managed certificate of the site until it has a SAN for each domain of the site, the
+// instructions being generated for the SANs of the certificate
+func waitForInstructions(ctx context.Context, client *Client, siteId int) error {
+	siteDomainDetailsDto, err := client.GetWebsiteDomains(ctx, strconv.Itoa(siteId))
+	if err != nil {
+		return fmt.Errorf("could not get the domains of the site: %w", err)
+	}
+	if len(siteDomainDetailsDto.Data) == 0 {
+		return nil
+	}
+	for poll := 1; ; poll++ {
+		siteCertificateV3Response, _ := client.GetSiteCertificateRequestStatus(ctx, siteId, nil)
+		if siteCertificateV3Response != nil && len(siteCertificateV3Response.Data) > 0 && siteCertificateV3Response.Data[0].CertificatesDetails != nil &&
+			validateSans(siteCertificateV3Response.Data[0], siteDomainDetailsDto.Data) {
+			return nil
+		}
+		if poll == sslInstructionsCertificatePolls {
+			return fmt.Errorf("the certificate doesn't cover the domains of the site after %d polls", poll)
+		}
+		if err := sslInstructionsSleep(ctx, sslInstructionsCertificatePollInterval); err != nil {
+			return fmt.Errorf("the certificate doesn't cover the domains of the site: %w", err)
+		}
+	}
 }
 
 func validateSans(siteCertificateDTO SiteCertificateDTO, siteDomainDetails []SiteDomainDetails) bool {
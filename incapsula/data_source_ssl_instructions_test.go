@@ -1,9 +1,16 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -64,3 +71,142 @@ site_id=incapsula_site_v3.%s.id
 		incapsulaSslInstructionsDataSource, siteV3ResourceNameForDomainTest)
 	return result
 }
+
+func TestFlattenSSLInstructionRecords(t *testing.T) {
+	instructions := []InstructionsDTO{
+		{Domain: "_b.example.com", RecordType: "CNAME", VerificationCode: "b.validation.example", ValidationMethod: "CNAME", RelatedSansDetails: []SanDetailsDTO{{SanId: 2, DomainIds: []int{20}}}},
+		{Domain: "Example.com.", RecordType: "TXT", VerificationCode: "code-2", ValidationMethod: "DNS", RelatedSansDetails: []SanDetailsDTO{{SanId: 3, DomainIds: []int{30}}}},
+		{Domain: "example.com", RecordType: "TXT", VerificationCode: "code-1", ValidationMethod: "DNS", RelatedSansDetails: []SanDetailsDTO{{SanId: 1, DomainIds: []int{10}}}},
+		{Domain: "example.com", RecordType: "TXT", VerificationCode: "code-1", ValidationMethod: "DNS", RelatedSansDetails: []SanDetailsDTO{{SanId: 1, DomainIds: []int{10}}}},
+	}
+
+	records := flattenSSLInstructionRecords(instructions)
+	expected := []interface{}{
+		map[string]interface{}{"fqdn": "_b.example.com", "type": "CNAME", "values": []string{"b.validation.example"}, "ttl": sslInstructionRecordTTL, "validation_method": "CNAME", "domain_ids": []int{20}},
+		map[string]interface{}{"fqdn": "example.com", "type": "TXT", "values": []string{"code-1", "code-2"}, "ttl": sslInstructionRecordTTL, "validation_method": "DNS", "domain_ids": []int{10, 30}},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("Unexpected records, expected: %v, got: %v", expected, records)
+	}
+
+	// The records don't depend on the order of the instructions
+	reversed := []InstructionsDTO{instructions[3], instructions[2], instructions[1], instructions[0]}
+	if records := flattenSSLInstructionRecords(reversed); !reflect.DeepEqual(records, expected) {
+		t.Errorf("Unexpected records of the reversed instructions, expected: %v, got: %v", expected, records)
+	}
+
+	recordMaps, diags := sslInstructionRecordsByFQDN(records)
+	expectedMaps := map[string]map[string]interface{}{
+		"record_types":              {"_b.example.com": "CNAME", "example.com": "TXT"},
+		"record_values":             {"_b.example.com": "b.validation.example", "example.com": "code-1,code-2"},
+		"record_ttls":               {"_b.example.com": sslInstructionRecordTTL, "example.com": sslInstructionRecordTTL},
+		"record_validation_methods": {"_b.example.com": "CNAME", "example.com": "DNS"},
+	}
+	if diags != nil || !reflect.DeepEqual(recordMaps, expectedMaps) {
+		t.Errorf("Unexpected records by FQDN: %v, %v", recordMaps, diags)
+	}
+}
+
+func TestSSLInstructionRecordsByFQDNCollision(t *testing.T) {
+	instructions := []InstructionsDTO{
+		{Domain: "example.com", RecordType: "TXT", VerificationCode: "code-1", ValidationMethod: "DNS", RelatedSansDetails: []SanDetailsDTO{{SanId: 1, DomainIds: []int{10}}}},
+		{Domain: "example.com", RecordType: "CNAME", VerificationCode: "validation.example", ValidationMethod: "CNAME", RelatedSansDetails: []SanDetailsDTO{{SanId: 2, DomainIds: []int{20}}}},
+	}
+
+	recordMaps, diags := sslInstructionRecordsByFQDN(flattenSSLInstructionRecords(instructions))
+	if recordMaps["record_types"]["example.com"] != "CNAME" || recordMaps["record_validation_methods"]["example.com"] != "CNAME" {
+		t.Errorf("Should have kept the CNAME record, got: %v", recordMaps)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "example.com has a CNAME record and a TXT record") {
+		t.Errorf("Should have warned about the TXT record left out, got: %v", diags)
+	}
+}
+
+func TestDataSourceSSLInstructionsReadFakeAPI(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	sleep := sslInstructionsSleep
+	sslInstructionsSleep = func(ctx context.Context, d time.Duration) error { return nil }
+	t.Cleanup(func() { sslInstructionsSleep = sleep })
+
+	siteAddResponse, err := client.AddSite(ctx, "www.instructions.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+	siteCertificateV3Response, diags := client.RequestSiteCertificate(ctx, siteAddResponse.SiteID, "DNS", nil)
+	if diags.HasError() {
+		t.Fatalf("Should not have received an error requesting the certificate, got: %v", diags)
+	}
+	var domainIDs []interface{}
+	for _, san := range siteCertificateV3Response.Data[0].CertificatesDetails[0].Sans {
+		domainIDs = append(domainIDs, strconv.Itoa(san.DomainIds[0]))
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceSSLInstructions().Schema, map[string]interface{}{
+		"site_id":                         siteID,
+		"domain_ids":                      domainIDs,
+		"managed_certificate_settings_id": siteID,
+		"wait_until_available":            true,
+	})
+	if diags := dataSourceSSLInstructionsRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %v", diags)
+	}
+	if d.Get("records.#") != 2 || d.Get("records.0.fqdn") != "instructions.example.com" || d.Get("records.0.type") != "TXT" || d.Get("records.0.validation_method") != "DNS" {
+		t.Errorf("Unexpected records: %v", d.Get("records"))
+	}
+	recordValues := d.Get("record_values").(map[string]interface{})
+	if recordTypes := d.Get("record_types").(map[string]interface{}); len(recordTypes) != 2 || recordTypes["instructions.example.com"] != "TXT" ||
+		!strings.HasPrefix(recordValues["instructions.example.com"].(string), "globalsign-domain-verification=") {
+		t.Errorf("Unexpected records by FQDN: %v, %v", recordTypes, recordValues)
+	}
+	if recordTTLs := d.Get("record_ttls").(map[string]interface{}); recordTTLs["instructions.example.com"] != sslInstructionRecordTTL {
+		t.Errorf("Unexpected TTLs by FQDN: %v", recordTTLs)
+	}
+
+	// A domain without instructions is reported when the timeout is reached, here after the first poll
+	sslInstructionsSleep = func(ctx context.Context, d time.Duration) error { return context.DeadlineExceeded }
+	d = schema.TestResourceDataRaw(t, dataSourceSSLInstructions().Schema, map[string]interface{}{
+		"site_id":                         siteID,
+		"domain_ids":                      append(domainIDs, "1"),
+		"managed_certificate_settings_id": siteID,
+		"wait_until_available":            true,
+	})
+	diags = dataSourceSSLInstructionsRead(ctx, d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "the SSL instructions of domains [1] of site") {
+		t.Errorf("Should have reported the domain without instructions, got: %v", diags)
+	}
+}
+
+func TestWaitForInstructions(t *testing.T) {
+	sleep := sslInstructionsSleep
+	sslInstructionsSleep = func(ctx context.Context, d time.Duration) error { return nil }
+	t.Cleanup(func() { sslInstructionsSleep = sleep })
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case endpointDomainManagement + "123/domains":
+			rw.Write([]byte(`{"data": [{"id": 1, "siteId": 123, "domain": "www.example.com"}]}`))
+		case fmt.Sprintf("%s%d%s", endpointSiteCertV3BasePath, 123, endpointSiteCertV3Suffix):
+			polls++
+			rw.WriteHeader(http.StatusNotFound)
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	config := &Config{APIID: "foo", APIKey: "bar", BaseURLAPI: server.URL}
+	client := &Client{config: config, httpClient: &http.Client{}}
+
+	err := waitForInstructions(context.Background(), client, 123)
+	if err == nil || err.Error() != "the certificate doesn't cover the domains of the site after 20 polls" || polls != sslInstructionsCertificatePolls {
+		t.Errorf("Should have reported the certificate not covering the domains after %d polls, got: %v after %d polls", sslInstructionsCertificatePolls, err, polls)
+	}
+
+	err = waitForInstructions(context.Background(), client, 456)
+	if err == nil || !strings.HasPrefix(err.Error(), "could not get the domains of the site") {
+		t.Errorf("Should have reported the error getting the domains, got: %v", err)
+	}
+}
@@ -50,3 +50,23 @@ func suppressEquivalentJSONStringDiffs(k, old, new string, d *schema.ResourceDat
 
 	return reflect.DeepEqual(o1, o2)
 }
+
+// suppressUnreadableAttributeDiff suppresses the diff of an attribute the API doesn't return, such as a password,
+// while the state doesn't hold it yet, i.e. after an import. The attribute must be read with configuredString
+func suppressUnreadableAttributeDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == "" && new != ""
+}
+
+// configuredString returns the value of a string attribute as set in the configuration, falling back to the state
+// when the configuration is not available. Attributes whose diff is suppressed keep their state value in the plan
+func configuredString(d *schema.ResourceData, key string) string {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().IsObjectType() || !rawConfig.Type().HasAttribute(key) {
+		return d.Get(key).(string)
+	}
+	value := rawConfig.GetAttr(key)
+	if value.IsNull() || !value.IsKnown() {
+		return d.Get(key).(string)
+	}
+	return value.AsString()
+}
@@ -30,3 +30,37 @@ func TestSuppressEquivalentStringDiffsSameStringsDifferent(t *testing.T) {
 		t.Errorf("Should not be equivalent")
 	}
 }
+
+func TestSuppressUnreadableAttributeDiffImported(t *testing.T) {
+	d := resourceWAFLogSetup().Data(nil)
+	d.SetId("123")
+
+	if !suppressUnreadableAttributeDiff("sftp_password", "", "secret", d) {
+		t.Errorf("Should be suppressed while the state doesn't hold the value")
+	}
+	if suppressUnreadableAttributeDiff("sftp_password", "old", "secret", d) {
+		t.Errorf("Should not be suppressed once the state holds the value")
+	}
+}
+
+func TestSuppressUnreadableAttributeDiffNew(t *testing.T) {
+	d := resourceWAFLogSetup().Data(nil)
+
+	if suppressUnreadableAttributeDiff("sftp_password", "", "secret", d) {
+		t.Errorf("Should not be suppressed when creating the resource")
+	}
+}
+
+func TestSuppressImportedCertificateSigningRequestDiff(t *testing.T) {
+	d := resourceCertificateSigningRequest().Data(nil)
+	d.SetId("123")
+
+	if !suppressImportedCertificateSigningRequestDiff("domain", "", "example.com", d) {
+		t.Errorf("Should be suppressed for an imported CSR")
+	}
+
+	d.Set("csr_content", "-----BEGIN CERTIFICATE REQUEST-----")
+	if suppressImportedCertificateSigningRequestDiff("email", "", "joe@example.com", d) {
+		t.Errorf("Should not be suppressed once the CSR is generated")
+	}
+}
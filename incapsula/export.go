@@ -0,0 +1,449 @@
+package incapsula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const exportPageSize = 100
+
+const exportFileHeader = "# Generated by terraform-provider-incapsula export\n"
+
+// exportDeliveryRuleCategories are the categories of incapsula_delivery_rules_configuration
+var exportDeliveryRuleCategories = []string{"REDIRECT", "REWRITE", "REWRITE_RESPONSE", "FORWARD"}
+
+// exportCacheRuleActionPrefix is the prefix of the actions of the cache rules, listed along with the incap rules
+const exportCacheRuleActionPrefix = "HTTP_CACHE_"
+
+// exportUnsupportedResources are the resources of a site that are never exported, with the reason
+var exportUnsupportedResources = map[string]string{
+	"incapsula_custom_certificate":     "the API doesn't return the certificate file and its private key",
+	"incapsula_custom_hsm_certificate": "the API doesn't return the certificate file and its HSM details",
+}
+
+// exportSiemConnectionTypes maps the storage types of the SIEM connections to their resource type
+var exportSiemConnectionTypes = map[string]string{
+	"CUSTOMER_S3":     "incapsula_siem_connection",
+	"CUSTOMER_S3_ARN": "incapsula_siem_connection",
+	"CUSTOMER_SPLUNK": "incapsula_siem_splunk_connection",
+	"CUSTOMER_SFTP":   "incapsula_siem_sftp_connection",
+}
+
+// ExportOptions selects what the export command walks through and where the configuration is written
+type ExportOptions struct {
+	// Directory the .tf files are written to, created if needed
+	OutputDir string
+
+	// Account to export, defaults to the default_account_id of the provider, then to the account of the API credentials
+	AccountID int
+
+	// Sites to export, all the sites of the account when empty
+	SiteIDs []int
+}
+
+// ExportResult sums up an export
+type ExportResult struct {
+	// Files written to the output directory
+	Files []string
+
+	// Number of resources written to the configuration
+	Resources int
+
+	// Resources or lists that could not be read, and were left out of the configuration
+	Warnings []string
+}
+
+// exportedResource is a resource read from the account, ready to be written as a resource block and an import block
+type exportedResource struct {
+	Type     string
+	Name     string
+	ImportID string
+	Body     string
+}
+
+func (r *exportedResource) address() string {
+	return r.Type + "." + r.Name
+}
+
+// exportedVariable is a variable declared for a sensitive attribute, whose value the API doesn't return
+type exportedVariable struct {
+	Name       string
+	Type       string
+	Resource   string
+	Attribute  string
+	IsRequired bool
+}
+
+type exporter struct {
+	provider  *schema.Provider
+	client    *Client
+	options   ExportOptions
+	accountID int
+
+	resources []*exportedResource
+	variables []*exportedVariable
+	names     map[string]bool
+	// siteAddresses maps the IDs of the exported sites to their resource address, so that other resources reference them
+	siteAddresses map[string]string
+	warnings      []string
+}
+
+// Export configures the provider from the environment, as Terraform would with an empty provider block,
+// then writes the configuration and the import blocks of the resources of the account
+func Export(ctx context.Context, options ExportOptions) (*ExportResult, error) {
+	provider := Provider()
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		return nil, fmt.Errorf("Error configuring the provider: %s", diagnosticsSummary(diags))
+	}
+	client, ok := provider.Meta().(*Client)
+	if !ok {
+		return nil, errors.New("Error configuring the provider: no API client")
+	}
+	return newExporter(provider, client, options).run(ctx)
+}
+
+func newExporter(provider *schema.Provider, client *Client, options ExportOptions) *exporter {
+	accountID := options.AccountID
+	if accountID == 0 && client.config != nil {
+		accountID = client.config.DefaultAccountID
+	}
+	if accountID == 0 && client.accountStatus != nil {
+		accountID = client.accountStatus.AccountID
+	}
+	return &exporter{
+		provider:      provider,
+		client:        client,
+		options:       options,
+		accountID:     accountID,
+		names:         map[string]bool{},
+		siteAddresses: map[string]string{},
+	}
+}
+
+func (e *exporter) warn(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	log.Printf("[WARN] Export: %s", message)
+	e.warnings = append(e.warnings, message)
+}
+
+func (e *exporter) run(ctx context.Context) (*ExportResult, error) {
+	siteIDs := e.options.SiteIDs
+	if len(siteIDs) == 0 {
+		var err error
+		siteIDs, err = e.listSites(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, siteID := range siteIDs {
+		e.exportSite(ctx, strconv.Itoa(siteID))
+	}
+
+	// Account level resources are only exported along with the whole account
+	if len(e.options.SiteIDs) == 0 {
+		e.exportPolicies(ctx)
+		e.exportSiem(ctx)
+	}
+
+	resourceTypes := make([]string, 0, len(exportUnsupportedResources))
+	for resourceType := range exportUnsupportedResources {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	for _, resourceType := range resourceTypes {
+		e.warn("%s resources are not exported, %s", resourceType, exportUnsupportedResources[resourceType])
+	}
+
+	files, err := e.write()
+	if err != nil {
+		return nil, err
+	}
+	return &ExportResult{Files: files, Resources: len(e.resources), Warnings: e.warnings}, nil
+}
+
+func (e *exporter) listSites(ctx context.Context) ([]int, error) {
+	sites, err := e.client.listAllSites(ctx, e.options.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	siteIDs := make([]int, len(sites))
+	for i, site := range sites {
+		siteIDs[i] = site.SiteID
+	}
+	return siteIDs, nil
+}
+
+func (e *exporter) exportSite(ctx context.Context, siteID string) {
+	site := e.exportResource(ctx, "incapsula_site", siteID, func(d *schema.ResourceData) string {
+		return d.Get("domain").(string)
+	})
+	siteName := "site_" + siteID
+	if site != nil {
+		e.siteAddresses[siteID] = site.address()
+		siteName = site.Name
+	}
+
+	e.exportResource(ctx, "incapsula_site_domain_configuration", siteID, func(d *schema.ResourceData) string {
+		return siteName
+	})
+
+	for page := 0; ; page++ {
+		incapRuleListResponse, err := e.client.ListIncapRules(ctx, siteID, exportPageSize, page)
+		if err != nil {
+			e.warn("could not list the incap rules of site %s: %s", siteID, err)
+			break
+		}
+		count := 0
+		for _, rules := range incapRuleListResponse.IncapRules {
+			for _, rule := range rules {
+				count++
+				resourceType := "incapsula_incap_rule"
+				if strings.HasPrefix(rule.Action, exportCacheRuleActionPrefix) {
+					resourceType = "incapsula_cache_rule"
+				}
+				e.exportResource(ctx, resourceType, siteID+"/"+rule.ID.String(), func(d *schema.ResourceData) string {
+					return siteName + "_" + d.Get("name").(string)
+				})
+			}
+		}
+		if count < exportPageSize {
+			break
+		}
+	}
+
+	for _, category := range exportDeliveryRuleCategories {
+		deliveryRulesListDTO, diags := e.client.ReadDeliveryRuleConfiguration(ctx, siteID, category)
+		if diags.HasError() || deliveryRulesListDTO == nil {
+			e.warn("could not read the %s delivery rules of site %s: %s", category, siteID, diagnosticsSummary(diags))
+			continue
+		}
+		if len(deliveryRulesListDTO.RulesList) == 0 {
+			continue
+		}
+		e.exportResource(ctx, "incapsula_delivery_rules_configuration", siteID+"/"+category, func(d *schema.ResourceData) string {
+			return siteName + "_" + category
+		})
+	}
+
+	e.exportResource(ctx, "incapsula_data_centers_configuration", siteID, func(d *schema.ResourceData) string {
+		return siteName
+	})
+	e.exportSecurityRuleExceptions(ctx, siteID, siteName)
+	e.exportWaitingRooms(ctx, siteID, siteName)
+}
+
+func (e *exporter) exportSecurityRuleExceptions(ctx context.Context, siteID string, siteName string) {
+	siteStatusResponse, err := e.client.ListSecurityRuleExceptions(ctx, siteID, "")
+	if err != nil {
+		e.warn("could not list the security rule exceptions of site %s: %s", siteID, err)
+		return
+	}
+
+	exceptionIDs := map[string][]int{}
+	for _, rule := range siteStatusResponse.Security.Acls.Rules {
+		for _, exception := range rule.Exceptions {
+			exceptionIDs[rule.ID] = append(exceptionIDs[rule.ID], exception.ID)
+		}
+	}
+	for _, rule := range siteStatusResponse.Security.Waf.Rules {
+		for _, exception := range rule.Exceptions {
+			exceptionIDs[rule.ID] = append(exceptionIDs[rule.ID], exception.ID)
+		}
+	}
+
+	ruleIDs := make([]string, 0, len(exceptionIDs))
+	for ruleID := range exceptionIDs {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+	for _, ruleID := range ruleIDs {
+		// The other rules, such as the whitelisted IPs, don't accept exceptions
+		if _, ok := securityRuleExceptionParamMapping[ruleID]; !ok {
+			continue
+		}
+		for _, exceptionID := range exceptionIDs[ruleID] {
+			e.exportResource(ctx, "incapsula_security_rule_exception", fmt.Sprintf("%s/%s/%d", siteID, ruleID, exceptionID), func(d *schema.ResourceData) string {
+				return siteName + "_" + strings.TrimPrefix(strings.TrimPrefix(ruleID, "api.threats."), "api.acl.")
+			})
+		}
+	}
+}
+
+func (e *exporter) exportWaitingRooms(ctx context.Context, siteID string, siteName string) {
+	accountID := strconv.Itoa(e.accountID)
+	waitingRooms, diags := e.client.ListWaitingRooms(ctx, accountID, siteID)
+	if diags.HasError() || waitingRooms == nil {
+		e.warn("could not list the waiting rooms of site %s: %s", siteID, diagnosticsSummary(diags))
+		return
+	}
+	for _, waitingRoom := range waitingRooms.Data {
+		e.exportResource(ctx, "incapsula_waiting_room", fmt.Sprintf("%s/%s/%d", accountID, siteID, waitingRoom.Id), func(d *schema.ResourceData) string {
+			return siteName + "_" + d.Get("name").(string)
+		})
+	}
+}
+
+func (e *exporter) exportPolicies(ctx context.Context) {
+	policies, err := e.client.GetAllPoliciesForAccount(ctx, strconv.Itoa(e.accountID))
+	if err != nil {
+		e.warn("could not list the policies of account %d: %s", e.accountID, err)
+		return
+	}
+	for _, policy := range *policies {
+		e.exportResource(ctx, "incapsula_policy", strconv.Itoa(policy.ID), func(d *schema.ResourceData) string {
+			return d.Get("name").(string)
+		})
+	}
+}
+
+func (e *exporter) exportSiem(ctx context.Context) {
+	accountID := strconv.Itoa(e.accountID)
+
+	connections, err := e.client.ListSiemConnections(ctx, accountID)
+	if err != nil {
+		e.warn("could not list the SIEM connections of account %d: %s", e.accountID, err)
+	}
+	for _, connection := range connections {
+		resourceType, ok := exportSiemConnectionTypes[connection.StorageType]
+		if !ok {
+			e.warn("SIEM connection %s has an unsupported storage type %s", connection.ID, connection.StorageType)
+			continue
+		}
+		e.exportResource(ctx, resourceType, accountID+"/"+connection.ID, func(d *schema.ResourceData) string {
+			return d.Get("connection_name").(string)
+		})
+	}
+
+	logConfigurations, err := e.client.ListSiemLogConfigurations(ctx, accountID)
+	if err != nil {
+		e.warn("could not list the SIEM log configurations of account %d: %s", e.accountID, err)
+		return
+	}
+	for _, logConfiguration := range logConfigurations.Data {
+		e.exportResource(ctx, "incapsula_siem_log_configuration", accountID+"/"+logConfiguration.ID, func(d *schema.ResourceData) string {
+			return d.Get("configuration_name").(string)
+		})
+	}
+}
+
+// exportResource imports and reads a resource the way terraform import does, then renders its configuration.
+// It returns nil when the resource could not be read or doesn't exist anymore
+func (e *exporter) exportResource(ctx context.Context, resourceType string, importID string, name func(d *schema.ResourceData) string) *exportedResource {
+	resource := e.provider.ResourcesMap[resourceType]
+	d := resource.Data(nil)
+	d.SetId(importID)
+
+	if resource.Importer != nil && resource.Importer.StateContext != nil {
+		imported, err := resource.Importer.StateContext(ctx, d, e.client)
+		if err != nil {
+			e.warn("could not import %s %s: %s", resourceType, importID, err)
+			return nil
+		}
+		d = imported[0]
+	}
+
+	var diags diag.Diagnostics
+	if resource.ReadContext != nil {
+		diags = resource.ReadContext(ctx, d, e.client)
+	} else if resource.Read != nil {
+		diags = diag.FromErr(resource.Read(d, e.client))
+	}
+	if diags.HasError() {
+		e.warn("could not read %s %s: %s", resourceType, importID, diagnosticsSummary(diags))
+		return nil
+	}
+	if d.Id() == "" {
+		e.warn("%s %s doesn't exist anymore", resourceType, importID)
+		return nil
+	}
+
+	exported := &exportedResource{Type: resourceType, ImportID: importID}
+	exported.Name = e.uniqueName(resourceType, name(d))
+	exported.Body = e.renderResource(exported, resource.Schema, d)
+	e.resources = append(e.resources, exported)
+	return exported
+}
+
+// diagnosticsSummary joins the errors of diagnostics into a single line
+func diagnosticsSummary(diags diag.Diagnostics) string {
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			summaries = append(summaries, strings.TrimSuffix(strings.TrimSpace(d.Summary+": "+d.Detail), ":"))
+		}
+	}
+	return strings.Join(summaries, "; ")
+}
+
+var exportNameInvalidCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// uniqueName turns a domain or display name into a resource name, unique among the resources of the same type
+func (e *exporter) uniqueName(resourceType string, base string) string {
+	name := strings.Trim(exportNameInvalidCharacters.ReplaceAllString(strings.ToLower(base), "_"), "_")
+	if name == "" {
+		name = strings.TrimPrefix(resourceType, "incapsula_")
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
+	unique := name
+	for i := 2; e.names[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	e.names[resourceType+"."+unique] = true
+	return unique
+}
+
+// write writes one file per resource type, the import blocks and the variables of the sensitive attributes
+func (e *exporter) write() ([]string, error) {
+	if err := os.MkdirAll(e.options.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("Error creating the output directory: %s", err)
+	}
+
+	contents := map[string]*strings.Builder{}
+	var fileNames []string
+	file := func(name string) *strings.Builder {
+		if _, ok := contents[name]; !ok {
+			contents[name] = &strings.Builder{}
+			contents[name].WriteString(exportFileHeader)
+			fileNames = append(fileNames, name)
+		}
+		return contents[name]
+	}
+
+	for _, resource := range e.resources {
+		fmt.Fprintf(file(resource.Type+".tf"), "\nresource %q %q {\n%s}\n", resource.Type, resource.Name, resource.Body)
+	}
+	for _, resource := range e.resources {
+		fmt.Fprintf(file("imports.tf"), "\nimport {\n  to = %s\n  id = %s\n}\n", resource.address(), hclString(resource.ImportID))
+	}
+	for _, variable := range e.variables {
+		fmt.Fprintf(file("variables.tf"), "\nvariable %q {\n  description = %s\n  type        = %s\n  sensitive   = true\n",
+			variable.Name, hclString(fmt.Sprintf("%s of %s, not returned by the API", variable.Attribute, variable.Resource)), variable.Type)
+		if !variable.IsRequired {
+			file("variables.tf").WriteString("  default     = null\n")
+		}
+		file("variables.tf").WriteString("}\n")
+	}
+
+	sort.Strings(fileNames)
+	for _, name := range fileNames {
+		if err := os.WriteFile(filepath.Join(e.options.OutputDir, name), []byte(contents[name].String()), 0644); err != nil {
+			return nil, fmt.Errorf("Error writing %s: %s", name, err)
+		}
+	}
+	return fileNames, nil
+}
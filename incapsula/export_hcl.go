@@ -0,0 +1,206 @@
+package incapsula
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const exportIndent = "  "
+
+var hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// hclString quotes a string for HCL, escaping the template sequences so that the value is kept as is
+func hclString(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{")
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// hclValue renders a primitive, list or map value of the state
+func hclValue(value interface{}, indent string) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return hclString(v)
+	case *schema.Set:
+		return hclValue(v.List(), indent)
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = hclValue(item, indent)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return "{}"
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var lines []string
+		for _, key := range keys {
+			name := key
+			if !hclIdentifier.MatchString(key) {
+				name = hclString(key)
+			}
+			lines = append(lines, fmt.Sprintf("%s%s%s = %s", indent, exportIndent, name, hclValue(v[key], indent+exportIndent)))
+		}
+		return "{\n" + strings.Join(lines, "\n") + "\n" + indent + "}"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isZeroValue reports whether a value of the state is the zero value of its type, i.e. most likely not configured
+func isZeroValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case *schema.Set:
+		return v.Len() == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// shouldExport reports whether an attribute is written to the configuration.
+// Read only attributes are left out, as well as optional ones holding their default or zero value
+func shouldExport(s *schema.Schema, value interface{}) bool {
+	if !s.Required && !s.Optional {
+		return false
+	}
+	if s.Deprecated != "" {
+		return false
+	}
+	if s.Required {
+		return true
+	}
+	if s.Default != nil {
+		return fmt.Sprint(value) != fmt.Sprint(s.Default)
+	}
+	return !isZeroValue(value)
+}
+
+func hclVariableType(s *schema.Schema) string {
+	switch s.Type {
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeInt, schema.TypeFloat:
+		return "number"
+	case schema.TypeList, schema.TypeSet:
+		return "list(string)"
+	case schema.TypeMap:
+		return "map(string)"
+	}
+	return "string"
+}
+
+// renderResource renders the body of the resource block of an exported resource
+func (e *exporter) renderResource(resource *exportedResource, schemaMap map[string]*schema.Schema, d *schema.ResourceData) string {
+	var body strings.Builder
+	e.renderBlock(&body, resource, "", exportIndent, schemaMap, d.Get)
+	return body.String()
+}
+
+// renderBlock renders the attributes and nested blocks of a schema, attributes first, with their equal signs aligned as terraform fmt does
+func (e *exporter) renderBlock(body *strings.Builder, resource *exportedResource, path string, indent string, schemaMap map[string]*schema.Schema, get func(string) interface{}) {
+	keys := make([]string, 0, len(schemaMap))
+	for key := range schemaMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	type attribute struct{ name, value string }
+	var attributes []attribute
+	var blocks strings.Builder
+	exported := map[string]bool{}
+
+	for _, key := range keys {
+		s := schemaMap[key]
+		value := get(key)
+
+		if nested, ok := s.Elem.(*schema.Resource); ok && (s.Type == schema.TypeList || s.Type == schema.TypeSet) {
+			if !s.Required && !s.Optional {
+				continue
+			}
+			items := value
+			if set, ok := value.(*schema.Set); ok {
+				items = set.List()
+			}
+			for i, item := range items.([]interface{}) {
+				fields, _ := item.(map[string]interface{})
+				fmt.Fprintf(&blocks, "\n%s%s {\n", indent, key)
+				e.renderBlock(&blocks, resource, fmt.Sprintf("%s%s.%d.", path, key, i), indent+exportIndent, nested.Schema, func(field string) interface{} {
+					return fields[field]
+				})
+				fmt.Fprintf(&blocks, "%s}\n", indent)
+			}
+			continue
+		}
+
+		if !shouldExport(s, value) || conflictsWithExported(s, path, exported) {
+			continue
+		}
+		exported[key] = true
+
+		switch {
+		case s.Sensitive:
+			attributes = append(attributes, attribute{key, "var." + e.addVariable(resource, path+key, s)})
+		case key == "site_id" && e.siteAddresses[fmt.Sprint(value)] != "" && resource.Type != "incapsula_site":
+			attributes = append(attributes, attribute{key, e.siteAddresses[fmt.Sprint(value)] + ".id"})
+		default:
+			attributes = append(attributes, attribute{key, hclValue(value, indent)})
+		}
+	}
+
+	width := 0
+	for _, a := range attributes {
+		if len(a.name) > width {
+			width = len(a.name)
+		}
+	}
+	for _, a := range attributes {
+		fmt.Fprintf(body, "%s%-*s = %s\n", indent, width, a.name, a.value)
+	}
+	body.WriteString(blocks.String())
+}
+
+// conflictsWithExported reports whether an attribute conflicts with an attribute already written, since the state may hold both
+func conflictsWithExported(s *schema.Schema, path string, exported map[string]bool) bool {
+	for _, conflict := range append(append([]string{}, s.ConflictsWith...), s.ExactlyOneOf...) {
+		if exported[strings.TrimPrefix(conflict, path)] {
+			return true
+		}
+	}
+	return false
+}
+
+// addVariable declares the variable holding a sensitive attribute, the API either masks these values or they must not end up in the configuration
+func (e *exporter) addVariable(resource *exportedResource, attribute string, s *schema.Schema) string {
+	name := strings.TrimPrefix(resource.Type, "incapsula_") + "_" + resource.Name + "_" + strings.ReplaceAll(attribute, ".", "_")
+	e.variables = append(e.variables, &exportedVariable{
+		Name:       name,
+		Type:       hclVariableType(s),
+		Resource:   resource.address(),
+		Attribute:  attribute,
+		IsRequired: s.Required,
+	})
+	return name
+}
@@ -0,0 +1,264 @@
+package incapsula
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+////////////////////////////////////////////////////////////////
+// HCL rendering tests
+////////////////////////////////////////////////////////////////
+
+func TestHclString(t *testing.T) {
+	cases := map[string]string{
+		`plain`:                `"plain"`,
+		`URL == "/admin"`:      `"URL == \"/admin\""`,
+		"line\nbreak\ttab":     `"line\nbreak\ttab"`,
+		`back\slash`:           `"back\\slash"`,
+		"${var.x} and %{ if }": `"$${var.x} and %%{ if }"`,
+	}
+	for value, expected := range cases {
+		if actual := hclString(value); actual != expected {
+			t.Errorf("hclString(%q) = %s, expected %s", value, actual, expected)
+		}
+	}
+}
+
+func TestExportUniqueName(t *testing.T) {
+	e := newExporter(nil, &Client{}, ExportOptions{})
+
+	names := []string{
+		e.uniqueName("incapsula_site", "www.Example.com"),
+		e.uniqueName("incapsula_site", "www.example.com"),
+		e.uniqueName("incapsula_site", "1.example.com"),
+		e.uniqueName("incapsula_site", "..."),
+		e.uniqueName("incapsula_policy", "www.example.com"),
+	}
+	expected := []string{"www_example_com", "www_example_com_2", "_1_example_com", "site", "www_example_com"}
+	for i := range names {
+		if names[i] != expected[i] {
+			t.Errorf("Unexpected name %d, expected %s, got: %s", i, expected[i], names[i])
+		}
+	}
+}
+
+func TestExportShouldExport(t *testing.T) {
+	computed := &schema.Schema{Type: schema.TypeString, Computed: true}
+	required := &schema.Schema{Type: schema.TypeString, Required: true}
+	optional := &schema.Schema{Type: schema.TypeString, Optional: true}
+	withDefault := &schema.Schema{Type: schema.TypeInt, Optional: true, Default: 10}
+	deprecated := &schema.Schema{Type: schema.TypeString, Optional: true, Deprecated: "use something else"}
+
+	if shouldExport(computed, "value") {
+		t.Errorf("Computed attributes should not be exported")
+	}
+	if !shouldExport(required, "") {
+		t.Errorf("Required attributes should always be exported")
+	}
+	if shouldExport(optional, "") || !shouldExport(optional, "value") {
+		t.Errorf("Optional attributes should only be exported when set")
+	}
+	if shouldExport(withDefault, 10) || !shouldExport(withDefault, 0) {
+		t.Errorf("Attributes with a default should only be exported when they differ from it")
+	}
+	if shouldExport(deprecated, "value") {
+		t.Errorf("Deprecated attributes should not be exported")
+	}
+}
+
+func TestExportRenderBlock(t *testing.T) {
+	e := newExporter(nil, &Client{}, ExportOptions{})
+	e.siteAddresses["42"] = "incapsula_site.www_example_com"
+
+	schemaMap := map[string]*schema.Schema{
+		"site_id":  {Type: schema.TypeString, Required: true},
+		"name":     {Type: schema.TypeString, Required: true},
+		"password": {Type: schema.TypeString, Optional: true, Sensitive: true},
+		"host":     {Type: schema.TypeString, Optional: true, ConflictsWith: []string{"hosts"}},
+		"hosts":    {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}, ConflictsWith: []string{"host"}},
+		"id_only":  {Type: schema.TypeString, Computed: true},
+		"header": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name":  {Type: schema.TypeString, Required: true},
+					"value": {Type: schema.TypeString, Optional: true, Sensitive: true},
+				},
+			},
+		},
+	}
+	values := map[string]interface{}{
+		"site_id":  "42",
+		"name":     "my rule",
+		"password": "secret",
+		"host":     "a.example.com",
+		"hosts":    []interface{}{"a.example.com"},
+		"id_only":  "123",
+		"header":   []interface{}{map[string]interface{}{"name": "X-Token", "value": "secret"}},
+	}
+
+	resource := &exportedResource{Type: "incapsula_test", Name: "my_rule"}
+	var body strings.Builder
+	e.renderBlock(&body, resource, "", exportIndent, schemaMap, func(key string) interface{} { return values[key] })
+
+	expected := `  host     = "a.example.com"
+  name     = "my rule"
+  password = var.test_my_rule_password
+  site_id  = incapsula_site.www_example_com.id
+
+  header {
+    name  = "X-Token"
+    value = var.test_my_rule_header_0_value
+  }
+`
+	if body.String() != expected {
+		t.Errorf("Unexpected block, expected:\n%s\ngot:\n%s", expected, body.String())
+	}
+	var attributes []string
+	for _, variable := range e.variables {
+		attributes = append(attributes, variable.Attribute)
+	}
+	if strings.Join(attributes, ",") != "header.0.value,password" {
+		t.Errorf("Unexpected variables: %v", attributes)
+	}
+}
+
+////////////////////////////////////////////////////////////////
+// Export tests against the fake Imperva API
+////////////////////////////////////////////////////////////////
+
+func TestExportFakeAPI(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "export.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+	rule := &IncapRule{Name: "Block admin", Action: "RULE_ACTION_BLOCK", Filter: `URL == "/admin"`, Enabled: true}
+	ruleWithID, err := client.AddIncapRule(ctx, siteID, rule)
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the rule, got: %s", err)
+	}
+
+	outputDir := t.TempDir()
+	options := ExportOptions{OutputDir: outputDir, AccountID: server.AccountID, SiteIDs: []int{siteAddResponse.SiteID}}
+	result, err := newExporter(Provider(), client, options).run(ctx)
+	if err != nil {
+		t.Fatalf("Should not have received an error exporting, got: %s", err)
+	}
+
+	rules, err := os.ReadFile(filepath.Join(outputDir, "incapsula_incap_rule.tf"))
+	if err != nil {
+		t.Fatalf("The incap rules should have been written, got: %s (warnings: %v)", err, result.Warnings)
+	}
+	for _, expected := range []string{`resource "incapsula_incap_rule" "export_example_com_block_admin"`, `filter     = "URL == \"/admin\""`, `site_id    = incapsula_site.export_example_com.id`, `account_id = 1000`} {
+		if !strings.Contains(string(rules), expected) {
+			t.Errorf("The incap rules should contain %s, got:\n%s", expected, rules)
+		}
+	}
+
+	sites, err := os.ReadFile(filepath.Join(outputDir, "incapsula_site.tf"))
+	if err != nil || !strings.Contains(string(sites), `resource "incapsula_site" "export_example_com"`) {
+		t.Errorf("The site should have been written, got: %s %s", sites, err)
+	}
+
+	imports, err := os.ReadFile(filepath.Join(outputDir, "imports.tf"))
+	if err != nil {
+		t.Fatalf("The import blocks should have been written, got: %s", err)
+	}
+	expectedImport := `id = "` + siteID + "/" + strconv.Itoa(ruleWithID.RuleID) + `"`
+	if !strings.Contains(string(imports), expectedImport) {
+		t.Errorf("The import blocks should contain %s, got:\n%s", expectedImport, imports)
+	}
+}
+
+func TestExportFakeAPISiteResources(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "resources.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+	cacheRule := &CacheRule{Name: "Cache images", Action: "HTTP_CACHE_MAKE_STATIC", Filter: `URL == "/images"`, Enabled: true, TTL: 60}
+	if _, err := client.AddCacheRule(ctx, siteID, cacheRule); err != nil {
+		t.Fatalf("Should not have received an error adding the cache rule, got: %s", err)
+	}
+	if _, err := client.AddSecurityRuleException(ctx, siteAddResponse.SiteID, sqlInjectionExceptionRuleID, "", "", "", "", "", "/login", "", ""); err != nil {
+		t.Fatalf("Should not have received an error adding the security rule exception, got: %s", err)
+	}
+	waitingRoom := &WaitingRoomDTO{Name: "Sales", Enabled: true, ThresholdSettings: ThresholdSettings{EntranceRateEnabled: true, EntranceRateThreshold: 100}}
+	if _, diags := client.CreateWaitingRoom(ctx, strconv.Itoa(server.AccountID), siteID, waitingRoom); diags.HasError() {
+		t.Fatalf("Should not have received an error adding the waiting room, got: %v", diags)
+	}
+
+	outputDir := t.TempDir()
+	options := ExportOptions{OutputDir: outputDir, AccountID: server.AccountID, SiteIDs: []int{siteAddResponse.SiteID}}
+	result, err := newExporter(Provider(), client, options).run(ctx)
+	if err != nil {
+		t.Fatalf("Should not have received an error exporting, got: %s", err)
+	}
+
+	expectedResources := map[string]string{
+		"incapsula_cache_rule":                 `resource "incapsula_cache_rule" "resources_example_com_cache_images"`,
+		"incapsula_security_rule_exception":    `resource "incapsula_security_rule_exception" "resources_example_com_sql_injection"`,
+		"incapsula_waiting_room":               `resource "incapsula_waiting_room" "resources_example_com_sales"`,
+		"incapsula_data_centers_configuration": `resource "incapsula_data_centers_configuration" "resources_example_com"`,
+	}
+	for resourceType, expected := range expectedResources {
+		content, err := os.ReadFile(filepath.Join(outputDir, resourceType+".tf"))
+		if err != nil || !strings.Contains(string(content), expected) {
+			t.Errorf("The %s resources should contain %s, got: %s %v (warnings: %v)", resourceType, expected, content, err, result.Warnings)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "incapsula_incap_rule.tf")); err == nil {
+		t.Errorf("The cache rule should not have been exported as an incap rule")
+	}
+
+	found := false
+	for _, warning := range result.Warnings {
+		if strings.HasPrefix(warning, "incapsula_custom_certificate resources are not exported") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Should have warned about the custom certificates, got: %v", result.Warnings)
+	}
+}
+
+func TestExportFakeAPIListSites(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	for _, domain := range []string{"a.example.com", "b.example.com", "c.example.com"} {
+		if _, err := client.AddSite(ctx, domain, "", "false", "", "false", 0, false, false, ""); err != nil {
+			t.Fatalf("Should not have received an error adding the site, got: %s", err)
+		}
+	}
+
+	siteListResponse, err := client.ListSites(ctx, server.AccountID, 2, 1)
+	if err != nil {
+		t.Fatalf("Should not have received an error listing the sites, got: %s", err)
+	}
+	if len(siteListResponse.Sites) != 1 || siteListResponse.Sites[0].Domain != "c.example.com" {
+		t.Errorf("Unexpected second page of sites: %+v", siteListResponse.Sites)
+	}
+
+	siteIDs, err := newExporter(Provider(), client, ExportOptions{}).listSites(ctx)
+	if err != nil {
+		t.Fatalf("Should not have received an error listing the sites, got: %s", err)
+	}
+	if len(siteIDs) != 3 {
+		t.Errorf("Should have listed 3 sites, got: %v", siteIDs)
+	}
+}
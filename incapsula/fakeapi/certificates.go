@@ -0,0 +1,250 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sanStatusPending   = "PENDING_USER_ACTION"
+	sanStatusValidated = "VALIDATED"
+
+	certificateStatusInProcess = "IN_PROCESS"
+	certificateStatusActive    = "ACTIVE"
+)
+
+var validationMethods = map[string]bool{"CNAME": true, "DNS": true, "EMAIL": true, "HTML": true}
+
+// siteCertificate is the Imperva managed certificate requested for a site
+type siteCertificate struct {
+	ValidationMethod string
+	ID               int
+	Status           string
+	ExpirationDate   int64
+	Sans             []*san
+}
+
+type san struct {
+	ID               int    `json:"sanId"`
+	Value            string `json:"sanValue"`
+	ValidationMethod string `json:"validationMethod"`
+	Status           string `json:"status"`
+	StatusDate       int64  `json:"statusDate"`
+	VerificationCode string `json:"verificationCode,omitempty"`
+	CnameValue       string `json:"cnameValidationValue,omitempty"`
+	DomainIDs        []int  `json:"domainIds"`
+}
+
+func (s *Server) registerCertificates(mux *http.ServeMux) {
+	mux.HandleFunc("GET /certificates-ui/v3/sites/{siteId}/certificates/managed", s.handleCertificateRead)
+	mux.HandleFunc("POST /certificates-ui/v3/sites/{siteId}/certificates/managed", s.handleCertificateRequest)
+	mux.HandleFunc("DELETE /certificates-ui/v3/sites/{siteId}/certificates/managed", s.handleCertificateDelete)
+	mux.HandleFunc("POST /certificates-ui/v3/sites/{siteId}/certificates/managed/validate", s.handleCertificateValidate)
+	mux.HandleFunc("GET /certificates-ui/v3/instructions/all", s.handleSSLInstructions)
+}
+
+func (s *Server) newSiteCertificate(site *site, validationMethod string) *siteCertificate {
+	certificate := &siteCertificate{
+		ValidationMethod: validationMethod,
+		ID:               s.newID(),
+		Status:           certificateStatusInProcess,
+		ExpirationDate:   time.Now().AddDate(1, 0, 0).UnixMilli(),
+	}
+	values := []string{site.Domain}
+	if strings.HasPrefix(site.Domain, "www.") {
+		values = append(values, strings.TrimPrefix(site.Domain, "www."))
+	}
+	for _, value := range values {
+		sanID := s.newID()
+		certificate.Sans = append(certificate.Sans, &san{
+			ID:               sanID,
+			Value:            value,
+			ValidationMethod: validationMethod,
+			Status:           sanStatusPending,
+			StatusDate:       time.Now().UnixMilli(),
+			VerificationCode: fmt.Sprintf("globalsign-domain-verification=fake-%d", sanID),
+			CnameValue:       fmt.Sprintf("_%d.%s", sanID, value),
+			DomainIDs:        []int{sanID},
+		})
+	}
+	return certificate
+}
+
+func writeSiteCertificate(w http.ResponseWriter, status int, siteID int, certificate *siteCertificate) {
+	details := map[string]interface{}{
+		"id":             certificate.ID,
+		"name":           fmt.Sprintf("Imperva managed certificate %d", certificate.ID),
+		"status":         certificate.Status,
+		"type":           "ATLAS",
+		"expirationDate": certificate.ExpirationDate,
+		"sans":           certificate.Sans,
+	}
+	writeJSON(w, status, map[string]interface{}{"data": []interface{}{map[string]interface{}{
+		"siteId":                  siteID,
+		"defaultValidationMethod": certificate.ValidationMethod,
+		"certificateDetails":      []interface{}{details},
+	}}})
+}
+
+// siteCertificateFromPath returns the site and its certificate request, writing the JSON:API error when there is none
+func (s *Server) siteCertificateFromPath(w http.ResponseWriter, r *http.Request) (*site, *siteCertificate, bool) {
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	certificate, ok := s.certificates[site.ID]
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "", "Not Found", fmt.Sprintf("No managed certificate was requested for site %d", site.ID))
+		return nil, nil, false
+	}
+	return site, certificate, true
+}
+
+func (s *Server) handleCertificateRequest(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		DefaultValidationMethod string `json:"defaultValidationMethod"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeErrors(w, http.StatusBadRequest, "", "Bad Request", fmt.Sprintf("Invalid request body: %s", err))
+		return
+	}
+	if request.DefaultValidationMethod == "" {
+		request.DefaultValidationMethod = "CNAME"
+	}
+	if !validationMethods[request.DefaultValidationMethod] {
+		writeErrors(w, http.StatusBadRequest, "/defaultValidationMethod", "Bad Request", fmt.Sprintf("Unsupported validation method %s", request.DefaultValidationMethod))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	if _, exists := s.certificates[site.ID]; exists {
+		writeErrors(w, http.StatusConflict, "", "Conflict", fmt.Sprintf("A managed certificate was already requested for site %d", site.ID))
+		return
+	}
+
+	certificate := s.newSiteCertificate(site, request.DefaultValidationMethod)
+	s.certificates[site.ID] = certificate
+	writeSiteCertificate(w, http.StatusOK, site.ID, certificate)
+}
+
+func (s *Server) handleCertificateRead(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, certificate, ok := s.siteCertificateFromPath(w, r)
+	if !ok {
+		return
+	}
+	writeSiteCertificate(w, http.StatusOK, site.ID, certificate)
+}
+
+func (s *Server) handleCertificateDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, certificate, ok := s.siteCertificateFromPath(w, r)
+	if !ok {
+		return
+	}
+	delete(s.certificates, site.ID)
+	writeSiteCertificate(w, http.StatusOK, site.ID, certificate)
+}
+
+// handleCertificateValidate validates the SANs of the given domain IDs right away, the certificate is active once all its SANs are validated
+func (s *Server) handleCertificateValidate(w http.ResponseWriter, r *http.Request) {
+	var domainIDs []int
+	if err := json.NewDecoder(r.Body).Decode(&domainIDs); err != nil {
+		writeErrors(w, http.StatusBadRequest, "", "Bad Request", fmt.Sprintf("Invalid request body: %s", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, certificate, ok := s.siteCertificateFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	sans := map[int]*san{}
+	for _, san := range certificate.Sans {
+		for _, domainID := range san.DomainIDs {
+			sans[domainID] = san
+		}
+	}
+	for _, domainID := range domainIDs {
+		if _, ok := sans[domainID]; !ok {
+			writeErrors(w, http.StatusBadRequest, "/domainIds", "Bad Request", fmt.Sprintf("Unknown domain id %d", domainID))
+			return
+		}
+	}
+
+	validated := true
+	for _, domainID := range domainIDs {
+		sans[domainID].Status = sanStatusValidated
+		sans[domainID].StatusDate = time.Now().UnixMilli()
+	}
+	for _, san := range certificate.Sans {
+		validated = validated && san.Status == sanStatusValidated
+	}
+	if validated {
+		certificate.Status = certificateStatusActive
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// handleSSLInstructions returns the DNS records validating the SANs of the managed certificate of a site, for the SANs
+// pending validation
+func (s *Server) handleSSLInstructions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	siteID, err := strconv.Atoi(r.URL.Query().Get("extSiteId"))
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "/extSiteId", "Bad Request", fmt.Sprintf("Invalid site id %s", r.URL.Query().Get("extSiteId")))
+		return
+	}
+	if _, ok := s.sites[siteID]; !ok {
+		writeErrors(w, http.StatusNotFound, "", "Not Found", fmt.Sprintf("Site %d was not found", siteID))
+		return
+	}
+
+	instructions := []interface{}{}
+	if certificate, ok := s.certificates[siteID]; ok {
+		for _, san := range certificate.Sans {
+			if san.Status != sanStatusPending {
+				continue
+			}
+			instruction := map[string]interface{}{
+				"validationMethod":   san.ValidationMethod,
+				"certificateLevel":   "SITE",
+				"relatedSansDetails": []interface{}{map[string]interface{}{"sanId": san.ID, "sanValue": san.Value, "domainIds": san.DomainIDs}},
+			}
+			switch san.ValidationMethod {
+			case "CNAME":
+				instruction["domain"] = san.CnameValue
+				instruction["recordType"] = "CNAME"
+				instruction["verificationCode"] = fmt.Sprintf("_%d.validation.incapdns.net", san.ID)
+			case "DNS":
+				instruction["domain"] = san.Value
+				instruction["recordType"] = "TXT"
+				instruction["verificationCode"] = san.VerificationCode
+			default:
+				continue
+			}
+			instructions = append(instructions, instruction)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": instructions})
+}
@@ -0,0 +1,190 @@
+package fakeapi
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	hostingTypeImperva = "IMPERVA"
+	hostingTypeHSM     = "HSM"
+)
+
+// customCertificate is the custom certificate of a site, uploaded to Imperva or stored in an HSM
+type customCertificate struct {
+	ID             int
+	HostingType    string
+	InputHash      string
+	Hostnames      []string
+	ExpirationDate int64
+}
+
+func (s *Server) registerCustomCertificates(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/prov/v1/sites/customCertificate/upload", s.handleCustomCertificateUpload)
+	mux.HandleFunc("POST /api/prov/v1/sites/customCertificate/remove", s.handleCustomCertificateRemove)
+	mux.HandleFunc("PUT /api/prov/v2/sites/{siteId}/hsmCertificate", s.handleHSMCertificatePut)
+	mux.HandleFunc("DELETE /api/prov/v2/sites/{siteId}/hsmCertificate", s.handleHSMCertificateDelete)
+	mux.HandleFunc("GET /certificates-ui/v3/certificates", s.handleCertificatesDetails)
+}
+
+// newCustomCertificate parses a PEM or DER certificate, either in base64 format or as is. PFX files are not supported
+func (s *Server) newCustomCertificate(certificate string, hostingType string) (*customCertificate, error) {
+	content := []byte(certificate)
+	if decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(certificate), "")); err == nil {
+		content = decoded
+	}
+	if block, _ := pem.Decode(content); block != nil {
+		content = block.Bytes
+	}
+	parsed, err := x509.ParseCertificate(content)
+	if err != nil {
+		return nil, err
+	}
+	hostnames := append([]string{}, parsed.DNSNames...)
+	if len(hostnames) == 0 && parsed.Subject.CommonName != "" {
+		hostnames = []string{parsed.Subject.CommonName}
+	}
+	return &customCertificate{
+		ID:             s.newID(),
+		HostingType:    hostingType,
+		Hostnames:      hostnames,
+		ExpirationDate: parsed.NotAfter.UnixMilli(),
+	}, nil
+}
+
+func (s *Server) handleCustomCertificateUpload(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromForm(w, r)
+	if !ok {
+		return
+	}
+	certificate, err := s.newCustomCertificate(r.FormValue("certificate"), hostingTypeImperva)
+	if err != nil {
+		writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"certificate": fmt.Sprintf("invalid certificate: %s", err)})
+		return
+	}
+	certificate.InputHash = r.FormValue("input_hash")
+	site.CustomCertificate = certificate
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"res": resOK, "res_message": "OK"})
+}
+
+func (s *Server) handleCustomCertificateRemove(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromForm(w, r)
+	if !ok {
+		return
+	}
+	site.CustomCertificate = nil
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"res": resOK, "res_message": "OK"})
+}
+
+func (s *Server) handleHSMCertificatePut(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Data struct {
+			Certificate string `json:"certificate"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeErrors(w, http.StatusBadRequest, "", "Bad Request", fmt.Sprintf("Invalid request body: %s", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	certificate, err := s.newCustomCertificate(request.Data.Certificate, hostingTypeHSM)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "/data/certificate", "Bad Request", fmt.Sprintf("Invalid certificate: %s", err))
+		return
+	}
+	certificate.InputHash = r.URL.Query().Get("input_hash")
+	site.CustomCertificate = certificate
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"res": resOK, "res_message": "OK"})
+}
+
+func (s *Server) handleHSMCertificateDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	site.CustomCertificate = nil
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"res": resOK, "res_message": "OK"})
+}
+
+// handleCertificatesDetails lists the custom and managed certificates covering the site of the extSiteId query param
+func (s *Server) handleCertificatesDetails(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	siteID, err := strconv.Atoi(r.URL.Query().Get("extSiteId"))
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, "/extSiteId", "Bad Request", fmt.Sprintf("Invalid site id %s", r.URL.Query().Get("extSiteId")))
+		return
+	}
+	site, ok := s.sites[siteID]
+	if !ok || (r.URL.Query().Get("caid") != "" && site.AccountID != s.accountID(r)) {
+		writeErrors(w, http.StatusNotFound, "", "Not Found", fmt.Sprintf("Site %d was not found", siteID))
+		return
+	}
+
+	certificates := []interface{}{}
+	if custom := site.CustomCertificate; custom != nil {
+		sans := []interface{}{}
+		for _, hostname := range custom.Hostnames {
+			sans = append(sans, map[string]interface{}{"sanValue": hostname, "expirationDate": custom.ExpirationDate, "status": sanStatusValidated})
+		}
+		certificates = append(certificates, map[string]interface{}{
+			"id":             custom.ID,
+			"name":           fmt.Sprintf("Custom certificate %d", custom.ID),
+			"status":         certificateStatusActive,
+			"type":           "CUSTOM",
+			"hostingType":    custom.HostingType,
+			"expirationDate": custom.ExpirationDate,
+			"sans":           sans,
+		})
+	}
+	if managed, ok := s.certificates[site.ID]; ok {
+		certificates = append(certificates, map[string]interface{}{
+			"id":             managed.ID,
+			"name":           fmt.Sprintf("Imperva managed certificate %d", managed.ID),
+			"status":         managed.Status,
+			"type":           "ATLAS",
+			"expirationDate": managed.ExpirationDate,
+			"sans":           managed.Sans,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": certificates})
+}
+
+// customCertificateStatus is the ssl.custom_certificate section of sites/status
+func (s *site) customCertificateStatus() map[string]interface{} {
+	if s.CustomCertificate == nil {
+		return map[string]interface{}{"active": false}
+	}
+	return map[string]interface{}{
+		"active":         true,
+		"inputHash":      s.CustomCertificate.InputHash,
+		"expirationDate": s.CustomCertificate.ExpirationDate,
+	}
+}
@@ -0,0 +1,40 @@
+package fakeapi
+
+import (
+	"net/http"
+	"sort"
+)
+
+func (s *Server) registerDomains(mux *http.ServeMux) {
+	mux.HandleFunc("GET /site-domain-manager/v2/sites/{siteId}/domains", s.handleDomainsList)
+}
+
+// handleDomainsList lists the domains of a site. The domains are not managed by the fake, they are the SANs of the
+// managed certificate of the site, with their domain IDs, and there is none before the certificate is requested
+func (s *Server) handleDomainsList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var sans []*san
+	if certificate, ok := s.certificates[site.ID]; ok {
+		sans = append(sans, certificate.Sans...)
+	}
+	sort.Slice(sans, func(i, j int) bool { return sans[i].DomainIDs[0] < sans[j].DomainIDs[0] })
+
+	domains := []interface{}{}
+	for _, san := range sans {
+		domains = append(domains, map[string]interface{}{
+			"id":         san.DomainIDs[0],
+			"siteId":     site.ID,
+			"domain":     san.Value,
+			"mainDomain": san.Value == site.Domain,
+			"managed":    true,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": domains})
+}
@@ -0,0 +1,145 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+var policyTypes = map[string]bool{"ACL": true, "WHITELIST": true, "WAF_RULES": true, "NOTIFICATION": true}
+
+func (s *Server) registerPolicies(mux *http.ServeMux) {
+	mux.HandleFunc("POST /policies/v2/policies", s.handlePolicyAdd)
+	mux.HandleFunc("GET /policies/v2/policies", s.handlePolicyList)
+	mux.HandleFunc("GET /policies/v2/policies/{policyId}", s.handlePolicyRead)
+	mux.HandleFunc("PUT /policies/v2/policies/{policyId}", s.handlePolicyUpdate)
+	mux.HandleFunc("DELETE /policies/v2/policies/{policyId}", s.handlePolicyDelete)
+}
+
+// writePolicyError writes the error envelope of the policies API
+func writePolicyError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"value": nil, "isError": true, "message": message})
+}
+
+// decodePolicy parses and validates the policy of the request body
+func decodePolicy(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	var policy map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		writePolicyError(w, http.StatusBadRequest, fmt.Sprintf("Invalid policy: %s", err))
+		return nil, false
+	}
+	if name, _ := policy["name"].(string); name == "" {
+		writePolicyError(w, http.StatusBadRequest, "Invalid policy: name is required")
+		return nil, false
+	}
+	if policyType, _ := policy["policyType"].(string); !policyTypes[policyType] {
+		writePolicyError(w, http.StatusBadRequest, fmt.Sprintf("Invalid policy: unknown policyType %v", policy["policyType"]))
+		return nil, false
+	}
+	if policy["policySettings"] == nil {
+		policy["policySettings"] = []interface{}{}
+	}
+	if policy["defaultPolicyConfig"] == nil {
+		policy["defaultPolicyConfig"] = []interface{}{}
+	}
+	return policy, true
+}
+
+// accountPolicy returns the policy of the policyId path value, writing the error when it doesn't exist in the account
+func (s *Server) accountPolicy(w http.ResponseWriter, r *http.Request) (int, map[string]interface{}, bool) {
+	policyID, ok := pathID(r, "policyId")
+	if !ok {
+		writePolicyError(w, http.StatusBadRequest, fmt.Sprintf("Invalid policy id %s", r.PathValue("policyId")))
+		return 0, nil, false
+	}
+	policy, ok := s.policies[policyID]
+	if !ok || (r.URL.Query().Get("caid") != "" && policy["accountId"] != s.accountID(r)) {
+		writePolicyError(w, http.StatusNotFound, fmt.Sprintf("Policy with id %d was not found", policyID))
+		return 0, nil, false
+	}
+	return policyID, policy, true
+}
+
+func (s *Server) handlePolicyAdd(w http.ResponseWriter, r *http.Request) {
+	policy, ok := decodePolicy(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policyID := s.newID()
+	policy["id"] = policyID
+	policy["accountId"] = s.accountID(r)
+	policy["isMarkedAsDefault"] = false
+	s.policies[policyID] = policy
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": policy, "isError": false})
+}
+
+func (s *Server) handlePolicyList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountID := s.accountID(r)
+	policies := []interface{}{}
+	ids := make([]int, 0, len(s.policies))
+	for id := range s.policies {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if s.policies[id]["accountId"] == accountID {
+			policies = append(policies, s.policies[id])
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": policies, "isError": false})
+}
+
+func (s *Server) handlePolicyRead(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, policy, ok := s.accountPolicy(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": policy, "isError": false})
+}
+
+func (s *Server) handlePolicyUpdate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policyID, existing, ok := s.accountPolicy(w, r)
+	if !ok {
+		return
+	}
+	policy, ok := decodePolicy(w, r)
+	if !ok {
+		return
+	}
+
+	policy["id"] = policyID
+	policy["accountId"] = existing["accountId"]
+	policy["isMarkedAsDefault"] = existing["isMarkedAsDefault"]
+	s.policies[policyID] = policy
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": policy, "isError": false})
+}
+
+func (s *Server) handlePolicyDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policyID, _, ok := s.accountPolicy(w, r)
+	if !ok {
+		return
+	}
+	delete(s.policies, policyID)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": nil, "isError": false})
+}
@@ -0,0 +1,212 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Action prefixes of the incap rules and of the cache rules, which are listed together
+const (
+	incapRuleActionPrefix = "RULE_ACTION_"
+	cacheRuleActionPrefix = "HTTP_CACHE_"
+)
+
+// rule is an incap rule or a cache rule of the APIv2, stored as the JSON document it was submitted with
+type rule struct {
+	ID     int
+	SiteID int
+	Fields map[string]interface{}
+}
+
+func (s *Server) registerRules(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/prov/v2/sites/{siteId}/rules", s.handleRuleAdd)
+	mux.HandleFunc("GET /api/prov/v2/sites/{siteId}/rules/{ruleId}", s.handleRuleRead)
+	mux.HandleFunc("PUT /api/prov/v2/sites/{siteId}/rules/{ruleId}", s.handleRuleUpdate)
+	mux.HandleFunc("DELETE /api/prov/v2/sites/{siteId}/rules/{ruleId}", s.handleRuleDelete)
+	mux.HandleFunc("POST /api/prov/v1/sites/incapRules/list", s.handleRuleList)
+	mux.HandleFunc("POST /api/prov/v2/sites/{siteId}/settings/cache/rules", s.handleCacheRuleAdd)
+	mux.HandleFunc("GET /api/prov/v2/sites/{siteId}/settings/cache/rules/{ruleId}", s.handleRuleRead)
+	mux.HandleFunc("PUT /api/prov/v2/sites/{siteId}/settings/cache/rules/{ruleId}", s.handleCacheRuleUpdate)
+	mux.HandleFunc("DELETE /api/prov/v2/sites/{siteId}/settings/cache/rules/{ruleId}", s.handleRuleDelete)
+}
+
+// ruleSite returns the site of the siteId path value, writing the error when it doesn't exist
+func (s *Server) ruleSite(w http.ResponseWriter, r *http.Request) (*site, bool) {
+	siteID, ok := pathID(r, "siteId")
+	if !ok {
+		writeResError(w, http.StatusBadRequest, resInvalidInput, "Invalid input", map[string]string{"siteId": "invalid site id : " + r.PathValue("siteId")})
+		return nil, false
+	}
+	site, ok := s.sites[siteID]
+	if !ok {
+		writeResError(w, http.StatusUnauthorized, resUnknownSite, "Unknown/unauthorized site_id", map[string]string{"site_id": strconv.Itoa(siteID)})
+		return nil, false
+	}
+	return site, true
+}
+
+// siteRule returns the rule of the ruleId path value, writing the error when it doesn't exist on the site
+func (s *Server) siteRule(w http.ResponseWriter, r *http.Request) (*rule, bool) {
+	site, ok := s.ruleSite(w, r)
+	if !ok {
+		return nil, false
+	}
+	ruleID, ok := pathID(r, "ruleId")
+	if !ok {
+		writeResError(w, http.StatusBadRequest, resInvalidInput, "Invalid input", map[string]string{"ruleId": "invalid rule : " + r.PathValue("ruleId")})
+		return nil, false
+	}
+	rule, ok := s.rules[ruleID]
+	if !ok || rule.SiteID != site.ID {
+		writeResError(w, http.StatusNotFound, resObjectNotFound, "Object is not found", map[string]string{"rule_id": fmt.Sprintf("rule with id %d not found", ruleID)})
+		return nil, false
+	}
+	return rule, true
+}
+
+// decodeRule parses and validates the rule of the request body, whose action must have the given prefix
+func decodeRule(w http.ResponseWriter, r *http.Request, actionPrefix string) (map[string]interface{}, bool) {
+	var fields map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		writeResError(w, http.StatusBadRequest, resInvalidInput, "Invalid input", map[string]string{"body": err.Error()})
+		return nil, false
+	}
+	if name, _ := fields["name"].(string); name == "" {
+		writeResError(w, http.StatusBadRequest, resInvalidInput, "Invalid input", map[string]string{"name": "rule name is required"})
+		return nil, false
+	}
+	if action, _ := fields["action"].(string); !strings.HasPrefix(action, actionPrefix) {
+		writeResError(w, http.StatusBadRequest, resInvalidInput, "Invalid input", map[string]string{"action": fmt.Sprintf("invalid rule action : %v", fields["action"])})
+		return nil, false
+	}
+	delete(fields, "rule_id")
+	return fields, true
+}
+
+func (r *rule) document() map[string]interface{} {
+	document := map[string]interface{}{"rule_id": r.ID}
+	for key, value := range r.Fields {
+		document[key] = value
+	}
+	return document
+}
+
+func (s *Server) handleRuleAdd(w http.ResponseWriter, r *http.Request) {
+	s.addRule(w, r, incapRuleActionPrefix)
+}
+
+func (s *Server) handleCacheRuleAdd(w http.ResponseWriter, r *http.Request) {
+	s.addRule(w, r, cacheRuleActionPrefix)
+}
+
+func (s *Server) addRule(w http.ResponseWriter, r *http.Request, actionPrefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.ruleSite(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := decodeRule(w, r, actionPrefix)
+	if !ok {
+		return
+	}
+
+	rule := &rule{ID: s.newID(), SiteID: site.ID, Fields: fields}
+	s.rules[rule.ID] = rule
+	writeJSON(w, http.StatusOK, rule.document())
+}
+
+func (s *Server) handleRuleRead(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.siteRule(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, rule.document())
+}
+
+func (s *Server) handleRuleUpdate(w http.ResponseWriter, r *http.Request) {
+	s.updateRule(w, r, incapRuleActionPrefix)
+}
+
+func (s *Server) handleCacheRuleUpdate(w http.ResponseWriter, r *http.Request) {
+	s.updateRule(w, r, cacheRuleActionPrefix)
+}
+
+func (s *Server) updateRule(w http.ResponseWriter, r *http.Request, actionPrefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.siteRule(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := decodeRule(w, r, actionPrefix)
+	if !ok {
+		return
+	}
+
+	rule.Fields = fields
+	writeJSON(w, http.StatusOK, rule.document())
+}
+
+func (s *Server) handleRuleDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.siteRule(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.rules, rule.ID)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"res": resOK, "res_message": "OK"})
+}
+
+// handleRuleList lists the rules of a site with the APIv1, all of them in the "All" category
+func (s *Server) handleRuleList(w http.ResponseWriter, r *http.Request) {
+	pageSize, pageNum, ok := pageFromForm(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromForm(w, r)
+	if !ok {
+		return
+	}
+
+	var ids []int
+	for id, rule := range s.rules {
+		if rule.SiteID == site.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	rules := []interface{}{}
+	for _, id := range paginate(ids, pageSize, pageNum) {
+		rule := s.rules[id]
+		rules = append(rules, map[string]interface{}{
+			"id":     strconv.Itoa(rule.ID),
+			"name":   rule.Fields["name"],
+			"action": rule.Fields["action"],
+			"rule":   rule.Fields["filter"],
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"incap_rules":    map[string]interface{}{"All": rules},
+		"delivery_rules": map[string]interface{}{},
+		"res":            resOK,
+		"res_message":    "OK",
+	})
+}
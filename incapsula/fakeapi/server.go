@@ -0,0 +1,182 @@
+// Package fakeapi is an in-process fake of the Imperva API, for provider tests that need a real
+// create, read, update and delete lifecycle without a live account.
+//
+// The server keeps its state in memory and answers with the same envelopes as the real API:
+// res/res_message/debug_info for the APIv1 form endpoints, and JSON:API errors for the newer APIs.
+// Only a subset of the API is implemented: the v1 account and sites endpoints, the security rule exceptions,
+// the v2 incap rules and cache rules, the policies, the custom and HSM certificates, the waiting rooms, the listing of
+// the domains of a site, and the v3 site management, managed certificates, SSL instructions and certificates details
+// endpoints
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Default credentials and account of a new server
+const (
+	DefaultAPIID     = "fake-api-id"
+	DefaultAPIKey    = "fake-api-key"
+	DefaultAccountID = 1000
+)
+
+// APIv1 response codes
+const (
+	resOK                   = 0
+	resInvalidInput         = 2
+	resAuthentication       = 4
+	resObjectNotFound       = 2002
+	resInvalidParameterName = 6001
+	resUnknownSite          = 9413
+)
+
+// Server is a stateful fake Imperva API served over HTTP on the loopback interface
+type Server struct {
+	// URL is the root URL of the server, to be used as base_url_api
+	URL string
+
+	APIID     string
+	APIKey    string
+	AccountID int
+
+	server *httptest.Server
+	nextID atomic.Int64
+
+	mu           sync.Mutex
+	sites        map[int]*site
+	rules        map[int]*rule
+	policies     map[int]map[string]interface{}
+	certificates map[int]*siteCertificate
+	waitingRooms map[int]*waitingRoom
+}
+
+// NewServer starts a fake API accepting the default credentials
+func NewServer() *Server {
+	s := &Server{
+		APIID:        DefaultAPIID,
+		APIKey:       DefaultAPIKey,
+		AccountID:    DefaultAccountID,
+		sites:        map[int]*site{},
+		rules:        map[int]*rule{},
+		policies:     map[int]map[string]interface{}{},
+		certificates: map[int]*siteCertificate{},
+		waitingRooms: map[int]*waitingRoom{},
+	}
+	s.nextID.Store(100000)
+
+	mux := http.NewServeMux()
+	s.registerSites(mux)
+	s.registerRules(mux)
+	s.registerPolicies(mux)
+	s.registerSitesV3(mux)
+	s.registerCertificates(mux)
+	s.registerSiteSettings(mux)
+	s.registerWhitelists(mux)
+	s.registerCustomCertificates(mux)
+	s.registerWaitingRooms(mux)
+	s.registerDomains(mux)
+
+	s.server = httptest.NewServer(s.authenticate(mux))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// BaseURL is the APIv1 URL, to be used as base_url
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/prov/v1"
+}
+
+// BaseURLRev2 is the APIv2 URL, to be used as base_url_rev_2
+func (s *Server) BaseURLRev2() string {
+	return s.URL + "/api/prov/v2"
+}
+
+// BaseURLRev3 is the APIv3 URL, to be used as base_url_rev_3
+func (s *Server) BaseURLRev3() string {
+	return s.URL + "/api/prov/v3"
+}
+
+// BaseURLAPI is the URL of the newer APIs, to be used as base_url_api
+func (s *Server) BaseURLAPI() string {
+	return s.URL
+}
+
+func (s *Server) newID() int {
+	return int(s.nextID.Add(1))
+}
+
+// authenticate rejects the requests that don't carry the credentials of the server
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-id") == s.APIID && r.Header.Get("x-api-key") == s.APIKey {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if isAPIv1(r) {
+			writeResError(w, http.StatusUnauthorized, resAuthentication, "Authentication missing or invalid", nil)
+			return
+		}
+		writeErrors(w, http.StatusUnauthorized, "", "Unauthorized", "Authentication missing or invalid")
+	})
+}
+
+// accountID returns the account a request targets: the caid query param, then the account_id form field, then the account of the credentials
+func (s *Server) accountID(r *http.Request) int {
+	for _, value := range []string{r.URL.Query().Get("caid"), r.FormValue("account_id")} {
+		if accountID, err := strconv.Atoi(value); err == nil && accountID != 0 {
+			return accountID
+		}
+	}
+	return s.AccountID
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeResError writes an APIv1 error: res, res_message and debug_info
+func writeResError(w http.ResponseWriter, status int, res int, message string, debugInfo map[string]string) {
+	info := map[string]string{"id-info": "13007"}
+	for key, value := range debugInfo {
+		info[key] = value
+	}
+	writeJSON(w, status, map[string]interface{}{"res": res, "res_message": message, "debug_info": info})
+}
+
+// writeErrors writes a JSON:API errors envelope, as returned by the v2 and v3 APIs
+func writeErrors(w http.ResponseWriter, status int, pointer string, title string, detail string) {
+	apiError := map[string]interface{}{
+		"status": status,
+		"id":     fmt.Sprintf("fake-%d", status),
+		"title":  title,
+		"detail": detail,
+	}
+	if pointer != "" {
+		apiError["source"] = map[string]string{"pointer": pointer}
+	}
+	writeJSON(w, status, map[string]interface{}{"errors": []interface{}{apiError}})
+}
+
+// pathID parses a numeric path value of the request
+func pathID(r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	return id, err == nil
+}
+
+func isAPIv1(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, "/api/prov/v1/")
+}
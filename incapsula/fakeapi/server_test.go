@@ -0,0 +1,213 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// do sends a request with the credentials of the server and decodes the JSON response
+func do(t *testing.T, s *Server, method string, path string, contentType string, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Should not have received an error creating the request, got: %s", err)
+	}
+	req.Header.Set("x-api-id", s.APIID)
+	req.Header.Set("x-api-key", s.APIKey)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Should not have received an error sending %s %s, got: %s", method, path, err)
+	}
+	defer resp.Body.Close()
+	responseBody, _ := io.ReadAll(resp.Body)
+
+	var decoded map[string]interface{}
+	if len(responseBody) > 0 {
+		if err := json.Unmarshal(responseBody, &decoded); err != nil {
+			t.Fatalf("Should have received a JSON response from %s %s, got: %s", method, path, string(responseBody))
+		}
+	}
+	return resp.StatusCode, decoded
+}
+
+func postForm(t *testing.T, s *Server, path string, values url.Values) map[string]interface{} {
+	t.Helper()
+	_, body := do(t, s, http.MethodPost, path, "application/x-www-form-urlencoded", values.Encode())
+	return body
+}
+
+func addSite(t *testing.T, s *Server, domain string) int {
+	t.Helper()
+	body := postForm(t, s, "/api/prov/v1/sites/add", url.Values{"domain": {domain}})
+	if body["res"] != float64(resOK) {
+		t.Fatalf("Should have added site %s, got: %v", domain, body)
+	}
+	return int(body["site_id"].(float64))
+}
+
+func TestAuthentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	resp, err := http.PostForm(s.BaseURL()+"/account", url.Values{})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Should have rejected the request without credentials, got status: %d", resp.StatusCode)
+	}
+
+	body := postForm(t, s, "/api/prov/v1/account", url.Values{})
+	if body["res"] != float64(resOK) || body["account_id"] != float64(DefaultAccountID) {
+		t.Errorf("Should have returned the account of the credentials, got: %v", body)
+	}
+}
+
+func TestSitesLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	siteID := addSite(t, s, "www.example.com")
+	body := postForm(t, s, "/api/prov/v1/sites/add", url.Values{"domain": {"www.example.com"}})
+	if body["res"] != float64(resInvalidInput) {
+		t.Errorf("Should have rejected the existing domain, got: %v", body)
+	}
+
+	body = postForm(t, s, "/api/prov/v1/sites/configure", url.Values{"site_id": {fmt.Sprint(siteID)}, "param": {"active"}, "value": {"bypass"}})
+	if body["res"] != float64(resOK) {
+		t.Errorf("Should have configured the site, got: %v", body)
+	}
+	body = postForm(t, s, "/api/prov/v1/sites/configure", url.Values{"site_id": {fmt.Sprint(siteID)}, "param": {"unknown"}, "value": {"1"}})
+	if body["res"] != float64(resInvalidParameterName) {
+		t.Errorf("Should have rejected the unknown parameter, got: %v", body)
+	}
+
+	body = postForm(t, s, "/api/prov/v1/sites/status", url.Values{"site_id": {fmt.Sprint(siteID)}})
+	if body["domain"] != "www.example.com" || body["active"] != "bypass" || body["account_id"] != float64(DefaultAccountID) {
+		t.Errorf("Unexpected status of the site: %v", body)
+	}
+
+	addSite(t, s, "shop.example.com")
+	body = postForm(t, s, "/api/prov/v1/sites/list", url.Values{"page_size": {"1"}, "page_num": {"1"}})
+	if sites := body["sites"].([]interface{}); len(sites) != 1 || sites[0].(map[string]interface{})["domain"] != "shop.example.com" {
+		t.Errorf("Should have listed the second page of the sites, got: %v", body["sites"])
+	}
+
+	body = postForm(t, s, "/api/prov/v1/sites/delete", url.Values{"site_id": {fmt.Sprint(siteID)}})
+	if body["res"] != float64(resOK) {
+		t.Errorf("Should have deleted the site, got: %v", body)
+	}
+	body = postForm(t, s, "/api/prov/v1/sites/status", url.Values{"site_id": {fmt.Sprint(siteID)}})
+	if body["res"] != float64(resUnknownSite) {
+		t.Errorf("Should have reported the deleted site as unknown, got: %v", body)
+	}
+}
+
+func TestRulesLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	siteID := addSite(t, s, "www.example.com")
+	rulesPath := fmt.Sprintf("/api/prov/v2/sites/%d/rules", siteID)
+
+	status, body := do(t, s, http.MethodPost, rulesPath, "application/json", `{"name": "rule", "action": "DENY"}`)
+	if status != http.StatusBadRequest || body["res"] != float64(resInvalidInput) {
+		t.Errorf("Should have rejected the invalid action, got: %d %v", status, body)
+	}
+
+	status, body = do(t, s, http.MethodPost, rulesPath, "application/json", `{"name": "rule", "action": "RULE_ACTION_ALERT", "filter": "Full-URL == \"/admin\""}`)
+	if status != http.StatusOK {
+		t.Fatalf("Should have added the rule, got: %d %v", status, body)
+	}
+	rulePath := fmt.Sprintf("%s/%d", rulesPath, int(body["rule_id"].(float64)))
+
+	status, _ = do(t, s, http.MethodPut, rulePath, "application/json", `{"name": "renamed", "action": "RULE_ACTION_BLOCK"}`)
+	if status != http.StatusOK {
+		t.Errorf("Should have updated the rule, got status: %d", status)
+	}
+	status, body = do(t, s, http.MethodGet, rulePath, "", "")
+	if status != http.StatusOK || body["name"] != "renamed" || body["action"] != "RULE_ACTION_BLOCK" {
+		t.Errorf("Should have read the updated rule, got: %d %v", status, body)
+	}
+
+	// The rules are deleted along with their site
+	postForm(t, s, "/api/prov/v1/sites/delete", url.Values{"site_id": {fmt.Sprint(siteID)}})
+	if len(s.rules) != 0 {
+		t.Errorf("Should have deleted the rules of the deleted site, got: %v", s.rules)
+	}
+}
+
+func TestManagedCertificateValidation(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	siteID := addSite(t, s, "www.example.com")
+	certificatePath := fmt.Sprintf("/certificates-ui/v3/sites/%d/certificates/managed", siteID)
+
+	status, _ := do(t, s, http.MethodGet, certificatePath, "", "")
+	if status != http.StatusNotFound {
+		t.Errorf("Should not have found a certificate before the request, got status: %d", status)
+	}
+	status, body := do(t, s, http.MethodPost, certificatePath, "application/json", `{"defaultValidationMethod": "CNAME"}`)
+	if status != http.StatusOK {
+		t.Fatalf("Should have requested the certificate, got: %d %v", status, body)
+	}
+	status, _ = do(t, s, http.MethodPost, certificatePath, "application/json", `{}`)
+	if status != http.StatusConflict {
+		t.Errorf("Should have rejected a second request, got status: %d", status)
+	}
+
+	// The domains of the site are the SANs of the certificate
+	_, body = do(t, s, http.MethodGet, fmt.Sprintf("/site-domain-manager/v2/sites/%d/domains", siteID), "", "")
+	if domains := body["data"].([]interface{}); len(domains) != 2 || domains[0].(map[string]interface{})["domain"] != "www.example.com" {
+		t.Errorf("Should have listed the domains of the 2 SANs, got: %v", domains)
+	}
+
+	// The www domain and the naked domain are pending validation
+	_, body = do(t, s, http.MethodGet, fmt.Sprintf("/certificates-ui/v3/instructions/all?extSiteId=%d", siteID), "", "")
+	if instructions := body["data"].([]interface{}); len(instructions) != 2 {
+		t.Errorf("Should have returned the instructions of the 2 SANs, got: %v", instructions)
+	}
+
+	var domainIDs []string
+	for _, san := range s.certificates[siteID].Sans {
+		domainIDs = append(domainIDs, fmt.Sprint(san.DomainIDs[0]))
+	}
+	status, _ = do(t, s, http.MethodPost, certificatePath+"/validate", "application/json", "["+domainIDs[0]+"]")
+	if status != http.StatusCreated || s.certificates[siteID].Status != certificateStatusInProcess {
+		t.Errorf("Should have kept the certificate in process until all the SANs are validated, got: %d %s", status, s.certificates[siteID].Status)
+	}
+	status, _ = do(t, s, http.MethodPost, certificatePath+"/validate", "application/json", "["+strings.Join(domainIDs, ",")+"]")
+	if status != http.StatusCreated || s.certificates[siteID].Status != certificateStatusActive {
+		t.Errorf("Should have activated the certificate once all the SANs are validated, got: %d %s", status, s.certificates[siteID].Status)
+	}
+	_, body = do(t, s, http.MethodGet, fmt.Sprintf("/certificates-ui/v3/instructions/all?extSiteId=%d", siteID), "", "")
+	if instructions := body["data"].([]interface{}); len(instructions) != 0 {
+		t.Errorf("Should not have returned instructions for the validated SANs, got: %v", instructions)
+	}
+}
+
+func TestErrorEnvelopes(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	body := postForm(t, s, "/api/prov/v1/sites/status", url.Values{"site_id": {"1"}})
+	if body["res"] != float64(resUnknownSite) || body["debug_info"] == nil {
+		t.Errorf("Should have returned the APIv1 error envelope, got: %v", body)
+	}
+
+	status, body := do(t, s, http.MethodGet, "/certificates-ui/v3/sites/1/certificates/managed", "", "")
+	errors, _ := body["errors"].([]interface{})
+	if status != http.StatusNotFound || len(errors) != 1 || errors[0].(map[string]interface{})["status"] != float64(http.StatusNotFound) {
+		t.Errorf("Should have returned the JSON:API error envelope, got: %d %v", status, body)
+	}
+}
@@ -0,0 +1,83 @@
+package fakeapi
+
+import (
+	"net/http"
+)
+
+// The site settings are read only, with the defaults of a new site. They are enough for the site to be read
+// by the incapsula_site resource and data source
+
+func (s *Server) registerSiteSettings(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/prov/v1/sites/data-privacy/show", s.handleDataStorageRegionRead)
+	mux.HandleFunc("GET /api/prov/v2/sites/{siteId}/settings/masking", s.handleMaskingRead)
+	mux.HandleFunc("GET /api/prov/v2/sites/{siteId}/settings/cache", s.handlePerformanceRead)
+	mux.HandleFunc("GET /api/prov/v3/sites/{siteId}/data-centers-configuration", s.handleDataCentersConfigurationRead)
+}
+
+func (s *Server) handleDataStorageRegionRead(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.siteFromForm(w, r); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"region": "US", "res": resOK, "res_message": "OK"})
+}
+
+func (s *Server) handleMaskingRead(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.ruleSite(w, r); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"hashing_enabled": false})
+}
+
+func (s *Server) handlePerformanceRead(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.ruleSite(w, r); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"mode":     map[string]interface{}{"level": "standard", "https": "disabled", "time": 0},
+		"key":      map[string]interface{}{"unite_naked_full_cache": false, "comply_vary": false},
+		"response": map[string]interface{}{"stale_content": map[string]interface{}{"mode": "DISABLED"}, "cache_404": map[string]interface{}{"enabled": false}},
+		"ttl":      map[string]interface{}{"use_shortest_caching": false, "prefer_last_modified": false},
+		"client_side": map[string]interface{}{
+			"enable_client_side_caching": true,
+			"comply_no_cache":            false,
+			"send_age_header":            false,
+		},
+	})
+}
+
+func (s *Server) handleDataCentersConfigurationRead(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.ruleSite(w, r)
+	if !ok {
+		return
+	}
+	address := site.Settings["site_ip"]
+	if address == "" {
+		address = "192.0.2.10"
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": []interface{}{map[string]interface{}{
+			"lbAlgorithm": "BEST_CONNECTION_TIME",
+			"dataCenters": []interface{}{map[string]interface{}{
+				"id":          site.ID,
+				"name":        "Main DC",
+				"ipMode":      "SINGLE_IP",
+				"lbAlgorithm": "BEST_CONNECTION_TIME",
+				"isEnabled":   true,
+				"isActive":    true,
+				"servers":     []interface{}{map[string]interface{}{"address": address, "isEnabled": true, "serverMode": "ACTIVE"}},
+			}},
+		}},
+	})
+}
@@ -0,0 +1,303 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// site is shared by the APIv1 sites endpoints and the v3 site management API
+type site struct {
+	ID                int
+	AccountID         int
+	Domain            string
+	DisplayName       string
+	RefID             string
+	SiteType          string
+	Active            string
+	AccelerationLevel string
+	CreationTime      int64
+	Cname             string
+	// Settings holds the other parameters set with sites/configure
+	Settings map[string]string
+	// Whitelists holds the exceptions of the security rules, by rule ID
+	Whitelists map[string][]*whitelist
+	// CustomCertificate is the custom certificate of the site, nil when there is none
+	CustomCertificate *customCertificate
+}
+
+// sitesConfigureParams are the sites/configure parameters stored as is, on top of the ones mapped to site fields
+var sitesConfigureParams = map[string]bool{
+	"approver":                 true,
+	"domain_email":             true,
+	"domain_redirect_to_full":  true,
+	"domain_validation":        true,
+	"ignore_ssl":               true,
+	"logs_account_id":          true,
+	"naked_domain_san":         true,
+	"remove_ssl":               true,
+	"restricted_cname_reuse":   true,
+	"seal_location":            true,
+	"site_ip":                  true,
+	"wildcard_san":             true,
+	"add_naked_domain_san":     true,
+	"use_wildcard_san":         true,
+	"support_all_tls_versions": true,
+}
+
+func (s *Server) registerSites(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/prov/v1/account", s.handleAccount)
+	mux.HandleFunc("POST /api/prov/v1/sites/add", s.handleSiteAdd)
+	mux.HandleFunc("POST /api/prov/v1/sites/status", s.handleSiteStatus)
+	mux.HandleFunc("POST /api/prov/v1/sites/configure", s.handleSiteConfigure)
+	mux.HandleFunc("POST /api/prov/v1/sites/delete", s.handleSiteDelete)
+	mux.HandleFunc("POST /api/prov/v1/sites/list", s.handleSiteList)
+}
+
+func (s *Server) newSite(accountID int, domain string, siteType string) *site {
+	id := s.newID()
+	return &site{
+		ID:                id,
+		AccountID:         accountID,
+		Domain:            domain,
+		DisplayName:       domain,
+		SiteType:          siteType,
+		Active:            "active",
+		AccelerationLevel: "standard",
+		CreationTime:      time.Now().UnixMilli(),
+		Cname:             fmt.Sprintf("%d.x.incapdns.net", id),
+		Settings:          map[string]string{},
+		Whitelists:        map[string][]*whitelist{},
+	}
+}
+
+// siteFromForm returns the site of the site_id form field, writing the APIv1 error when it doesn't exist
+func (s *Server) siteFromForm(w http.ResponseWriter, r *http.Request) (*site, bool) {
+	siteID, err := strconv.Atoi(r.FormValue("site_id"))
+	if err != nil {
+		writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"site_id": "missing or invalid site_id"})
+		return nil, false
+	}
+	site, ok := s.sites[siteID]
+	if !ok {
+		writeResError(w, http.StatusOK, resUnknownSite, "Unknown/unauthorized site_id", map[string]string{"site_id": strconv.Itoa(siteID)})
+		return nil, false
+	}
+	return site, true
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, r *http.Request) {
+	account := map[string]interface{}{
+		"account_id":   s.AccountID,
+		"email":        "fake@example.com",
+		"plan_id":      "ent100",
+		"plan_name":    "Enterprise",
+		"account_name": "Fake account",
+		"user_name":    "Fake user",
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"account":      account,
+		"account_id":   s.AccountID,
+		"account_type": "Enterprise",
+		"res":          resOK,
+		"res_message":  "OK",
+	})
+}
+
+func (s *Server) handleSiteAdd(w http.ResponseWriter, r *http.Request) {
+	domain := strings.TrimSpace(r.FormValue("domain"))
+	if domain == "" || !strings.Contains(domain, ".") {
+		writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"domain": "missing or invalid domain"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.sites {
+		if existing.Domain == domain {
+			writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"domain": "site already exists: " + domain})
+			return
+		}
+	}
+
+	site := s.newSite(s.accountID(r), domain, "CLOUD_WAF")
+	site.RefID = r.FormValue("ref_id")
+	for _, param := range []string{"site_ip", "force_ssl", "naked_domain_san", "wildcard_san", "logs_account_id"} {
+		if value := r.FormValue(param); value != "" {
+			site.Settings[param] = value
+		}
+	}
+	s.sites[site.ID] = site
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"site_id": site.ID, "res": resOK, "res_message": "OK"})
+}
+
+func (s *Server) handleSiteStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromForm(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, site.status())
+}
+
+func (s *Server) handleSiteConfigure(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromForm(w, r)
+	if !ok {
+		return
+	}
+
+	param, value := r.FormValue("param"), r.FormValue("value")
+	switch {
+	case param == "active":
+		if value != "active" && value != "bypass" {
+			writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"value": "active must be active or bypass"})
+			return
+		}
+		site.Active = value
+	case param == "acceleration_level":
+		site.AccelerationLevel = value
+	case param == "ref_id":
+		site.RefID = value
+	case param == "display_name":
+		site.DisplayName = value
+	case sitesConfigureParams[param]:
+		site.Settings[param] = value
+	default:
+		writeResError(w, http.StatusOK, resInvalidParameterName, "Invalid configuration parameter name", map[string]string{"Param value is invalid": param})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"site_id": site.ID, "res": resOK, "res_message": "OK"})
+}
+
+func (s *Server) handleSiteList(w http.ResponseWriter, r *http.Request) {
+	pageSize, pageNum, ok := pageFromForm(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountID := s.accountID(r)
+	var ids []int
+	for id, site := range s.sites {
+		if site.AccountID == accountID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	sites := []interface{}{}
+	for _, id := range paginate(ids, pageSize, pageNum) {
+		sites = append(sites, s.sites[id].status())
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"sites": sites, "res": resOK, "res_message": "OK"})
+}
+
+func (s *Server) handleSiteDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromForm(w, r)
+	if !ok {
+		return
+	}
+	s.deleteSite(site.ID)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"res": resOK, "res_message": "OK"})
+}
+
+// deleteSite removes a site along with its rules, waiting rooms and certificate request
+func (s *Server) deleteSite(siteID int) {
+	delete(s.sites, siteID)
+	delete(s.certificates, siteID)
+	for ruleID, rule := range s.rules {
+		if rule.SiteID == siteID {
+			delete(s.rules, ruleID)
+		}
+	}
+	for waitingRoomID, waitingRoom := range s.waitingRooms {
+		if waitingRoom.SiteID == siteID {
+			delete(s.waitingRooms, waitingRoomID)
+		}
+	}
+}
+
+// status is the sites/status representation of the site
+func (s *site) status() map[string]interface{} {
+	status := map[string]interface{}{
+		"site_id":                s.ID,
+		"status":                 "pending-dns-changes",
+		"domain":                 s.Domain,
+		"display_name":           s.DisplayName,
+		"account_id":             s.AccountID,
+		"acceleration_level":     s.AccelerationLevel,
+		"acceleration_level_raw": s.AccelerationLevel,
+		"site_creation_date":     s.CreationTime,
+		"active":                 s.Active,
+		"ips":                    []string{"192.0.2.10"},
+		"dns": []map[string]interface{}{
+			{"dns_record_name": s.Domain, "set_type_to": "CNAME", "set_data_to": []string{s.Cname}},
+		},
+		"original_dns":           []interface{}{},
+		"warnings":               []interface{}{},
+		"additionalErrors":       []interface{}{},
+		"res":                    resOK,
+		"res_message":            "OK",
+		"debug_info":             map[string]string{"id-info": "999999"},
+		"restricted_cname_reuse": s.Settings["restricted_cname_reuse"] == "true",
+		"add_naked_domain_san":   s.Settings["naked_domain_san"] == "true",
+		"use_wildcard_san_instead_of_full_domain_san": s.Settings["wildcard_san"] == "true",
+		"security": s.securityStatus(),
+		"ssl":      map[string]interface{}{"custom_certificate": s.customCertificateStatus()},
+	}
+	if s.RefID != "" {
+		status["ref_id"] = s.RefID
+	}
+	return status
+}
+
+// pageFromForm parses the page_size and page_num form fields of the APIv1 list endpoints
+func pageFromForm(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	pageSize, pageNum := 50, 0
+	if value := r.FormValue("page_size"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 || size > 100 {
+			writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"page_size": "page_size must be between 1 and 100"})
+			return 0, 0, false
+		}
+		pageSize = size
+	}
+	if value := r.FormValue("page_num"); value != "" {
+		num, err := strconv.Atoi(value)
+		if err != nil || num < 0 {
+			writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"page_num": "invalid page_num"})
+			return 0, 0, false
+		}
+		pageNum = num
+	}
+	return pageSize, pageNum, true
+}
+
+func paginate(ids []int, pageSize int, pageNum int) []int {
+	start := pageSize * pageNum
+	if start >= len(ids) {
+		return nil
+	}
+	end := start + pageSize
+	if end > len(ids) {
+		end = len(ids)
+	}
+	return ids[start:end]
+}
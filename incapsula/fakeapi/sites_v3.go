@@ -0,0 +1,173 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// siteV3 is the representation of a site in the v3 site management API
+type siteV3 struct {
+	ID           int    `json:"id"`
+	AccountID    int    `json:"accountId"`
+	CreationTime int64  `json:"creationTime"`
+	Cname        string `json:"cname"`
+	Name         string `json:"name"`
+	SiteType     string `json:"type"`
+	RefID        string `json:"refId,omitempty"`
+	Active       bool   `json:"active"`
+}
+
+var siteV3Types = map[string]bool{"CLOUD_WAF": true}
+
+func (s *Server) registerSitesV3(mux *http.ServeMux) {
+	mux.HandleFunc("POST /sites-mgmt/v3/sites", s.handleSiteV3Add)
+	mux.HandleFunc("GET /sites-mgmt/v3/sites", s.handleSiteV3List)
+	mux.HandleFunc("GET /sites-mgmt/v3/sites/{siteId}", s.handleSiteV3Read)
+	mux.HandleFunc("PATCH /sites-mgmt/v3/sites/{siteId}", s.handleSiteV3Update)
+	mux.HandleFunc("DELETE /sites-mgmt/v3/sites/{siteId}", s.handleSiteV3Delete)
+}
+
+func (s *site) v3() siteV3 {
+	return siteV3{
+		ID:           s.ID,
+		AccountID:    s.AccountID,
+		CreationTime: s.CreationTime,
+		Cname:        s.Cname,
+		Name:         s.DisplayName,
+		SiteType:     s.SiteType,
+		RefID:        s.RefID,
+		Active:       s.Active == "active",
+	}
+}
+
+func writeSiteV3(w http.ResponseWriter, site *site) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": []siteV3{site.v3()}})
+}
+
+// siteFromPath returns the site of the siteId path value, writing the JSON:API error when it doesn't exist in the account
+func (s *Server) siteFromPath(w http.ResponseWriter, r *http.Request) (*site, bool) {
+	siteID, ok := pathID(r, "siteId")
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, "/siteId", "Bad Request", fmt.Sprintf("Invalid site id %s", r.PathValue("siteId")))
+		return nil, false
+	}
+	site, ok := s.sites[siteID]
+	if !ok || (r.URL.Query().Get("caid") != "" && site.AccountID != s.accountID(r)) {
+		writeErrors(w, http.StatusNotFound, "", "Not Found", fmt.Sprintf("Site %d was not found", siteID))
+		return nil, false
+	}
+	return site, true
+}
+
+func decodeSiteV3(w http.ResponseWriter, r *http.Request) (*siteV3, bool) {
+	var request siteV3
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeErrors(w, http.StatusBadRequest, "", "Bad Request", fmt.Sprintf("Invalid request body: %s", err))
+		return nil, false
+	}
+	return &request, true
+}
+
+func (s *Server) handleSiteV3Add(w http.ResponseWriter, r *http.Request) {
+	request, ok := decodeSiteV3(w, r)
+	if !ok {
+		return
+	}
+	if strings.TrimSpace(request.Name) == "" {
+		writeErrors(w, http.StatusBadRequest, "/name", "Bad Request", "Site name is required")
+		return
+	}
+	if !siteV3Types[request.SiteType] {
+		writeErrors(w, http.StatusBadRequest, "/type", "Bad Request", fmt.Sprintf("Unsupported site type %s", request.SiteType))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site := s.newSite(s.accountID(r), request.Name, request.SiteType)
+	site.RefID = request.RefID
+	s.sites[site.ID] = site
+	writeSiteV3(w, site)
+}
+
+func (s *Server) handleSiteV3List(w http.ResponseWriter, r *http.Request) {
+	page, size := 0, 50
+	if value := r.URL.Query().Get("page"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			page = n
+		}
+	}
+	if value := r.URL.Query().Get("size"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			size = n
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountID := s.accountID(r)
+	var ids []int
+	for id, site := range s.sites {
+		if site.AccountID == accountID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	sites := []siteV3{}
+	for _, id := range paginate(ids, size, page) {
+		sites = append(sites, s.sites[id].v3())
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": sites})
+}
+
+func (s *Server) handleSiteV3Read(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	writeSiteV3(w, site)
+}
+
+func (s *Server) handleSiteV3Update(w http.ResponseWriter, r *http.Request) {
+	request, ok := decodeSiteV3(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	if request.Name != "" {
+		site.DisplayName = request.Name
+	}
+	if request.RefID != "" {
+		site.RefID = request.RefID
+	}
+	writeSiteV3(w, site)
+}
+
+func (s *Server) handleSiteV3Delete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	s.deleteSite(site.ID)
+	writeSiteV3(w, site)
+}
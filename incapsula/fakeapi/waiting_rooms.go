@@ -0,0 +1,162 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// waitingRoom is a waiting room of a site, stored as the JSON document it was submitted with
+type waitingRoom struct {
+	ID     int
+	SiteID int
+	Fields map[string]interface{}
+}
+
+func (s *Server) registerWaitingRooms(mux *http.ServeMux) {
+	mux.HandleFunc("POST /waiting-room-settings/v3/sites/{siteId}/waiting-rooms", s.handleWaitingRoomAdd)
+	mux.HandleFunc("GET /waiting-room-settings/v3/sites/{siteId}/waiting-rooms", s.handleWaitingRoomList)
+	mux.HandleFunc("GET /waiting-room-settings/v3/sites/{siteId}/waiting-rooms/{waitingRoomId}", s.handleWaitingRoomRead)
+	mux.HandleFunc("PUT /waiting-room-settings/v3/sites/{siteId}/waiting-rooms/{waitingRoomId}", s.handleWaitingRoomUpdate)
+	mux.HandleFunc("DELETE /waiting-room-settings/v3/sites/{siteId}/waiting-rooms/{waitingRoomId}", s.handleWaitingRoomDelete)
+}
+
+func (wr *waitingRoom) document(accountID int) map[string]interface{} {
+	document := map[string]interface{}{"id": wr.ID, "accountId": accountID}
+	for key, value := range wr.Fields {
+		document[key] = value
+	}
+	return document
+}
+
+func (s *Server) writeWaitingRooms(w http.ResponseWriter, status int, site *site, waitingRooms ...*waitingRoom) {
+	data := []interface{}{}
+	for _, waitingRoom := range waitingRooms {
+		data = append(data, waitingRoom.document(site.AccountID))
+	}
+	writeJSON(w, status, map[string]interface{}{"data": data})
+}
+
+// decodeWaitingRoom parses the waiting room of the request body, whose name must be unique among the waiting rooms of
+// the site
+func (s *Server) decodeWaitingRoom(w http.ResponseWriter, r *http.Request, site *site, waitingRoomID int) (map[string]interface{}, bool) {
+	var fields map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		writeErrors(w, http.StatusBadRequest, "", "Bad Request", fmt.Sprintf("Invalid request body: %s", err))
+		return nil, false
+	}
+	name, _ := fields["name"].(string)
+	if strings.TrimSpace(name) == "" {
+		writeErrors(w, http.StatusBadRequest, "/name", "Bad Request", "Waiting room name is required")
+		return nil, false
+	}
+	for _, existing := range s.waitingRooms {
+		if existing.SiteID == site.ID && existing.ID != waitingRoomID && existing.Fields["name"] == name {
+			writeErrors(w, http.StatusBadRequest, "/name", "Bad Request", fmt.Sprintf("A waiting room named %s already exists on site %d", name, site.ID))
+			return nil, false
+		}
+	}
+	delete(fields, "id")
+	delete(fields, "accountId")
+	return fields, true
+}
+
+// siteWaitingRoom returns the site and the waiting room of the path values, writing the JSON:API error when they
+// don't exist
+func (s *Server) siteWaitingRoom(w http.ResponseWriter, r *http.Request) (*site, *waitingRoom, bool) {
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	waitingRoomID, ok := pathID(r, "waitingRoomId")
+	waitingRoom, exists := s.waitingRooms[waitingRoomID]
+	if !ok || !exists || waitingRoom.SiteID != site.ID {
+		writeErrors(w, http.StatusNotFound, "", "Not Found", fmt.Sprintf("Waiting room %s was not found", r.PathValue("waitingRoomId")))
+		return nil, nil, false
+	}
+	return site, waitingRoom, true
+}
+
+func (s *Server) handleWaitingRoomAdd(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := s.decodeWaitingRoom(w, r, site, 0)
+	if !ok {
+		return
+	}
+
+	waitingRoom := &waitingRoom{ID: s.newID(), SiteID: site.ID, Fields: fields}
+	s.waitingRooms[waitingRoom.ID] = waitingRoom
+	s.writeWaitingRooms(w, http.StatusCreated, site, waitingRoom)
+}
+
+func (s *Server) handleWaitingRoomList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromPath(w, r)
+	if !ok {
+		return
+	}
+	var ids []int
+	for id, waitingRoom := range s.waitingRooms {
+		if waitingRoom.SiteID == site.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	var waitingRooms []*waitingRoom
+	for _, id := range ids {
+		waitingRooms = append(waitingRooms, s.waitingRooms[id])
+	}
+	s.writeWaitingRooms(w, http.StatusOK, site, waitingRooms...)
+}
+
+func (s *Server) handleWaitingRoomRead(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, waitingRoom, ok := s.siteWaitingRoom(w, r)
+	if !ok {
+		return
+	}
+	s.writeWaitingRooms(w, http.StatusOK, site, waitingRoom)
+}
+
+func (s *Server) handleWaitingRoomUpdate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, waitingRoom, ok := s.siteWaitingRoom(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := s.decodeWaitingRoom(w, r, site, waitingRoom.ID)
+	if !ok {
+		return
+	}
+
+	waitingRoom.Fields = fields
+	s.writeWaitingRooms(w, http.StatusOK, site, waitingRoom)
+}
+
+func (s *Server) handleWaitingRoomDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, waitingRoom, ok := s.siteWaitingRoom(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.waitingRooms, waitingRoom.ID)
+	s.writeWaitingRooms(w, http.StatusOK, site, waitingRoom)
+}
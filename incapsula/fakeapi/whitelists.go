@@ -0,0 +1,157 @@
+package fakeapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// whitelist is an exception of a security rule, holding the values of each exception parameter
+type whitelist struct {
+	ID     int
+	Values map[string][]string
+}
+
+// whitelistParams maps the sites/configure/whitelists parameters to the exception types of sites/status
+var whitelistParams = map[string]string{
+	"client_app_types": "api.rule_exception_type.client_app_type",
+	"client_apps":      "api.rule_exception_type.client_app_id",
+	"continents":       "api.rule_exception_type.continent",
+	"countries":        "api.rule_exception_type.country",
+	"ips":              "api.rule_exception_type.client_ip",
+	"parameters":       "api.rule_exception_type.http_parameter",
+	"urls":             "api.rule_exception_type.url",
+	"user_agents":      "api.rule_exception_type.user_agent",
+}
+
+// whitelistRules are the security rules accepting exceptions, and whether they are ACL rules
+var whitelistRules = map[string]bool{
+	"api.acl.blacklisted_countries":       true,
+	"api.acl.blacklisted_ips":             true,
+	"api.acl.blacklisted_urls":            true,
+	"api.threats.backdoor":                false,
+	"api.threats.bot_access_control":      false,
+	"api.threats.cross_site_scripting":    false,
+	"api.threats.ddos":                    false,
+	"api.threats.illegal_resource_access": false,
+	"api.threats.remote_file_inclusion":   false,
+	"api.threats.sql_injection":           false,
+}
+
+func (s *Server) registerWhitelists(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/prov/v1/sites/configure/whitelists", s.handleWhitelistConfigure)
+}
+
+// handleWhitelistConfigure adds an exception, edits the parameters sent of an existing one, or deletes it
+func (s *Server) handleWhitelistConfigure(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	site, ok := s.siteFromForm(w, r)
+	if !ok {
+		return
+	}
+	ruleID := r.FormValue("rule_id")
+	if _, ok := whitelistRules[ruleID]; !ok {
+		writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"Unknown rule_id. The value should be selected out of the ACLs or security rules ids": ruleID})
+		return
+	}
+
+	values := map[string][]string{}
+	for param := range whitelistParams {
+		if value := r.FormValue(param); value != "" {
+			values[param] = strings.Split(value, ",")
+		}
+	}
+
+	whitelistID := r.FormValue("whitelist_id")
+	if whitelistID == "" {
+		if len(values) == 0 {
+			writeResError(w, http.StatusOK, resInvalidInput, "Invalid input", map[string]string{"exception": "at least one exception parameter must be set"})
+			return
+		}
+		exception := &whitelist{ID: s.newID(), Values: values}
+		site.Whitelists[ruleID] = append(site.Whitelists[ruleID], exception)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"exception_id": strconv.Itoa(exception.ID), "res": strconv.Itoa(resOK), "status": "ok"})
+		return
+	}
+
+	index := -1
+	for i, exception := range site.Whitelists[ruleID] {
+		if strconv.Itoa(exception.ID) == whitelistID {
+			index = i
+		}
+	}
+	if index < 0 {
+		writeResError(w, http.StatusOK, resObjectNotFound, "Object not found", map[string]string{"whitelist_id": whitelistID})
+		return
+	}
+
+	if r.FormValue("delete_whitelist") == "true" {
+		exceptions := site.Whitelists[ruleID]
+		site.Whitelists[ruleID] = append(exceptions[:index:index], exceptions[index+1:]...)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"res": resOK, "res_message": "OK", "status": "ok"})
+		return
+	}
+
+	for param, value := range values {
+		site.Whitelists[ruleID][index].Values[param] = value
+	}
+	writeJSON(w, http.StatusOK, site.status())
+}
+
+// securityStatus is the security section of sites/status, listing the rules having exceptions
+func (s *site) securityStatus() map[string]interface{} {
+	ruleIDs := make([]string, 0, len(s.Whitelists))
+	for ruleID := range s.Whitelists {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+
+	wafRules, aclRules := []interface{}{}, []interface{}{}
+	for _, ruleID := range ruleIDs {
+		exceptions := []interface{}{}
+		for _, exception := range s.Whitelists[ruleID] {
+			exceptions = append(exceptions, map[string]interface{}{"id": exception.ID, "values": exception.status()})
+		}
+		rule := map[string]interface{}{"id": ruleID, "exceptions": exceptions}
+		if whitelistRules[ruleID] {
+			aclRules = append(aclRules, rule)
+		} else {
+			wafRules = append(wafRules, rule)
+		}
+	}
+	return map[string]interface{}{
+		"waf":  map[string]interface{}{"rules": wafRules},
+		"acls": map[string]interface{}{"rules": aclRules},
+	}
+}
+
+// status is the sites/status representation of the values of the exception, one entry per exception type
+func (e *whitelist) status() []interface{} {
+	params := make([]string, 0, len(e.Values))
+	for param := range e.Values {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	values := []interface{}{}
+	for _, param := range params {
+		value := map[string]interface{}{"id": whitelistParams[param], "name": param}
+		switch param {
+		case "countries", "continents":
+			value["geo"] = map[string]interface{}{param: e.Values[param]}
+		case "urls":
+			urls := []interface{}{}
+			for _, url := range e.Values[param] {
+				urls = append(urls, map[string]string{"value": url, "pattern": "EQUALS"})
+			}
+			value["urls"] = urls
+		default:
+			value[param] = e.Values[param]
+		}
+		values = append(values, value)
+	}
+	return values
+}
@@ -0,0 +1,185 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const lookupPageSize = 100
+
+// isNumericID reports whether an import ID is a numeric identifier rather than a name
+func isNumericID(id string) bool {
+	_, err := strconv.Atoi(id)
+	return err == nil
+}
+
+// lookupAccountID returns the account names are looked up in: the provider default_account_id, then the account of the API credentials.
+// It returns 0 when neither is known, the API then defaults to the account of the credentials
+func (c *Client) lookupAccountID() int {
+	if c.config != nil && c.config.DefaultAccountID != 0 {
+		return c.config.DefaultAccountID
+	}
+	if c.accountStatus != nil {
+		return c.accountStatus.AccountID
+	}
+	return 0
+}
+
+// ambiguousLookupError reports a name matching several objects, listing their IDs so that one can be imported by ID
+func ambiguousLookupError(kind string, name string, ids []string) error {
+	sort.Strings(ids)
+	return fmt.Errorf("%s name %q is ambiguous, it matches %d objects with IDs %s, import it by ID instead", kind, name, len(ids), strings.Join(ids, ", "))
+}
+
+// listAllSites lists the sites of an account, page after page
+func (c *Client) listAllSites(ctx context.Context, accountID int) ([]SiteStatusResponse, error) {
+	var sites []SiteStatusResponse
+	for page := 0; ; page++ {
+		siteListResponse, err := c.ListSites(ctx, accountID, lookupPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+		sites = append(sites, siteListResponse.Sites...)
+		if len(siteListResponse.Sites) < lookupPageSize {
+			return sites, nil
+		}
+	}
+}
+
+// lookupSiteID resolves a site ID or domain to a site ID
+func (c *Client) lookupSiteID(ctx context.Context, siteIDOrDomain string) (string, error) {
+	return c.lookupSiteIDInAccount(ctx, c.lookupAccountID(), siteIDOrDomain)
+}
+
+// lookupSiteIDInAccount resolves a site ID or domain to a site ID, among the sites of the given account
+func (c *Client) lookupSiteIDInAccount(ctx context.Context, accountID int, siteIDOrDomain string) (string, error) {
+	if isNumericID(siteIDOrDomain) {
+		return siteIDOrDomain, nil
+	}
+
+	sites, err := c.listAllSites(ctx, accountID)
+	if err != nil {
+		return "", fmt.Errorf("Error looking up site %q: %s", siteIDOrDomain, err)
+	}
+	var ids []string
+	for _, site := range sites {
+		if strings.EqualFold(site.Domain, siteIDOrDomain) {
+			ids = append(ids, strconv.Itoa(site.SiteID))
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no site with domain %q found in account %d", siteIDOrDomain, accountID)
+	case 1:
+		return ids[0], nil
+	}
+	return "", ambiguousLookupError("site", siteIDOrDomain, ids)
+}
+
+// isCacheRuleAction reports whether a rule listed with the incap rules of a site is a cache rule
+func isCacheRuleAction(action string) bool {
+	return strings.HasPrefix(action, "HTTP_CACHE_")
+}
+
+// lookupIncapRuleID resolves an incap rule ID or name to a rule ID, among the rules of a site
+func (c *Client) lookupIncapRuleID(ctx context.Context, siteID string, ruleIDOrName string) (string, error) {
+	return c.lookupSiteRuleID(ctx, "incap rule", siteID, ruleIDOrName, func(rule IncapRuleListItem) bool {
+		return !isCacheRuleAction(rule.Action)
+	})
+}
+
+// lookupCacheRuleID resolves a cache rule ID or name to a rule ID, among the rules of a site
+func (c *Client) lookupCacheRuleID(ctx context.Context, siteID string, ruleIDOrName string) (string, error) {
+	return c.lookupSiteRuleID(ctx, "cache rule", siteID, ruleIDOrName, func(rule IncapRuleListItem) bool {
+		return isCacheRuleAction(rule.Action)
+	})
+}
+
+// lookupSiteRuleID resolves a rule ID or name to a rule ID, among the rules of a site of the given kind. The incap
+// rules and the cache rules of a site are listed together
+func (c *Client) lookupSiteRuleID(ctx context.Context, kind string, siteID string, ruleIDOrName string, isKind func(IncapRuleListItem) bool) (string, error) {
+	if isNumericID(ruleIDOrName) {
+		return ruleIDOrName, nil
+	}
+
+	var ids []string
+	for page := 0; ; page++ {
+		incapRuleListResponse, err := c.ListIncapRules(ctx, siteID, lookupPageSize, page)
+		if err != nil {
+			return "", fmt.Errorf("Error looking up %s %q of site %s: %s", kind, ruleIDOrName, siteID, err)
+		}
+		count := 0
+		for _, rules := range incapRuleListResponse.IncapRules {
+			for _, rule := range rules {
+				count++
+				if rule.Name == ruleIDOrName && isKind(rule) {
+					ids = append(ids, rule.ID.String())
+				}
+			}
+		}
+		if count < lookupPageSize {
+			break
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q found on site %s", kind, ruleIDOrName, siteID)
+	case 1:
+		return ids[0], nil
+	}
+	return "", ambiguousLookupError(kind, ruleIDOrName, ids)
+}
+
+// lookupWaitingRoomID resolves a waiting room ID or name to a waiting room ID, among the waiting rooms of a site
+func (c *Client) lookupWaitingRoomID(ctx context.Context, accountID string, siteID string, waitingRoomIDOrName string) (string, error) {
+	if isNumericID(waitingRoomIDOrName) {
+		return waitingRoomIDOrName, nil
+	}
+
+	waitingRoomsResponse, diags := c.ListWaitingRooms(ctx, accountID, siteID)
+	if diags.HasError() {
+		return "", fmt.Errorf("Error looking up waiting room %q of site %s: %s", waitingRoomIDOrName, siteID, diagnosticsSummary(diags))
+	}
+	var ids []string
+	for _, waitingRoom := range waitingRoomsResponse.Data {
+		if waitingRoom.Name == waitingRoomIDOrName {
+			ids = append(ids, strconv.FormatInt(waitingRoom.Id, 10))
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no waiting room named %q found on site %s", waitingRoomIDOrName, siteID)
+	case 1:
+		return ids[0], nil
+	}
+	return "", ambiguousLookupError("waiting room", waitingRoomIDOrName, ids)
+}
+
+// lookupPolicyID resolves a policy ID or name to a policy ID, among the policies of the account
+func (c *Client) lookupPolicyID(ctx context.Context, policyIDOrName string) (string, error) {
+	if isNumericID(policyIDOrName) {
+		return policyIDOrName, nil
+	}
+
+	accountID := c.lookupAccountID()
+	policies, err := c.GetAllPoliciesForAccount(ctx, strconv.Itoa(accountID))
+	if err != nil {
+		return "", fmt.Errorf("Error looking up policy %q: %s", policyIDOrName, err)
+	}
+	var ids []string
+	for _, policy := range *policies {
+		if policy.Name == policyIDOrName {
+			ids = append(ids, strconv.Itoa(policy.ID))
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no policy named %q found in account %d", policyIDOrName, accountID)
+	case 1:
+		return ids[0], nil
+	}
+	return "", ambiguousLookupError("policy", policyIDOrName, ids)
+}
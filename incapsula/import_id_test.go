@@ -0,0 +1,221 @@
+package incapsula
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+////////////////////////////////////////////////////////////////
+// Import ID lookup tests against the fake Imperva API
+////////////////////////////////////////////////////////////////
+
+func TestLookupSiteID(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "lookup.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	expectedSiteID := strconv.Itoa(siteAddResponse.SiteID)
+
+	siteID, err := client.lookupSiteID(ctx, "Lookup.Example.com")
+	if err != nil || siteID != expectedSiteID {
+		t.Errorf("Should have found site %s by domain, got: %s %v", expectedSiteID, siteID, err)
+	}
+
+	siteID, err = client.lookupSiteID(ctx, "123")
+	if err != nil || siteID != "123" {
+		t.Errorf("Numeric IDs should be kept as is, got: %s %v", siteID, err)
+	}
+
+	_, err = client.lookupSiteID(ctx, "missing.example.com")
+	if err == nil || !strings.Contains(err.Error(), `no site with domain "missing.example.com"`) {
+		t.Errorf("Should have received a not found error, got: %v", err)
+	}
+}
+
+func TestLookupIncapRuleID(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "rules.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+
+	var ruleIDs []string
+	for _, name := range []string{"block/admin", "duplicate", "duplicate"} {
+		ruleWithID, err := client.AddIncapRule(ctx, siteID, &IncapRule{Name: name, Action: "RULE_ACTION_BLOCK", Filter: `URL == "/admin"`})
+		if err != nil {
+			t.Fatalf("Should not have received an error adding the rule, got: %s", err)
+		}
+		ruleIDs = append(ruleIDs, strconv.Itoa(ruleWithID.RuleID))
+	}
+
+	ruleID, err := client.lookupIncapRuleID(ctx, siteID, "block/admin")
+	if err != nil || ruleID != ruleIDs[0] {
+		t.Errorf("Should have found rule %s by name, got: %s %v", ruleIDs[0], ruleID, err)
+	}
+
+	_, err = client.lookupIncapRuleID(ctx, siteID, "duplicate")
+	if err == nil || !strings.Contains(err.Error(), "is ambiguous") || !strings.Contains(err.Error(), ruleIDs[1]) || !strings.Contains(err.Error(), ruleIDs[2]) {
+		t.Errorf("Should have received an ambiguity error listing both rules, got: %v", err)
+	}
+
+	_, err = client.lookupIncapRuleID(ctx, siteID, "missing")
+	if err == nil || !strings.Contains(err.Error(), `no incap rule named "missing"`) {
+		t.Errorf("Should have received a not found error, got: %v", err)
+	}
+}
+
+func TestImportIncapRuleByName(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "import.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	ruleWithID, err := client.AddIncapRule(ctx, strconv.Itoa(siteAddResponse.SiteID), &IncapRule{Name: "block/admin", Action: "RULE_ACTION_BLOCK", Filter: `URL == "/admin"`})
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the rule, got: %s", err)
+	}
+
+	resource := resourceIncapRule()
+	d := resource.Data(nil)
+	d.SetId("import.example.com/block/admin")
+	imported, err := resource.Importer.StateContext(ctx, d, client)
+	if err != nil {
+		t.Fatalf("Should not have received an error importing the rule, got: %s", err)
+	}
+	if imported[0].Id() != strconv.Itoa(ruleWithID.RuleID) || imported[0].Get("site_id") != strconv.Itoa(siteAddResponse.SiteID) {
+		t.Errorf("Unexpected imported rule %s on site %v", imported[0].Id(), imported[0].Get("site_id"))
+	}
+}
+
+func TestLookupCacheRuleID(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "cache.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+
+	// An incap rule with the same name isn't a cache rule
+	if _, err := client.AddIncapRule(ctx, siteID, &IncapRule{Name: "static", Action: "RULE_ACTION_BLOCK", Filter: `URL == "/admin"`}); err != nil {
+		t.Fatalf("Should not have received an error adding the incap rule, got: %s", err)
+	}
+	cacheRuleWithID, err := client.AddCacheRule(ctx, siteID, &CacheRule{Name: "static", Action: "HTTP_CACHE_MAKE_STATIC", Filter: `URL == "/static"`, Enabled: true, TTL: 60})
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the cache rule, got: %s", err)
+	}
+
+	ruleID, err := client.lookupCacheRuleID(ctx, siteID, "static")
+	if err != nil || ruleID != strconv.Itoa(cacheRuleWithID.RuleID) {
+		t.Errorf("Should have found cache rule %d by name, got: %s %v", cacheRuleWithID.RuleID, ruleID, err)
+	}
+
+	resource := resourceCacheRule()
+	d := resource.Data(nil)
+	d.SetId("cache.example.com/static")
+	imported, err := resource.Importer.StateContext(ctx, d, client)
+	if err != nil {
+		t.Fatalf("Should not have received an error importing the cache rule, got: %s", err)
+	}
+	if imported[0].Id() != strconv.Itoa(cacheRuleWithID.RuleID) || imported[0].Get("site_id") != siteID {
+		t.Errorf("Unexpected imported cache rule %s on site %v", imported[0].Id(), imported[0].Get("site_id"))
+	}
+
+	_, err = client.lookupCacheRuleID(ctx, siteID, "missing")
+	if err == nil || !strings.Contains(err.Error(), `no cache rule named "missing"`) {
+		t.Errorf("Should have received a not found error, got: %v", err)
+	}
+}
+
+func TestImportWaitingRoomByName(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+	accountID := strconv.Itoa(server.AccountID)
+
+	siteAddResponse, err := client.AddSite(ctx, "queue.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+	waitingRoomDTOResponse, diags := client.CreateWaitingRoom(ctx, accountID, siteID, &WaitingRoomDTO{Name: "sales/black friday", Enabled: true})
+	if diags.HasError() {
+		t.Fatalf("Should not have received an error adding the waiting room, got: %v", diags)
+	}
+	waitingRoomID := strconv.FormatInt(waitingRoomDTOResponse.Data[0].Id, 10)
+
+	resource := resourceWaitingRoom()
+	d := resource.Data(nil)
+	d.SetId(accountID + "/queue.example.com/sales/black friday")
+	imported, err := resource.Importer.StateContext(ctx, d, client)
+	if err != nil {
+		t.Fatalf("Should not have received an error importing the waiting room, got: %s", err)
+	}
+	if imported[0].Id() != waitingRoomID || imported[0].Get("site_id") != siteID || imported[0].Get("account_id") != accountID {
+		t.Errorf("Unexpected imported waiting room %s on site %v of account %v", imported[0].Id(), imported[0].Get("site_id"), imported[0].Get("account_id"))
+	}
+
+	_, err = client.lookupWaitingRoomID(ctx, accountID, siteID, "missing")
+	if err == nil || !strings.Contains(err.Error(), `no waiting room named "missing"`) {
+		t.Errorf("Should have received a not found error, got: %v", err)
+	}
+}
+
+func TestLookupPolicyID(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	var policyIDs []string
+	for _, name := range []string{"allowlist", "acl", "acl"} {
+		policyExtended, err := client.AddPolicy(ctx, &PolicySubmitted{Name: name, Enabled: true, PolicyType: "ACL", PolicySettings: []PolicySetting{}})
+		if err != nil {
+			t.Fatalf("Should not have received an error adding the policy, got: %s", err)
+		}
+		policyIDs = append(policyIDs, strconv.Itoa(policyExtended.Value.ID))
+	}
+
+	policyID, err := client.lookupPolicyID(ctx, "allowlist")
+	if err != nil || policyID != policyIDs[0] {
+		t.Errorf("Should have found policy %s by name, got: %s %v", policyIDs[0], policyID, err)
+	}
+
+	_, err = client.lookupPolicyID(ctx, "acl")
+	if err == nil || !strings.Contains(err.Error(), "is ambiguous") {
+		t.Errorf("Should have received an ambiguity error, got: %v", err)
+	}
+}
+
+func TestImportAccountUserByEmail(t *testing.T) {
+	resource := resourceAccountUser()
+
+	client := &Client{config: &Config{DefaultAccountID: 123}}
+	d := resource.Data(nil)
+	d.SetId("joe@example.com")
+	imported, err := resource.Importer.StateContext(context.Background(), d, client)
+	if err != nil || imported[0].Id() != "123/joe@example.com" {
+		t.Errorf("Should have imported the user of the default account, got: %v %v", d.Id(), err)
+	}
+
+	d = resource.Data(nil)
+	d.SetId("456/joe@example.com")
+	imported, err = resource.Importer.StateContext(context.Background(), d, client)
+	if err != nil || imported[0].Id() != "456/joe@example.com" {
+		t.Errorf("Should have kept the account of the ID, got: %v %v", d.Id(), err)
+	}
+
+	d = resource.Data(nil)
+	d.SetId("joe@example.com")
+	if _, err := resource.Importer.StateContext(context.Background(), d, &Client{config: &Config{}}); err == nil {
+		t.Errorf("Should have received an error without a known account")
+	}
+}
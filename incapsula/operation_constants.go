@@ -6,6 +6,7 @@ const CreateSite = "create_site"
 const ReadSite = "read_site"
 const UpdateSite = "update_site"
 const DeleteSite = "delete_site"
+const ReadSitesAll = "read_sites_all"
 
 const CreatePolicy = "create_policy"
 const ReadPolicy = "read_policy"
@@ -31,6 +32,7 @@ const ReadSubAccount = "read_sub_account"
 const DeleteSubAccount = "delete_sub_account"
 
 const CreateWAFLogSetup = "create_waf_log_setup"
+const ReadWAFLogSetup = "read_waf_log_setup"
 const DeleteWAFLogSetup = "delete_waf_log_setup"
 const ActivateWAFLogSetup = "activate_waf_log_setup"
 const UpdateStatusWAFLogSetup = "update_status_waf_log_setup"
@@ -66,6 +68,7 @@ const CreateIncapRule = "create_incap_rule"
 const ReadIncapRule = "read_incap_rule"
 const UpdateIncapRule = "update_incap_rule"
 const DeleteIncapRule = "delete_incap_rule"
+const ReadIncapRulesAll = "read_incap_rules_all"
 
 const UpdateSecurityRule = "update_security_rule"
 
@@ -186,16 +189,19 @@ const CreateSiemConnection = "create_siem_connection"
 const ReadSiemConnection = "read_siem_connection"
 const UpdateSiemConnection = "update_siem_connection"
 const DeleteSiemConnection = "delete_siem_connection"
+const ReadSiemConnectionsAll = "read_siem_connections_all"
 
 const CreateSiemLogConfiguration = "create_siem_log_configuration"
 const ReadSiemLogConfiguration = "read_siem_log_configuration"
 const UpdateSiemLogConfiguration = "update_siem_log_configuration"
 const DeleteSiemLogConfiguration = "delete_siem_log_configuration"
+const ReadSiemLogConfigurationsAll = "read_siem_log_configurations_all"
 
 const CreateWaitingRoom = "create_waiting_room"
 const ReadWaitingRoom = "read_waiting_room"
 const UpdateWaitingRoom = "update_waiting_room"
 const DeleteWaitingRoom = "delete_waiting_room"
+const ReadWaitingRoomsAll = "read_waiting_rooms_all"
 
 const CreateAbpWebsites = "create_abp_websites"
 const ReadAbpWebsites = "read_abp_websites"
@@ -203,9 +209,11 @@ const UpdateAbpWebsites = "update_abp_websites"
 const DeleteAbpWebsites = "delete_abp_websites"
 
 const RequestSiteCert = "request_site_cert"
+const ReadSiteCertificatesDetails = "read_site_certificates_details"
 
 const AddV3Site = "add_v3_site"
 const UpdateV3Site = "update_v3_site"
+const ReadV3SitesAll = "read_v3_sites_all"
 
 const ReadDeliveryRuleConfiguration = "read_delivery_rules_configuration"
 const UpdateDeliveryRuleConfiguration = "update_delivery_rules_configuration"
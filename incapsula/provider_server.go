@@ -0,0 +1,115 @@
+package incapsula
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The SDK validates the arguments of a resource one at a time. The checks of an argument depending on the other
+// arguments, such as the arguments of an incap rule action or the fields of its filter, are run on the configuration
+// of the resource by the gRPC server of the provider, so that their diagnostics point to the argument, which
+// CustomizeDiff errors can't
+
+// resourceConfigValidators validate the configuration of a resource type, which may hold unknown values
+var resourceConfigValidators = map[string]func(config cty.Value) diag.Diagnostics{
+	"incapsula_incap_rule":                   validateIncapRuleConfig,
+	"incapsula_delivery_rules_configuration": validateDeliveryRulesConfig,
+	"incapsula_custom_certificate":           validateCertificateConfig,
+}
+
+// providerServer is the gRPC server of the SDK, running the resourceConfigValidators after the validation of the SDK
+type providerServer struct {
+	*schema.GRPCProviderServer
+	provider *schema.Provider
+}
+
+// NewGRPCProviderServer returns the gRPC server of the provider
+func NewGRPCProviderServer(provider *schema.Provider) tfprotov5.ProviderServer {
+	return &providerServer{GRPCProviderServer: schema.NewGRPCProviderServer(provider), provider: provider}
+}
+
+// retryStatusCodesConfiguredKey is the context key telling the configuration of the provider that retry_status_codes
+// is set, possibly to an empty list
+type retryStatusCodesConfiguredKey struct{}
+
+func retryStatusCodesConfigured(ctx context.Context) bool {
+	configured, _ := ctx.Value(retryStatusCodesConfiguredKey{}).(bool)
+	return configured
+}
+
+// ConfigureProvider tells an empty retry_status_codes from an unset one, which the SDK reads the same way
+func (s *providerServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	if req.Config != nil {
+		config, err := msgpack.Unmarshal(req.Config.MsgPack, schema.InternalMap(s.provider.Schema).CoreConfigSchema().ImpliedType())
+		if err == nil && config.IsKnown() && !config.IsNull() {
+			if statusCodes := config.GetAttr("retry_status_codes"); statusCodes.IsKnown() && !statusCodes.IsNull() {
+				ctx = context.WithValue(ctx, retryStatusCodesConfiguredKey{}, true)
+			}
+		}
+	}
+	return s.GRPCProviderServer.ConfigureProvider(ctx, req)
+}
+
+func (s *providerServer) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
+	resp, err := s.GRPCProviderServer.ValidateResourceTypeConfig(ctx, req)
+	validate, ok := resourceConfigValidators[req.TypeName]
+	if err != nil || !ok || req.Config == nil {
+		return resp, err
+	}
+	resource, ok := s.provider.ResourcesMap[req.TypeName]
+	if !ok {
+		return resp, nil
+	}
+
+	// The configuration that can't be decoded was already reported by the SDK
+	config, err := msgpack.Unmarshal(req.Config.MsgPack, resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		return resp, nil
+	}
+	for _, d := range validate(config) {
+		resp.Diagnostics = append(resp.Diagnostics, protoDiagnostic(d))
+	}
+	return resp, nil
+}
+
+func protoDiagnostic(d diag.Diagnostic) *tfprotov5.Diagnostic {
+	severity := tfprotov5.DiagnosticSeverityError
+	if d.Severity == diag.Warning {
+		severity = tfprotov5.DiagnosticSeverityWarning
+	}
+	return &tfprotov5.Diagnostic{
+		Severity:  severity,
+		Summary:   d.Summary,
+		Detail:    d.Detail,
+		Attribute: protoAttributePath(d.AttributePath),
+	}
+}
+
+// protoAttributePath converts the path of a diagnostic, nil for the diagnostics of the whole resource
+func protoAttributePath(path cty.Path) *tftypes.AttributePath {
+	if len(path) == 0 {
+		return nil
+	}
+	attributePath := tftypes.NewAttributePath()
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			attributePath = attributePath.WithAttributeName(step.Name)
+		case cty.IndexStep:
+			switch step.Key.Type() {
+			case cty.Number:
+				index, _ := step.Key.AsBigFloat().Int64()
+				attributePath = attributePath.WithElementKeyInt(int(index))
+			case cty.String:
+				attributePath = attributePath.WithElementKeyString(step.Key.AsString())
+			}
+		}
+	}
+	return attributePath
+}
@@ -2,17 +2,25 @@ package incapsula
 
 import (
 	"context"
+	"hash/fnv"
+	"math/rand"
 	"os"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-incapsula/incapsula/fakeapi"
 )
 
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 var testAccProviderConfigure sync.Once
+var testAccEnvironmentSetUp sync.Once
 
 func ThreeValidPoPs() []string {
 	validPoPs := []string{"hkg", "lon", "iad"}
@@ -41,8 +49,60 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderServerConfigureRetryStatusCodes(t *testing.T) {
+	server := fakeapi.NewServer()
+	t.Cleanup(server.Close)
+
+	configure := func(statusCodes cty.Value) *Client {
+		provider := Provider()
+		configType := schema.InternalMap(provider.Schema).CoreConfigSchema().ImpliedType()
+		attributes := map[string]cty.Value{}
+		for name, attributeType := range configType.AttributeTypes() {
+			attributes[name] = cty.NullVal(attributeType)
+		}
+		attributes["api_id"] = cty.StringVal(server.APIID)
+		attributes["api_key"] = cty.StringVal(server.APIKey)
+		attributes["base_url"] = cty.StringVal(server.BaseURL())
+		attributes["base_url_rev_2"] = cty.StringVal(server.BaseURLRev2())
+		attributes["base_url_rev_3"] = cty.StringVal(server.BaseURLRev3())
+		attributes["base_url_api"] = cty.StringVal(server.BaseURLAPI())
+		attributes["retry_status_codes"] = statusCodes
+		config, err := msgpack.Marshal(cty.ObjectVal(attributes), configType)
+		if err != nil {
+			t.Fatalf("Should not have received an error encoding the configuration, got: %s", err)
+		}
+
+		resp, err := NewGRPCProviderServer(provider).ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+			Config: &tfprotov5.DynamicValue{MsgPack: config},
+		})
+		if err != nil || len(resp.Diagnostics) != 0 {
+			t.Fatalf("Should have configured the provider, got: %v %v", err, resp.Diagnostics)
+		}
+		return provider.Meta().(*Client)
+	}
+
+	if client := configure(cty.NullVal(cty.List(cty.Number))); client.config.RetryStatusCodes != nil {
+		t.Errorf("Should have kept the default status codes when retry_status_codes is unset, got: %v", client.config.RetryStatusCodes)
+	}
+	if client := configure(cty.ListValEmpty(cty.Number)); client.config.RetryStatusCodes == nil || len(client.retryPolicy().StatusCodes) != 0 {
+		t.Errorf("Should have disabled the retries on status codes when retry_status_codes is empty, got: %v", client.config.RetryStatusCodes)
+	}
+	if client := configure(cty.ListVal([]cty.Value{cty.NumberIntVal(503)})); len(client.config.RetryStatusCodes) != 1 || client.config.RetryStatusCodes[0] != 503 {
+		t.Errorf("Should have set the status codes of retry_status_codes, got: %v", client.config.RetryStatusCodes)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
+	// The tests without a recorded cassette can't be replayed
+	if os.Getenv("INCAPSULA_CASSETTE_MODE") == cassetteModeReplay {
+		if _, err := os.Stat(cassettePath(cassetteDirFromEnv(), t.Name())); os.IsNotExist(err) {
+			t.Skipf("No cassette recorded for %s", t.Name())
+		}
+	}
+
 	testAccProviderConfigure.Do(func() {
+		setUpAccTestEnvironment()
+
 		if v := os.Getenv("INCAPSULA_API_ID"); v == "" {
 			t.Fatal("INCAPSULA_API_ID must be set for acceptance tests")
 		}
@@ -56,4 +116,72 @@ func testAccPreCheck(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
+
+	useCassette(t)
+}
+
+// setUpAccTestEnvironment starts the fake API, or sets the credentials of the replayed interactions. It runs before the
+// test configurations are generated, since GenerateTestDomain needs the API ID and the test domain
+func setUpAccTestEnvironment() {
+	testAccEnvironmentSetUp.Do(func() {
+		if os.Getenv("INCAPSULA_FAKE_API") != "" {
+			useFakeAPI()
+		}
+
+		// Replayed interactions don't need credentials
+		if os.Getenv("INCAPSULA_CASSETTE_MODE") == cassetteModeReplay {
+			setEnvIfEmpty("INCAPSULA_API_ID", "replay")
+			setEnvIfEmpty("INCAPSULA_API_KEY", "replay")
+			setEnvIfEmpty("INCAPSULA_CUSTOM_TEST_DOMAIN", ".replay.example.com")
+		}
+	})
+}
+
+// useFakeAPI points the provider to an in-process fake Imperva API, so that the acceptance tests
+// of the resources it implements run without a live account
+func useFakeAPI() {
+	server := fakeapi.NewServer()
+	os.Setenv("INCAPSULA_API_ID", server.APIID)
+	os.Setenv("INCAPSULA_API_KEY", server.APIKey)
+	os.Setenv("INCAPSULA_BASE_URL", server.BaseURL())
+	os.Setenv("INCAPSULA_BASE_URL_REV_2", server.BaseURLRev2())
+	os.Setenv("INCAPSULA_BASE_URL_REV_3", server.BaseURLRev3())
+	os.Setenv("INCAPSULA_BASE_URL_API", server.BaseURLAPI())
+	setEnvIfEmpty("INCAPSULA_CUSTOM_TEST_DOMAIN", ".fakeapi.example.com")
+}
+
+func setEnvIfEmpty(key string, value string) {
+	if os.Getenv(key) == "" {
+		os.Setenv(key, value)
+	}
+}
+
+// testNameSuffix returns the number appended to the names generated by a test. It's random against a live account, and
+// derived from the test name when cassettes are used, so that the replayed requests hold the names that were recorded
+func testNameSuffix(t *testing.T) int {
+	if os.Getenv("INCAPSULA_CASSETTE_MODE") == "" {
+		return rand.New(rand.NewSource(time.Now().UnixNano())).Intn(1000)
+	}
+	if t == nil {
+		return 0
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(t.Name()))
+	return int(hash.Sum32() % 1000)
+}
+
+// useCassette records or replays the API interactions of the test in its own cassette,
+// when INCAPSULA_CASSETTE_MODE is set
+func useCassette(t *testing.T) {
+	if sharedCassetteTransport == nil {
+		return
+	}
+	if err := sharedCassetteTransport.Use(t.Name()); err != nil {
+		t.Fatalf("Error saving the previous cassette: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := sharedCassetteTransport.Eject(); err != nil {
+			t.Errorf("Error saving cassette %s: %s", t.Name(), err)
+		}
+	})
 }
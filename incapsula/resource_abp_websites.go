@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -56,7 +57,7 @@ func resourceAbpWebsites() *schema.Resource {
 				d.SetId(accountIdStr)
 
 				client := meta.(*Client)
-				abpWebsites, diags := client.ReadAbpWebsites(accountId)
+				abpWebsites, diags := client.ReadAbpWebsites(ctx, accountId)
 
 				for _, diagnostic := range diags {
 					if diagnostic.Severity == diag.Error {
@@ -143,6 +144,12 @@ func resourceAbpWebsites() *schema.Resource {
 				Optional: true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -343,7 +350,7 @@ func resourceAbpWebsitesCreate(ctx context.Context, data *schema.ResourceData, m
 	}
 	var abpWebsites *AbpTerraformAccount
 
-	abpWebsites, diags = client.CreateAbpWebsites(accountId, account)
+	abpWebsites, diags = client.CreateAbpWebsites(ctx, accountId, account)
 
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to create ABP websites for Account ID %d", accountId)
@@ -364,7 +371,7 @@ func resourceAbpWebsitesRead(ctx context.Context, data *schema.ResourceData, m i
 	accountId := data.Get("account_id").(int)
 
 	var abpWebsites *AbpTerraformAccount
-	abpWebsites, diags = client.ReadAbpWebsites(accountId)
+	abpWebsites, diags = client.ReadAbpWebsites(ctx, accountId)
 
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to read ABP websites for Account ID %d", accountId)
@@ -387,7 +394,7 @@ func resourceAbpWebsitesUpdate(ctx context.Context, data *schema.ResourceData, m
 	}
 
 	var abpWebsites *AbpTerraformAccount
-	abpWebsites, diags = client.UpdateAbpWebsites(accountId, account)
+	abpWebsites, diags = client.UpdateAbpWebsites(ctx, accountId, account)
 
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to update ABP websites for Account ID %d", accountId)
@@ -406,7 +413,7 @@ func resourceAbpWebsitesDelete(ctx context.Context, data *schema.ResourceData, m
 	accountId := data.Get("account_id").(int)
 	autoPublish := data.Get("auto_publish").(bool)
 
-	_, diags = client.DeleteAbpWebsites(accountId, autoPublish)
+	_, diags = client.DeleteAbpWebsites(ctx, accountId, autoPublish)
 
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to delete ABP websites for Account ID %d", accountId)
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -374,7 +375,7 @@ func testAccCheckAbpWebsitesExists(websitesresponse *AbpTerraformAccount) resour
 			return err
 		}
 
-		response, _ := client.ReadAbpWebsites(id)
+		response, _ := client.ReadAbpWebsites(context.Background(), id)
 		if response == nil {
 			return fmt.Errorf("Failed to retrieve ABP Websites (id=%d)", accountID)
 		}
@@ -606,7 +607,7 @@ func testAccCheckAbpWebsitesDestroy(state *terraform.State) error {
 			return err
 		}
 
-		websitesResponse, _ := client.ReadAbpWebsites(id)
+		websitesResponse, _ := client.ReadAbpWebsites(context.Background(), id)
 		if websitesResponse == nil {
 			return fmt.Errorf("Failed to check ABP Websites status (id=%s)", accountID)
 		}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -100,7 +101,7 @@ func getAccountPolicyAssociationFromClient(state *terraform.State) (*AccountPoli
 	}
 
 	client := testAccProvider.Meta().(*Client)
-	return client.GetAccountPolicyAssociation(accountIDStr)
+	return client.GetAccountPolicyAssociation(context.Background(), accountIDStr)
 }
 
 func validateResourceSlice(resourceKey string, expectedSize int, f func(AccountPolicyAssociationV3) []string) resource.TestCheckFunc {
@@ -1,19 +1,22 @@
 package incapsula
 
 import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
+	"time"
 )
 
 func resourceAccountRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAccountRoleCreate,
-		Read:   resourceAccountRoleRead,
-		Update: resourceAccountRoleUpdate,
-		Delete: resourceAccountRoleDelete,
+		CreateContext: resourceAccountRoleCreate,
+		ReadContext:   resourceAccountRoleRead,
+		UpdateContext: resourceAccountRoleUpdate,
+		DeleteContext: resourceAccountRoleDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -45,6 +48,12 @@ func resourceAccountRole() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -77,7 +86,7 @@ func populateRoleDetailsDTO(d *schema.ResourceData) RoleDetailsBasicDTO {
 	return requestDTO
 }
 
-func resourceAccountRoleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountId := d.Get("account_id").(int)
 
@@ -89,11 +98,11 @@ func resourceAccountRoleCreate(d *schema.ResourceData, m interface{}) error {
 	requestDTO.RoleDetailsBasicDTO = roleDetailsBasicDTO
 	requestDTO.AccountId = d.Get("account_id").(int)
 
-	responseDTO, err := client.AddAccountRole(requestDTO)
+	responseDTO, err := client.AddAccountRole(ctx, requestDTO)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula account role: %s\n", err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the Account Role ID
@@ -101,17 +110,17 @@ func resourceAccountRoleCreate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Created Incapsula account role: %s, Id: %d\n", responseDTO.RoleName, responseDTO.RoleId)
 
 	// Set the rest of the state from the resource read
-	return resourceAccountRoleRead(d, m)
+	return resourceAccountRoleRead(ctx, d, m)
 }
 
-func resourceAccountRoleRead(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	roleID, _ := strconv.Atoi(d.Id())
 
 	log.Printf("[INFO] Reading Incapsula account role ID: %d\n", roleID)
 
-	accountRoleResponse, err := client.GetAccountRole(roleID)
+	accountRoleResponse, err := client.GetAccountRole(ctx, roleID)
 
 	// Account object may have been deleted
 	if accountRoleResponse != nil && accountRoleResponse.ErrorCode == 1047 {
@@ -122,7 +131,7 @@ func resourceAccountRoleRead(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account role ID: %d, %s\n", roleID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.Set("account_id", accountRoleResponse.AccountId)
@@ -139,36 +148,36 @@ func resourceAccountRoleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceAccountRoleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	roleID, _ := strconv.Atoi(d.Id())
 
 	log.Printf("[INFO] Updating Incapsula account role with id: %d\n", roleID)
 
 	requestDTO := populateRoleDetailsDTO(d)
-	responseDTO, err := client.UpdateAccountRole(roleID, requestDTO)
+	responseDTO, err := client.UpdateAccountRole(ctx, roleID, requestDTO)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula account role: %s\n", err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	log.Printf("[INFO] Updated Incapsula account role: %s, Id: %d\n", responseDTO.RoleName, responseDTO.RoleId)
 	// Set the rest of the state from the resource read
-	return resourceAccountRoleRead(d, m)
+	return resourceAccountRoleRead(ctx, d, m)
 }
 
-func resourceAccountRoleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceAccountRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	roleID, _ := strconv.Atoi(d.Id())
 
 	log.Printf("[INFO] Deleting Incapsula account role with id: %d\n", roleID)
 
-	err := client.DeleteAccountRole(roleID)
+	err := client.DeleteAccountRole(ctx, roleID)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula account role with id: %d, %s\n", roleID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -99,7 +100,7 @@ func testCheckIncapsulaAccountRoleDestroy(state *terraform.State) error {
 			return fmt.Errorf("Account Role ID conversion error for %s: %s", accountRoleIDStr, err)
 		}
 
-		accountRoleResponse, err := client.GetAccountRole(accountRoleID)
+		accountRoleResponse, err := client.GetAccountRole(context.Background(), accountRoleID)
 
 		// Account object may have been deleted
 		if accountRoleResponse != nil && accountRoleResponse.ErrorCode != 1047 {
@@ -128,7 +129,7 @@ func testCheckIncapsulaAccountRoleExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		accountRoleStatusResponse, err := client.GetAccountRole(accountRoleID)
+		accountRoleStatusResponse, err := client.GetAccountRole(context.Background(), accountRoleID)
 		if accountRoleStatusResponse == nil {
 			return fmt.Errorf("Incapsula account role id: %d does not exist", accountRoleID)
 		}
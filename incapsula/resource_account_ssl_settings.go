@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
+	"time"
 )
 
 func resourceAccountSSLSettings() *schema.Resource {
@@ -112,6 +113,12 @@ func resourceAccountSSLSettings() *schema.Resource {
 				Default:     false,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -156,7 +163,7 @@ func resourceAccountSSLSettingsUpdate(ctx context.Context, d *schema.ResourceDat
 		fieldVal := d.Get("enable_hsts_for_new_sites").(bool)
 		accountSSLSettingsDTO.EnableHSTSForNewSites = &fieldVal
 	}
-	accountSSLSettingsDTOResponse, diags := client.UpdateAccountSSLSettings(&accountSSLSettingsDTO, accountID)
+	accountSSLSettingsDTOResponse, diags := client.UpdateAccountSSLSettings(ctx, &accountSSLSettingsDTO, accountID)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Could not update Incapsula account SSL settings for Account ID: %s, %v\n", accountID, diags)
 		return diags
@@ -171,11 +178,11 @@ func resourceAccountSSLSettingsUpdate(ctx context.Context, d *schema.ResourceDat
 	err := d.Set("account_id", accountID)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account SSL settings after update for Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	if err != nil {
 		log.Printf("[ERROR] Could not update last_update field of Incapsula account SSL settings resource for Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	resourceAccountSSLSettingsRead(ctx, d, m)
 
@@ -209,7 +216,7 @@ func resourceAccountSSLSettingsRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[INFO] Reading Incapsula account SSL settings for Account ID: %s\n", accountID)
 
-	accountSSLSettingsDTOResponse, diagFromClient := client.GetAccountSSLSettings(accountID)
+	accountSSLSettingsDTOResponse, diagFromClient := client.GetAccountSSLSettings(ctx, accountID)
 
 	if diagFromClient != nil && diagFromClient.HasError() {
 		log.Printf("[ERROR] Could not read Incapsula account SSL settings for Account ID: %s, %v\n", accountID, diagFromClient)
@@ -217,10 +224,10 @@ func resourceAccountSSLSettingsRead(ctx context.Context, d *schema.ResourceData,
 	}
 	accountSSLSettingsDTO := accountSSLSettingsDTOResponse.Data[0]
 	if err := d.Set("use_wild_card_san_instead_of_fqdn", accountSSLSettingsDTO.ImpervaCertificate.UseWildCardSanInsteadOfFQDN); err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	if err := d.Set("add_naked_domain_san_for_www_sites", accountSSLSettingsDTO.ImpervaCertificate.AddNakedDomainSanForWWWSites); err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	if accountSSLSettingsDTO.ImpervaCertificate.Delegation.AllowedDomainsForCNAMEValidation != nil {
 		numberOfNotInheritedDomains := 0
@@ -247,17 +254,17 @@ func resourceAccountSSLSettingsRead(ctx context.Context, d *schema.ResourceData,
 			}
 		}
 		if err := d.Set("allowed_domain_for_cname_validation", domainsList); err != nil {
-			return diag.FromErr(err)
+			return diagnosticsFromError(d, err)
 		}
 	}
 	if err := d.Set("allow_cname_validation", accountSSLSettingsDTO.ImpervaCertificate.Delegation.AllowCNAMEValidation); err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	if err := d.Set("enable_hsts_for_new_sites", accountSSLSettingsDTO.EnableHSTSForNewSites); err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	if err := d.Set("allow_support_old_tls_versions", accountSSLSettingsDTO.AllowSupportOldTLSVersions); err != nil {
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	d.SetId(accountID)
 	return diags
@@ -273,7 +280,7 @@ func resourceAccountSSLSettingsDelete(ctx context.Context, d *schema.ResourceDat
 
 	log.Printf("[INFO] Reseting Incapsula account SSL settings for Account ID: %s\n", accountID)
 
-	diag = client.DeleteAccountSSLSettings(accountID)
+	diag = client.DeleteAccountSSLSettings(ctx, accountID)
 
 	if diag != nil && diag.HasError() {
 		log.Printf("[ERROR] Could not delete Incapsula account SSL settings for Account ID: %s, %v\n", accountID, diag[0].Detail)
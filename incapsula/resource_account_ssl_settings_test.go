@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -98,7 +99,7 @@ func testCheckAccAccountSSLSettingsAfterFullUpdate() resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		settings, diagnostics := client.GetAccountSSLSettings("")
+		settings, diagnostics := client.GetAccountSSLSettings(context.Background(), "")
 		if diagnostics != nil && diagnostics.HasError() {
 			return fmt.Errorf("failed to get account ssl settings after full update resource %s", accountSSLSettingsResourceName)
 		}
@@ -118,7 +119,7 @@ func testCheckAccAccountSSLSettingsAfterPartialUpdate1() resource.TestCheckFunc
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		settings, diagnostics := client.GetAccountSSLSettings("")
+		settings, diagnostics := client.GetAccountSSLSettings(context.Background(), "")
 		if diagnostics != nil && diagnostics.HasError() {
 			return fmt.Errorf("failed to get account ssl settings after partial update1 resource %s", accountSSLSettingsResourceName)
 		}
@@ -138,7 +139,7 @@ func testCheckAccAccountSSLSettingsAfterPartialUpdate2() resource.TestCheckFunc
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		settings, diagnostics := client.GetAccountSSLSettings("")
+		settings, diagnostics := client.GetAccountSSLSettings(context.Background(), "")
 		if diagnostics != nil && diagnostics.HasError() {
 			return fmt.Errorf("failed to get account ssl settings after partia2 update1 resource %s", accountSSLSettingsResourceName)
 		}
@@ -158,7 +159,7 @@ func testCheckAccAccountSSLSettingsAfterPartialUpdate3() resource.TestCheckFunc
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		settings, diagnostics := client.GetAccountSSLSettings("")
+		settings, diagnostics := client.GetAccountSSLSettings(context.Background(), "")
 		if diagnostics != nil && diagnostics.HasError() {
 			return fmt.Errorf("failed to get account ssl settings after partia3 update1 resource %s", accountSSLSettingsResourceName)
 		}
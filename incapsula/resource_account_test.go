@@ -1,13 +1,12 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
-	"math/rand"
 	"os"
 	"strconv"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -106,7 +105,7 @@ func testCheckIncapsulaAccountDestroy(state *terraform.State) error {
 			return fmt.Errorf("Account ID conversion error for %s: %s", accountIDStr, err)
 		}
 
-		_, err = client.AccountStatus(accountID, ReadAccount)
+		_, err = client.AccountStatus(context.Background(), accountID, ReadAccount)
 
 		if err == nil {
 			return fmt.Errorf("Incapsula account id: %d still exists", accountID)
@@ -134,7 +133,7 @@ func testCheckIncapsulaAccountExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+		accountStatusResponse, err := client.AccountStatus(context.Background(), accountID, ReadAccount)
 		if accountStatusResponse == nil {
 			return fmt.Errorf("Incapsula account id: %d does not exist", accountID)
 		}
@@ -190,9 +189,7 @@ func GenerateTestEmail(t *testing.T) string {
 		t.Fatal("INCAPSULA_API_ID must be set for acceptance tests")
 	}
 
-	s3 := rand.NewSource(time.Now().UnixNano())
-	r3 := rand.New(s3)
-	generatedDomain = "id" + os.Getenv("INCAPSULA_API_ID") + strconv.Itoa(r3.Intn(1000)) + testEmail
+	generatedDomain = "id" + os.Getenv("INCAPSULA_API_ID") + strconv.Itoa(testNameSuffix(t)) + testEmail
 
 	return generatedDomain
 }
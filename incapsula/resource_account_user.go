@@ -3,6 +3,7 @@ package incapsula
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"net/mail"
@@ -15,12 +16,22 @@ const sleepTimeSeconds = 2
 
 func resourceAccountUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserCreate,
-		Read:   resourceUserRead,
-		Update: resourceUserUpdate,
-		Delete: resourceUserDelete,
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				// A user is imported either by account_id/email, or by its email in the account of the provider
+				if !strings.Contains(d.Id(), "/") {
+					accountID := m.(*Client).lookupAccountID()
+					if accountID == 0 {
+						return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/email, or email with the provider default_account_id set", d.Id())
+					}
+					d.SetId(fmt.Sprintf("%d/%s", accountID, d.Id()))
+				}
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -109,10 +120,16 @@ func resourceAccountUser() *schema.Resource {
 			}
 			return nil
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	email := d.Get("email").(string)
@@ -122,6 +139,7 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 
 	roleIds := d.Get("role_ids").(*schema.Set)
 	UserAddResponse, err := client.AddAccountUser(
+		ctx,
 		accountId,
 		email,
 		d.Get("first_name").(string),
@@ -131,7 +149,7 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create user for email: %s, %s\n", email, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the User ID
@@ -141,28 +159,30 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
 	log.Printf("[DEBUG] Avoid timing/race condition, sleeping %d seconds\n", sleepTimeSeconds)
-	time.Sleep(sleepTimeSeconds * time.Second)
+	if err := sleepWithContext(ctx, sleepTimeSeconds*time.Second); err != nil {
+		return diagnosticsFromError(d, err)
+	}
 
 	// Set the rest of the state from the resource read
-	return resourceUserRead(d, m)
+	return resourceUserRead(ctx, d, m)
 }
 
-func resourceUserRead(d *schema.ResourceData, m interface{}) error {
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	userID := d.Id()
 	stringSlice := strings.Split(userID, "/")
 	if len(stringSlice) != 2 {
-		return fmt.Errorf("Error parsing ID, actual value: %s, expected numeric id and string seperated by '/'\n", stringSlice)
+		return diag.Errorf("Error parsing ID, actual value: %s, expected numeric id and string seperated by '/'\n", stringSlice)
 	}
 	accountID, _ := strconv.Atoi(stringSlice[0])
 	email := stringSlice[1]
 	log.Printf("[INFO] Reading Incapsula user : %s\n", userID)
 
-	userStatusResponse, err := client.GetAccountUser(accountID, email)
+	userStatusResponse, err := client.GetAccountUser(ctx, accountID, email)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula user: %s, %s\n", email, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	log.Printf("[INFO]listRoles : %v\n", userStatusResponse.Data[0].Roles)
@@ -177,7 +197,7 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("email", userStatusResponse.Data[0].Email)
 	d.Set("account_id", userStatusResponse.Data[0].AccountID)
 
-	accountStatusResponse, err := client.AccountStatus(accountID, ReadAccount)
+	accountStatusResponse, err := client.AccountStatus(ctx, accountID, ReadAccount)
 	if accountStatusResponse != nil && accountStatusResponse.AccountType == "Sub Account" {
 		log.Printf("[DEBUG] User creation on Sub Account, setting null value to avoid forces replacement\n")
 		d.Set("first_name", nil)
@@ -194,7 +214,7 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	email := d.Get("email").(string)
 	accountId := d.Get("account_id").(int)
@@ -203,13 +223,14 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 
 	roleIds := d.Get("role_ids").(*schema.Set)
 	userUpdateResponse, err := client.UpdateAccountUser(
+		ctx,
 		accountId,
 		email,
 		roleIds.List(),
 	)
 	if err != nil {
 		log.Printf("[ERROR] Could not update user for email: %s, %s\n", email, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	log.Printf("[Info] New Roles for user %s : %+v\n", email, userUpdateResponse.Data[0].Roles)
@@ -217,24 +238,26 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
 	log.Printf("[DEBUG] Avoid timing/race condition, sleeping %d seconds\n", sleepTimeSeconds)
-	time.Sleep(sleepTimeSeconds * time.Second)
+	if err := sleepWithContext(ctx, sleepTimeSeconds*time.Second); err != nil {
+		return diagnosticsFromError(d, err)
+	}
 
 	// Set the rest of the state from the resource read
-	return resourceUserRead(d, m)
+	return resourceUserRead(ctx, d, m)
 }
 
-func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 	email := d.Get("email").(string)
 
 	log.Printf("[INFO] Deleting Incapsula user: %s\n", email)
 
-	err := client.DeleteAccountUser(accountID, email)
+	err := client.DeleteAccountUser(ctx, accountID, email)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula user: %s %s\n", email, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -244,7 +267,9 @@ func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
 	// There may be a timing/race condition here
 	// Set an arbitrary period to sleep
 	log.Printf("[DEBUG] Avoid timing/race condition, sleeping %d seconds\n", sleepTimeSeconds)
-	time.Sleep(sleepTimeSeconds * time.Second)
+	if err := sleepWithContext(ctx, sleepTimeSeconds*time.Second); err != nil {
+		return diagnosticsFromError(d, err)
+	}
 
 	log.Printf("[INFO] Deleted Incapsula user: %s\n", email)
 
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -155,7 +156,7 @@ func testCheckIncapsulaAccountUserDestroy(state *terraform.State) error {
 		}
 		keyEmail := keyParts[1]
 
-		accountUserStatusResponse, err := client.GetAccountUser(keyAccountID, keyEmail)
+		accountUserStatusResponse, err := client.GetAccountUser(context.Background(), keyAccountID, keyEmail)
 
 		// Account object may have been deleted
 		if accountUserStatusResponse != nil {
@@ -193,7 +194,7 @@ func testCheckIncapsulaAccountUserExists(name string) resource.TestCheckFunc {
 		keyEmail := keyParts[1]
 
 		client := testAccProvider.Meta().(*Client)
-		accountUserStatusResponse, err := client.GetAccountUser(keyAccountID, keyEmail)
+		accountUserStatusResponse, err := client.GetAccountUser(context.Background(), keyAccountID, keyEmail)
 		if accountUserStatusResponse == nil {
 			return fmt.Errorf(
 				"Incapsula account user with email: %s for account ID %d does not exist",
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -67,7 +68,7 @@ func testACCStateApiSecurityApiConfigDestroy(s *terraform.State) error {
 			fmt.Errorf("failed to convert API Id from import command, actual value : %s, expected numeric id", rs.Primary.ID)
 		}
 
-		_, err = client.GetApiSecurityApiConfig(siteIDInt, apiID)
+		_, err = client.GetApiSecurityApiConfig(context.Background(), siteIDInt, apiID)
 		if err == nil {
 			return fmt.Errorf("Resource %s for Incapsula Api Security Api:  Api Id %d, site ID %d still exists", apiSecApiConfigResourceName, apiID, siteIDInt)
 		}
@@ -115,7 +116,7 @@ func testCheckIncapsulaApiConfigExists() resource.TestCheckFunc {
 		apiIDInt, err := strconv.ParseInt(apiID, 10, 64)
 
 		client := testAccProvider.Meta().(*Client)
-		_, err = client.GetApiSecurityApiConfig(siteIdInt, apiIDInt)
+		_, err = client.GetApiSecurityApiConfig(context.Background(), siteIdInt, apiIDInt)
 		if err != nil {
 			return fmt.Errorf("Incapsula API Security API Config : %s (SiteId : %d, API Id %d) does not exist", apiSecApiConfigResource, siteIdInt, apiIDInt)
 		}
@@ -1,23 +1,26 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceApiSecurityEndpointConfig() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApiSecurityEndpointConfigCreate,
-		Read:   resourceApiSecurityEndpointConfigRead,
-		Update: resourceApiSecurityEndpointConfigUpdate,
-		Delete: resourceApiSecurityEndpointConfigDelete,
+		CreateContext: resourceApiSecurityEndpointConfigCreate,
+		ReadContext:   resourceApiSecurityEndpointConfigRead,
+		UpdateContext: resourceApiSecurityEndpointConfigUpdate,
+		DeleteContext: resourceApiSecurityEndpointConfigDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of API Security Endpoint ID (%q), expected api_id/endpoint_id", d.Id())
@@ -86,16 +89,22 @@ func resourceApiSecurityEndpointConfig() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"ALERT_ONLY", "BLOCK_REQUEST", "BLOCK_USER", "BLOCK_IP", "IGNORE", "DEFAULT"}, false),
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceApiSecurityEndpointConfigRead(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityEndpointConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Read Incapsula API-security endpoint configuration for ID: %s", d.Id())
 	client := m.(*Client)
-	endpointGetResponse, err := client.GetApiSecurityEndpointConfig(int64(d.Get("api_id").(int)), d.Id())
+	endpointGetResponse, err := client.GetApiSecurityEndpointConfig(ctx, int64(d.Get("api_id").(int)), d.Id())
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula API-security endpoint: %s - %s\n", d.Get("id"), err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.Set("missing_param_violation_action", endpointGetResponse.Value.ViolationActions.MissingParamViolationAction)
@@ -106,9 +115,9 @@ func resourceApiSecurityEndpointConfigRead(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceApiSecurityEndpointConfigCreate(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityEndpointConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	endpointGetAllResponse, _ := client.GetApiSecurityAllEndpointsConfig(int64(d.Get("api_id").(int)))
+	endpointGetAllResponse, _ := client.GetApiSecurityAllEndpointsConfig(ctx, int64(d.Get("api_id").(int)))
 	var found bool
 	var endpointId string
 	for _, entry := range endpointGetAllResponse.Value {
@@ -120,15 +129,15 @@ func resourceApiSecurityEndpointConfigCreate(d *schema.ResourceData, m interface
 	}
 
 	if !found {
-		return fmt.Errorf("[ERROR] API-security endpoint [%s %s] doesn't exist and will not be updated.", d.Get("method").(string), d.Get("path").(string))
+		return diag.Errorf("[ERROR] API-security endpoint [%s %s] doesn't exist and will not be updated.", d.Get("method").(string), d.Get("path").(string))
 	}
 	log.Printf("[DEBUG] found endpoint id %s", endpointId)
 	d.SetId(endpointId)
 
-	return resourceApiSecurityEndpointConfigUpdate(d, m)
+	return resourceApiSecurityEndpointConfigUpdate(ctx, d, m)
 }
 
-func resourceApiSecurityEndpointConfigUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityEndpointConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	payload := ApiSecurityEndpointConfigPostPayload{
@@ -143,16 +152,16 @@ func resourceApiSecurityEndpointConfigUpdate(d *schema.ResourceData, m interface
 	if err != nil {
 		fmt.Errorf("Endpoint ID should be numeric. Actual value: %s", d.Id())
 	}
-	_, err = client.PostApiSecurityEndpointConfig(int64(d.Get("api_id").(int)), endpointId, &payload)
+	_, err = client.PostApiSecurityEndpointConfig(ctx, int64(d.Get("api_id").(int)), endpointId, &payload)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	return resourceApiSecurityEndpointConfigRead(d, m)
+	return resourceApiSecurityEndpointConfigRead(ctx, d, m)
 }
 
-func resourceApiSecurityEndpointConfigDelete(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecurityEndpointConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Deleting Incapsula API-security endpoint configuration isn't supported. Request made for endpoint ID: %s \n", d.Id())
 	return nil
 }
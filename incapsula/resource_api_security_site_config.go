@@ -1,22 +1,25 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceApiSecuritySiteConfig() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApiSecuritySiteConfigUpdate,
-		Read:   resourceApiSecuritySiteConfigRead,
-		Update: resourceApiSecuritySiteConfigUpdate,
-		Delete: resourceApiSecuritySiteConfigDelete,
+		CreateContext: resourceApiSecuritySiteConfigUpdate,
+		ReadContext:   resourceApiSecuritySiteConfigRead,
+		UpdateContext: resourceApiSecuritySiteConfigUpdate,
+		DeleteContext: resourceApiSecuritySiteConfigDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				siteID, err := strconv.Atoi(d.Id())
 				if err != nil {
 					fmt.Errorf("failed to convert Site Id from import command, actual value: %s, expected numeric id", d.Id())
@@ -93,10 +96,16 @@ func resourceApiSecuritySiteConfig() *schema.Resource {
 				Computed:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceApiSecuritySiteConfigUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecuritySiteConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Update Incapsula API-security site configuration for site ID: %d", d.Get("site_id"))
 
 	client := m.(*Client)
@@ -114,29 +123,30 @@ func resourceApiSecuritySiteConfigUpdate(d *schema.ResourceData, m interface{})
 	}
 
 	apiSecuritySiteConfigPostResponse, err := client.UpdateApiSecuritySiteConfig(
+		ctx,
 		int64(d.Get("site_id").(int)),
 		&payload)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not update Incapsula API-security Site Configuration on site id: %d - %s\n", d.Get("site_id"), err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	siteID := strconv.FormatInt(apiSecuritySiteConfigPostResponse.Value.SiteId, 10)
 	d.SetId(siteID)
 	log.Printf("[INFO] Updated Incapsula API-security site configuration with ID: %s\n", siteID)
 
-	return resourceApiSecuritySiteConfigRead(d, m)
+	return resourceApiSecuritySiteConfigRead(ctx, d, m)
 }
 
-func resourceApiSecuritySiteConfigRead(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecuritySiteConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := int64(d.Get("site_id").(int))
 
-	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(siteId)
+	apiSecuritySiteConfigGetResponse, err := client.ReadApiSecuritySiteConfig(ctx, siteId)
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula API-security site configuration for site ID: %d - %s\n", siteId, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set computed values
@@ -151,7 +161,7 @@ func resourceApiSecuritySiteConfigRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceApiSecuritySiteConfigDelete(d *schema.ResourceData, m interface{}) error {
+func resourceApiSecuritySiteConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Printf("[ERROR] Deleting Incapsula API-security site configuration isn't supported. request made for site ID: %d \n", d.Get("site_id"))
 	d.SetId("")
 	return nil
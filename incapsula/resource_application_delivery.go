@@ -6,6 +6,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,7 +23,7 @@ func resourceApplicationDelivery() *schema.Resource {
 		UpdateContext: resourceApplicationDeliveryUpdate,
 		DeleteContext: resourceApplicationDeliveryDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				siteID, err := strconv.Atoi(d.Id())
 				if err != nil {
 					fmt.Errorf("failed to convert Site Id from import command for Application Delivery, actual value: %s, expected numeric id", d.Id())
@@ -304,6 +305,12 @@ func resourceApplicationDelivery() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -312,13 +319,13 @@ func resourceApplicationDeliveryRead(ctx context.Context, d *schema.ResourceData
 	siteID := d.Get("site_id").(int)
 	siteIdStr := strconv.Itoa(siteID)
 
-	applicationDelivery, diag := client.GetApplicationDelivery(siteID)
+	applicationDelivery, diag := client.GetApplicationDelivery(ctx, siteID)
 	if diag != nil {
 		log.Printf("[ERROR] Could not get Incapsula Application Delivery for Site Id: %d\n", siteID)
 		return diag
 	}
 
-	errorPages, diag := client.GetErrorPages(siteID)
+	errorPages, diag := client.GetErrorPages(ctx, siteID)
 	if diag != nil {
 		log.Printf("[ERROR] Could not get Incapsula Error Pages for Site Id: %d\n", siteID)
 		return diag
@@ -432,7 +439,7 @@ func resourceApplicationDeliveryUpdate(ctx context.Context, d *schema.ResourceDa
 		Redirection:      redirection,
 	}
 
-	_, diags = client.UpdateApplicationDelivery(siteID, &payload)
+	_, diags = client.UpdateApplicationDelivery(ctx, siteID, &payload)
 
 	if diags != nil {
 		log.Printf("[ERROR] Could not update Incapsula Application Delivery for Site Id: %d - %s\n", siteID, diags[0].Detail)
@@ -465,7 +472,7 @@ func resourceApplicationDeliveryUpdate(ctx context.Context, d *schema.ResourceDa
 			},
 		}
 
-		_, diags := client.UpdateErrorPages(siteID, &customErrorPage)
+		_, diags := client.UpdateErrorPages(ctx, siteID, &customErrorPage)
 
 		if diags != nil {
 			log.Printf("[ERROR] Could not get Incapsula Error Pages for Site Id: %d\n", siteID)
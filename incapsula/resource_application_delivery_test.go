@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -108,7 +109,7 @@ func testCheckApplicationDeliveryExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, err2 := client.GetApplicationDelivery(siteId)
+		_, err2 := client.GetApplicationDelivery(context.Background(), siteId)
 		if err2 != nil {
 			fmt.Errorf("Incapsula Application Delivery doesn't exist")
 		}
@@ -1,22 +1,25 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func resourceATOSiteAllowlist() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceATOSiteAllowlistUpdate,
-		Read:   resourceATOSiteAllowlistRead,
-		Update: resourceATOSiteAllowlistUpdate,
-		Delete: resourceATOSiteAllowlistDelete,
+		CreateContext: resourceATOSiteAllowlistUpdate,
+		ReadContext:   resourceATOSiteAllowlistRead,
+		UpdateContext: resourceATOSiteAllowlistUpdate,
+		DeleteContext: resourceATOSiteAllowlistDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 				// If this id is of form <account_id>/<site_id> extract the sub account Id as well
 				if strings.Contains(d.Id(), "/") {
@@ -73,10 +76,16 @@ func resourceATOSiteAllowlist() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceATOSiteAllowlistRead(d *schema.ResourceData, m interface{}) error {
+func resourceATOSiteAllowlistRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	// Fetch our http client
 	client := m.(*Client)
@@ -85,23 +94,23 @@ func resourceATOSiteAllowlistRead(d *schema.ResourceData, m interface{}) error {
 	siteId, ok := d.Get("site_id").(int)
 
 	if !ok {
-		return fmt.Errorf("site_id should be of type int. Received : %s", d.Get("site_id"))
+		return diag.Errorf("site_id should be of type int. Received : %s", d.Get("site_id"))
 	}
 
 	if siteId == 0 {
 		siteIdFromResourceId, conversionError := strconv.Atoi(d.Id())
 		if conversionError != nil {
-			return fmt.Errorf("atleast one of id or site_id should be set for incapsula_ato_site_allowlist")
+			return diag.Errorf("atleast one of id or site_id should be set for incapsula_ato_site_allowlist")
 		}
 		siteId = siteIdFromResourceId
 	}
 
 	accountId := d.Get("account_id").(int)
-	atoAllowlistDTO, status, err := client.GetAtoSiteAllowlistWithRetries(accountId, siteId)
+	atoAllowlistDTO, status, err := client.GetAtoSiteAllowlistWithRetries(ctx, accountId, siteId)
 
 	// Handle fetch error
 	if err != nil {
-		return fmt.Errorf("[Error] getting ATO allowlist: %s", err)
+		return diag.Errorf("[Error] getting ATO allowlist: %s", err)
 	}
 
 	// Initialize the data map that terraform uses
@@ -144,13 +153,13 @@ func resourceATOSiteAllowlistRead(d *schema.ResourceData, m interface{}) error {
 	err = d.Set("allowlist", atoAllowlistEntry["allowlist"])
 	if err != nil {
 		e := fmt.Errorf("[Error] Error in reading allowlist values : %s", err)
-		return e
+		return diagnosticsFromError(d, e)
 	}
 
 	return nil
 }
 
-func resourceATOSiteAllowlistUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceATOSiteAllowlistUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := d.Get("site_id").(int)
 	accountId := d.Get("account_id").(int)
@@ -167,29 +176,29 @@ func resourceATOSiteAllowlistUpdate(d *schema.ResourceData, m interface{}) error
 	if err != nil {
 		e := fmt.Errorf("[Error] Error forming ATO allow list object for API call : %s", err)
 		log.Printf(e.Error())
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	err = client.UpdateATOSiteAllowlistWithRetries(atoAllowlistDTO)
+	err = client.UpdateATOSiteAllowlistWithRetries(ctx, atoAllowlistDTO)
 	if err != nil {
 		e := fmt.Errorf("[ERROR] Could not update ATO site allowlist for site ID : %d Error : %s \n", atoAllowlistDTO.SiteId, err)
-		return e
+		return diagnosticsFromError(d, e)
 	}
 
-	return resourceATOSiteAllowlistRead(d, m)
+	return resourceATOSiteAllowlistRead(ctx, d, m)
 }
 
-func resourceATOSiteAllowlistDelete(d *schema.ResourceData, m interface{}) error {
+func resourceATOSiteAllowlistDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := d.Get("site_id").(int)
 	accountId := d.Get("account_id").(int)
 
 	log.Printf("[DEBUG] Deleting ATO site allowlist for site ID %d \n", siteId)
 
-	err := client.DeleteATOSiteAllowlist(accountId, siteId)
+	err := client.DeleteATOSiteAllowlist(ctx, accountId, siteId)
 	if err != nil {
 		e := fmt.Errorf("[ERROR] Could not delete ATO site allowlist for site ID : %d Error : %s \n", siteId, err)
-		return e
+		return diagnosticsFromError(d, e)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -67,7 +68,7 @@ func testCheckATOSiteAllowlistConfigExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		aTOAllowlistDTO, _, err := client.GetAtoSiteAllowlistWithRetries(accountId, siteId)
+		aTOAllowlistDTO, _, err := client.GetAtoSiteAllowlistWithRetries(context.Background(), accountId, siteId)
 		if err != nil {
 			return fmt.Errorf("[ERROR] cannot fetch ATO allowlist for site ID %d, Error : %s", siteId, err)
 		}
@@ -119,7 +120,7 @@ func testACCStateATOSiteAllowlistConfigDestroy(s *terraform.State) error {
 		accountId := rs.Primary.Attributes["account_id"]
 		accountIdInt, _ := strconv.Atoi(accountId)
 
-		atoAllowlistDTO, _, _ := client.GetAtoSiteAllowlist(accountIdInt, siteIdInt)
+		atoAllowlistDTO, _, _ := client.GetAtoSiteAllowlist(context.Background(), accountIdInt, siteIdInt)
 		if err != nil && atoAllowlistDTO != nil && atoAllowlistDTO.Allowlist != nil && len(atoAllowlistDTO.Allowlist) > 0 {
 			return fmt.Errorf("resource %s for ATO site allowlist : Api Id %s, site ID %d still exists", atoSiteAllowlistResourceType, rs.Primary.ID, siteIdInt)
 		}
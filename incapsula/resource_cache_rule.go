@@ -1,30 +1,42 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-incapsula/incapsula/rulefilter"
 )
 
 func resourceCacheRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCacheRuleCreate,
-		Read:   resourceCacheRuleRead,
-		Update: resourceCacheRuleUpdate,
-		Delete: resourceCacheRuleDelete,
+		CreateContext: resourceCacheRuleCreate,
+		ReadContext:   resourceCacheRuleRead,
+		UpdateContext: resourceCacheRuleUpdate,
+		DeleteContext: resourceCacheRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// Rule names may contain slashes, domains can't
+				idSlice := strings.SplitN(d.Id(), "/", 2)
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id or domain/rule_name", d.Id())
 				}
 
-				siteID := idSlice[0]
+				client := meta.(*Client)
+				siteID, err := client.lookupSiteID(ctx, idSlice[0])
+				if err != nil {
+					return nil, err
+				}
 				d.Set("site_id", siteID)
 
-				ruleID := idSlice[1]
+				ruleID, err := client.lookupCacheRuleID(ctx, siteID, idSlice[1])
+				if err != nil {
+					return nil, err
+				}
 				d.SetId(ruleID)
 
 				return []*schema.ResourceData{d}, nil
@@ -39,6 +51,13 @@ func resourceCacheRule() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. When not set, the requests are sent with the provider default_account_id, and the account of the site is read into the state.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"name": {
 				Description: "Rule name",
 				Type:        schema.TypeString,
@@ -50,9 +69,11 @@ func resourceCacheRule() *schema.Resource {
 				Required:    true,
 			},
 			"filter": {
-				Description: "The filter defines the conditions that trigger the rule action, if left empty, the rule is always run.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:      "The filter defines the conditions that trigger the rule action, if left empty, the rule is always run.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateRuleFilter(rulefilter.CacheRule),
+				DiffSuppressFunc: suppressEquivalentRuleFilterDiffs,
 			},
 			"enabled": {
 				Description: "Boolean that specifies if the rule should be enabled.",
@@ -81,10 +102,17 @@ func resourceCacheRule() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceCacheRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 
 	rule := CacheRule{
@@ -98,27 +126,28 @@ func resourceCacheRuleCreate(d *schema.ResourceData, m interface{}) error {
 		DifferentiateByValue: d.Get("differentiate_by_value").(string),
 	}
 
-	ruleWithID, err := client.AddCacheRule(d.Get("site_id").(string), &rule)
+	ruleWithID, err := client.AddCacheRule(ctx, d.Get("site_id").(string), &rule)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId(strconv.Itoa(ruleWithID.RuleID))
 
-	return resourceCacheRuleRead(d, m)
+	return resourceCacheRuleRead(ctx, d, m)
 }
 
-func resourceCacheRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	rule, statusCode, err := client.ReadCacheRule(d.Get("site_id").(string), ruleID)
+	rule, statusCode, err := client.ReadCacheRule(ctx, d.Get("site_id").(string), ruleID)
 
 	// If the rule is deleted on the server, blow it out locally and run through the normal TF cycle
 	if statusCode == 404 {
@@ -127,7 +156,7 @@ func resourceCacheRuleRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Update all of the properties
@@ -140,10 +169,11 @@ func resourceCacheRuleRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("text", rule.Text)
 	d.Set("differentiate_by_value", rule.DifferentiateByValue)
 
-	return nil
+	return diagnosticsFromError(d, setResourceAccountID(ctx, d, client))
 }
 
-func resourceCacheRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 
 	rule := CacheRule{
@@ -159,29 +189,30 @@ func resourceCacheRuleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	err = client.UpdateCacheRule(d.Get("site_id").(string), ruleID, &rule)
+	err = client.UpdateCacheRule(ctx, d.Get("site_id").(string), ruleID, &rule)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	return resourceCacheRuleRead(d, m)
+	return resourceCacheRuleRead(ctx, d, m)
 }
 
-func resourceCacheRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCacheRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	err = client.DeleteCacheRule(d.Get("site_id").(string), ruleID)
+	err = client.DeleteCacheRule(ctx, d.Get("site_id").(string), ruleID)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"testing"
@@ -72,7 +73,7 @@ func testAccCheckIncapsulaCacheRuleDestroy(state *terraform.State) error {
 			return fmt.Errorf("Incapsula Site ID does not exist for Cache Rule ID %d", ruleID)
 		}
 
-		_, statusCode, err := client.ReadCacheRule(siteID, ruleID)
+		_, statusCode, err := client.ReadCacheRule(context.Background(), siteID, ruleID)
 		if statusCode != 404 {
 			return fmt.Errorf("Incapsula Cache Rule %d (site id: %s) should have received 404 status code", ruleID, siteID)
 		}
@@ -102,7 +103,7 @@ func testCheckIncapsulaCacheRuleExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, statusCode, err := client.ReadCacheRule(siteID, ruleID)
+		_, statusCode, err := client.ReadCacheRule(context.Background(), siteID, ruleID)
 		if statusCode != 200 {
 			return fmt.Errorf("Incapsula Cache Rule: %s (site id: %s) should have received 200 status code", name, siteID)
 		}
@@ -1,20 +1,31 @@
 package incapsula
 
 import (
+	"context"
 	"crypto/sha1"
+	"crypto/x509"
 	"encoding/hex"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
+	"time"
 )
 
+// certificateInspectionAttributes are the computed attributes describing the leaf certificate
+var certificateInspectionAttributes = []string{"not_before", "not_after", "subject", "issuer", "sans", "fingerprint_sha256"}
+
 func resourceCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCertificateCreate,
-		Read:   resourceCertificateRead,
-		Update: resourceCertificateUpdate,
-		Delete: resourceCertificateDelete,
+		CreateContext: resourceCertificateCreate,
+		ReadContext:   resourceCertificateRead,
+		UpdateContext: resourceCertificateUpdate,
+		DeleteContext: resourceCertificateDelete,
+		CustomizeDiff: resourceCertificateCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.SetId("12345")
 				d.Set("site_id", d.Get("site_id").(string))
 				return []*schema.ResourceData{d}, nil
@@ -65,14 +76,61 @@ func resourceCertificate() *schema.Resource {
 					return false
 				},
 			},
+			"expiration_warning_days": {
+				Description:  "The number of days before the expiration of the certificate of the configuration from which plan and apply warn about it. 0 disables the warning.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			// Computed Attributes
+			"not_before": {
+				Description: "The time the certificate is valid from, in RFC 3339 format. Empty for PFX certificate files.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"not_after": {
+				Description: "The time the certificate expires, in RFC 3339 format. Empty for PFX certificate files.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"subject": {
+				Description: "The subject of the certificate. Empty for PFX certificate files.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"issuer": {
+				Description: "The issuer of the certificate. Empty for PFX certificate files.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sans": {
+				Description: "The subject alternative names of the certificate. Empty for PFX certificate files.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"fingerprint_sha256": {
+				Description: "The SHA-256 fingerprint of the certificate, in lower case hexadecimal. Empty for PFX certificate files.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
-func resourceCertificateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	inputHash := createHash(d)
 	_, err := client.AddCertificate(
+		ctx,
 		d.Get("site_id").(string),
 		d.Get("certificate").(string),
 		d.Get("private_key").(string),
@@ -82,22 +140,22 @@ func resourceCertificateCreate(d *schema.ResourceData, m interface{}) error {
 	)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// TODO: Setting this to arbitrary value as there is only one cert for each site.
 	d.SetId("12345")
 
-	return resourceCertificateRead(d, m)
+	return resourceCertificateRead(ctx, d, m)
 }
 
-func resourceCertificateRead(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListCertificatesResponse for the data center
 	client := m.(*Client)
 
 	siteID := d.Get("site_id").(string)
 	operation := getOperation(d)
-	listCertificatesResponse, err := client.ListCertificates(siteID, operation)
+	listCertificatesResponse, err := client.ListCertificates(ctx, siteID, operation)
 
 	// List data centers response object may indicate that the Site ID has been deleted (9413)
 	if listCertificatesResponse != nil && listCertificatesResponse.Res == 9413 {
@@ -108,15 +166,125 @@ func resourceCertificateRead(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read custom certificate from Incapsula site for site_id: %s, %s\n", siteID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.Set("input_hash", listCertificatesResponse.SSL.CustomCertificate.InputHash)
 	d.SetId("12345")
 
+	// The certificate is inspected from the configuration, it is missing after an import
+	chain, err := parseCertificateChain(d.Get("certificate").(string))
+	if err != nil || len(chain) == 0 {
+		for _, attribute := range certificateInspectionAttributes {
+			d.Set(attribute, nil)
+		}
+		return nil
+	}
+	for attribute, value := range flattenCertificateInspection(chain[0]) {
+		d.Set(attribute, value)
+	}
+	return nil
+}
+
+// validateCertificateConfig warns about a certificate of the configuration that expires soon. The configuration is
+// validated by every plan and apply, so the warning is shown before the certificate is uploaded
+func validateCertificateConfig(config cty.Value) diag.Diagnostics {
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	certificate := config.GetAttr("certificate")
+	if certificate.IsNull() || !certificate.IsKnown() {
+		return nil
+	}
+	warningDays := 30
+	if value := config.GetAttr("expiration_warning_days"); !value.IsNull() {
+		if !value.IsKnown() {
+			return nil
+		}
+		days, _ := value.AsBigFloat().Int64()
+		warningDays = int(days)
+	}
+
+	// The certificates that can't be parsed are reported by the plan, PFX files aren't inspected
+	chain, err := parseCertificateChain(certificate.AsString())
+	if err != nil || len(chain) == 0 {
+		return nil
+	}
+	diags := certificateExpirationWarning(chain[0], warningDays, time.Now())
+	for i := range diags {
+		diags[i].AttributePath = cty.GetAttrPath("certificate")
+	}
+	return diags
+}
+
+// resourceCertificateCustomizeDiff inspects a new certificate: the private key must match it, and the file must hold
+// its chain of intermediate certificates. The computed attributes describing it are then known at plan time
+func resourceCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("certificate") && !d.HasChange("private_key") {
+		return nil
+	}
+	if !d.NewValueKnown("certificate") || !d.NewValueKnown("private_key") {
+		for _, attribute := range certificateInspectionAttributes {
+			d.SetNewComputed(attribute)
+		}
+		return nil
+	}
+
+	chain, err := parseCertificateChain(d.Get("certificate").(string))
+	if err != nil {
+		return fmt.Errorf("Error inspecting the certificate: %w", err)
+	}
+	if len(chain) == 0 {
+		for _, attribute := range certificateInspectionAttributes {
+			d.SetNew(attribute, nil)
+		}
+		return nil
+	}
+
+	if privateKey := d.Get("private_key").(string); privateKey != "" {
+		if err := checkCertificatePrivateKey(chain[0], privateKey); err != nil {
+			return fmt.Errorf("Error inspecting the certificate: %w", err)
+		}
+	}
+	if err := checkCertificateChain(chain); err != nil {
+		return fmt.Errorf("Error inspecting the certificate: %w", err)
+	}
+
+	for attribute, value := range flattenCertificateInspection(chain[0]) {
+		if err := d.SetNew(attribute, value); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func flattenCertificateInspection(leaf *x509.Certificate) map[string]interface{} {
+	return map[string]interface{}{
+		"not_before":         leaf.NotBefore.UTC().Format(time.RFC3339),
+		"not_after":          leaf.NotAfter.UTC().Format(time.RFC3339),
+		"subject":            leaf.Subject.String(),
+		"issuer":             leaf.Issuer.String(),
+		"sans":               certificateSANs(leaf),
+		"fingerprint_sha256": certificateFingerprintSHA256(leaf),
+	}
+}
+
+// certificateExpirationWarning warns when the certificate expires within the given number of days
+func certificateExpirationWarning(leaf *x509.Certificate, warningDays int, now time.Time) diag.Diagnostics {
+	if warningDays == 0 || leaf.NotAfter.After(now.AddDate(0, 0, warningDays)) {
+		return nil
+	}
+	summary := fmt.Sprintf("The custom certificate of %s expires on %s", leaf.Subject, leaf.NotAfter.UTC().Format(time.RFC3339))
+	if !leaf.NotAfter.After(now) {
+		summary = fmt.Sprintf("The custom certificate of %s expired on %s", leaf.Subject, leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  summary,
+		Detail:   fmt.Sprintf("The certificate expires within %d days (expiration_warning_days), upload a renewed certificate.", warningDays),
+	}}
+}
+
 func getOperation(d *schema.ResourceData) string {
 	isCustomCertificate := d.Get("api_detail") != nil
 	operation := ReadCustomCertificate
@@ -128,12 +296,18 @@ func getOperation(d *schema.ResourceData) string {
 	return operation
 }
 
-func resourceCertificateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
+	// The warning window is only used by the provider, the certificate isn't uploaded again
+	if !d.HasChangeExcept("expiration_warning_days") {
+		return resourceCertificateRead(ctx, d, m)
+	}
+
 	inputHash := createHash(d)
 
 	_, err := client.EditCertificate(
+		ctx,
 		d.Get("site_id").(string),
 		d.Get("certificate").(string),
 		d.Get("private_key").(string),
@@ -143,23 +317,24 @@ func resourceCertificateUpdate(d *schema.ResourceData, m interface{}) error {
 	)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId("12345")
-	return resourceCertificateRead(d, m)
+	return resourceCertificateRead(ctx, d, m)
 }
 
-func resourceCertificateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	err := client.DeleteCertificate(
+		ctx,
 		d.Get("site_id").(string),
 		d.Get("auth_type").(string),
 	)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -1,10 +1,13 @@
 package incapsula
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
+	"time"
 )
 
 var apiDetailsResource = schema.Resource{
@@ -32,14 +35,14 @@ var apiDetailsResource = schema.Resource{
 
 func resourceCustomCertificateHsm() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCertificateHsmCreateAndUpdate,
+		CreateContext: resourceCertificateHsmCreateAndUpdate,
 		//HSM & custom certificate using same read from resource_certificate.go
 		//but with different operation header in the rest request
-		Read:   resourceCertificateRead,
-		Update: resourceCertificateHsmCreateAndUpdate,
-		Delete: resourceCertificateHsmDelete,
+		ReadContext:   resourceCertificateRead,
+		UpdateContext: resourceCertificateHsmCreateAndUpdate,
+		DeleteContext: resourceCertificateHsmDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.SetId("12345")
 				d.Set("site_id", d.Get("site_id").(string))
 				return []*schema.ResourceData{d}, nil
@@ -88,12 +91,18 @@ func resourceCustomCertificateHsm() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
 // resourceCertificateHsmCreateAndUpdate Create and update are the same rest end point & logic, so we don't need
 // additional function. This is the api behaviour, we can't just update part of the certificate
-func resourceCertificateHsmCreateAndUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateHsmCreateAndUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	siteId := d.Get("site_id").(string)
 	log.Printf("[DEBUG] Start createing HSM custome certificate for site id %s", siteId)
 	client := m.(*Client)
@@ -104,6 +113,7 @@ func resourceCertificateHsmCreateAndUpdate(d *schema.ResourceData, m interface{}
 
 	inputHash := createHashFromHSMDataDTO(&hSMDataDTO, siteId)
 	_, err := client.AddHsmCertificate(
+		ctx,
 		siteId,
 		inputHash,
 		&hSMDataDTO,
@@ -111,13 +121,13 @@ func resourceCertificateHsmCreateAndUpdate(d *schema.ResourceData, m interface{}
 
 	if err != nil {
 		log.Printf("[ERROR] Uploading HSM custom certificate to Site ID %s got error: %s\n", d.Get("site_id"), err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId("12345")
 	log.Printf("[DEBUG] Done createing HSM custome certificate for site id %s, now reding the data", siteId)
 
-	return resourceCertificateRead(d, m)
+	return resourceCertificateRead(ctx, d, m)
 }
 
 func getHsmDetailsFromResource(d *schema.ResourceData) []HSMDetailsDTO {
@@ -142,15 +152,15 @@ func getHsmDetailsFromResource(d *schema.ResourceData) []HSMDetailsDTO {
 	return hsmDetailList
 }
 
-func resourceCertificateHsmDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateHsmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteId := d.Get("site_id").(string)
 	log.Printf("[DEBUG] Strt removing HSM certificate for site id: %s with resourceCertificateHsmDelete", siteId)
-	err := client.DeleteHsmCertificate(siteId)
+	err := client.DeleteHsmCertificate(ctx, siteId)
 
 	if err != nil {
 		log.Printf("[ERROR] Removing HSM certificate for site id: %s faild with error: %s", siteId, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"log"
 	"os"
@@ -126,7 +127,7 @@ func checkHsmCustomCertificateExists(fullResourceName string) resource.TestCheck
 		siteIdStr := res.Primary.Attributes["site_id"]
 		accountId, _ := strconv.Atoi(siteIdStr)
 		log.Printf("[INFO] ****Test**** siteId: %d ", accountId)
-		listCertificatesResponse, err := client.ListCertificates(siteIdStr, ReadHSMCustomCertificate)
+		listCertificatesResponse, err := client.ListCertificates(context.Background(), siteIdStr, ReadHSMCustomCertificate)
 		if err != nil {
 			return err
 		}
@@ -1,15 +1,32 @@
 package incapsula
 
 import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"strconv"
+	"time"
 )
 
 func resourceCertificateSigningRequest() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCertificateSigningRequestCreate,
-		Read:   resourceCertificateSigningRequestRead,
-		Update: resourceCertificateSigningRequestUpdate,
-		Delete: resourceCertificateSigningRequestDelete,
+		CreateContext: resourceCertificateSigningRequestCreate,
+		ReadContext:   resourceCertificateSigningRequestRead,
+		UpdateContext: resourceCertificateSigningRequestUpdate,
+		DeleteContext: resourceCertificateSigningRequestDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if _, err := strconv.Atoi(d.Id()); err != nil {
+					return nil, fmt.Errorf("failed to convert site ID from import command, actual value: %s, expected numeric id", d.Id())
+				}
+
+				d.Set("site_id", d.Id())
+				log.Printf("[DEBUG] To Import Certificate Signing Request for site ID: %s", d.Id())
+				return []*schema.ResourceData{d}, nil
+			},
+		},
 
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
@@ -21,39 +38,46 @@ func resourceCertificateSigningRequest() *schema.Resource {
 			},
 			// Optional Arguments
 			"domain": {
-				Description: "common name. For example: example.com.",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "common name. For example: example.com.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressImportedCertificateSigningRequestDiff,
 			},
 			"email": {
-				Description: "Email address. For example: joe@example.com.",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "Email address. For example: joe@example.com.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressImportedCertificateSigningRequestDiff,
 			},
 			"country": {
-				Description: "The two-letter ISO code for the country where your organization is located.",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "The two-letter ISO code for the country where your organization is located.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressImportedCertificateSigningRequestDiff,
 			},
 			"state": {
-				Description: "The state/region where your organization is located. This should not be abbreviated.",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "The state/region where your organization is located. This should not be abbreviated.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressImportedCertificateSigningRequestDiff,
 			},
 			"city": {
-				Description: "The city where your organization is located.",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "The city where your organization is located.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressImportedCertificateSigningRequestDiff,
 			},
 			"organization": {
-				Description: "The legal name of your organization. This should not be abbreviated or include suffixes such as Inc., Corp., or LLC.",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "The legal name of your organization. This should not be abbreviated or include suffixes such as Inc., Corp., or LLC.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressImportedCertificateSigningRequestDiff,
 			},
 			"organization_unit": {
-				Description: "The division of your organization handling the certificate. For example, IT Department.",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "The division of your organization handling the certificate. For example, IT Department.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressImportedCertificateSigningRequestDiff,
 			},
 			// Computed Arguments
 			"csr_content": {
@@ -62,38 +86,56 @@ func resourceCertificateSigningRequest() *schema.Resource {
 				Computed:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceCertificateSigningRequestCreate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateSigningRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	certificateSigningRequestResponse, err := client.CreateCertificateSigningRequest(
+		ctx,
 		d.Get("site_id").(string),
-		d.Get("domain").(string),
-		d.Get("email").(string),
-		d.Get("country").(string),
-		d.Get("state").(string),
-		d.Get("city").(string),
-		d.Get("organization").(string),
-		d.Get("organization_unit").(string),
+		configuredString(d, "domain"),
+		configuredString(d, "email"),
+		configuredString(d, "country"),
+		configuredString(d, "state"),
+		configuredString(d, "city"),
+		configuredString(d, "organization"),
+		configuredString(d, "organization_unit"),
 	)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.Set("csr_content", certificateSigningRequestResponse.CsrContent)
+	// The subject of an imported CSR is tracked once the CSR is generated again
+	for _, key := range []string{"domain", "email", "country", "state", "city", "organization", "organization_unit"} {
+		d.Set(key, configuredString(d, key))
+	}
 
 	d.SetId(d.Get("site_id").(string))
 
 	return nil
 }
 
-func resourceCertificateSigningRequestRead(d *schema.ResourceData, m interface{}) error {
+// suppressImportedCertificateSigningRequestDiff suppresses the diff of the subject of an imported CSR, which the API doesn't return,
+// so that importing doesn't generate a new CSR. The CSR content is only unknown after an import
+func suppressImportedCertificateSigningRequestDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("csr_content").(string) == "" && suppressUnreadableAttributeDiff(k, old, new, d)
+}
+
+func resourceCertificateSigningRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The API doesn't return the CSR, the state is kept as is
 	return nil
 }
 
-func resourceCertificateSigningRequestUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateSigningRequestUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.HasChange("domain") ||
 		d.HasChange("email") ||
 		d.HasChange("country") ||
@@ -101,11 +143,11 @@ func resourceCertificateSigningRequestUpdate(d *schema.ResourceData, m interface
 		d.HasChange("city") ||
 		d.HasChange("organization") ||
 		d.HasChange("organization_unit") {
-		return resourceCertificateSigningRequestCreate(d, m)
+		return resourceCertificateSigningRequestCreate(ctx, d, m)
 	}
 	return nil
 }
 
-func resourceCertificateSigningRequestDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCertificateSigningRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
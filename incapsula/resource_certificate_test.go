@@ -1,7 +1,10 @@
 package incapsula
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -59,7 +62,7 @@ func testCheckIncapsulaCertificateExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		listCertificatesResponse, _ := client.ListCertificates(siteID, ReadHSMCustomCertificate)
+		listCertificatesResponse, _ := client.ListCertificates(context.Background(), siteID, ReadHSMCustomCertificate)
 		if listCertificatesResponse == nil && listCertificatesResponse.Res == 9413 {
 			return fmt.Errorf("Incapsula Custom Certificate : %s (SiteId : %s) does not exist", certificateResource, siteID)
 		}
@@ -80,7 +83,7 @@ func testAccCheckIncapsulaCertificateDestroy(state *terraform.State) error {
 		}
 
 		// List certificates response object may indicate that the certificate has been deleted (9413)
-		listCertificatesResponse, _ := client.ListCertificates(siteID, ReadHSMCustomCertificate)
+		listCertificatesResponse, _ := client.ListCertificates(context.Background(), siteID, ReadHSMCustomCertificate)
 		if listCertificatesResponse != nil && listCertificatesResponse.Res != 9413 {
 			return fmt.Errorf("Resource %s for Incapsula Custom Certificate: site ID %s still exists", certificateResourceName, siteID)
 		}
@@ -190,3 +193,44 @@ func getCertificateTemplate(Domain string) *x509.Certificate {
 
 	return template
 }
+
+func TestResourceCertificateUpdateExpirationWarningDaysOnly(t *testing.T) {
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() == "/"+endpointCertificateEdit {
+			uploads++
+		}
+		rw.Write([]byte(`{"res":0,"res_message":"OK","ssl":{"custom_certificate":{"active":true,"inputHash":"hash"}}}`))
+	}))
+	defer server.Close()
+	client := &Client{config: &Config{APIID: "foo", APIKey: "bar", BaseURL: server.URL}, httpClient: &http.Client{}}
+
+	r := resourceCertificate()
+	state := &terraform.InstanceState{ID: "12345", Attributes: map[string]string{
+		"id":                      "12345",
+		"site_id":                 "1234",
+		"certificate":             "foo",
+		"auth_type":               "RSA",
+		"input_hash":              calculateHash("foo", "", ""),
+		"expiration_warning_days": "30",
+	}}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"site_id":                 "1234",
+		"certificate":             "foo",
+		"expiration_warning_days": 60,
+	})
+	diff, err := r.Diff(context.Background(), state, config, client)
+	if err != nil || diff == nil {
+		t.Fatalf("Should have planned the change of expiration_warning_days, got: %v, %v", diff, err)
+	}
+	newState, diags := r.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %v", diags)
+	}
+	if uploads != 0 {
+		t.Errorf("Should not have uploaded the certificate again, got %d uploads", uploads)
+	}
+	if newState.Attributes["expiration_warning_days"] != "60" {
+		t.Errorf("Should have stored the new expiration_warning_days, got: %s", newState.Attributes["expiration_warning_days"])
+	}
+}
@@ -1,12 +1,15 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -17,12 +20,12 @@ const (
 
 func resourceCSPSiteConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCSPSiteConfigurationUpdate,
-		Read:   resourceCSPSiteConfigurationRead,
-		Update: resourceCSPSiteConfigurationUpdate,
-		Delete: resourceCSPSiteConfigurationDelete,
+		CreateContext: resourceCSPSiteConfigurationUpdate,
+		ReadContext:   resourceCSPSiteConfigurationRead,
+		UpdateContext: resourceCSPSiteConfigurationUpdate,
+		DeleteContext: resourceCSPSiteConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				keyParts := strings.Split(d.Id(), "/")
 				if len(keyParts) != 2 {
 					return nil, fmt.Errorf("Error parsing ID, actual value: %s, expected numeric id and string seperated by '/'\n", d.Id())
@@ -75,20 +78,26 @@ func resourceCSPSiteConfiguration() *schema.Resource {
 				Optional: true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceCSPSiteConfigurationRead(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	accountID := d.Get("account_id").(int)
 
 	log.Printf("[DEBUG] Reading CSP site configuration for site ID:  %d of account %d.", siteID, accountID)
 
-	cspSite, err := client.GetCSPSite(accountID, siteID)
+	cspSite, err := client.GetCSPSite(ctx, accountID, siteID)
 	if err != nil {
 		log.Printf("[ERROR] Could not get CSP site config: %s - %s\n", d.Id(), err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	log.Printf("[DEBUG] Reading CSP site configuration for site ID: %d , response: %v.", siteID, cspSite)
 
@@ -112,7 +121,7 @@ func resourceCSPSiteConfigurationRead(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceCSPSiteConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	emails := d.Get("email_addresses").(*schema.Set)
 	siteID := d.Get("site_id").(int)
@@ -146,23 +155,23 @@ func resourceCSPSiteConfigurationUpdate(d *schema.ResourceData, m interface{}) e
 	}
 
 	log.Printf("[DEBUG] Updating CSP site configuration for site ID: %d , values: %v.", siteID, cspSiteConfig)
-	updatedSite, err := client.UpdateCSPSiteWithRetries(accountID, siteID, &cspSiteConfig)
+	updatedSite, err := client.UpdateCSPSiteWithRetries(ctx, accountID, siteID, &cspSiteConfig)
 	if err != nil {
 		log.Printf("[ERROR] Could not update CSP site config: %s - %s\n", d.Id(), err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	log.Printf("[DEBUG] Updating CSP site configuration for site ID: %d , got response: %v.", siteID, updatedSite)
 	newID := fmt.Sprintf("%d/%d", accountID, siteID)
 	d.SetId(newID)
 
-	return resourceCSPSiteConfigurationRead(d, m)
+	return resourceCSPSiteConfigurationRead(ctx, d, m)
 }
 
-func resourceCSPSiteConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Deleting the CSP settings is just setting the site mode to off
 	d.Set("mode", cspSiteModeOff)
-	if err := resourceCSPSiteConfigurationUpdate(d, m); err != nil {
-		return err
+	if diags := resourceCSPSiteConfigurationUpdate(ctx, d, m); diags.HasError() {
+		return diags
 	}
 	d.SetId("")
 	return nil
@@ -1,13 +1,16 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -17,12 +20,12 @@ const (
 
 func resourceCSPSiteDomain() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCSPSiteDomainUpdate,
-		Read:   resourceCSPSiteDomainRead,
-		Update: resourceCSPSiteDomainUpdate,
-		Delete: resourceCSPSiteDomainDelete,
+		CreateContext: resourceCSPSiteDomainUpdate,
+		ReadContext:   resourceCSPSiteDomainRead,
+		UpdateContext: resourceCSPSiteDomainUpdate,
+		DeleteContext: resourceCSPSiteDomainDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				keyParts := strings.Split(d.Id(), "/")
 				if len(keyParts) != 3 {
 					return nil, fmt.Errorf("Error parsing ID, actual value: %s, expected two numeric IDs and string seperated by '/'\n", d.Id())
@@ -92,10 +95,16 @@ func resourceCSPSiteDomain() *schema.Resource {
 				Optional: true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 	siteID := d.Get("site_id").(int)
@@ -104,7 +113,7 @@ func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[DEBUG] Reading CSP domain for site ID: %d , domain reference: %s , domain: %s", siteID, domainRef, domain)
 
-	cspNotes, err := client.getCSPDomainNotes(accountID, siteID, domain)
+	cspNotes, err := client.getCSPDomainNotes(ctx, accountID, siteID, domain)
 	if err != nil {
 		log.Printf("[ERROR] Could not get CSP domain notes: %s - %s\n", d.Id(), err)
 	} else {
@@ -120,7 +129,7 @@ func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// First check if it's a pre-approved domain, and update resource according to that
-	preApprovedDomain, err := client.getCSPPreApprovedDomain(accountID, siteID, domain)
+	preApprovedDomain, err := client.getCSPPreApprovedDomain(ctx, accountID, siteID, domain)
 	if err != nil {
 		log.Printf("[ERROR] Could not get CSP pre-approved domain : %s - %s\n", d.Id(), err)
 	} else {
@@ -133,7 +142,7 @@ func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// If domain wasn't found as pre-approved domain, check if status set directly and update accordingly
-	status, err := client.getCSPDomainStatus(accountID, siteID, domain)
+	status, err := client.getCSPDomainStatus(ctx, accountID, siteID, domain)
 	if err != nil {
 		log.Printf("[ERROR] Could not get CSP domain status: %s - %s\n", d.Id(), err)
 	} else if status.Blocked != nil {
@@ -155,7 +164,7 @@ func resourceCSPSiteDomainRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceCSPSiteDomainUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 	siteID := d.Get("site_id").(int)
@@ -174,10 +183,10 @@ func resourceCSPSiteDomainUpdate(d *schema.ResourceData, m interface{}) error {
 			ReferenceID: base64.RawURLEncoding.EncodeToString([]byte(domain)),
 		}
 		log.Printf("[DEBUG] Updating CSP domain for site ID: %d , domain: %v\n", siteID, dom)
-		updatedDom, err := client.updateCSPPreApprovedDomain(accountID, siteID, &dom)
+		updatedDom, err := client.updateCSPPreApprovedDomain(ctx, accountID, siteID, &dom)
 		if err != nil {
 			log.Printf("[ERROR] Could not update CSP pre-approved domain: %v - %s\n", dom, err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 		log.Printf("[DEBUG] Updating CSP domain %v for site ID: %d , got response: %v.", dom, siteID, updatedDom)
 	} else if strings.Compare(status, cspDomainStatusBlocked) == 0 {
@@ -189,27 +198,27 @@ func resourceCSPSiteDomainUpdate(d *schema.ResourceData, m interface{}) error {
 		*(st.Blocked) = true
 		*(st.Reviewed) = true
 
-		domainStatus, err := client.updateCSPDomainStatus(accountID, siteID, domain, &st)
+		domainStatus, err := client.updateCSPDomainStatus(ctx, accountID, siteID, domain, &st)
 		if err != nil || domainStatus.Blocked == nil || domainStatus.Reviewed == nil {
 			e := fmt.Errorf("[ERROR] Could not update CSP domain %s status: %v - %s\n", domain, status, err)
-			return e
+			return diagnosticsFromError(d, e)
 		}
 	}
 
 	// Remove all existing notes and add them freshly
-	client.deleteCSPDomainNotes(accountID, siteID, domain)
+	client.deleteCSPDomainNotes(ctx, accountID, siteID, domain)
 	for _, note := range notes.List() {
-		client.addCSPDomainNote(accountID, siteID, domain, note.(string))
+		client.addCSPDomainNote(ctx, accountID, siteID, domain, note.(string))
 	}
 
 	newID := fmt.Sprintf("%d/%d/%s", accountID, siteID, domRef)
 	log.Printf("[DEBUG] Update CSP Domain, setting key %s to: %s", d.Id(), newID)
 	d.SetId(newID)
 
-	return resourceCSPSiteDomainRead(d, m)
+	return resourceCSPSiteDomainRead(ctx, d, m)
 }
 
-func resourceCSPSiteDomainDelete(d *schema.ResourceData, m interface{}) error {
+func resourceCSPSiteDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 	siteID := d.Get("site_id").(int)
@@ -218,10 +227,10 @@ func resourceCSPSiteDomainDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[DEBUG] Deleting CSP domain %s from site ID %d\n", domain, siteID)
 
 	if strings.Compare(status, cspDomainStatusAllowed) == 0 {
-		err := client.deleteCSPPreApprovedDomains(accountID, siteID, base64.RawURLEncoding.EncodeToString([]byte(domain)))
+		err := client.deleteCSPPreApprovedDomains(ctx, accountID, siteID, base64.RawURLEncoding.EncodeToString([]byte(domain)))
 		if err != nil {
 			log.Printf("[ERROR] Could not delete CSP pre-approved domain %s for site ID %d: %s\n", domain, siteID, err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	} else if strings.Compare(status, cspDomainStatusBlocked) == 0 {
 		newStatus := CSPDomainStatus{
@@ -230,13 +239,13 @@ func resourceCSPSiteDomainDelete(d *schema.ResourceData, m interface{}) error {
 		}
 		*newStatus.Blocked = false
 		*newStatus.Reviewed = false
-		ret, err := client.updateCSPDomainStatus(accountID, siteID, domain, &newStatus)
+		ret, err := client.updateCSPDomainStatus(ctx, accountID, siteID, domain, &newStatus)
 		if err != nil {
 			log.Printf("[ERROR] Could not delete CSP domain status %s for site ID %d: %s\n", domain, siteID, err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 		if ret.Blocked == nil || ret.Reviewed == nil {
-			return fmt.Errorf("[ERROR] Could not update CSP domain %s status to: %v got: %v\n", domain, newStatus, ret)
+			return diag.Errorf("[ERROR] Could not update CSP domain %s status to: %v got: %v\n", domain, newStatus, ret)
 		}
 	}
 	log.Printf("[DEBUG] Deleted CSP domain %s for site ID: %d successfully", domain, siteID)
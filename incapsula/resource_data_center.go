@@ -1,11 +1,13 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -13,12 +15,12 @@ import (
 func resourceDataCenter() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "This resource is deprecated. It will be removed in a future version. Please use resource incapsula_data_centers_configuration instead.",
-		Create:             resourceDataCenterCreate,
-		Read:               resourceDataCenterRead,
-		Update:             resourceDataCenterUpdate,
-		Delete:             resourceDataCenterDelete,
+		CreateContext:      resourceDataCenterCreate,
+		ReadContext:        resourceDataCenterRead,
+		UpdateContext:      resourceDataCenterUpdate,
+		DeleteContext:      resourceDataCenterDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/dc_id", d.Id())
@@ -41,6 +43,13 @@ func resourceDataCenter() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. When not set, the requests are sent with the provider default_account_id, and the account of the site is read into the state.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"name": {
 				Description: "The new data center's name.",
 				Type:        schema.TypeString,
@@ -72,18 +81,21 @@ func resourceDataCenter() *schema.Resource {
 			Create: schema.DefaultTimeout(1 * time.Minute),
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 			Update: schema.DefaultTimeout(1 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
-func resourceDataCenterCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 
 	var dataCenterAddResponse *DataCenterAddResponse
 	var err error
 
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
 		dataCenterAddResponse, err = client.AddDataCenter(
+			ctx,
 			d.Get("site_id").(string),
 			d.Get("name").(string),
 			d.Get("server_address").(string),
@@ -99,20 +111,21 @@ func resourceDataCenterCreate(d *schema.ResourceData, m interface{}) error {
 	})
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the dc ID
 	d.SetId(dataCenterAddResponse.DataCenterID)
 
-	return resourceDataCenterRead(d, m)
+	return resourceDataCenterRead(ctx, d, m)
 }
 
-func resourceDataCenterRead(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	// Implement by reading the ListDataCentersResponse for the data center
 	client := m.(*Client)
 
-	listDataCentersResponse, err := client.ListDataCenters(d.Get("site_id").(string))
+	listDataCentersResponse, err := client.ListDataCenters(ctx, d.Get("site_id").(string))
 
 	// List data centers response object may indicate that the Site ID has been deleted (9413)
 	if listDataCentersResponse != nil {
@@ -133,7 +146,7 @@ func resourceDataCenterRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	found := false
@@ -155,14 +168,16 @@ func resourceDataCenterRead(d *schema.ResourceData, m interface{}) error {
 		return nil
 	}
 
-	return nil
+	return diagnosticsFromError(d, setResourceAccountID(ctx, d, client))
 }
 
-func resourceDataCenterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 
-	return resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+	return diagnosticsFromError(d, resource.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
 		_, err := client.EditDataCenter(
+			ctx,
 			d.Id(),
 			d.Get("name").(string),
 			d.Get("is_content").(string),
@@ -174,14 +189,15 @@ func resourceDataCenterUpdate(d *schema.ResourceData, m interface{}) error {
 		}
 
 		return nil
-	})
+	}))
 }
 
-func resourceDataCenterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 
-	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
-		err := client.DeleteDataCenter(d.Id())
+	return diagnosticsFromError(d, resource.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		err := client.DeleteDataCenter(ctx, d.Id())
 
 		if err != nil {
 			return resource.RetryableError(fmt.Errorf("Error deleting data center %s for Site ID %s: %s", d.Id(), d.Get("site_id"), err))
@@ -192,5 +208,5 @@ func resourceDataCenterDelete(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 
 		return nil
-	})
+	}))
 }
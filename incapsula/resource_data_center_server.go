@@ -1,22 +1,25 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDataCenterServer() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "This resource is deprecated. It will be removed in a future version. Please use resource incapsula_data_centers_configuration instead.",
-		Create:             resourceDataCenterServerCreate,
-		Read:               resourceDataCenterServerRead,
-		Update:             resourceDataCenterServerUpdate,
-		Delete:             resourceDataCenterServerDelete,
+		CreateContext:      resourceDataCenterServerCreate,
+		ReadContext:        resourceDataCenterServerRead,
+		UpdateContext:      resourceDataCenterServerUpdate,
+		DeleteContext:      resourceDataCenterServerDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 3 || idSlice[0] == "" || idSlice[1] == "" || idSlice[2] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/dc_id/server_id", d.Id())
@@ -67,13 +70,20 @@ func resourceDataCenterServer() *schema.Resource {
 				Default:     "true",
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceDataCenterServerCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	dataCenterServerAddResponse, err := client.AddDataCenterServer(
+		ctx,
 		d.Get("dc_id").(string),
 		d.Get("server_address").(string),
 		d.Get("is_standby").(string),
@@ -81,20 +91,20 @@ func resourceDataCenterServerCreate(d *schema.ResourceData, m interface{}) error
 	)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the server ID
 	d.SetId(dataCenterServerAddResponse.ServerID)
 
-	return resourceDataCenterServerRead(d, m)
+	return resourceDataCenterServerRead(ctx, d, m)
 }
 
-func resourceDataCenterServerRead(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListDataCentersResponse for the data centers
 	client := m.(*Client)
 
-	listDataCentersResponse, err := client.ListDataCenters(d.Get("site_id").(string))
+	listDataCentersResponse, err := client.ListDataCenters(ctx, d.Get("site_id").(string))
 
 	// List data centers response object may indicate that the Site ID has been deleted (9413)
 	if listDataCentersResponse != nil {
@@ -113,7 +123,7 @@ func resourceDataCenterServerRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	found := false
@@ -147,10 +157,11 @@ func resourceDataCenterServerRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDataCenterServerUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	_, err := client.EditDataCenterServer(
+		ctx,
 		d.Id(),
 		d.Get("server_address").(string),
 		d.Get("is_standby").(string),
@@ -158,19 +169,19 @@ func resourceDataCenterServerUpdate(d *schema.ResourceData, m interface{}) error
 	)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	return nil
 }
 
-func resourceDataCenterServerDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDataCenterServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	serverID := d.Id()
-	err := client.DeleteDataCenterServer(serverID)
+	err := client.DeleteDataCenterServer(ctx, serverID)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
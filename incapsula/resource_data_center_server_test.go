@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -70,7 +71,7 @@ func testAccCheckIncapsulaDataCenterServerDestroy(state *terraform.State) error
 			return nil
 		}
 
-		listDataCenterResponse, _ := client.ListDataCenters(siteID)
+		listDataCenterResponse, _ := client.ListDataCenters(context.Background(), siteID)
 
 		// See comment above - the data center may have already been deleted
 		// This workaround will be removed in the future
@@ -111,7 +112,7 @@ func testCheckIncapsulaDataCenterServerExists(name string) resource.TestCheckFun
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		dataCenterListResponse, err := client.ListDataCenters(siteID)
+		dataCenterListResponse, err := client.ListDataCenters(context.Background(), siteID)
 		if dataCenterListResponse == nil {
 			return fmt.Errorf("Incapsula data center: %s (site id: %s) does not exist\n%s", name, siteID, err)
 		}
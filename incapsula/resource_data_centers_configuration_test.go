@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -75,7 +76,7 @@ func testAccCheckIncapsulaDataCentersConfigurationDestroy(state *terraform.State
 			return nil
 		}
 
-		listDataCenterResponse, _ := client.GetDataCentersConfiguration(siteID)
+		listDataCenterResponse, _ := client.GetDataCentersConfiguration(context.Background(), siteID)
 
 		// See comment above - the data center may have already been deleted
 		// This workaround will be removed in the future
@@ -124,12 +125,12 @@ func testCheckIncapsulaDataCentersConfigurationExists(name string) resource.Test
 
 		// If the site has already been deleted then return nil
 		// Otherwise check the data center list
-		_, err = client.SiteStatus(domain, siteID)
+		_, err = client.SiteStatus(context.Background(), domain, siteID)
 		if err != nil {
 			return nil
 		}
 
-		responseDTO, err := client.GetDataCentersConfiguration(siteIDString)
+		responseDTO, err := client.GetDataCentersConfiguration(context.Background(), siteIDString)
 		if responseDTO == nil || responseDTO.Data == nil || len(responseDTO.Data) == 0 {
 			return fmt.Errorf("Incapsula data centers configuration: %s (Site ID: %d) does not exist\n%s", name, siteID, err)
 		}
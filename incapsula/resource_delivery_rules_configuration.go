@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-incapsula/incapsula/rulefilter"
 )
 
 func resourceDeliveryRulesConfiguration() *schema.Resource {
@@ -19,7 +22,7 @@ func resourceDeliveryRulesConfiguration() *schema.Resource {
 		UpdateContext: resourceDeliveryRulesConfigurationUpdate,
 		DeleteContext: resourceDeliveryRulesConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(data.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/category", data.Id())
@@ -76,9 +79,11 @@ func resourceDeliveryRulesConfiguration() *schema.Resource {
 						},
 
 						"filter": {
-							Type:        schema.TypeString,
-							Description: "Defines the conditions that trigger the rule action",
-							Optional:    true,
+							Type:             schema.TypeString,
+							Description:      "Defines the conditions that trigger the rule action",
+							Optional:         true,
+							ValidateDiagFunc: validateRuleFilter(rulefilter.DeliveryRule),
+							DiffSuppressFunc: suppressEquivalentRuleFilterDiffs,
 						},
 
 						"from": {
@@ -178,6 +183,12 @@ func resourceDeliveryRulesConfiguration() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -195,7 +206,7 @@ func resourceDeliveryRulesConfigurationUpdate(ctx context.Context, data *schema.
 		RulesList: createRulesListFromState(data),
 	}
 
-	deliveryRulesListDTO, diags := client.UpdateDeliveryRuleConfiguration(siteID, category, &rulesListDTO)
+	deliveryRulesListDTO, diags := client.UpdateDeliveryRuleConfiguration(ctx, siteID, category, &rulesListDTO)
 
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to update delivery rules of category %s for Site ID %s", category, siteID)
@@ -220,7 +231,7 @@ func resourceDeliveryRulesConfigurationRead(ctx context.Context, data *schema.Re
 	siteID := data.Get("site_id").(string)
 	category := data.Get("category").(string)
 
-	deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
+	deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(ctx, siteID, category)
 
 	fmt.Println(deliveryRulesListDTO)
 
@@ -283,7 +294,7 @@ func resourceDeliveryRulesConfigurationDelete(ctx context.Context, data *schema.
 		RulesList: []DeliveryRuleDto{},
 	}
 
-	_, diags = client.UpdateDeliveryRuleConfiguration(siteID, category, &emptyRulesList)
+	_, diags = client.UpdateDeliveryRuleConfiguration(ctx, siteID, category, &emptyRulesList)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to delete delivery rules in category %s for Site ID %s", category, siteID)
 		return diags
@@ -391,6 +402,27 @@ func validateConfig(data *schema.ResourceData) diag.Diagnostics {
 	return diags
 }
 
+// validateDeliveryRulesConfig rejects the response fields in the filter of the rules whose action applies to the
+// request
+func validateDeliveryRulesConfig(config cty.Value) diag.Diagnostics {
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	rules := config.GetAttr("rule")
+	if rules.IsNull() || !rules.IsKnown() {
+		return nil
+	}
+	var diags diag.Diagnostics
+	for i, rule := range rules.AsValueSlice() {
+		if rule.IsNull() || !rule.IsKnown() {
+			continue
+		}
+		path := cty.GetAttrPath("rule").IndexInt(i).GetAttr("filter")
+		diags = append(diags, validateRuleFilterAction(rule.GetAttr("filter"), rule.GetAttr("action"), "a delivery rule", path)...)
+	}
+	return diags
+}
+
 var ruleArgsToActionMap = map[string][]string{
 	"from":                      {"RULE_ACTION_REDIRECT", "RULE_ACTION_REWRITE_HEADER", "RULE_ACTION_REWRITE_COOKIE", "RULE_ACTION_RESPONSE_REWRITE_HEADER", "RULE_ACTION_REWRITE_URL"},
 	"to":                        {"RULE_ACTION_REDIRECT", "RULE_ACTION_REWRITE_HEADER", "RULE_ACTION_REWRITE_COOKIE", "RULE_ACTION_RESPONSE_REWRITE_HEADER", "RULE_ACTION_REWRITE_URL"},
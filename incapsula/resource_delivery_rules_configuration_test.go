@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -135,7 +136,7 @@ func testAccCheckIncapsulaDeliveryRuleDestroy(state *terraform.State) error {
 			return fmt.Errorf("Rule category does not exist for Site ID is : %s ", siteID)
 		}
 
-		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
+		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, category)
 
 		// If the site has already been deleted then return nil
 		// Otherwise check the delivery rules list
@@ -143,7 +144,7 @@ func testAccCheckIncapsulaDeliveryRuleDestroy(state *terraform.State) error {
 		if err != nil {
 			return fmt.Errorf("Couldn't parse site ID: %s ", siteID)
 		}
-		_, siteStatusErr := client.SiteStatus(domain, siteIdInt)
+		_, siteStatusErr := client.SiteStatus(context.Background(), domain, siteIdInt)
 		if siteStatusErr != nil {
 			return nil
 		}
@@ -175,7 +176,7 @@ func testCheckIncapsulaDeliveryRuleExists(name string, numRules int) resource.Te
 
 		client := testAccProvider.Meta().(*Client)
 		category, ok := res.Primary.Attributes["category"]
-		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
+		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, category)
 
 		if !ok {
 			return fmt.Errorf("Rule category : %s ,does not exist for Site ID is : %s ", siteID, category)
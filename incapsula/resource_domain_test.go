@@ -1,15 +1,14 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
 	"testing"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"math/rand"
 )
 
 const domainResourceName = "incapsula_domain.test-terraform-domain"
@@ -21,9 +20,7 @@ func GenerateTestDomainName(t *testing.T) string {
 		t.Fatal("INCAPSULA_API_ID must be set for acceptance tests")
 	}
 
-	s3 := rand.NewSource(time.Now().UnixNano())
-	r3 := rand.New(s3)
-	domainName = "id" + os.Getenv("INCAPSULA_API_ID") + strconv.Itoa(r3.Intn(1000)) + ".incaptest.co"
+	domainName = "id" + os.Getenv("INCAPSULA_API_ID") + strconv.Itoa(testNameSuffix(t)) + ".incaptest.co"
 	return domainName
 }
 
@@ -82,7 +79,7 @@ func testCheckIncapsulaDomainDestroy(state *terraform.State) error {
 		siteV3Request := SiteV3Request{}
 		siteV3Request.Name = siteName
 
-		_, diags := client.GetV3Site(&siteV3Request, "123")
+		_, diags := client.GetV3Site(context.Background(), &siteV3Request, "123")
 
 		if diags == nil {
 			return fmt.Errorf("incapsula site for domain: %s (site id: %d) still exists", siteName, siteID)
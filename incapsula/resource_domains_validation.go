@@ -2,8 +2,10 @@ package incapsula
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -44,14 +46,59 @@ func resourceDomainsValidation() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"wait_for_issued": {
+				Description: "Wait until the managed certificate is issued, rather than until the domains are validated. Default: false.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
+// sslValidationBackoffSchedule are the delays between the polls of the certificate, the last one is repeated until
+// the timeout
+var sslValidationBackoffSchedule = []time.Duration{
+	10 * time.Second,
+	10 * time.Second,
+	20 * time.Second,
+	30 * time.Second,
+	60 * time.Second,
+}
+
+// sslValidationSleep waits between the polls of the certificate, it returns an error once the context is done
+var sslValidationSleep = sleepWithContext
+
+// Statuses of the SANs of a managed certificate
+const (
+	sanStatusValidated = "VALIDATED"
+	sanStatusIssued    = "ISSUED"
+)
+
+// sanFailureStatuses are the statuses of the SANs that won't be validated without a new certificate request
+var sanFailureStatuses = []string{"FAILED", "VALIDATION_FAILED", "EXPIRED", "REVOKED", "CANCELED"}
+
+// sslValidationProgress is the state of the validation of the domains, from the managed certificate of the site
+type sslValidationProgress struct {
+	validated bool
+	issued    bool
+	// pending describes the domains that aren't validated yet
+	pending []string
+	// failures are the domains whose validation failed, one error per domain
+	failures diag.Diagnostics
+}
+
 func resourceSSLValidationAdd(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	var diags diag.Diagnostics
 	siteId := d.Get("site_id").(int)
+	waitForIssued := d.Get("wait_for_issued").(bool)
 	log.Printf("[INFO] requesting site cert to site ID: %d to %v", siteId, d)
 	domains := d.Get("domain_ids").(*schema.Set)
 	var index = 0
@@ -61,23 +108,110 @@ func resourceSSLValidationAdd(ctx context.Context, d *schema.ResourceData, m int
 		domainIds[index] = dom
 		index++
 	}
-	for i := 0; i < 50; i++ {
-		siteCertificateV3Response, _ := client.GetSiteCertificateRequestStatus(siteId, nil)
-		b := siteCertificateV3Response != nil && siteCertificateV3Response.Data != nil && len(siteCertificateV3Response.Data) > 0
-		b = b && siteCertificateV3Response.Data[0].CertificatesDetails != nil && len(siteCertificateV3Response.Data[0].CertificatesDetails) > 0
-		b = b && siteCertificateV3Response.Data[0].CertificatesDetails[0].Sans != nil && len(siteCertificateV3Response.Data[0].CertificatesDetails[0].Sans) == len(domainIds)
-		for _, value := range siteCertificateV3Response.Data[0].CertificatesDetails[0].Sans {
-			b = b && value.Status == "VALIDATED"
+	// Poll until all the domains are validated, or until the certificate is issued, bounded by the create/update timeout
+	var progress sslValidationProgress
+	var statusDiags diag.Diagnostics
+	for attempt := 0; ; attempt++ {
+		var siteCertificateV3Response *SiteCertificateV3Response
+		siteCertificateV3Response, statusDiags = client.GetSiteCertificateRequestStatus(ctx, siteId, nil)
+		// The progress of the last successful poll is reported on timeout
+		if !statusDiags.HasError() {
+			progress = sslValidationProgressOf(siteCertificateV3Response, domainIds)
+		}
+		if progress.failures.HasError() {
+			return progress.failures
 		}
-		if b {
-			diags = client.ValidateDomains(siteId, domainIds)
+		if progress.validated && !waitForIssued {
+			diags = client.ValidateDomains(ctx, siteId, domainIds)
 			d.SetId(strconv.Itoa(siteId))
 			return diags
 		}
-		client.ValidateDomains(siteId, domainIds)
-		time.Sleep(10 * time.Second)
+		if progress.validated && progress.issued {
+			d.SetId(strconv.Itoa(siteId))
+			return nil
+		}
+		if !progress.validated {
+			client.ValidateDomains(ctx, siteId, domainIds)
+		}
+
+		backoff := sslValidationBackoffSchedule[len(sslValidationBackoffSchedule)-1]
+		if attempt < len(sslValidationBackoffSchedule) {
+			backoff = sslValidationBackoffSchedule[attempt]
+		}
+		if err := sslValidationSleep(ctx, backoff); err != nil {
+			break
+		}
+	}
+
+	waitingFor := "validated"
+	if progress.validated {
+		waitingFor = "issued"
+	}
+	detail := fmt.Sprintf("The certificate of site %d was not %s before the timeout.", siteId, waitingFor)
+	if len(progress.pending) > 0 {
+		detail += " Pending domains:\n  " + strings.Join(progress.pending, "\n  ")
+	}
+	if statusDiags.HasError() {
+		detail += "\nLast error: " + diagnosticsSummary(statusDiags)
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("SSL validation of site %d was not completed before the timeout", siteId),
+		Detail:   detail,
+	}}
+}
+
+// sslValidationProgressOf checks the SANs of the managed certificate covering each domain. A domain is validated when
+// its SAN is validated or issued, and the certificate is issued once it is active
+func sslValidationProgressOf(siteCertificateV3Response *SiteCertificateV3Response, domainIds []int) sslValidationProgress {
+	progress := sslValidationProgress{}
+	if siteCertificateV3Response == nil || len(siteCertificateV3Response.Data) == 0 || len(siteCertificateV3Response.Data[0].CertificatesDetails) == 0 {
+		progress.pending = []string{"the managed certificate of the site was not found"}
+		return progress
+	}
+	certificate := siteCertificateV3Response.Data[0].CertificatesDetails[0]
+
+	sans := map[int]SanDTO{}
+	for _, san := range certificate.Sans {
+		for _, domainId := range san.DomainIds {
+			sans[domainId] = san
+		}
+	}
+	// Without the domain IDs of the SANs, the domains are covered once there is one SAN per domain
+	if len(sans) == 0 && len(certificate.Sans) == len(domainIds) {
+		for i, domainId := range domainIds {
+			sans[domainId] = certificate.Sans[i]
+		}
+	}
+
+	progress.validated = true
+	for _, domainId := range domainIds {
+		san, ok := sans[domainId]
+		switch {
+		case !ok:
+			progress.validated = false
+			progress.pending = append(progress.pending, fmt.Sprintf("domain %d: not covered by the certificate yet", domainId))
+		case contains(sanFailureStatuses, san.Status):
+			progress.validated = false
+			progress.failures = append(progress.failures, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("SSL validation of %s failed", san.SanValue),
+				Detail:   fmt.Sprintf("The SAN %s of domain %d has the status %s%s. Request the certificate again once the cause is fixed.", san.SanValue, domainId, san.Status, sanStatusDateSuffix(san)),
+			})
+		case san.Status != sanStatusValidated && san.Status != sanStatusIssued:
+			progress.validated = false
+			progress.pending = append(progress.pending, fmt.Sprintf("%s (domain %d): %s, %s validation", san.SanValue, domainId, san.Status, san.ValidationMethod))
+		}
+	}
+	progress.issued = progress.validated && certificate.Status == "ACTIVE"
+	return progress
+}
+
+func sanStatusDateSuffix(san SanDTO) string {
+	if san.StatusDate == 0 {
+		return ""
 	}
-	panic("ssl validation was not completed as expected")
+	return fmt.Sprintf(" since %s", time.UnixMilli(san.StatusDate).UTC().Format(time.RFC3339))
 }
 
 func resourceSSLValidationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
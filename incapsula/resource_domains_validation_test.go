@@ -0,0 +1,103 @@
+package incapsula
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSSLValidationProgressOf(t *testing.T) {
+	response := func(status string, sans ...SanDTO) *SiteCertificateV3Response {
+		return &SiteCertificateV3Response{Data: []SiteCertificateDTO{{CertificatesDetails: []CertificateDTO{{Status: status, Sans: sans}}}}}
+	}
+	validated := SanDTO{SanValue: "a.example.com", Status: sanStatusValidated, DomainIds: []int{1}}
+	pending := SanDTO{SanValue: "b.example.com", Status: "PENDING_USER_ACTION", ValidationMethod: "CNAME", DomainIds: []int{2}}
+	failed := SanDTO{SanValue: "b.example.com", Status: "EXPIRED", DomainIds: []int{2}}
+
+	progress := sslValidationProgressOf(response("IN_PROCESS", validated, pending), []int{1, 2})
+	if progress.validated || len(progress.pending) != 1 || !strings.Contains(progress.pending[0], "b.example.com (domain 2): PENDING_USER_ACTION, CNAME validation") {
+		t.Errorf("Should have reported the pending domain, got: %+v", progress)
+	}
+
+	progress = sslValidationProgressOf(response("IN_PROCESS", validated), []int{1})
+	if !progress.validated || progress.issued {
+		t.Errorf("Should have reported the validated domains of the certificate not issued yet, got: %+v", progress)
+	}
+	progress = sslValidationProgressOf(response("ACTIVE", validated), []int{1})
+	if !progress.validated || !progress.issued {
+		t.Errorf("Should have reported the issued certificate, got: %+v", progress)
+	}
+
+	progress = sslValidationProgressOf(response("IN_PROCESS", validated, failed), []int{1, 2})
+	if len(progress.failures) != 1 || progress.failures[0].Summary != "SSL validation of b.example.com failed" {
+		t.Errorf("Should have reported the failed domain, got: %+v", progress.failures)
+	}
+
+	progress = sslValidationProgressOf(response("IN_PROCESS", validated), []int{1, 3})
+	if progress.validated || len(progress.pending) != 1 || progress.pending[0] != "domain 3: not covered by the certificate yet" {
+		t.Errorf("Should have reported the domain not covered by the certificate, got: %+v", progress)
+	}
+
+	// The SANs without domain IDs match the domains once there is one SAN per domain
+	progress = sslValidationProgressOf(response("ACTIVE", SanDTO{SanValue: "a.example.com", Status: sanStatusIssued}), []int{1})
+	if !progress.validated || !progress.issued {
+		t.Errorf("Should have matched the SAN without domain IDs, got: %+v", progress)
+	}
+}
+
+func TestResourceSSLValidationWaitForIssuedFakeAPI(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	sleep := sslValidationSleep
+	sslValidationSleep = func(ctx context.Context, d time.Duration) error { return nil }
+	t.Cleanup(func() { sslValidationSleep = sleep })
+
+	siteAddResponse, err := client.AddSite(ctx, "www.validation.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := siteAddResponse.SiteID
+	siteCertificateV3Response, diags := client.RequestSiteCertificate(ctx, siteID, "CNAME", nil)
+	if diags.HasError() {
+		t.Fatalf("Should not have received an error requesting the certificate, got: %v", diags)
+	}
+	var domainIDs []interface{}
+	for _, san := range siteCertificateV3Response.Data[0].CertificatesDetails[0].Sans {
+		for _, domainID := range san.DomainIds {
+			domainIDs = append(domainIDs, strconv.Itoa(domainID))
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDomainsValidation().Schema, map[string]interface{}{
+		"site_id":         siteID,
+		"domain_ids":      domainIDs,
+		"wait_for_issued": true,
+	})
+	if diags := resourceSSLValidationAdd(ctx, d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error, got: %v", diags)
+	}
+	if d.Id() != strconv.Itoa(siteID) {
+		t.Errorf("Unexpected ID: %s", d.Id())
+	}
+	siteCertificateV3Response, _ = client.GetSiteCertificateRequestStatus(ctx, siteID, nil)
+	if status := siteCertificateV3Response.Data[0].CertificatesDetails[0].Status; status != "ACTIVE" {
+		t.Errorf("Should have waited for the certificate to be issued, got status: %s", status)
+	}
+
+	// A domain that isn't covered by the certificate is reported when the timeout is reached, here after the first poll
+	sslValidationSleep = func(ctx context.Context, d time.Duration) error { return context.DeadlineExceeded }
+	d = schema.TestResourceDataRaw(t, resourceDomainsValidation().Schema, map[string]interface{}{
+		"site_id":         siteID,
+		"domain_ids":      append(domainIDs, "1"),
+		"wait_for_issued": true,
+	})
+	diags = resourceSSLValidationAdd(ctx, d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, "domain 1: not covered by the certificate yet") {
+		t.Errorf("Should have reported the pending domain on timeout, got: %v", diags)
+	}
+}
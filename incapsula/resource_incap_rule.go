@@ -1,30 +1,44 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-incapsula/incapsula/rulefilter"
 )
 
 func resourceIncapRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceIncapRuleCreate,
-		Read:   resourceIncapRuleRead,
-		Update: resourceIncapRuleUpdate,
-		Delete: resourceIncapRuleDelete,
+		CreateContext: resourceIncapRuleCreate,
+		ReadContext:   resourceIncapRuleRead,
+		UpdateContext: resourceIncapRuleUpdate,
+		DeleteContext: resourceIncapRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(d.Id(), "/")
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// Rule names may contain slashes, domains can't
+				idSlice := strings.SplitN(d.Id(), "/", 2)
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id or domain/rule_name", d.Id())
 				}
 
-				siteID := idSlice[0]
+				client := meta.(*Client)
+				siteID, err := client.lookupSiteID(ctx, idSlice[0])
+				if err != nil {
+					return nil, err
+				}
 				d.Set("site_id", siteID)
 
-				ruleID := idSlice[1]
+				ruleID, err := client.lookupIncapRuleID(ctx, siteID, idSlice[1])
+				if err != nil {
+					return nil, err
+				}
 				d.SetId(ruleID)
 
 				return []*schema.ResourceData{d}, nil
@@ -39,6 +53,13 @@ func resourceIncapRule() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"account_id": {
+				Description: "Numeric identifier of the account to operate on. When not set, the requests are sent with the provider default_account_id, and the account of the site is read into the state.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
 			"name": {
 				Description: "Rule name",
 				Type:        schema.TypeString,
@@ -51,12 +72,11 @@ func resourceIncapRule() *schema.Resource {
 			},
 			// Optional Arguments
 			"filter": {
-				Description: "The filter defines the conditions that trigger the rule action. For action `RULE_ACTION_SIMPLIFIED_REDIRECT` filter is not relevant. For other actions, if left empty, the rule is always run.",
-				Type:        schema.TypeString,
-				Optional:    true,
-				DiffSuppressFunc: func(k, remoteState, desiredState string, d *schema.ResourceData) bool {
-					return strings.TrimSpace(remoteState) == strings.TrimSpace(desiredState)
-				},
+				Description:      "The filter defines the conditions that trigger the rule action. For action `RULE_ACTION_SIMPLIFIED_REDIRECT` filter is not relevant. For other actions, if left empty, the rule is always run.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateRuleFilter(rulefilter.IncapRule),
+				DiffSuppressFunc: suppressEquivalentRuleFilterDiffs,
 			},
 			"response_code": {
 				Description: "For `RULE_ACTION_REDIRECT` or `RULE_ACTION_SIMPLIFIED_REDIRECT` rule's response code, valid values are `302`, `301`, `303`, `307`, `308`. For `RULE_ACTION_RESPONSE_REWRITE_RESPONSE_CODE` rule's response code, valid values are all 3-digits numbers. For `RULE_ACTION_CUSTOM_ERROR_RESPONSE`, valid values are `400`, `401`, `402`, `403`, `404`, `405`, `406`, `407`, `408`, `409`, `410`, `411`, `412`, `413`, `414`, `415`, `416`, `417`, `419`, `420`, `422`, `423`, `424`, `500`, `501`, `502`, `503`, `504`, `505`, `507`.",
@@ -189,10 +209,17 @@ func resourceIncapRule() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceIncapRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 	rewriteExisting := new(bool)
 	action := d.Get("action").(string)
@@ -209,7 +236,7 @@ func resourceIncapRuleCreate(d *schema.ResourceData, m interface{}) error {
 		valStr := v.(string)
 		valBool, err := strconv.ParseBool(valStr)
 		if err != nil {
-			return fmt.Errorf("Error parsing send_notifications: %s", err)
+			return diag.Errorf("Error parsing send_notifications: %s", err)
 		}
 		sendNotifications = &valBool
 	}
@@ -251,27 +278,28 @@ func resourceIncapRuleCreate(d *schema.ResourceData, m interface{}) error {
 		BlockDurationDetails:  blockDurationDetails,
 	}
 
-	ruleWithID, err := client.AddIncapRule(d.Get("site_id").(string), &rule)
+	ruleWithID, err := client.AddIncapRule(ctx, d.Get("site_id").(string), &rule)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId(strconv.Itoa(ruleWithID.RuleID))
 
-	return resourceIncapRuleRead(d, m)
+	return resourceIncapRuleRead(ctx, d, m)
 }
 
-func resourceIncapRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	rule, statusCode, err := client.ReadIncapRule(d.Get("site_id").(string), ruleID)
+	rule, statusCode, err := client.ReadIncapRule(ctx, d.Get("site_id").(string), ruleID)
 
 	// If the rule is deleted on the server, blow it out locally and run through the normal TF cycle
 	if statusCode == 404 {
@@ -280,7 +308,7 @@ func resourceIncapRuleRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Update all of the properties
@@ -325,10 +353,11 @@ func resourceIncapRuleRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("rewrite_existing", true)
 	}
 
-	return nil
+	return diagnosticsFromError(d, setResourceAccountID(ctx, d, client))
 }
 
-func resourceIncapRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 	rewriteExisting := new(bool)
 	action := d.Get("action").(string)
@@ -345,7 +374,7 @@ func resourceIncapRuleUpdate(d *schema.ResourceData, m interface{}) error {
 		valStr := v.(string)
 		valBool, err := strconv.ParseBool(valStr)
 		if err != nil {
-			return fmt.Errorf("Error parsing send_notifications: %s", err)
+			return diag.Errorf("Error parsing send_notifications: %s", err)
 		}
 		sendNotifications = &valBool
 	}
@@ -389,29 +418,30 @@ func resourceIncapRuleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	_, err = client.UpdateIncapRule(d.Get("site_id").(string), ruleID, &rule)
+	_, err = client.UpdateIncapRule(ctx, d.Get("site_id").(string), ruleID, &rule)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	return nil
 }
 
-func resourceIncapRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceIncapRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ctx = contextWithResourceAccountID(ctx, d)
 	client := m.(*Client)
 
 	ruleID, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	err = client.DeleteIncapRule(d.Get("site_id").(string), ruleID)
+	err = client.DeleteIncapRule(ctx, d.Get("site_id").(string), ruleID)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -420,3 +450,118 @@ func resourceIncapRuleDelete(d *schema.ResourceData, m interface{}) error {
 
 	return nil
 }
+
+// incapRuleActionArguments are the action-specific arguments an incap rule action requires, and the ones it accepts.
+// The other action-specific arguments are not applicable to the action
+type incapRuleActionArguments struct {
+	required []string
+	allowed  []string
+}
+
+var incapRuleSecurityActionArguments = incapRuleActionArguments{allowed: []string{"send_notifications"}}
+
+var incapRuleBlockActionArguments = incapRuleActionArguments{allowed: []string{"send_notifications", "block_duration_type", "block_duration", "block_duration_min", "block_duration_max"}}
+
+var incapRuleRewriteActionArguments = incapRuleActionArguments{required: []string{"rewrite_name", "to"}, allowed: []string{"from", "add_missing", "rewrite_existing"}}
+
+var incapRuleActionArgumentsMap = map[string]incapRuleActionArguments{
+	"RULE_ACTION_REDIRECT":                       {required: []string{"from", "to"}, allowed: []string{"response_code"}},
+	"RULE_ACTION_SIMPLIFIED_REDIRECT":            {required: []string{"from", "to"}, allowed: []string{"response_code"}},
+	"RULE_ACTION_REWRITE_URL":                    {required: []string{"from", "to"}, allowed: []string{"response_code"}},
+	"RULE_ACTION_REWRITE_HEADER":                 incapRuleRewriteActionArguments,
+	"RULE_ACTION_REWRITE_COOKIE":                 incapRuleRewriteActionArguments,
+	"RULE_ACTION_RESPONSE_REWRITE_HEADER":        incapRuleRewriteActionArguments,
+	"RULE_ACTION_DELETE_HEADER":                  {required: []string{"rewrite_name"}, allowed: []string{"multiple_deletions"}},
+	"RULE_ACTION_RESPONSE_DELETE_HEADER":         {required: []string{"rewrite_name"}, allowed: []string{"multiple_deletions"}},
+	"RULE_ACTION_DELETE_COOKIE":                  {required: []string{"rewrite_name"}},
+	"RULE_ACTION_RESPONSE_REWRITE_RESPONSE_CODE": {required: []string{"response_code"}},
+	"RULE_ACTION_CUSTOM_ERROR_RESPONSE":          {allowed: []string{"response_code", "error_type", "error_response_format", "error_response_data"}},
+	"RULE_ACTION_FORWARD_TO_DC":                  {required: []string{"dc_id"}},
+	"RULE_ACTION_FORWARD_TO_PORT":                {required: []string{"port_forwarding_context", "port_forwarding_value"}},
+	"RULE_ACTION_RATE":                           {required: []string{"rate_context", "rate_interval"}},
+	"RULE_ACTION_WAF_OVERRIDE":                   {required: []string{"override_waf_rule", "override_waf_action"}},
+	"RULE_ACTION_ALERT":                          incapRuleSecurityActionArguments,
+	"RULE_ACTION_BLOCK":                          incapRuleSecurityActionArguments,
+	"RULE_ACTION_RETRY":                          incapRuleSecurityActionArguments,
+	"RULE_ACTION_INTRUSIVE_HTML":                 incapRuleSecurityActionArguments,
+	"RULE_ACTION_CAPTCHA":                        incapRuleSecurityActionArguments,
+	"RULE_ACTION_BLOCK_USER":                     incapRuleBlockActionArguments,
+	"RULE_ACTION_BLOCK_IP":                       incapRuleBlockActionArguments,
+}
+
+// incapRuleArgumentUnsetValues are the values of the action-specific arguments that are considered not set, on top of
+// the empty string, 0 and false: the default of rewrite_existing, and send_notifications set to false
+var incapRuleArgumentUnsetValues = map[string]cty.Value{
+	"rewrite_existing":   cty.True,
+	"send_notifications": cty.StringVal("false"),
+}
+
+// incapRuleActionSpecificArguments returns the sorted arguments that only apply to some actions
+func incapRuleActionSpecificArguments() []string {
+	arguments := map[string]bool{}
+	for _, actionArguments := range incapRuleActionArgumentsMap {
+		for _, argument := range append(append([]string{}, actionArguments.required...), actionArguments.allowed...) {
+			arguments[argument] = true
+		}
+	}
+	sorted := make([]string, 0, len(arguments))
+	for argument := range arguments {
+		sorted = append(sorted, argument)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// validateIncapRuleConfig rejects the arguments the action requires but are missing, the ones that are not
+// applicable to the action, and the response fields in the filter of an action applied to the request. Only the
+// configuration is checked, not the defaults nor the state
+func validateIncapRuleConfig(config cty.Value) diag.Diagnostics {
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	action := config.GetAttr("action")
+	if action.IsNull() || !action.IsKnown() {
+		return nil
+	}
+	diags := validateIncapRuleActionArguments(action.AsString(), config.GetAttr)
+	return append(diags, validateRuleFilterAction(config.GetAttr("filter"), action, "an incap rule", cty.GetAttrPath("filter"))...)
+}
+
+// validateIncapRuleActionArguments checks the configured arguments of an action. Null, empty and unset values are not
+// configured, unknown values may be. Actions missing from the table are left to the API
+func validateIncapRuleActionArguments(action string, configuredValue func(argument string) cty.Value) diag.Diagnostics {
+	actionArguments, ok := incapRuleActionArgumentsMap[action]
+	if !ok {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	for _, argument := range incapRuleActionSpecificArguments() {
+		value := configuredValue(argument)
+		configured := !value.IsNull() && (!value.IsKnown() || !value.RawEquals(cty.Zero) && !value.RawEquals(cty.StringVal("")) && !value.RawEquals(cty.False))
+		if unsetValue, ok := incapRuleArgumentUnsetValues[argument]; ok && value.RawEquals(unsetValue) {
+			configured = false
+		}
+		switch {
+		case contains(actionArguments.required, argument):
+			if !configured {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Missing required argument",
+					Detail:        fmt.Sprintf("Configuration argument '%s' is required by action %s", argument, action),
+					AttributePath: cty.GetAttrPath(argument),
+				})
+			}
+		case !contains(actionArguments.allowed, argument):
+			if configured && value.IsKnown() {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Argument not applicable to the action",
+					Detail:        fmt.Sprintf("Configuration argument '%s' is not applicable to action %s", argument, action),
+					AttributePath: cty.GetAttrPath(argument),
+				})
+			}
+		}
+	}
+	return diags
+}
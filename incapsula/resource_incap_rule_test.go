@@ -1,10 +1,17 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -15,6 +22,109 @@ const incapRuleName = "Example Incap Rule Alert"
 const incapRuleResourceNameBlockDuration = "incapsula_incap_rule.testacc-terraform-incap-rule-block-duration"
 const incapRuleNameBlockDuration = "Example Incap Rule Block Duration"
 
+func TestValidateIncapRuleActionArguments(t *testing.T) {
+	validate := func(action string, config map[string]cty.Value) diag.Diagnostics {
+		return validateIncapRuleActionArguments(action, func(argument string) cty.Value {
+			if value, ok := config[argument]; ok {
+				return value
+			}
+			return cty.NullVal(cty.String)
+		})
+	}
+
+	cases := []struct {
+		action   string
+		config   map[string]cty.Value
+		expected []string
+	}{
+		{"RULE_ACTION_ALERT", map[string]cty.Value{"send_notifications": cty.StringVal("true")}, nil},
+		{"RULE_ACTION_FORWARD_TO_DC", map[string]cty.Value{"dc_id": cty.NumberIntVal(12)}, nil},
+		{"RULE_ACTION_FORWARD_TO_DC", map[string]cty.Value{"dc_id": cty.UnknownVal(cty.Number)}, nil},
+		{"RULE_ACTION_FORWARD_TO_DC", map[string]cty.Value{"dc_id": cty.Zero}, []string{"'dc_id' is required by action RULE_ACTION_FORWARD_TO_DC"}},
+		{"RULE_ACTION_RATE", map[string]cty.Value{"rate_context": cty.StringVal("IP")}, []string{"'rate_interval' is required by action RULE_ACTION_RATE"}},
+		{"RULE_ACTION_BLOCK", map[string]cty.Value{"from": cty.StringVal("/a"), "dc_id": cty.NumberIntVal(1)}, []string{
+			"'dc_id' is not applicable to action RULE_ACTION_BLOCK",
+			"'from' is not applicable to action RULE_ACTION_BLOCK",
+		}},
+		{"RULE_ACTION_BLOCK", map[string]cty.Value{"from": cty.StringVal(""), "multiple_deletions": cty.False, "to": cty.UnknownVal(cty.String)}, nil},
+		{"RULE_ACTION_REWRITE_HEADER", map[string]cty.Value{"rewrite_name": cty.StringVal("X-Id"), "to": cty.StringVal("1"), "rewrite_existing": cty.True}, nil},
+		{"RULE_ACTION_DELETE_HEADER", map[string]cty.Value{"rewrite_name": cty.StringVal("X-Id"), "rewrite_existing": cty.True}, nil},
+		{"RULE_ACTION_REDIRECT", map[string]cty.Value{"from": cty.StringVal("/a"), "to": cty.StringVal("/b"), "send_notifications": cty.StringVal("false"), "rewrite_existing": cty.True}, nil},
+		{"RULE_ACTION_REDIRECT", map[string]cty.Value{"from": cty.StringVal("/a"), "to": cty.StringVal("/b"), "send_notifications": cty.StringVal("true"), "rewrite_existing": cty.False}, []string{"'send_notifications' is not applicable to action RULE_ACTION_REDIRECT"}},
+		{"RULE_ACTION_UNKNOWN_TO_THE_PROVIDER", map[string]cty.Value{"from": cty.StringVal("/a")}, nil},
+	}
+	for _, c := range cases {
+		diags := validate(c.action, c.config)
+		if len(diags) != len(c.expected) {
+			t.Errorf("Unexpected errors for %s %v, expected: %v, got: %v", c.action, c.config, c.expected, diags)
+			continue
+		}
+		for i, expected := range c.expected {
+			argument := strings.Split(expected, "'")[1]
+			if diags[i].Severity != diag.Error || diags[i].Detail != "Configuration argument "+expected || !diags[i].AttributePath.Equals(cty.GetAttrPath(argument)) {
+				t.Errorf("Unexpected error for %s %v, expected: %s on %s, got: %s on %v", c.action, c.config, expected, argument, diags[i].Detail, diags[i].AttributePath)
+			}
+		}
+	}
+}
+
+func TestValidateIncapRuleConfigFilterAction(t *testing.T) {
+	config := func(action string, filter string) cty.Value {
+		attributes := map[string]cty.Value{"action": cty.StringVal(action), "filter": cty.StringVal(filter)}
+		for _, argument := range incapRuleActionSpecificArguments() {
+			attributes[argument] = cty.NullVal(cty.String)
+		}
+		attributes["rewrite_name"] = cty.StringVal("X-Id")
+		return cty.ObjectVal(attributes)
+	}
+
+	if diags := validateIncapRuleConfig(config("RULE_ACTION_RESPONSE_DELETE_HEADER", `ResponseCode == 404`)); len(diags) != 0 {
+		t.Errorf("Should have accepted the response field for a response action, got: %v", diags)
+	}
+	diags := validateIncapRuleConfig(config("RULE_ACTION_DELETE_HEADER", `ResponseCode == 404`))
+	if len(diags) != 1 || !diags[0].AttributePath.Equals(cty.GetAttrPath("filter")) || !strings.Contains(diags[0].Detail, "field ResponseCode cannot be used in the filter of an incap rule with action RULE_ACTION_DELETE_HEADER") {
+		t.Errorf("Should have rejected the response field for a request action, got: %v", diags)
+	}
+}
+
+func TestValidateIncapRuleConfigProviderServer(t *testing.T) {
+	provider := Provider()
+	configType := provider.ResourcesMap["incapsula_incap_rule"].CoreConfigSchema().ImpliedType()
+	attributes := map[string]cty.Value{}
+	for name, attributeType := range configType.AttributeTypes() {
+		attributes[name] = cty.NullVal(attributeType)
+	}
+	attributes["site_id"] = cty.StringVal("123")
+	attributes["name"] = cty.StringVal("Forward")
+	attributes["action"] = cty.StringVal("RULE_ACTION_FORWARD_TO_DC")
+	attributes["from"] = cty.StringVal("/a")
+	config, err := msgpack.Marshal(cty.ObjectVal(attributes), configType)
+	if err != nil {
+		t.Fatalf("Should not have received an error encoding the configuration, got: %s", err)
+	}
+
+	resp, err := NewGRPCProviderServer(provider).ValidateResourceTypeConfig(context.Background(), &tfprotov5.ValidateResourceTypeConfigRequest{
+		TypeName: "incapsula_incap_rule",
+		Config:   &tfprotov5.DynamicValue{MsgPack: config},
+	})
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	expected := map[string]string{
+		"dc_id": "Configuration argument 'dc_id' is required by action RULE_ACTION_FORWARD_TO_DC",
+		"from":  "Configuration argument 'from' is not applicable to action RULE_ACTION_FORWARD_TO_DC",
+	}
+	if len(resp.Diagnostics) != len(expected) {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	for _, d := range resp.Diagnostics {
+		steps := d.Attribute.Steps()
+		if len(steps) != 1 || d.Severity != tfprotov5.DiagnosticSeverityError || d.Detail != expected[string(steps[0].(tftypes.AttributeName))] {
+			t.Errorf("Unexpected diagnostic %s on %v", d.Detail, d.Attribute)
+		}
+	}
+}
+
 func TestAccIncapsulaIncapRule_Basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -133,7 +243,7 @@ func testAccCheckIncapsulaIncapRuleDestroy(state *terraform.State) error {
 			return fmt.Errorf("Incapsula Site ID does not exist for Rule ID %d", ruleID)
 		}
 
-		_, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+		_, statusCode, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 		if statusCode != 404 {
 			return fmt.Errorf("Incapsula Incap Rule %d (site id: %s) should have received 404 status code", ruleID, siteID)
 		}
@@ -163,7 +273,7 @@ func testCheckIncapsulaIncapRuleExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		_, statusCode, err := client.ReadIncapRule(siteID, ruleID)
+		_, statusCode, err := client.ReadIncapRule(context.Background(), siteID, ruleID)
 		if statusCode != 200 {
 			return fmt.Errorf("Incapsula Incap Rule: %s (site id: %s) should have received 200 status code", name, siteID)
 		}
@@ -247,3 +357,31 @@ resource "incapsula_incap_rule" "testacc-terraform-incap-rule-block-duration" {
 }`, incapRuleNameBlockDuration, siteResourceName,
 	)
 }
+
+func TestResourceIncapRuleImportSetsAccountID(t *testing.T) {
+	client, server := newFakeAPIClient(t)
+	ctx := context.Background()
+
+	siteAddResponse, err := client.AddSite(ctx, "account.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := strconv.Itoa(siteAddResponse.SiteID)
+	ruleWithID, err := client.AddIncapRule(ctx, siteID, &IncapRule{Name: "alert", Action: "RULE_ACTION_ALERT", Filter: `URL == "/admin"`})
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the rule, got: %s", err)
+	}
+
+	d := resourceIncapRule().Data(nil)
+	d.SetId(fmt.Sprintf("%s/%d", siteID, ruleWithID.RuleID))
+	imported, err := resourceIncapRule().Importer.StateContext(ctx, d, client)
+	if err != nil {
+		t.Fatalf("Should not have received an error importing the rule, got: %s", err)
+	}
+	if diags := resourceIncapRuleRead(ctx, imported[0], client); diags.HasError() {
+		t.Fatalf("Should not have received an error reading the rule, got: %v", diags)
+	}
+	if imported[0].Get("account_id") != server.AccountID {
+		t.Errorf("Should have set the account of the site, got: %v", imported[0].Get("account_id"))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -68,6 +69,12 @@ func resourceManagedCertificate() *schema.Resource {
 				Computed:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -83,7 +90,7 @@ func resourceManagedCertificateAdd(ctx context.Context, d *schema.ResourceData,
 	validationMethod, _ := d.Get("default_validation_method").(string)
 	id, _ := strconv.Atoi(siteId)
 	log.Printf("[INFO] requesting site cert to site ID: %d to %v", id, d)
-	siteCertificateV3Response, diags := client.RequestSiteCertificate(id, validationMethod, accountId)
+	siteCertificateV3Response, diags := client.RequestSiteCertificate(ctx, id, validationMethod, accountId)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed request site cert to site ID: %d, %v\n", id, diags)
 		return diags
@@ -98,7 +105,7 @@ func resourceManagedCertificateAdd(ctx context.Context, d *schema.ResourceData,
 	err := d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site id after delete v3 site: %s\n", err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	resourceManagedCertificateRead(ctx, d, m)
 	return diags
@@ -115,7 +122,7 @@ func resourceManagedCertificateRead(ctx context.Context, d *schema.ResourceData,
 	}
 	id, _ := strconv.Atoi(siteId)
 	log.Printf("[INFO] get site cert status of site ID: %d to %v", id, d)
-	siteCertificateV3Response, diags := client.GetSiteCertificateRequestStatus(id, accountId)
+	siteCertificateV3Response, diags := client.GetSiteCertificateRequestStatus(ctx, id, accountId)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed get site cert status of site ID: %d, %v\n", id, diags)
 		return diags
@@ -130,12 +137,12 @@ func resourceManagedCertificateRead(ctx context.Context, d *schema.ResourceData,
 	err := d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site id after request site cert to site ID: %d, %s\n", id, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	err = d.Set("default_validation_method", siteCertificateV3Response.Data[0].DefaultValidationMethod)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Default vlidation method after request site cert to site ID: %d, %s\n", id, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 
 	if d.Get("account_id") == nil || d.Get("account_id") == 0 {
@@ -143,7 +150,7 @@ func resourceManagedCertificateRead(ctx context.Context, d *schema.ResourceData,
 		err = d.Set("account_id", client.accountStatus.AccountID)
 		if err != nil {
 			log.Printf("[ERROR] Could not read account_id after request site cert to site ID: %d, %s\n", id, err)
-			return diag.FromErr(err)
+			return diagnosticsFromError(d, err)
 		}
 	}
 	d.SetId(strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
@@ -161,7 +168,7 @@ func resourceManagedCertificateDelete(ctx context.Context, d *schema.ResourceDat
 		accountId = &accountIdValue
 	}
 	log.Printf("[INFO] deleting site cert request of site ID: %d to %v", id, d)
-	siteCertificateV3Response, diags := client.DeleteRequestSiteCertificate(id, accountId)
+	siteCertificateV3Response, diags := client.DeleteRequestSiteCertificate(ctx, id, accountId)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed delete site cert request of site ID: %d, %v\n", id, diags)
 		return diags
@@ -176,7 +183,7 @@ func resourceManagedCertificateDelete(ctx context.Context, d *schema.ResourceDat
 	err := d.Set("site_id", strconv.Itoa(siteCertificateV3Response.Data[0].SiteId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site id after delete v3 site: %s\n", err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	resourceManagedCertificateRead(ctx, d, m)
 	return diags
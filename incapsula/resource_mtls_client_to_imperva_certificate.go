@@ -1,6 +1,9 @@
 package incapsula
 
 import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"time"
 	//"crypto/sha1"
 	//"encoding/hex"
 	"encoding/base64"
@@ -13,12 +16,12 @@ import (
 
 func resourceMtlsClientToImpervaCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceClientCaCertificateCreate,
-		Read:   resourceClientCaCertificateRead,
-		Update: resourceClientCaCertificateUpdate,
-		Delete: resourceClientCaCertificateDelete,
+		CreateContext: resourceClientCaCertificateCreate,
+		ReadContext:   resourceClientCaCertificateRead,
+		UpdateContext: resourceClientCaCertificateUpdate,
+		DeleteContext: resourceClientCaCertificateDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.MarkNewResource()
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
@@ -67,13 +70,19 @@ func resourceMtlsClientToImpervaCertificate() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceClientCaCertificateUpdate(d *schema.ResourceData, m interface{}) error {
-	return fmt.Errorf("Update action is not supported for current resource. Please create a new Mutual TLS Client to Imperva CA Certificate resource and only then, delete the old one.")
+func resourceClientCaCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return diag.Errorf("Update action is not supported for current resource. Please create a new Mutual TLS Client to Imperva CA Certificate resource and only then, delete the old one.")
 }
-func resourceClientCaCertificateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceClientCaCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	encodedCert := d.Get("certificate").(string)
 	// Standard Base64 Decoding
@@ -86,33 +95,34 @@ func resourceClientCaCertificateCreate(d *schema.ResourceData, m interface{}) er
 	}
 
 	mTLSCertificate, err := client.AddClientCaCertificate(
+		ctx,
 		decodedCert,
 		d.Get("account_id").(string),
 		d.Get("certificate_name").(string),
 	)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId(strconv.Itoa(mTLSCertificate.Id))
-	return resourceClientCaCertificateRead(d, m)
+	return resourceClientCaCertificateRead(ctx, d, m)
 }
 
-func resourceClientCaCertificateRead(d *schema.ResourceData, m interface{}) error {
+func resourceClientCaCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	accountIDStr := d.Get("account_id").(string)
 	certificateIDStr := d.Id()
 
-	clientToImpervaCertificateData, certificateExits, err := client.GetClientCaCertificate(accountIDStr, certificateIDStr)
+	clientToImpervaCertificateData, certificateExits, err := client.GetClientCaCertificate(ctx, accountIDStr, certificateIDStr)
 	if !certificateExits && !d.IsNewResource() {
 		d.SetId("")
 		return nil
 	}
 
 	if err != nil || !certificateExits {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId(strconv.Itoa(clientToImpervaCertificateData.Id))
@@ -120,14 +130,14 @@ func resourceClientCaCertificateRead(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceClientCaCertificateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceClientCaCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	accountID := d.Get("account_id").(string)
 	certificateID := d.Id()
-	err := client.DeleteClientCaCertificate(accountID, certificateID)
+	err := client.DeleteClientCaCertificate(ctx, accountID, certificateID)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -1,21 +1,24 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func resourceMtlsClientToImpervaCertificateSiteAssociation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteMtlsClientToImpervaCertificateAssociationCreate,
-		Read:   resourceSiteMtlsClientToImpervaCertificateAssociationRead,
-		Update: resourceSiteMtlsClientToImpervaCertificateAssociationCreate,
-		Delete: resourceSiteMtlsClientToImpervaCertificateAssociationDelete,
+		CreateContext: resourceSiteMtlsClientToImpervaCertificateAssociationCreate,
+		ReadContext:   resourceSiteMtlsClientToImpervaCertificateAssociationRead,
+		UpdateContext: resourceSiteMtlsClientToImpervaCertificateAssociationCreate,
+		DeleteContext: resourceSiteMtlsClientToImpervaCertificateAssociationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.MarkNewResource()
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) < 2 || len(idSlice) > 3 || idSlice[0] == "" || idSlice[1] == "" {
@@ -68,20 +71,26 @@ func resourceMtlsClientToImpervaCertificateSiteAssociation() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceSiteMtlsClientToImpervaCertificateAssociationRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsClientToImpervaCertificateAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	siteID, certificateID, accountID, err := validateInput(d)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	mTLSCertificateData, associationExists, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(siteID, certificateID, accountID)
+	mTLSCertificateData, associationExists, err := client.GetSiteMtlsClientToImpervaCertificateAssociation(ctx, siteID, certificateID, accountID)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	if !associationExists && !d.IsNewResource() {
 		log.Printf("Site to mutual TLS Imperva to Origin Certificate association with Site ID %d, Certificate ID %d doesn't exist any more. The resource will be deleted from terraform state.", siteID, certificateID)
@@ -89,7 +98,7 @@ func resourceSiteMtlsClientToImpervaCertificateAssociationRead(d *schema.Resourc
 		return nil
 	}
 	if mTLSCertificateData == nil {
-		return fmt.Errorf("Couldn't find the Incapsula Client to Imperva CA Certificate Site Association. Site Id %d, certificate ID %d", siteID, certificateID)
+		return diag.Errorf("Couldn't find the Incapsula Client to Imperva CA Certificate Site Association. Site Id %d, certificate ID %d", siteID, certificateID)
 	}
 
 	// Generate synthetic ID
@@ -99,37 +108,39 @@ func resourceSiteMtlsClientToImpervaCertificateAssociationRead(d *schema.Resourc
 	return nil
 }
 
-func resourceSiteMtlsClientToImpervaCertificateAssociationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsClientToImpervaCertificateAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID, certificateID, accountID, err := validateInput(d)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	err = client.CreateSiteMtlsClientToImpervaCertificateAssociation(
+		ctx,
 		certificateID,
 		siteID,
 		accountID,
 	)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 		//todo -add error message
 	}
-	return resourceSiteMtlsClientToImpervaCertificateAssociationRead(d, m)
+	return resourceSiteMtlsClientToImpervaCertificateAssociationRead(ctx, d, m)
 }
 
-func resourceSiteMtlsClientToImpervaCertificateAssociationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsClientToImpervaCertificateAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID, certificateID, accountID, err := validateInput(d)
 
 	err = client.DeleteSiteMtlsClientToImpervaCertificateAssociation(
+		ctx,
 		certificateID,
 		siteID,
 		accountID,
 	)
 	if err != nil {
 		//todo - check error
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId("")
@@ -1,19 +1,22 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
+	"time"
 )
 
 func resourceMtlsImpervaToOriginCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceMTLSImpervaToOriginCertificateCreate,
-		Read:   resourceMTLSImpervaToOriginCertificateRead,
-		Update: resourceMTLSImpervaToOriginCertificateUpdate,
-		Delete: resourceMTLSImpervaToOriginCertificateDelete,
+		CreateContext: resourceMTLSImpervaToOriginCertificateCreate,
+		ReadContext:   resourceMTLSImpervaToOriginCertificateRead,
+		UpdateContext: resourceMTLSImpervaToOriginCertificateUpdate,
+		DeleteContext: resourceMTLSImpervaToOriginCertificateDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -79,10 +82,16 @@ func resourceMtlsImpervaToOriginCertificate() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceMTLSImpervaToOriginCertificateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceMTLSImpervaToOriginCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	inputHash := createHash(d)
 	accountID := d.Get("account_id").(string)
@@ -108,6 +117,7 @@ func resourceMTLSImpervaToOriginCertificateCreate(d *schema.ResourceData, m inte
 		}
 
 		savedCertificate, err := client.AddMTLSCertificate(
+			ctx,
 			decodedCert,
 			decodedPKey,
 			passphrase,
@@ -117,22 +127,22 @@ func resourceMTLSImpervaToOriginCertificateCreate(d *schema.ResourceData, m inte
 		)
 
 		if err != nil {
-			return err
+			return diagnosticsFromError(d, err)
 		}
 		d.SetId(strconv.Itoa(savedCertificate.Id))
 		log.Printf("[INFO] Created mutual TLS Imperva to Origin Certificate with ID: %s\n", d.Id())
 	}
 
-	return resourceMTLSImpervaToOriginCertificateRead(d, m)
+	return resourceMTLSImpervaToOriginCertificateRead(ctx, d, m)
 }
 
-func resourceMTLSImpervaToOriginCertificateRead(d *schema.ResourceData, m interface{}) error {
+func resourceMTLSImpervaToOriginCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(string)
 
-	mTLSCertificateData, err := client.GetMTLSCertificate(d.Id(), accountID)
+	mTLSCertificateData, err := client.GetMTLSCertificate(ctx, d.Id(), accountID)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId(strconv.Itoa(mTLSCertificateData.Id))
@@ -143,7 +153,7 @@ func resourceMTLSImpervaToOriginCertificateRead(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceMTLSImpervaToOriginCertificateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceMTLSImpervaToOriginCertificateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	inputHash := createHash(d)
 	accountID := d.Get("account_id").(string)
@@ -166,6 +176,7 @@ func resourceMTLSImpervaToOriginCertificateUpdate(d *schema.ResourceData, m inte
 		}
 
 		mTLSCertificateData, err := client.UpdateMTLSCertificate(
+			ctx,
 			d.Id(),
 			decodedCert,
 			decodedPKey,
@@ -176,22 +187,22 @@ func resourceMTLSImpervaToOriginCertificateUpdate(d *schema.ResourceData, m inte
 		)
 
 		if err != nil {
-			return err
+			return diagnosticsFromError(d, err)
 		}
 		d.SetId(strconv.Itoa(mTLSCertificateData.Id))
 		log.Printf("[INFO] Updated mutual TLS Imperva to Origin Certificate with ID: %s\n", d.Id())
 	}
-	return resourceMTLSImpervaToOriginCertificateRead(d, m)
+	return resourceMTLSImpervaToOriginCertificateRead(ctx, d, m)
 }
 
-func resourceMTLSImpervaToOriginCertificateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceMTLSImpervaToOriginCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(string)
 
-	err := client.DeleteMTLSCertificate(d.Id(), accountID)
+	err := client.DeleteMTLSCertificate(ctx, d.Id(), accountID)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
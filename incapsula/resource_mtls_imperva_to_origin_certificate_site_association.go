@@ -1,21 +1,24 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strings"
+	"time"
 )
 
 func resourceMtlsImpervaToOriginCertificateSiteAssociation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteMtlsCertificateAssociationCreate,
-		Read:   resourceSiteMtlsCertificateAssociationRead,
-		Update: resourceSiteMtlsCertificateAssociationCreate,
-		Delete: resourceSiteMtlsCertificateAssociationDelete,
+		CreateContext: resourceSiteMtlsCertificateAssociationCreate,
+		ReadContext:   resourceSiteMtlsCertificateAssociationRead,
+		UpdateContext: resourceSiteMtlsCertificateAssociationCreate,
+		DeleteContext: resourceSiteMtlsCertificateAssociationDelete,
 		//todo
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of Incapsula Site to Imperva to Origin mutual TLS Certificate Association resource ID, expected site_id/certificate_id, got %s", d.Id())
@@ -48,24 +51,30 @@ func resourceMtlsImpervaToOriginCertificateSiteAssociation() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceSiteMtlsCertificateAssociationRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsCertificateAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	siteID, certificateID, accountId, err := validateInput(d)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	associationExists, err := client.GetSiteMtlsCertificateAssociation(certificateID, siteID, accountId)
+	associationExists, err := client.GetSiteMtlsCertificateAssociation(ctx, certificateID, siteID, accountId)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	if associationExists == false {
-		return fmt.Errorf("Couldn't find the Incapsula Site - Imperva to Origin mutual TLS Certificate Association")
+		return diag.Errorf("Couldn't find the Incapsula Site - Imperva to Origin mutual TLS Certificate Association")
 	}
 
 	// Generate synthetic ID
@@ -75,35 +84,37 @@ func resourceSiteMtlsCertificateAssociationRead(d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceSiteMtlsCertificateAssociationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsCertificateAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID, certificateID, accountId, err := validateInput(d)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	err = client.CreateSiteMtlsCertificateAssociation(
+		ctx,
 		certificateID,
 		siteID,
 		accountId,
 	)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
-	return resourceSiteMtlsCertificateAssociationRead(d, m)
+	return resourceSiteMtlsCertificateAssociationRead(ctx, d, m)
 }
 
-func resourceSiteMtlsCertificateAssociationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMtlsCertificateAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID, certificateID, accountId, err := validateInput(d)
 
 	err = client.DeleteSiteMtlsCertificateAssociation(
+		ctx,
 		certificateID,
 		siteID,
 		accountId,
 	)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId("")
@@ -1,20 +1,23 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func resourceOriginPOP() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "This resource is deprecated. It will be removed in a future version. Please use resource incapsula_data_centers_configuration instead.",
-		Create:             resourceOriginPOPUpdate,
-		Read:               resourceOriginPOPRead,
-		Update:             resourceOriginPOPUpdate,
-		Delete:             resourceOriginPOPDelete,
+		CreateContext:      resourceOriginPOPUpdate,
+		ReadContext:        resourceOriginPOPRead,
+		UpdateContext:      resourceOriginPOPUpdate,
+		DeleteContext:      resourceOriginPOPDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -46,10 +49,16 @@ func resourceOriginPOP() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceOriginPOPUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceOriginPOPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	dcID := d.Get("dc_id").(int)
 	siteID := d.Get("site_id").(int)
@@ -57,11 +66,11 @@ func resourceOriginPOPUpdate(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Setting Incapsula origin POP: %s for data center: %d\n", originPOP, dcID)
 
-	err := client.SetOriginPOP(dcID, originPOP)
+	err := client.SetOriginPOP(ctx, dcID, originPOP)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not set Incapsula origin POP: %s for data center: %d: %s\n", originPOP, dcID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	log.Printf("[INFO] Set Incapsula origin POP: %s for data center: %d\n", originPOP, dcID)
@@ -69,10 +78,10 @@ func resourceOriginPOPUpdate(d *schema.ResourceData, m interface{}) error {
 	syntheticID := fmt.Sprintf("%d/%d", siteID, dcID)
 	d.SetId(syntheticID)
 
-	return resourceOriginPOPRead(d, m)
+	return resourceOriginPOPRead(ctx, d, m)
 }
 
-func resourceOriginPOPRead(d *schema.ResourceData, m interface{}) error {
+func resourceOriginPOPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the ListDataCentersResponse for the data centers
 	client := m.(*Client)
 	if !strings.Contains(d.Id(), "/") {
@@ -86,11 +95,11 @@ func resourceOriginPOPRead(d *schema.ResourceData, m interface{}) error {
 	siteID := strings.Split(d.Id(), "/")[0]
 	dcID := strings.Split(d.Id(), "/")[1]
 
-	listDataCentersResponse, err := client.ListDataCenters(siteID)
+	listDataCentersResponse, err := client.ListDataCenters(ctx, siteID)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read origin POP for data center: %s, site: %s %s\n", dcID, siteID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	found := false
@@ -119,14 +128,14 @@ func resourceOriginPOPRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceOriginPOPDelete(d *schema.ResourceData, m interface{}) error {
+func resourceOriginPOPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	dcID := d.Get("dc_id").(int)
-	err := client.SetOriginPOP(dcID, "")
+	err := client.SetOriginPOP(ctx, dcID, "")
 
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula origin POP for data center: %d: %s\n", dcID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	log.Printf("[INFO] Deleted Incapsula origin POP for data center: %d\n", dcID)
@@ -1,22 +1,37 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
+	"net"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func resourcePolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyCreate,
-		Read:   resourcePolicyRead,
-		Update: resourcePolicyUpdate,
-		Delete: resourcePolicyDelete,
+		CreateContext: resourcePolicyCreate,
+		ReadContext:   resourcePolicyRead,
+		UpdateContext: resourcePolicyUpdate,
+		DeleteContext: resourcePolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				policyID, err := m.(*Client).lookupPolicyID(ctx, d.Id())
+				if err != nil {
+					return nil, err
+				}
+
+				d.SetId(policyID)
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -36,41 +51,34 @@ func resourcePolicy() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			// Optional Arguments
+			"policy_setting": {
+				Description:  "A setting of the policy. Either policy_setting blocks or policy_settings must be specified.",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"policy_setting", "policy_settings"},
+				Elem:         resourcePolicySetting(),
+			},
 			"policy_settings": {
-				Description: "The policy settings as JSON string. See Imperva documentation for help with constructing a correct value.",
-				Type:        schema.TypeString,
-				Required:    true,
-				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-					oldValue = strings.ReplaceAll(oldValue, " ", "")
-					oldValue = strings.ReplaceAll(oldValue, "\n", "")
-					oldValue = strings.ReplaceAll(oldValue, "\"data\":{}", "")
-					oldValue = strings.ReplaceAll(oldValue, "\"policyDataExceptions\":[]", "")
-					oldValue = strings.ReplaceAll(oldValue, ",,", ",")
-					oldValue = strings.ReplaceAll(oldValue, ",}", "}")
-					oldValue = strings.ReplaceAll(oldValue, "{,", "{")
-
-					newValue = strings.ReplaceAll(newValue, " ", "")
-					newValue = strings.ReplaceAll(newValue, "\n", "")
-					newValue = strings.ReplaceAll(newValue, "\"data\":{}", "")
-					newValue = strings.ReplaceAll(newValue, "\"policyDataExceptions\":[]", "")
-					newValue = strings.ReplaceAll(newValue, ",,", ",")
-					newValue = strings.ReplaceAll(newValue, ",}", "}")
-					newValue = strings.ReplaceAll(newValue, "{,", "{")
-
-					return suppressEquivalentJSONStringDiffs(k, oldValue, newValue, d)
-				},
+				Description:      "The policy settings as JSON string. See Imperva documentation for help with constructing a correct value.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Deprecated:       "Use the policy_setting blocks instead",
+				ExactlyOneOf:     []string{"policy_setting", "policy_settings"},
+				DiffSuppressFunc: suppressEquivalentPolicySettingsDiffs,
 				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
 					// Check if valid JSON
 					d := val.(string)
-					var js interface{}
-					unMarshalErr := json.Unmarshal([]byte(d), &js)
+					var policySettings []PolicySetting
+					unMarshalErr := json.Unmarshal([]byte(d), &policySettings)
 					if unMarshalErr != nil {
 						errs = append(errs, fmt.Errorf("%q must be a valid JSON policy, please check your syntax, got: %s, message: %s", key, d, unMarshalErr))
 					}
 					return
 				},
 			},
-			// Optional Arguments
 			"account_id": {
 				Description: "The Account ID of the policy.",
 				Type:        schema.TypeInt,
@@ -85,9 +93,158 @@ func resourcePolicy() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		CustomizeDiff: resourcePolicyCustomizeDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourcePolicyV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourcePolicyStateUpgradeV0,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+var policySettingsActions = []string{"BLOCK", "ALLOW", "ALERT", "BLOCK_USER", "BLOCK_IP", "IGNORE"}
+
+var policySettingURLPatterns = []string{"CONTAINS", "EQUALS", "NOT_CONTAINS", "NOT_EQUALS", "NOT_PREFIX", "NOT_SUFFIX", "PREFIX", "SUFFIX"}
+
+var policySettingContinents = []string{"AF", "AN", "AS", "EU", "NA", "OC", "SA"}
+
+func resourcePolicySetting() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"policy_setting_type": {
+				Description:  "The type of the setting. For example: IP, GEO, URL, or for WAF_RULES policies REMOTE_FILE_INCLUSION, ILLEGAL_RESOURCE_ACCESS, CROSS_SITE_SCRIPTING, SQL_INJECTION.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"settings_action": {
+				Description:  "The action of the setting. Possible values: " + strings.Join(policySettingsActions, ", ") + ".",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(policySettingsActions, false),
+			},
+			"countries": {
+				Description: "The ISO 3166-1 alpha-2 codes of the countries the setting applies to, such as US.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z]{2}$`), "must be an ISO 3166-1 alpha-2 country code in upper case, such as US"),
+				},
+			},
+			"continents": {
+				Description: "The codes of the continents the setting applies to. Possible values: " + strings.Join(policySettingContinents, ", ") + ".",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(policySettingContinents, false),
+				},
+			},
+			"ips": {
+				Description: "The IPs the setting applies to: IP addresses, CIDR blocks or ranges, such as 192.0.2.1, 192.0.2.0/24 or 192.0.2.1-192.0.2.20.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validatePolicyIP,
+				},
+			},
+			"url": {
+				Description: "A URL the setting applies to.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pattern": {
+							Description:  "How the URL is matched. Possible values: " + strings.Join(policySettingURLPatterns, ", ") + ".",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(policySettingURLPatterns, false),
+						},
+						"url": {
+							Description:  "The URL, or part of the URL, such as /admin.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"header_value": {
+				Description: "The header value the setting applies to.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"exception": {
+				Description: "An exception to the setting. The setting doesn't apply to requests matching all the data of the exception.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"comment": {
+							Description: "A comment on the exception.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"data": {
+							Description: "A condition of the exception.",
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"exception_type": {
+										Description:  "The type of the condition. For example: GEO, IP, URL, CLIENT_ID, SITE_ID.",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+									"values": {
+										Description: "The values matched by the condition.",
+										Type:        schema.TypeSet,
+										Optional:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"validate_exception_data": {
+										Description: "Whether the API validates the values of the condition.",
+										Type:        schema.TypeBool,
+										Optional:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
+// validatePolicyIP validates an IP of a policy setting: an IP address, a CIDR block or a range of IP addresses
+func validatePolicyIP(val interface{}, key string) (warns []string, errs []error) {
+	ip := val.(string)
+	if net.ParseIP(ip) != nil {
+		return
+	}
+	if _, _, err := net.ParseCIDR(ip); err == nil {
+		return
+	}
+	if from, to, found := strings.Cut(ip, "-"); found && net.ParseIP(from) != nil && net.ParseIP(to) != nil {
+		return
+	}
+	errs = append(errs, fmt.Errorf("%q must be an IP address, a CIDR block or a range of IP addresses such as 192.0.2.1-192.0.2.20, got: %s", key, ip))
+	return
+}
+
 func getCurrentAccountId(d *schema.ResourceData, accountStatus *AccountStatusResponse) *int {
 	caid := d.Get("account_id").(int)
 	if accountStatus.isSubAccount() || caid == 0 {
@@ -97,12 +254,13 @@ func getCurrentAccountId(d *schema.ResourceData, accountStatus *AccountStatusRes
 	return &caid
 }
 
-func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
-	policySettingsString := d.Get("policy_settings").(string)
-	var policySettings []PolicySetting
-	err := json.Unmarshal([]byte(policySettingsString), &policySettings)
+	policySettings, err := policySettingsFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	policySubmitted := PolicySubmitted{
 		Name:           d.Get("name").(string),
@@ -113,32 +271,32 @@ func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
 		PolicySettings: policySettings,
 	}
 
-	policyAddResponse, err := client.AddPolicy(&policySubmitted)
+	policyAddResponse, err := client.AddPolicy(ctx, &policySubmitted)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula policy: %s - %s\n", policySubmitted.Name, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	policyID := strconv.Itoa(policyAddResponse.Value.ID)
 
 	d.SetId(policyID)
 	log.Printf("[INFO] Created Incapsula policy with ID: %s\n", policyID)
-	return resourcePolicyRead(d, m)
+	return resourcePolicyRead(ctx, d, m)
 }
 
-func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Id()
 
 	currentAccountId := getCurrentAccountId(d, client.accountStatus)
 
-	policyGetResponse, err := client.GetPolicy(policyID, currentAccountId)
+	policyGetResponse, err := client.GetPolicy(ctx, policyID, currentAccountId)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula policy: %s - %s\n", policyID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set computed values
@@ -156,29 +314,31 @@ func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
 	policySettingsJSONBytes, err := json.MarshalIndent(policyGetResponse.Value.PolicySettings, "", "    ")
 	if err != nil {
 		log.Printf("[ERROR] Could not get marshal Incapsula policy settings: %s - %s - %s\n", policyID, err, policySettingsJSONBytes)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	d.Set("policy_settings", string(policySettingsJSONBytes))
+	d.Set("policy_setting", flattenPolicySettings(policyGetResponse.Value.PolicySettings))
 
 	return nil
 }
 
-func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	if d.Get("account_id") != nil {
 		log.Printf("[WARN] Incapsula policy account id attribute is deprecated - please remove it\n")
 	}
-	policySettingsString := d.Get("policy_settings").(string)
-	var policySettings []PolicySetting
-	err = json.Unmarshal([]byte(policySettingsString), &policySettings)
+	policySettings, err := policySettingsFromResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	currentAccountId := getCurrentAccountId(d, client.accountStatus)
-	policyGetResponse, err := client.GetPolicy(d.Id(), currentAccountId)
+	policyGetResponse, err := client.GetPolicy(ctx, d.Id(), currentAccountId)
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula policy: %d - %s\n", id, err)
 		if strings.Contains(err.Error(), "404") {
@@ -186,7 +346,7 @@ func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	policySubmitted := PolicySubmitted{
@@ -198,23 +358,23 @@ func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
 		PolicySettings:      policySettings,
 	}
 
-	_, err = client.UpdatePolicy(id, &policySubmitted, currentAccountId)
+	_, err = client.UpdatePolicy(ctx, id, &policySubmitted, currentAccountId)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not update Incapsula policy: %s - %s\n", policySubmitted.Name, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	return nil
 }
 
-func resourcePolicyDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	currentAccountId := getCurrentAccountId(d, client.accountStatus)
-	err := client.DeletePolicy(d.Id(), currentAccountId)
+	err := client.DeletePolicy(ctx, d.Id(), currentAccountId)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -223,3 +383,262 @@ func resourcePolicyDelete(d *schema.ResourceData, m interface{}) error {
 
 	return nil
 }
+
+// policySettingsFromResourceData returns the settings of the policy_setting blocks, or of the policy_settings JSON when it is configured
+func policySettingsFromResourceData(d *schema.ResourceData) ([]PolicySetting, error) {
+	policySettingsJSON := ""
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		// Without the configuration, the blocks win over the JSON when they are set
+		if d.Get("policy_setting").(*schema.Set).Len() == 0 {
+			policySettingsJSON = d.Get("policy_settings").(string)
+		}
+	} else if value := rawConfig.GetAttr("policy_settings"); !value.IsNull() && value.IsKnown() {
+		policySettingsJSON = value.AsString()
+	}
+
+	if policySettingsJSON == "" {
+		return expandPolicySettings(d.Get("policy_setting").(*schema.Set)), nil
+	}
+	var policySettings []PolicySetting
+	if err := json.Unmarshal([]byte(policySettingsJSON), &policySettings); err != nil {
+		return nil, fmt.Errorf("Error parsing the policy_settings JSON: %s", err)
+	}
+	return policySettings, nil
+}
+
+func expandPolicySettings(policySettingSet *schema.Set) []PolicySetting {
+	policySettings := []PolicySetting{}
+	for _, item := range policySettingSet.List() {
+		policySettingMap := item.(map[string]interface{})
+		policySetting := PolicySetting{
+			SettingsAction:    policySettingMap["settings_action"].(string),
+			PolicySettingType: policySettingMap["policy_setting_type"].(string),
+			Data: PolicySettingData{
+				Ips:         expandPolicyStrings(policySettingMap["ips"]),
+				HeaderValue: policySettingMap["header_value"].(string),
+			},
+		}
+
+		countries := expandPolicyStrings(policySettingMap["countries"])
+		continents := expandPolicyStrings(policySettingMap["continents"])
+		if len(countries) > 0 || len(continents) > 0 {
+			policySetting.Data.Geo = &PolicySettingGeo{Countries: countries, Continents: continents}
+		}
+
+		for _, url := range policySettingMap["url"].(*schema.Set).List() {
+			urlMap := url.(map[string]interface{})
+			policySetting.Data.Urls = append(policySetting.Data.Urls, PolicySettingURL{
+				Pattern: urlMap["pattern"].(string),
+				URL:     urlMap["url"].(string),
+			})
+		}
+
+		for _, exception := range policySettingMap["exception"].(*schema.Set).List() {
+			exceptionMap := exception.(map[string]interface{})
+			policyDataException := PolicyDataException{Comment: exceptionMap["comment"].(string)}
+			for _, data := range exceptionMap["data"].(*schema.Set).List() {
+				dataMap := data.(map[string]interface{})
+				policyDataException.Data = append(policyDataException.Data, PolicyDataExceptionData{
+					ExceptionType:         dataMap["exception_type"].(string),
+					Values:                expandPolicyStrings(dataMap["values"]),
+					ValidateExceptionData: dataMap["validate_exception_data"].(bool),
+				})
+			}
+			policySetting.PolicyDataExceptions = append(policySetting.PolicyDataExceptions, policyDataException)
+		}
+
+		policySettings = append(policySettings, policySetting)
+	}
+	return policySettings
+}
+
+// expandPolicyStrings returns the sorted strings of a set, the order of the API requests doesn't depend on the set hashes
+func expandPolicyStrings(value interface{}) []string {
+	var values []string
+	for _, item := range value.(*schema.Set).List() {
+		values = append(values, item.(string))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// flattenPolicySettings returns the policy_setting blocks of the settings. The result only holds JSON types, since it
+// is also used to upgrade the state
+func flattenPolicySettings(policySettings []PolicySetting) []interface{} {
+	policySettingList := make([]interface{}, 0, len(policySettings))
+	for _, policySetting := range policySettings {
+		countries := []interface{}{}
+		continents := []interface{}{}
+		if policySetting.Data.Geo != nil {
+			countries = flattenPolicyStrings(policySetting.Data.Geo.Countries)
+			continents = flattenPolicyStrings(policySetting.Data.Geo.Continents)
+		}
+
+		urls := make([]interface{}, 0, len(policySetting.Data.Urls))
+		for _, url := range policySetting.Data.Urls {
+			urls = append(urls, map[string]interface{}{
+				"pattern": url.Pattern,
+				"url":     url.URL,
+			})
+		}
+
+		exceptions := make([]interface{}, 0, len(policySetting.PolicyDataExceptions))
+		for _, policyDataException := range policySetting.PolicyDataExceptions {
+			data := make([]interface{}, 0, len(policyDataException.Data))
+			for _, exceptionData := range policyDataException.Data {
+				data = append(data, map[string]interface{}{
+					"exception_type":          exceptionData.ExceptionType,
+					"values":                  flattenPolicyStrings(exceptionData.Values),
+					"validate_exception_data": exceptionData.ValidateExceptionData,
+				})
+			}
+			exceptions = append(exceptions, map[string]interface{}{
+				"comment": policyDataException.Comment,
+				"data":    data,
+			})
+		}
+
+		policySettingList = append(policySettingList, map[string]interface{}{
+			"policy_setting_type": policySetting.PolicySettingType,
+			"settings_action":     policySetting.SettingsAction,
+			"countries":           countries,
+			"continents":          continents,
+			"ips":                 flattenPolicyStrings(policySetting.Data.Ips),
+			"url":                 urls,
+			"header_value":        policySetting.Data.HeaderValue,
+			"exception":           exceptions,
+		})
+	}
+	return policySettingList
+}
+
+func flattenPolicyStrings(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+// normalizedPolicySettings returns the settings in a canonical JSON form: the order of the settings, of their
+// exceptions and of all the lists doesn't matter to the API
+func normalizedPolicySettings(policySettingsJSON string) (string, error) {
+	var policySettings []PolicySetting
+	if err := json.Unmarshal([]byte(policySettingsJSON), &policySettings); err != nil {
+		return "", err
+	}
+
+	settings := make([]string, 0, len(policySettings))
+	for _, policySetting := range policySettings {
+		data := &policySetting.Data
+		sort.Strings(data.Ips)
+		if data.Geo != nil {
+			sort.Strings(data.Geo.Countries)
+			sort.Strings(data.Geo.Continents)
+			if len(data.Geo.Countries) == 0 && len(data.Geo.Continents) == 0 {
+				data.Geo = nil
+			}
+		}
+		sort.Slice(data.Urls, func(i, j int) bool {
+			return data.Urls[i].Pattern+" "+data.Urls[i].URL < data.Urls[j].Pattern+" "+data.Urls[j].URL
+		})
+
+		exceptions := make([]string, 0, len(policySetting.PolicyDataExceptions))
+		for _, policyDataException := range policySetting.PolicyDataExceptions {
+			exceptionData := make([]string, 0, len(policyDataException.Data))
+			for _, data := range policyDataException.Data {
+				sort.Strings(data.Values)
+				exceptionDataJSON, _ := json.Marshal(data)
+				exceptionData = append(exceptionData, string(exceptionDataJSON))
+			}
+			sort.Strings(exceptionData)
+			exceptions = append(exceptions, policyDataException.Comment+"\n"+strings.Join(exceptionData, "\n"))
+		}
+		sort.Strings(exceptions)
+		policySetting.PolicyDataExceptions = nil
+
+		settingJSON, _ := json.Marshal(policySetting)
+		settings = append(settings, string(settingJSON)+"\n"+strings.Join(exceptions, "\n"))
+	}
+	sort.Strings(settings)
+	return strings.Join(settings, "\n\n"), nil
+}
+
+// suppressEquivalentPolicySettingsDiffs suppresses the diff of policy settings that only differ by their order, or by
+// empty data and exceptions
+func suppressEquivalentPolicySettingsDiffs(k, old, new string, d *schema.ResourceData) bool {
+	normalizedOld, err := normalizedPolicySettings(old)
+	if err != nil {
+		return false
+	}
+	normalizedNew, err := normalizedPolicySettings(new)
+	if err != nil {
+		return false
+	}
+	return normalizedOld == normalizedNew
+}
+
+// resourcePolicyCustomizeDiff marks the form of the settings that is not configured as changing along with the one
+// that is, the API response sets both of them
+func resourcePolicyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("policy_setting") && !d.HasChange("policy_settings") {
+		return d.SetNewComputed("policy_settings")
+	}
+	if d.HasChange("policy_settings") && !d.HasChange("policy_setting") {
+		return d.SetNewComputed("policy_setting")
+	}
+	return nil
+}
+
+// resourcePolicyV0 is the schema of the policies whose settings were only held by the policy_settings JSON
+func resourcePolicyV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+			"policy_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"policy_settings": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"account_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Optional: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// resourcePolicyStateUpgradeV0 fills the policy_setting blocks from the policy_settings JSON
+func resourcePolicyStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	policySettingsJSON, _ := rawState["policy_settings"].(string)
+	if strings.TrimSpace(policySettingsJSON) == "" {
+		return rawState, nil
+	}
+
+	var policySettings []PolicySetting
+	if err := json.Unmarshal([]byte(policySettingsJSON), &policySettings); err != nil {
+		return nil, fmt.Errorf("Error upgrading the state of Incapsula policy %v, could not parse policy_settings: %s", rawState["id"], err)
+	}
+	rawState["policy_setting"] = flattenPolicySettings(policySettings)
+	return rawState, nil
+}
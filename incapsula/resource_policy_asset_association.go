@@ -1,18 +1,21 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strings"
+	"time"
 )
 
 func resourcePolicyAssetAssociation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePolicyAssetAssociationCreate,
-		Read:   resourcePolicyAssetAssociationRead,
-		Update: nil,
-		Delete: resourcePolicyAssetAssociationDelete,
+		CreateContext: resourcePolicyAssetAssociationCreate,
+		ReadContext:   resourcePolicyAssetAssociationRead,
+		UpdateContext: nil,
+		DeleteContext: resourcePolicyAssetAssociationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -46,10 +49,16 @@ func resourcePolicyAssetAssociation() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourcePolicyAssetAssociationCreate(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Get("policy_id").(string)
@@ -57,11 +66,11 @@ func resourcePolicyAssetAssociationCreate(d *schema.ResourceData, m interface{})
 	assetType := d.Get("asset_type").(string)
 	currentAccountId := d.Get("account_id").(int)
 
-	err := client.AddPolicyAssetAssociation(policyID, assetID, assetType, &currentAccountId)
+	err := client.AddPolicyAssetAssociation(ctx, policyID, assetID, assetType, &currentAccountId)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula policy asset association: policy ID (%s) - asset ID (%s) - asset type (%s) - %s\n", policyID, assetID, assetType, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Generate synthetic ID
@@ -69,10 +78,10 @@ func resourcePolicyAssetAssociationCreate(d *schema.ResourceData, m interface{})
 	d.SetId(syntheticID)
 	log.Printf("[INFO] Created Incapsula policy asset association with ID: %s - policy ID (%s) - asset ID (%s) - asset type (%s)\n", syntheticID, policyID, assetID, assetType)
 
-	return resourcePolicyAssetAssociationRead(d, m)
+	return resourcePolicyAssetAssociationRead(ctx, d, m)
 }
 
-func resourcePolicyAssetAssociationRead(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := strings.Split(d.Id(), "/")[0]
@@ -84,11 +93,11 @@ func resourcePolicyAssetAssociationRead(d *schema.ResourceData, m interface{}) e
 	} else {
 		log.Printf("[INFO] Trying to read Incapsula Policy Asset Association: %s-%s-%s\n", policyID, assetID, assetType)
 	}
-	var isAssociated, err = client.isPolicyAssetAssociated(policyID, assetID, assetType, currentAccountId)
+	var isAssociated, err = client.isPolicyAssetAssociated(ctx, policyID, assetID, assetType, currentAccountId)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula Policy Asset Association: %s-%s-%s, err: %s\n", policyID, assetID, assetType, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	if !isAssociated {
@@ -111,7 +120,7 @@ func resourcePolicyAssetAssociationRead(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourcePolicyAssetAssociationDelete(d *schema.ResourceData, m interface{}) error {
+func resourcePolicyAssetAssociationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	policyID := d.Get("policy_id").(string)
@@ -123,10 +132,10 @@ func resourcePolicyAssetAssociationDelete(d *schema.ResourceData, m interface{})
 	} else {
 		log.Printf("[INFO] Trying to delete Incapsula Policy Asset Association: %s-%s-%s\n", policyID, assetID, assetType)
 	}
-	err := client.DeletePolicyAssetAssociation(policyID, assetID, assetType, currentAccountId)
+	err := client.DeletePolicyAssetAssociation(ctx, policyID, assetID, assetType, currentAccountId)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
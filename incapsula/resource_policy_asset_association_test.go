@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -64,7 +65,7 @@ func testCheckIncapsulaPolicyAssetAssociationExists(name string) resource.TestCh
 		assetID := splittedIds[1]
 		assetType := splittedIds[2]
 		client := testAccProvider.Meta().(*Client)
-		isAssociated, err := client.isPolicyAssetAssociated(policyId, assetID, assetType, nil)
+		isAssociated, err := client.isPolicyAssetAssociated(context.Background(), policyId, assetID, assetType, nil)
 		if err != nil {
 			return fmt.Errorf("Get Incapsula Policy Asset Association return error %s", err)
 		}
@@ -124,7 +125,7 @@ func testAccCheckIncapsulaPolicyAssetAssociationDestroy(state *terraform.State)
 		return fmt.Errorf("Failed to find incapsula Policy")
 	}
 
-	isAssetAssociated, err := client.isPolicyAssetAssociated(policyId, siteId, assetTypeWebsite, nil)
+	isAssetAssociated, err := client.isPolicyAssetAssociated(context.Background(), policyId, siteId, assetTypeWebsite, nil)
 	if err != nil {
 		return fmt.Errorf("Get Incapsula Policy Asset Association return error %s", err)
 	}
@@ -1,10 +1,14 @@
 package incapsula
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -103,10 +107,205 @@ func TestAccIncapsulaPolicy_basic(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateIdFunc: testAccStatePolicyID,
 			},
+			{
+				Config: testAccCheckIncapsulaPolicyConfigBlocks(t, aclPolicyName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckIncapsulaPolicyExists(policyResourceTypeAndName+aclPolicyName),
+					resource.TestCheckResourceAttr(policyResourceTypeAndName+aclPolicyName, "policy_setting.#", "2"),
+				),
+			},
+			{
+				ResourceName:            policyResourceTypeAndName + aclPolicyName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"policy_settings"},
+				ImportStateIdFunc:       testAccStatePolicyID,
+			},
 		},
 	})
 }
 
+func TestPolicySettingsExpandFlatten(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePolicy().Schema, map[string]interface{}{
+		"name":        "acl",
+		"enabled":     true,
+		"policy_type": "ACL",
+		"policy_setting": []interface{}{
+			map[string]interface{}{
+				"policy_setting_type": "URL",
+				"settings_action":     "BLOCK",
+				"url": []interface{}{
+					map[string]interface{}{"pattern": "PREFIX", "url": "/admin"},
+				},
+				"exception": []interface{}{
+					map[string]interface{}{
+						"comment": "Allow the office",
+						"data": []interface{}{
+							map[string]interface{}{"exception_type": "IP", "values": []interface{}{"192.0.2.2", "192.0.2.1"}},
+						},
+					},
+				},
+			},
+			map[string]interface{}{
+				"policy_setting_type": "GEO",
+				"settings_action":     "ALLOW",
+				"countries":           []interface{}{"US", "FR"},
+			},
+		},
+	})
+
+	policySettings, err := policySettingsFromResourceData(d)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(policySettings) != 2 {
+		t.Fatalf("Should have expanded 2 settings, got: %+v", policySettings)
+	}
+	for _, policySetting := range policySettings {
+		switch policySetting.PolicySettingType {
+		case "URL":
+			if len(policySetting.Data.Urls) != 1 || policySetting.Data.Urls[0].URL != "/admin" || policySetting.Data.Geo != nil {
+				t.Errorf("Unexpected URL setting data: %+v", policySetting.Data)
+			}
+			exceptions := policySetting.PolicyDataExceptions
+			if len(exceptions) != 1 || exceptions[0].Comment != "Allow the office" || strings.Join(exceptions[0].Data[0].Values, ",") != "192.0.2.1,192.0.2.2" {
+				t.Errorf("Unexpected URL setting exceptions: %+v", exceptions)
+			}
+		case "GEO":
+			if policySetting.Data.Geo == nil || strings.Join(policySetting.Data.Geo.Countries, ",") != "FR,US" {
+				t.Errorf("Unexpected GEO setting data: %+v", policySetting.Data)
+			}
+		default:
+			t.Errorf("Unexpected setting: %+v", policySetting)
+		}
+	}
+
+	// Reading the settings back must not change the blocks
+	expected := d.Get("policy_setting").(*schema.Set)
+	if err := d.Set("policy_setting", flattenPolicySettings(policySettings)); err != nil {
+		t.Fatalf("Should not have received an error setting the blocks, got: %s", err)
+	}
+	if actual := d.Get("policy_setting").(*schema.Set); !actual.Equal(expected) {
+		t.Errorf("The flattened blocks should match the configured ones, expected: %v, got: %v", expected.List(), actual.List())
+	}
+}
+
+func TestPolicySettingsFromJSON(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePolicy().Schema, map[string]interface{}{
+		"name":            "waf",
+		"enabled":         true,
+		"policy_type":     "WAF_RULES",
+		"policy_settings": wafPolicySettings,
+	})
+
+	policySettings, err := policySettingsFromResourceData(d)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	if len(policySettings) != 4 || policySettings[0].PolicySettingType != "REMOTE_FILE_INCLUSION" {
+		t.Errorf("Unexpected settings: %+v", policySettings)
+	}
+}
+
+func TestSuppressEquivalentPolicySettingsDiffs(t *testing.T) {
+	old := `[{"settingsAction":"BLOCK","policySettingType":"IP","data":{"ips":["10.0.0.1","10.0.0.2"]},"policyDataExceptions":[]},` +
+		`{"settingsAction":"BLOCK","policySettingType":"SQL_INJECTION","data":{}}]`
+	reordered := `[{"policySettingType":"SQL_INJECTION","settingsAction":"BLOCK"},` +
+		`{"settingsAction":"BLOCK","policySettingType":"IP","data":{"ips":["10.0.0.2","10.0.0.1"]}}]`
+	changed := `[{"policySettingType":"SQL_INJECTION","settingsAction":"BLOCK"},` +
+		`{"settingsAction":"BLOCK","policySettingType":"IP","data":{"ips":["10.0.0.3","10.0.0.1"]}}]`
+
+	if !suppressEquivalentPolicySettingsDiffs("policy_settings", old, reordered, nil) {
+		t.Errorf("The diff of reordered settings should be suppressed")
+	}
+	if suppressEquivalentPolicySettingsDiffs("policy_settings", old, changed, nil) {
+		t.Errorf("The diff of changed IPs should not be suppressed")
+	}
+	if !suppressEquivalentPolicySettingsDiffs("policy_settings", aclPolicySettingsUrlExceptions, aclPolicySettingsUrlExceptions, nil) {
+		t.Errorf("The diff of identical settings should be suppressed")
+	}
+}
+
+func TestValidatePolicyIP(t *testing.T) {
+	for _, ip := range []string{"192.0.2.1", "192.0.2.0/24", "192.0.2.1-192.0.2.20", "2001:db8::1", "2001:db8::/32"} {
+		if _, errs := validatePolicyIP(ip, "ips"); len(errs) != 0 {
+			t.Errorf("%s should be a valid IP, got: %v", ip, errs)
+		}
+	}
+	for _, ip := range []string{"", "192.0.2.300", "192.0.2.0/33", "192.0.2.1-", "example.com"} {
+		if _, errs := validatePolicyIP(ip, "ips"); len(errs) == 0 {
+			t.Errorf("%q should not be a valid IP", ip)
+		}
+	}
+}
+
+func TestResourcePolicyStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":              "123",
+		"name":            "acl",
+		"policy_type":     "ACL",
+		"policy_settings": aclPolicySettingsUrlExceptions,
+	}
+
+	upgradedState, err := resourcePolicyStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	policySettingList := upgradedState["policy_setting"].([]interface{})
+	if len(policySettingList) != 2 {
+		t.Fatalf("Should have upgraded 2 settings, got: %v", policySettingList)
+	}
+	ipSetting := policySettingList[0].(map[string]interface{})
+	if ipSetting["policy_setting_type"] != "IP" || len(ipSetting["ips"].([]interface{})) != 3 || len(ipSetting["exception"].([]interface{})) != 1 {
+		t.Errorf("Unexpected upgraded IP setting: %v", ipSetting)
+	}
+	if upgradedState["policy_settings"] != aclPolicySettingsUrlExceptions {
+		t.Errorf("The policy_settings JSON should be kept, got: %v", upgradedState["policy_settings"])
+	}
+
+	// The upgraded state must be readable with the current schema
+	if _, err := json.Marshal(upgradedState); err != nil {
+		t.Errorf("The upgraded state should be JSON, got: %s", err)
+	}
+	if _, err := resourcePolicyStateUpgradeV0(context.Background(), map[string]interface{}{"id": "123", "policy_settings": "{"}, nil); err == nil {
+		t.Errorf("Should have received an error upgrading invalid JSON")
+	}
+}
+
+func TestResourcePolicyFakeAPI(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+	accountStatusResponse, err := client.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Should not have received an error, got: %s", err)
+	}
+	client.accountStatus = accountStatusResponse
+
+	d := schema.TestResourceDataRaw(t, resourcePolicy().Schema, map[string]interface{}{
+		"name":        "allowlist",
+		"enabled":     true,
+		"policy_type": "WHITELIST",
+		"policy_setting": []interface{}{
+			map[string]interface{}{
+				"policy_setting_type": "IP",
+				"settings_action":     "ALLOW",
+				"ips":                 []interface{}{"192.0.2.1"},
+			},
+		},
+	})
+	if diags := resourcePolicyCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error creating the policy, got: %v", diags)
+	}
+
+	policySettingList := d.Get("policy_setting").(*schema.Set).List()
+	if len(policySettingList) != 1 || policySettingList[0].(map[string]interface{})["settings_action"] != "ALLOW" {
+		t.Errorf("Unexpected policy_setting blocks: %v", policySettingList)
+	}
+	if !strings.Contains(d.Get("policy_settings").(string), `"192.0.2.1"`) {
+		t.Errorf("The policy_settings JSON should hold the IP, got: %s", d.Get("policy_settings"))
+	}
+}
+
 func testAccStatePolicyID(state *terraform.State) (string, error) {
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != policyResourceType {
@@ -139,7 +338,7 @@ func testCheckIncapsulaPolicyExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Policy ID conversion error for %s: %s", policyIDStr, err)
 		}
 		client := testAccProvider.Meta().(*Client)
-		_, err = client.GetPolicy(policyIDStr, nil)
+		_, err = client.GetPolicy(context.Background(), policyIDStr, nil)
 		if err != nil {
 			return fmt.Errorf("Get Incapsula Policy return error %s", err)
 		}
@@ -151,6 +350,37 @@ func testAccCheckIncapsulaPolicyConfigBasic(t *testing.T, policyName string, ena
 	return testAccCheckIncapsulaSiteConfigBasic(GenerateTestDomain(t)) + createPolicyResourceString(policyName, enabled, policyType, policySettings)
 }
 
+func testAccCheckIncapsulaPolicyConfigBlocks(t *testing.T, policyName string) string {
+	return testAccCheckIncapsulaSiteConfigBasic(GenerateTestDomain(t)) + fmt.Sprintf(`
+resource "%s" "%s" {
+    name        = "%s"
+    enabled     = true
+    policy_type = "ACL"
+
+    policy_setting {
+        policy_setting_type = "IP"
+        settings_action     = "BLOCK"
+        ips                 = ["10.10.10.10", "10.10.10.0/24"]
+
+        exception {
+            comment = "Allow the office"
+            data {
+                exception_type = "IP"
+                values         = ["10.10.192.10"]
+            }
+        }
+    }
+
+    policy_setting {
+        policy_setting_type = "GEO"
+        settings_action     = "BLOCK"
+        countries           = ["WF", "AD"]
+        continents          = ["AS"]
+    }
+}`, policyResourceType, policyResourceName+policyName, policyName,
+	)
+}
+
 func createPolicyResourceString(policyName string, enabled bool, policyType string, policySettings string) string {
 	return fmt.Sprintf(`
 resource "%s" "%s" {
@@ -184,7 +414,7 @@ func testAccCheckIncapsulaPolicyDestroy(state *terraform.State) error {
 			return nil
 		}
 
-		getPolicyResponse, _ := client.GetPolicy(policyId, nil)
+		getPolicyResponse, _ := client.GetPolicy(context.Background(), policyId, nil)
 
 		if getPolicyResponse != nil {
 			return fmt.Errorf("Incapsula Policy with name: %s (id: %d) still exists", getPolicyResponse.Value.Name, getPolicyResponse.Value.ID)
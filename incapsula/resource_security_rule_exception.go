@@ -1,11 +1,14 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -38,12 +41,12 @@ type DeleteSecurityRuleExceptionResponse struct {
 
 func resourceSecurityRuleException() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSecurityRuleExceptionCreate,
-		Read:   resourceSecurityRuleExceptionRead,
-		Update: resourceSecurityRuleExceptionUpdate,
-		Delete: resourceSecurityRuleExceptionDelete,
+		CreateContext: resourceSecurityRuleExceptionCreate,
+		ReadContext:   resourceSecurityRuleExceptionRead,
+		UpdateContext: resourceSecurityRuleExceptionUpdate,
+		DeleteContext: resourceSecurityRuleExceptionDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 3 || idSlice[0] == "" || idSlice[1] == "" || idSlice[2] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id/rule_exception_id", d.Id())
@@ -142,16 +145,23 @@ func resourceSecurityRuleException() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
 
 	log.Printf("[INFO] Configuring Incapsula Security Rule Exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 	siteStatusResponse, err := client.AddSecurityRuleException(
+		ctx,
 		d.Get("site_id").(int),
 		ruleID,
 		d.Get("client_app_types").(string),
@@ -165,7 +175,7 @@ func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{})
 	)
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the rule exception ID
@@ -173,10 +183,10 @@ func resourceSecurityRuleExceptionCreate(d *schema.ResourceData, m interface{})
 
 	log.Printf("[INFO] Created Incapsula security rule exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceSecurityRuleExceptionRead(d, m)
+	return resourceSecurityRuleExceptionRead(ctx, d, m)
 }
 
-func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
@@ -186,7 +196,7 @@ func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) er
 
 	log.Printf("[INFO] Reading Incapsula security rule exception whitelist_id (%d) on rule_id (%s) \n", whitelistID, ruleID)
 
-	siteStatusResponse, err := client.ListSecurityRuleExceptions(siteID, ruleID)
+	siteStatusResponse, err := client.ListSecurityRuleExceptions(ctx, siteID, ruleID)
 
 	// Site object may have been deleted
 	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
@@ -197,7 +207,7 @@ func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) er
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula security rule exception whitelist_id (%d) on rule_id (%s) %s\n", whitelistID, ruleID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Now with the site status, iterate through the rules and find our ID
@@ -265,7 +275,7 @@ func resourceSecurityRuleExceptionRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -278,6 +288,7 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 	// ACL RuleIDs
 	case blacklistedCountriesExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			d.Get("client_app_types").(string),
@@ -292,10 +303,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case blacklistedIPsExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -310,10 +322,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case blacklistedURLsExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -328,10 +341,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case backdoorExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -346,10 +360,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case botAccessControlExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -364,10 +379,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case crossSiteScriptingExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -382,10 +398,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case ddosExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -400,10 +417,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case illegalResourceAccessExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -418,10 +436,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case remoteFileInclusionExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -436,10 +455,11 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case sqlInjectionExceptionRuleID:
 		_, err := client.EditSecurityRuleException(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -454,7 +474,7 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not update Incapsula security rule exception for rule_id (%s) on site_id (%d), %s\n", ruleID, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	}
 
@@ -463,10 +483,10 @@ func resourceSecurityRuleExceptionUpdate(d *schema.ResourceData, m interface{})
 
 	log.Printf("[INFO] Updated Incapsula security rule exception for rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceWAFSecurityRuleRead(d, m)
+	return resourceWAFSecurityRuleRead(ctx, d, m)
 }
 
-func resourceSecurityRuleExceptionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSecurityRuleExceptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -475,13 +495,14 @@ func resourceSecurityRuleExceptionDelete(d *schema.ResourceData, m interface{})
 	log.Printf("[INFO] Deleting Incapsula security rule exception whitelist_id (%s) for rule_id (%s) on site_id (%d)\n", whitelistID, ruleID, d.Get("site_id").(int))
 
 	err := client.DeleteSecurityRuleException(
+		ctx,
 		d.Get("site_id").(int),
 		ruleID,
 		whitelistID,
 	)
 	if err != nil {
 		log.Printf("[ERROR] Could not delete Incapsula security rule exception whitelist_id (%s) for rule_id (%s) on site_id (%d), %s\n", whitelistID, ruleID, d.Get("site_id").(int), err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -0,0 +1,395 @@
+package incapsula
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// securityRuleExceptionParams are the parameters of an exception, in the order of the exception blocks
+var securityRuleExceptionParams = []string{"client_app_types", "client_apps", "countries", "continents", "ips", "urls", "user_agents", "parameters"}
+
+func resourceSecurityRuleExceptions() *schema.Resource {
+	ruleIDs := make([]string, 0, len(securityRuleExceptionParamMapping))
+	for ruleID := range securityRuleExceptionParamMapping {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+
+	return &schema.Resource{
+		CreateContext: resourceSecurityRuleExceptionsCreate,
+		ReadContext:   resourceSecurityRuleExceptionsRead,
+		UpdateContext: resourceSecurityRuleExceptionsUpdate,
+		DeleteContext: resourceSecurityRuleExceptionsDelete,
+		CustomizeDiff: resourceSecurityRuleExceptionsCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				siteID, ruleID, err := parseSecurityRuleExceptionsID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("site_id", siteID)
+				d.Set("rule_id", ruleID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			// Required Arguments
+			"site_id": {
+				Description: "Numeric identifier of the site to operate on.",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"rule_id": {
+				Description:  "The identifier of the security rule, e.g api.threats.cross_site_scripting.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(ruleIDs, false),
+			},
+
+			// Optional Arguments
+			"exception": {
+				Description: "The exceptions of the security rule. Exceptions of the rule that are not listed are deleted.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_app_types": securityRuleExceptionValuesSchema("The client application types."),
+						"client_apps":      securityRuleExceptionValuesSchema("The client application IDs."),
+						"countries":        securityRuleExceptionValuesSchema("The country codes."),
+						"continents":       securityRuleExceptionValuesSchema("The continent codes."),
+						"ips":              securityRuleExceptionValuesSchema("The IPs or IP ranges, e.g: 192.168.1.1, 192.168.1.1-192.168.1.100 or 192.168.1.1/24."),
+						"urls":             securityRuleExceptionValuesSchema("The resource paths, e.g. /home or /admin/index.html."),
+						"user_agents":      securityRuleExceptionValuesSchema("The encoded user agents."),
+						"parameters":       securityRuleExceptionValuesSchema("The encoded parameters."),
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+func securityRuleExceptionValuesSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+			// The API receives the values as a comma separated list
+			ValidateFunc: validation.All(validation.StringIsNotWhiteSpace, validation.StringDoesNotContainAny(",")),
+		},
+	}
+}
+
+func parseSecurityRuleExceptionsID(id string) (int, string, error) {
+	idSlice := strings.Split(id, "/")
+	if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
+		return 0, "", fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", id)
+	}
+	siteID, err := strconv.Atoi(idSlice[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("unexpected format of ID (%q), the site_id must be numeric", id)
+	}
+	return siteID, idSlice[1], nil
+}
+
+// resourceSecurityRuleExceptionsCustomizeDiff checks the configured exceptions. It reads the raw configuration, since
+// the exception blocks without values are dropped from the set
+func resourceSecurityRuleExceptionsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	ruleID := rawConfig.GetAttr("rule_id")
+	if ruleID.IsNull() || !ruleID.IsKnown() {
+		return nil
+	}
+	return validateSecurityRuleExceptions(ruleID.AsString(), rawConfig.GetAttr("exception"))
+}
+
+// validateSecurityRuleExceptions checks that each exception block sets at least one parameter, and only the
+// parameters accepted by the rule. Null and empty values are not set, unknown values may be
+func validateSecurityRuleExceptions(ruleID string, exceptions cty.Value) error {
+	allowed, ok := securityRuleExceptionParamMapping[ruleID]
+	if !ok || exceptions.IsNull() || !exceptions.IsKnown() {
+		return nil
+	}
+
+	var errs []error
+	for it := exceptions.ElementIterator(); it.Next(); {
+		_, block := it.Element()
+		if block.IsNull() || !block.IsKnown() {
+			continue
+		}
+		configured, unknown := 0, false
+		for _, param := range securityRuleExceptionParams {
+			value := block.GetAttr(param)
+			switch {
+			case value.IsNull():
+				continue
+			case !value.IsKnown():
+				unknown = true
+			case value.LengthInt() == 0:
+				continue
+			}
+			configured++
+			if !contains(allowed, param) {
+				errs = append(errs, fmt.Errorf("%s cannot be set in the exceptions of rule %s, the exceptions of this rule accept: %s", param, ruleID, strings.Join(allowed, ", ")))
+			}
+		}
+		if configured == 0 && !unknown {
+			errs = append(errs, fmt.Errorf("each exception of rule %s must set at least one of: %s", ruleID, strings.Join(allowed, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func resourceSecurityRuleExceptionsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	siteID := d.Get("site_id").(int)
+	ruleID := d.Get("rule_id").(string)
+	d.SetId(fmt.Sprintf("%d/%s", siteID, ruleID))
+	return resourceSecurityRuleExceptionsApply(ctx, d, m)
+}
+
+func resourceSecurityRuleExceptionsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceSecurityRuleExceptionsApply(ctx, d, m)
+}
+
+// resourceSecurityRuleExceptionsApply changes the exceptions of the rule to the configured ones, then reads them back.
+// When a change fails, the exceptions are read back anyway, so that the state holds the changes already made
+func resourceSecurityRuleExceptionsApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	desired := expandSecurityRuleExceptions(d.Get("exception").(*schema.Set))
+	if err := applySecurityRuleExceptions(ctx, m.(*Client), d.Get("site_id").(int), d.Get("rule_id").(string), desired); err != nil {
+		diags := diagnosticsFromError(d, err)
+		return append(diags, resourceSecurityRuleExceptionsRead(ctx, d, m)...)
+	}
+	return resourceSecurityRuleExceptionsRead(ctx, d, m)
+}
+
+func resourceSecurityRuleExceptionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	siteID := d.Get("site_id").(int)
+	ruleID := d.Get("rule_id").(string)
+
+	log.Printf("[INFO] Reading Incapsula security rule exceptions of rule_id (%s) on site_id (%d)\n", ruleID, siteID)
+
+	siteStatusResponse, err := client.ListSecurityRuleExceptions(ctx, strconv.Itoa(siteID), ruleID)
+
+	// Site object may have been deleted
+	if siteStatusResponse != nil && fmt.Sprint(siteStatusResponse.Res) == "9413" {
+		log.Printf("[INFO] Incapsula Site with ID %d has already been deleted: %s\n", siteID, err)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Could not read Incapsula security rule exceptions of rule_id (%s) on site_id (%d): %s\n", ruleID, siteID, err)
+		return diagnosticsFromError(d, err)
+	}
+
+	exceptions := securityRuleExceptionsFromSiteStatus(siteStatusResponse, ruleID)
+	d.Set("exception", flattenSecurityRuleExceptions(exceptions))
+
+	log.Printf("[INFO] Read %d Incapsula security rule exceptions of rule_id (%s) on site_id (%d)\n", len(exceptions), ruleID, siteID)
+
+	return nil
+}
+
+func resourceSecurityRuleExceptionsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	siteID := d.Get("site_id").(int)
+	ruleID := d.Get("rule_id").(string)
+
+	err := applySecurityRuleExceptions(ctx, m.(*Client), siteID, ruleID, nil)
+	if err != nil {
+		log.Printf("[ERROR] Could not delete Incapsula security rule exceptions of rule_id (%s) on site_id (%d): %s\n", ruleID, siteID, err)
+		return diagnosticsFromError(d, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// applySecurityRuleExceptions reads the current exceptions of the rule, and adds, edits and deletes exceptions so that
+// they match the desired ones. The deletions come last, a failure midway leaves the rule with extra exceptions rather
+// than without the desired ones
+func applySecurityRuleExceptions(ctx context.Context, client *Client, siteID int, ruleID string, desired []SecurityRuleExceptionData) error {
+	siteStatusResponse, err := client.ListSecurityRuleExceptions(ctx, strconv.Itoa(siteID), ruleID)
+	if err != nil {
+		return err
+	}
+	plan := planSecurityRuleExceptions(securityRuleExceptionsFromSiteStatus(siteStatusResponse, ruleID), desired)
+
+	log.Printf("[INFO] Changing the Incapsula security rule exceptions of rule_id (%s) on site_id (%d): %d to delete, %d to edit, %d to add\n",
+		ruleID, siteID, len(plan.delete), len(plan.edit), len(plan.add))
+
+	for i := range plan.add {
+		if _, err := client.AddSecurityRuleExceptionData(ctx, siteID, ruleID, &plan.add[i]); err != nil {
+			return err
+		}
+	}
+	for i := range plan.edit {
+		if err := client.EditSecurityRuleExceptionData(ctx, siteID, ruleID, &plan.edit[i]); err != nil {
+			return err
+		}
+	}
+	for _, whitelistID := range plan.delete {
+		if err := client.DeleteSecurityRuleException(ctx, siteID, ruleID, whitelistID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// securityRuleExceptionsPlan holds the API calls turning the current exceptions of a rule into the desired ones
+type securityRuleExceptionsPlan struct {
+	add    []SecurityRuleExceptionData
+	edit   []SecurityRuleExceptionData
+	delete []string
+}
+
+// planSecurityRuleExceptions computes the fewest calls turning the current exceptions into the desired ones.
+// Current exceptions with the same values as a desired one are kept. The others are edited into the remaining
+// desired exceptions when possible, since an edit replaces the parameters it sends but leaves the others unchanged,
+// an exception can only be edited into one setting all of its parameters. The remaining exceptions are deleted
+// or added
+func planSecurityRuleExceptions(current []SecurityRuleExceptionData, desired []SecurityRuleExceptionData) securityRuleExceptionsPlan {
+	unmatched := map[string][]SecurityRuleExceptionData{}
+	for _, exception := range current {
+		key := securityRuleExceptionKey(&exception)
+		unmatched[key] = append(unmatched[key], exception)
+	}
+
+	var pending []SecurityRuleExceptionData
+	for _, exception := range desired {
+		key := securityRuleExceptionKey(&exception)
+		if len(unmatched[key]) > 0 {
+			unmatched[key] = unmatched[key][1:]
+			continue
+		}
+		pending = append(pending, exception)
+	}
+
+	var remaining []SecurityRuleExceptionData
+	for _, exceptions := range unmatched {
+		remaining = append(remaining, exceptions...)
+	}
+	sortSecurityRuleExceptions(remaining)
+	sortSecurityRuleExceptions(pending)
+
+	var plan securityRuleExceptionsPlan
+	for _, exception := range pending {
+		params := exception.params()
+		index := -1
+		for i, candidate := range remaining {
+			editable := true
+			for param := range candidate.params() {
+				if _, ok := params[param]; !ok {
+					editable = false
+					break
+				}
+			}
+			if editable {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			plan.add = append(plan.add, exception)
+			continue
+		}
+		exception.WhitelistID = remaining[index].WhitelistID
+		plan.edit = append(plan.edit, exception)
+		remaining = append(remaining[:index:index], remaining[index+1:]...)
+	}
+	for _, exception := range remaining {
+		plan.delete = append(plan.delete, exception.WhitelistID)
+	}
+	return plan
+}
+
+// securityRuleExceptionKey identifies the values of an exception, whatever their order and its whitelist ID
+func securityRuleExceptionKey(exception *SecurityRuleExceptionData) string {
+	params := exception.params()
+	var parts []string
+	for _, param := range securityRuleExceptionParams {
+		values, ok := params[param]
+		if !ok {
+			continue
+		}
+		sorted := append([]string{}, values...)
+		sort.Strings(sorted)
+		parts = append(parts, param+"="+strings.Join(sorted, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+func sortSecurityRuleExceptions(exceptions []SecurityRuleExceptionData) {
+	sort.SliceStable(exceptions, func(i, j int) bool {
+		return securityRuleExceptionKey(&exceptions[i]) < securityRuleExceptionKey(&exceptions[j])
+	})
+}
+
+func expandSecurityRuleExceptions(set *schema.Set) []SecurityRuleExceptionData {
+	var exceptions []SecurityRuleExceptionData
+	for _, item := range set.List() {
+		block := item.(map[string]interface{})
+		values := func(param string) []string {
+			var values []string
+			if set, ok := block[param].(*schema.Set); ok {
+				for _, value := range set.List() {
+					values = append(values, value.(string))
+				}
+			}
+			sort.Strings(values)
+			return values
+		}
+		exceptions = append(exceptions, SecurityRuleExceptionData{
+			ClientAppTypes: values("client_app_types"),
+			ClientApps:     values("client_apps"),
+			Countries:      values("countries"),
+			Continents:     values("continents"),
+			Ips:            values("ips"),
+			Urls:           values("urls"),
+			UserAgents:     values("user_agents"),
+			Parameters:     values("parameters"),
+		})
+	}
+	return exceptions
+}
+
+func flattenSecurityRuleExceptions(exceptions []SecurityRuleExceptionData) []interface{} {
+	blocks := make([]interface{}, 0, len(exceptions))
+	for _, exception := range exceptions {
+		block := map[string]interface{}{}
+		for param, values := range exception.params() {
+			items := make([]interface{}, len(values))
+			for i, value := range values {
+				items[i] = value
+			}
+			block[param] = items
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
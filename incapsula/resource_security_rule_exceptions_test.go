@@ -0,0 +1,203 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPlanSecurityRuleExceptions(t *testing.T) {
+	current := []SecurityRuleExceptionData{
+		{WhitelistID: "1", Ips: []string{"192.0.2.2", "192.0.2.1"}},
+		{WhitelistID: "2", Urls: []string{"/admin"}},
+		{WhitelistID: "3", Countries: []string{"US"}, Urls: []string{"/login"}},
+		{WhitelistID: "4", UserAgents: []string{"curl"}},
+	}
+	desired := []SecurityRuleExceptionData{
+		// Unchanged, whatever the order of the values
+		{Ips: []string{"192.0.2.1", "192.0.2.2"}},
+		// Edited, the URLs of exception 2 are replaced
+		{Urls: []string{"/administration"}},
+		// Added, exception 3 sets the countries, which an edit wouldn't remove
+		{Urls: []string{"/login"}},
+	}
+
+	plan := planSecurityRuleExceptions(current, desired)
+
+	expectedEdit := []SecurityRuleExceptionData{{WhitelistID: "2", Urls: []string{"/administration"}}}
+	if !reflect.DeepEqual(plan.edit, expectedEdit) {
+		t.Errorf("Unexpected edits, expected: %v, got: %v", expectedEdit, plan.edit)
+	}
+	expectedAdd := []SecurityRuleExceptionData{{Urls: []string{"/login"}}}
+	if !reflect.DeepEqual(plan.add, expectedAdd) {
+		t.Errorf("Unexpected additions, expected: %v, got: %v", expectedAdd, plan.add)
+	}
+	sort.Strings(plan.delete)
+	if !reflect.DeepEqual(plan.delete, []string{"3", "4"}) {
+		t.Errorf("Unexpected deletions, expected: [3 4], got: %v", plan.delete)
+	}
+
+	plan = planSecurityRuleExceptions(current, current)
+	if len(plan.add)+len(plan.edit)+len(plan.delete) != 0 {
+		t.Errorf("Should not have planned any change for the current exceptions, got: %+v", plan)
+	}
+}
+
+func TestValidateSecurityRuleExceptions(t *testing.T) {
+	block := func(values map[string]cty.Value) cty.Value {
+		attributes := map[string]cty.Value{}
+		for _, param := range securityRuleExceptionParams {
+			attributes[param] = cty.NullVal(cty.Set(cty.String))
+		}
+		for param, value := range values {
+			attributes[param] = value
+		}
+		return cty.ObjectVal(attributes)
+	}
+	cases := map[string]struct {
+		ruleID    string
+		exception cty.Value
+		error     string
+	}{
+		"accepted parameters": {sqlInjectionExceptionRuleID, block(map[string]cty.Value{
+			"urls":       cty.SetVal([]cty.Value{cty.StringVal("/a")}),
+			"parameters": cty.SetVal([]cty.Value{cty.StringVal("id")}),
+		}), ""},
+		"unknown parameter": {sqlInjectionExceptionRuleID, block(map[string]cty.Value{
+			"ips": cty.UnknownVal(cty.Set(cty.String)),
+		}), ""},
+		"rejected parameter": {blacklistedCountriesExceptionRuleID, block(map[string]cty.Value{
+			"countries": cty.SetVal([]cty.Value{cty.StringVal("US")}),
+		}), "countries cannot be set in the exceptions of rule api.acl.blacklisted_countries, the exceptions of this rule accept: client_app_types, ips, urls"},
+		"no parameter": {sqlInjectionExceptionRuleID, block(map[string]cty.Value{
+			"urls": cty.SetValEmpty(cty.String),
+		}), "each exception of rule api.threats.sql_injection must set at least one of: client_apps, countries, continents, ips, urls, parameters"},
+	}
+	for name, c := range cases {
+		err := validateSecurityRuleExceptions(c.ruleID, cty.SetVal([]cty.Value{c.exception}))
+		if c.error == "" && err != nil {
+			t.Errorf("%s: should not have received an error, got: %s", name, err)
+		}
+		if c.error != "" && (err == nil || err.Error() != c.error) {
+			t.Errorf("%s: expected error: %s, got: %v", name, c.error, err)
+		}
+	}
+}
+
+func TestResourceSecurityRuleExceptionsFakeAPI(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+	siteAddResponse, err := client.AddSite(ctx, "exceptions.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := siteAddResponse.SiteID
+
+	// An exception managed outside of the resource is deleted
+	if _, err := client.AddSecurityRuleExceptionData(ctx, siteID, sqlInjectionExceptionRuleID, &SecurityRuleExceptionData{Ips: []string{"198.51.100.1"}}); err != nil {
+		t.Fatalf("Should not have received an error adding the exception, got: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSecurityRuleExceptions().Schema, map[string]interface{}{
+		"site_id": siteID,
+		"rule_id": sqlInjectionExceptionRuleID,
+		"exception": []interface{}{
+			map[string]interface{}{"urls": []interface{}{"/admin", "/login"}},
+			map[string]interface{}{"ips": []interface{}{"192.0.2.1"}, "parameters": []interface{}{"q"}},
+		},
+	})
+	if diags := resourceSecurityRuleExceptionsCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error creating the exceptions, got: %v", diags)
+	}
+	if d.Id() != fmt.Sprintf("%d/%s", siteID, sqlInjectionExceptionRuleID) {
+		t.Errorf("Unexpected ID: %s", d.Id())
+	}
+
+	readExceptions := func() []SecurityRuleExceptionData {
+		siteStatusResponse, err := client.ListSecurityRuleExceptions(ctx, strconv.Itoa(siteID), sqlInjectionExceptionRuleID)
+		if err != nil {
+			t.Fatalf("Should not have received an error listing the exceptions, got: %s", err)
+		}
+		return securityRuleExceptionsFromSiteStatus(siteStatusResponse, sqlInjectionExceptionRuleID)
+	}
+	exceptions := readExceptions()
+	if len(exceptions) != 2 {
+		t.Fatalf("Should have replaced the exceptions of the rule with the configured ones, got: %+v", exceptions)
+	}
+	if d.Get("exception").(*schema.Set).Len() != 2 {
+		t.Errorf("Should have read back the exceptions, got: %v", d.Get("exception"))
+	}
+
+	if diags := resourceSecurityRuleExceptionsDelete(ctx, d, client); diags.HasError() {
+		t.Fatalf("Should not have received an error deleting the exceptions, got: %v", diags)
+	}
+	if exceptions := readExceptions(); len(exceptions) != 0 {
+		t.Errorf("Should have deleted the exceptions of the rule, got: %+v", exceptions)
+	}
+}
+
+// roundTripperFunc records the requests of a test before sending them
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestApplySecurityRuleExceptionsDeletesLast(t *testing.T) {
+	client, _ := newFakeAPIClient(t)
+	ctx := context.Background()
+	siteAddResponse, err := client.AddSite(ctx, "exceptions-order.example.com", "", "false", "", "false", 0, false, false, "")
+	if err != nil {
+		t.Fatalf("Should not have received an error adding the site, got: %s", err)
+	}
+	siteID := siteAddResponse.SiteID
+	for _, exception := range []SecurityRuleExceptionData{{Urls: []string{"/admin"}}, {Ips: []string{"192.0.2.1"}, Urls: []string{"/login"}}} {
+		if _, err := client.AddSecurityRuleExceptionData(ctx, siteID, sqlInjectionExceptionRuleID, &exception); err != nil {
+			t.Fatalf("Should not have received an error adding the exception, got: %s", err)
+		}
+	}
+
+	var calls []string
+	client.httpClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodPost && req.GetBody != nil {
+			body, _ := req.GetBody()
+			content, _ := io.ReadAll(body)
+			form, _ := url.ParseQuery(string(content))
+			switch {
+			case form.Get("rule_id") == "":
+			case form.Get("delete_whitelist") == "true":
+				calls = append(calls, "delete")
+			case form.Get("whitelist_id") != "":
+				calls = append(calls, "edit")
+			default:
+				calls = append(calls, "add")
+			}
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	// The first exception is edited, the second one can't be edited into the new one and is deleted
+	desired := []SecurityRuleExceptionData{{Urls: []string{"/administration"}}, {Countries: []string{"FR"}}}
+	if err := applySecurityRuleExceptions(ctx, client, siteID, sqlInjectionExceptionRuleID, desired); err != nil {
+		t.Fatalf("Should not have received an error applying the exceptions, got: %s", err)
+	}
+	if expected := []string{"add", "edit", "delete"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Unexpected order of the calls, expected: %v, got: %v", expected, calls)
+	}
+	siteStatusResponse, err := client.ListSecurityRuleExceptions(ctx, strconv.Itoa(siteID), sqlInjectionExceptionRuleID)
+	if err != nil {
+		t.Fatalf("Should not have received an error listing the exceptions, got: %s", err)
+	}
+	if exceptions := securityRuleExceptionsFromSiteStatus(siteStatusResponse, sqlInjectionExceptionRuleID); len(exceptions) != 2 {
+		t.Errorf("Should have replaced the exceptions of the rule with the desired ones, got: %+v", exceptions)
+	}
+}
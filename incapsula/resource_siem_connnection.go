@@ -1,12 +1,15 @@
 package incapsula
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"strings"
+	"time"
 )
 
 const StorageTypeCustomerS3 = "CUSTOMER_S3"
@@ -15,12 +18,12 @@ const sensitiveDataPlaceholder = "Sensitive data placeholder"
 
 func resourceSiemConnection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiemConnectionCreate,
-		Read:   resourceSiemConnectionRead,
-		Update: resourceSiemConnectionUpdate,
-		Delete: resourceSiemConnectionDelete,
+		CreateContext: resourceSiemConnectionCreate,
+		ReadContext:   resourceSiemConnectionRead,
+		UpdateContext: resourceSiemConnectionUpdate,
+		DeleteContext: resourceSiemConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/connection_id", d.Id())
@@ -116,6 +119,12 @@ func resourceSiemConnection() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -130,14 +139,14 @@ func siemConnectionResourceValidation(d *schema.ResourceData) error {
 	}
 	return nil
 }
-func resourceSiemConnectionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diagnosticsFromError(d, resErr)
 	}
 
 	client := m.(*Client)
-	response, statusCode, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	response, statusCode, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
 		StorageType:    d.Get("storage_type").(string),
@@ -148,22 +157,22 @@ func resourceSiemConnectionCreate(d *schema.ResourceData, m interface{}) error {
 		},
 	}}})
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	if (*statusCode == 201) && (response != nil) && (len(response.Data) == 1) {
 		d.SetId(response.Data[0].ID)
-		return resourceSiemConnectionRead(d, m)
+		return resourceSiemConnectionRead(ctx, d, m)
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemConnectionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiemConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	response, statusCode, err := client.ReadSiemConnection(d.Id(), d.Get("account_id").(string))
+	response, statusCode, err := client.ReadSiemConnection(ctx, d.Id(), d.Get("account_id").(string))
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	// If the connection is deleted on the server, blow it out locally and run through the normal TF cycle
 	if *statusCode == 404 {
@@ -182,18 +191,18 @@ func resourceSiemConnectionRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("path", connection.ConnectionInfo.(S3ConnectionInfo).Path)
 		return nil
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemConnectionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diagnosticsFromError(d, resErr)
 	}
 
 	client := m.(*Client)
-	_, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	_, _, err := client.UpdateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		ID:             d.Id(),
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
@@ -206,20 +215,20 @@ func resourceSiemConnectionUpdate(d *schema.ResourceData, m interface{}) error {
 	}}})
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	return nil
 }
 
-func resourceSiemConnectionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiemConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	ID := d.Id()
 	accountId := d.Get("account_id").(string)
 
-	_, err := client.DeleteSiemConnection(ID, accountId)
+	_, err := client.DeleteSiemConnection(ctx, ID, accountId)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -1,23 +1,26 @@
 package incapsula
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"strings"
+	"time"
 )
 
 const StorageTypeCustomerSftp = "CUSTOMER_SFTP"
 
 func resourceSiemSftpConnection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiemSftpConnectionCreate,
-		Read:   resourceSiemSftpConnectionRead,
-		Update: resourceSiemSftpConnectionUpdate,
-		Delete: resourceSiemSftpConnectionDelete,
+		CreateContext: resourceSiemSftpConnectionCreate,
+		ReadContext:   resourceSiemSftpConnectionRead,
+		UpdateContext: resourceSiemSftpConnectionUpdate,
+		DeleteContext: resourceSiemSftpConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/connection_id", d.Id())
@@ -96,6 +99,12 @@ func resourceSiemSftpConnection() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -109,14 +118,14 @@ func siemSftpConnectionResourceValidation(d *schema.ResourceData) error {
 	}
 	return nil
 }
-func resourceSiemSftpConnectionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSftpConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemSftpConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diagnosticsFromError(d, resErr)
 	}
 
 	client := m.(*Client)
-	response, statusCode, err := client.CreateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	response, statusCode, err := client.CreateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
 		StorageType:    StorageTypeCustomerSftp,
@@ -128,22 +137,22 @@ func resourceSiemSftpConnectionCreate(d *schema.ResourceData, m interface{}) err
 		},
 	}}})
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	if (*statusCode == 201) && (response != nil) && (len(response.Data) == 1) {
 		d.SetId(response.Data[0].ID)
-		return resourceSiemSftpConnectionRead(d, m)
+		return resourceSiemSftpConnectionRead(ctx, d, m)
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemSftpConnectionRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSftpConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	response, statusCode, err := client.ReadSiemConnection(d.Id(), d.Get("account_id").(string))
+	response, statusCode, err := client.ReadSiemConnection(ctx, d.Id(), d.Get("account_id").(string))
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	// If the connection is deleted on the server, blow it out locally and run through the normal TF cycle
 	if *statusCode == 404 {
@@ -160,18 +169,18 @@ func resourceSiemSftpConnectionRead(d *schema.ResourceData, m interface{}) error
 		d.Set("input_hash", connectionInfo.Password)
 		return nil
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemSftpConnectionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSftpConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := siemSftpConnectionResourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diagnosticsFromError(d, resErr)
 	}
 
 	client := m.(*Client)
-	_, _, err := client.UpdateSiemConnection(&SiemConnection{Data: []SiemConnectionData{{
+	_, _, err := client.UpdateSiemConnection(ctx, &SiemConnection{Data: []SiemConnectionData{{
 		ID:             d.Id(),
 		AssetID:        d.Get("account_id").(string),
 		ConnectionName: d.Get("connection_name").(string),
@@ -185,20 +194,20 @@ func resourceSiemSftpConnectionUpdate(d *schema.ResourceData, m interface{}) err
 	}}})
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	return nil
 }
 
-func resourceSiemSftpConnectionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiemSftpConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	ID := d.Id()
 	accountId := d.Get("account_id").(string)
 
-	_, err := client.DeleteSiemConnection(ID, accountId)
+	_, err := client.DeleteSiemConnection(ctx, ID, accountId)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -127,7 +128,7 @@ func getAccIncapsulaSftpSiemConnectionConfig(sftpSiemConnectionName string) stri
 
 func testAccReadSiemSftpConnection(client *Client, ID string, accountId string) error {
 	log.Printf("[INFO] SiemConnection ID: %s, accountId: %s", ID, accountId)
-	siemConnection, statusCode, err := client.ReadSiemConnection(ID, accountId)
+	siemConnection, statusCode, err := client.ReadSiemConnection(context.Background(), ID, accountId)
 	if err != nil {
 		return err
 	}
@@ -1,9 +1,12 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -38,12 +41,12 @@ var AttackAnalyticsDatasets = []string{"WAF_ANALYTICS_LOGS"}
 
 func resourceSiemLogConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiemLogConfigurationCreate,
-		Read:   resourceSiemLogConfigurationRead,
-		Update: resourceSiemLogConfigurationUpdate,
-		Delete: resourceSiemLogConfigurationDelete,
+		CreateContext: resourceSiemLogConfigurationCreate,
+		ReadContext:   resourceSiemLogConfigurationRead,
+		UpdateContext: resourceSiemLogConfigurationUpdate,
+		DeleteContext: resourceSiemLogConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/logConfiguration_id", d.Id())
@@ -127,6 +130,12 @@ func resourceSiemLogConfiguration() *schema.Resource {
 				Required:    false,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -167,14 +176,14 @@ func resourceValidation(d *schema.ResourceData) error {
 	return nil
 }
 
-func resourceSiemLogConfigurationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemLogConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := resourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diagnosticsFromError(d, resErr)
 	}
 
 	client := m.(*Client)
-	response, statusCode, err := client.CreateSiemLogConfiguration(&SiemLogConfiguration{Data: []SiemLogConfigurationData{{
+	response, statusCode, err := client.CreateSiemLogConfiguration(ctx, &SiemLogConfiguration{Data: []SiemLogConfigurationData{{
 		AssetID:           d.Get("account_id").(string),
 		ConfigurationName: d.Get("configuration_name").(string),
 		Provider:          d.Get("producer").(string),
@@ -188,22 +197,22 @@ func resourceSiemLogConfigurationCreate(d *schema.ResourceData, m interface{}) e
 		PublicKeyFileNAme: d.Get("public_key_file_name").(string),
 	}}})
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	if (*statusCode == 201) && (response != nil) && (len(response.Data) == 1) {
 		d.SetId(response.Data[0].ID)
-		return resourceSiemLogConfigurationRead(d, m)
+		return resourceSiemLogConfigurationRead(ctx, d, m)
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemLogConfigurationRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiemLogConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
-	reponse, statusCode, err := client.ReadSiemLogConfiguration(d.Id(), d.Get("account_id").(string))
+	reponse, statusCode, err := client.ReadSiemLogConfiguration(ctx, d.Id(), d.Get("account_id").(string))
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	// If the connection is deleted on the server, blow it out locally and run through the normal TF cycle
 	if *statusCode == 404 {
@@ -224,18 +233,18 @@ func resourceSiemLogConfigurationRead(d *schema.ResourceData, m interface{}) err
 		d.Set("public_key_file_name", logConfiguration.PublicKeyFileNAme)
 		return nil
 	} else {
-		return fmt.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
+		return diag.Errorf("[ERROR] Unsupported operation. Response status code: %d", *statusCode)
 	}
 }
 
-func resourceSiemLogConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiemLogConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	resErr := resourceValidation(d)
 	if resErr != nil {
-		return resErr
+		return diagnosticsFromError(d, resErr)
 	}
 
 	client := m.(*Client)
-	_, _, err := client.UpdateSiemLogConfiguration(&SiemLogConfiguration{Data: []SiemLogConfigurationData{{
+	_, _, err := client.UpdateSiemLogConfiguration(ctx, &SiemLogConfiguration{Data: []SiemLogConfigurationData{{
 		ID:                d.Id(),
 		AssetID:           d.Get("account_id").(string),
 		ConfigurationName: d.Get("configuration_name").(string),
@@ -251,20 +260,20 @@ func resourceSiemLogConfigurationUpdate(d *schema.ResourceData, m interface{}) e
 	}}})
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	return nil
 }
 
-func resourceSiemLogConfigurationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiemLogConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	ID := d.Id()
 	accountId := d.Get("account_id").(string)
-	_, err := client.DeleteSiemLogConfiguration(ID, accountId)
+	_, err := client.DeleteSiemLogConfiguration(ctx, ID, accountId)
 
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	// Set the ID to empty
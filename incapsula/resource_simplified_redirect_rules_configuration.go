@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,7 +20,7 @@ func resourceSimplifiedRedirectRulesConfiguration() *schema.Resource {
 		UpdateContext: resourceSimplifiedRedirectRulesConfigurationUpdate,
 		DeleteContext: resourceSimplifiedRedirectRulesConfigurationDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(data.Id(), "/")
 				if len(idSlice) != 1 || idSlice[0] == "" {
 					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id", data.Id())
@@ -82,6 +83,12 @@ func resourceSimplifiedRedirectRulesConfiguration() *schema.Resource {
 				},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -93,7 +100,7 @@ func resourceSimplifiedRedirectRulesConfigurationUpdate(ctx context.Context, dat
 		RulesList: createSimplifiedRedirectRulesListFromState(data),
 	}
 
-	simplifiedRediectRulesListDTO, diags := client.UpdateDeliveryRuleConfiguration(siteID, "SIMPLIFIED_REDIRECT", &rulesListDTO)
+	simplifiedRediectRulesListDTO, diags := client.UpdateDeliveryRuleConfiguration(ctx, siteID, "SIMPLIFIED_REDIRECT", &rulesListDTO)
 
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to update delivery rules of category SIMPLIFIED_REDIRECT for Site ID %s", siteID)
@@ -117,7 +124,7 @@ func resourceSimplifiedRedirectRulesConfigurationRead(ctx context.Context, data
 	client := m.(*Client)
 	siteID := data.Get("site_id").(string)
 
-	simplifiedRediectRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, "SIMPLIFIED_REDIRECT")
+	simplifiedRediectRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(ctx, siteID, "SIMPLIFIED_REDIRECT")
 
 	fmt.Println(simplifiedRediectRulesListDTO)
 
@@ -162,7 +169,7 @@ func resourceSimplifiedRedirectRulesConfigurationDelete(ctx context.Context, dat
 		RulesList: []DeliveryRuleDto{},
 	}
 
-	_, diags = client.UpdateDeliveryRuleConfiguration(siteID, "SIMPLIFIED_REDIRECT", &emptyRulesList)
+	_, diags = client.UpdateDeliveryRuleConfiguration(ctx, siteID, "SIMPLIFIED_REDIRECT", &emptyRulesList)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to delete delivery rules in category SIMPLIFIED_REDIRECT for Site ID %s", siteID)
 		return diags
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -113,7 +114,7 @@ func testAccCheckIncapsulaDeliverySimplifiedRedirectRuleDestroy(state *terraform
 			return fmt.Errorf("Incapsula Site ID does not exist")
 		}
 
-		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, category)
+		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, category)
 
 		// If the site has already been deleted then return nil
 		// Otherwise check the delivery rules list
@@ -121,7 +122,7 @@ func testAccCheckIncapsulaDeliverySimplifiedRedirectRuleDestroy(state *terraform
 		if err != nil {
 			return fmt.Errorf("Couldn't parse site ID: %s ", siteID)
 		}
-		_, siteStatusErr := client.SiteStatus(domain, siteIdInt)
+		_, siteStatusErr := client.SiteStatus(context.Background(), domain, siteIdInt)
 		if siteStatusErr != nil {
 			return nil
 		}
@@ -235,7 +236,7 @@ func testCheckSimplifiedRedirectRuleExists(name string, numRules int) resource.T
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(siteID, "SIMPLIFIED_REDIRECT")
+		deliveryRulesListDTO, diags := client.ReadDeliveryRuleConfiguration(context.Background(), siteID, "SIMPLIFIED_REDIRECT")
 
 		if !ok {
 			return fmt.Errorf("Rule category : %s ,does not exist for Site ID is : SIMPLIFIED_REDIRECT ", siteID)
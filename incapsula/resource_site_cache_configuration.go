@@ -1,22 +1,25 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSiteCacheConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceApplicationPerformanceUpdate,
-		Read:   resourceApplicationPerformanceRead,
-		Update: resourceApplicationPerformanceUpdate,
-		Delete: resourceApplicationPerformanceDelete,
+		CreateContext: resourceApplicationPerformanceUpdate,
+		ReadContext:   resourceApplicationPerformanceRead,
+		UpdateContext: resourceApplicationPerformanceUpdate,
+		DeleteContext: resourceApplicationPerformanceDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				siteID, err := strconv.Atoi(d.Id())
 				if err != nil {
 					return nil, fmt.Errorf("failed to convert Site Id from import command for Site Cache Configuration resource, actual value: %s, expected numeric id", d.Id())
@@ -169,10 +172,16 @@ func resourceSiteCacheConfiguration() *schema.Resource {
 				Default:     false,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceApplicationPerformanceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceApplicationPerformanceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	siteIdStr := strconv.Itoa(siteID)
@@ -200,23 +209,23 @@ func resourceApplicationPerformanceUpdate(d *schema.ResourceData, m interface{})
 	performanceSettings.TTL.PreferLastModified = d.Get("ttl_prefer_last_modified").(bool)
 	performanceSettings.TTL.UseShortestCaching = d.Get("ttl_use_shortest_caching").(bool)
 
-	_, err := client.UpdatePerformanceSettings(siteIdStr, &performanceSettings)
+	_, err := client.UpdatePerformanceSettings(ctx, siteIdStr, &performanceSettings)
 	if err != nil {
 		log.Printf("[ERROR] Could not update Incapsula performance settings for site_id: %s %s\n", d.Id(), err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
-	return resourceApplicationPerformanceRead(d, m)
+	return resourceApplicationPerformanceRead(ctx, d, m)
 }
 
-func resourceApplicationPerformanceRead(d *schema.ResourceData, m interface{}) error {
+func resourceApplicationPerformanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	siteIdStr := strconv.Itoa(siteID)
 
-	performanceSettingsResponse, err := client.GetPerformanceSettings(siteIdStr)
+	performanceSettingsResponse, err := client.GetPerformanceSettings(ctx, siteIdStr)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula site peformance settings for site id: %d, %s\n", siteID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.Set("client_comply_no_cache", performanceSettingsResponse.ClientSide.ComplyNoCache)
@@ -246,7 +255,7 @@ func resourceApplicationPerformanceRead(d *schema.ResourceData, m interface{}) e
 	return nil
 }
 
-func resourceApplicationPerformanceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceApplicationPerformanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 	return nil
 }
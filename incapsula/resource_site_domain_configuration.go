@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
@@ -13,12 +14,12 @@ import (
 
 func resourceSiteDomainConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Read:   resourceDomainRead,
-		Create: resourceDomainsCreate,
-		Delete: resourceDomainDelete,
-		Update: resourceDomainsUpdate,
+		ReadContext:   resourceDomainRead,
+		CreateContext: resourceDomainsCreate,
+		DeleteContext: resourceDomainDelete,
+		UpdateContext: resourceDomainsUpdate,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.Set("site_id", d.Id())
 				d.SetId(d.Id())
 				return []*schema.ResourceData{d}, nil
@@ -93,8 +94,10 @@ func resourceSiteDomainConfiguration() *schema.Resource {
 		},
 
 		Timeouts: &schema.ResourceTimeout{
-			Update: schema.DefaultTimeout(3 * time.Minute),
+			Create: schema.DefaultTimeout(3 * time.Minute),
 			Read:   schema.DefaultTimeout(3 * time.Minute),
+			Update: schema.DefaultTimeout(3 * time.Minute),
+			Delete: schema.DefaultTimeout(3 * time.Minute),
 		},
 	}
 }
@@ -124,36 +127,36 @@ func populateFromResourceToDTO(d *schema.ResourceData) []SiteDomainDetails {
 	return siteDomainDetails
 }
 
-func resourceDomainsCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDomainsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("deprecated").(bool) {
-		return fmt.Errorf("cannot create deprecated resource")
+		return diag.Errorf("cannot create deprecated resource")
 	}
-	return resourceDomainsUpdate(d, m)
+	return resourceDomainsUpdate(ctx, d, m)
 }
 
-func resourceDomainsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDomainsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("deprecated").(bool) {
 		return nil
 	}
 	client := m.(*Client)
 	siteID := d.Get("site_id").(string)
 	siteDomainDetails := populateFromResourceToDTO(d)
-	err := client.BulkUpdateDomainsToSite(siteID, siteDomainDetails)
+	err := client.BulkUpdateDomainsToSite(ctx, siteID, siteDomainDetails)
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
-	return resourceDomainRead(d, m)
+	return resourceDomainRead(ctx, d, m)
 }
 
-func resourceDomainRead(d *schema.ResourceData, m interface{}) error {
+func resourceDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("deprecated").(bool) {
 		fmt.Printf("[WARN] Resource incapsula_site_domain_configuration is deprecated. Any future changes will be ignored.\n")
 		return nil
 	}
 	client := m.(*Client)
-	siteDomainDetailsDto, err := client.GetWebsiteDomains(d.Get("site_id").(string))
+	siteDomainDetailsDto, err := client.GetWebsiteDomains(ctx, d.Get("site_id").(string))
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	if siteDomainDetailsDto.Errors != nil && len(siteDomainDetailsDto.Errors) > 0 {
@@ -165,9 +168,9 @@ func resourceDomainRead(d *schema.ResourceData, m interface{}) error {
 
 		out, err := json.Marshal(siteDomainDetailsDto.Errors)
 		if err != nil {
-			return err
+			return diagnosticsFromError(d, err)
 		}
-		return fmt.Errorf("error getting domains for site (%s): %s", d.Get("site_id"), string(out))
+		return diag.Errorf("error getting domains for site (%s): %s", d.Get("site_id"), string(out))
 	}
 
 	domains := &schema.Set{F: getHashFromDomain}
@@ -195,17 +198,17 @@ func resourceDomainRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceDomainDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	if d.Get("deprecated").(bool) {
 		d.SetId("")
 		return nil
 	}
 	client := m.(*Client)
 	siteID := d.Get("site_id").(string)
-	err := client.BulkUpdateDomainsToSite(siteID, []SiteDomainDetails{})
+	err := client.BulkUpdateDomainsToSite(ctx, siteID, []SiteDomainDetails{})
 	if err != nil {
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
-	return resourceDomainRead(d, m)
+	return resourceDomainRead(ctx, d, m)
 }
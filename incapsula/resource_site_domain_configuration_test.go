@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -53,7 +54,7 @@ func testCheckIncapsulaSiteDomainConfExists(fullSiteDomainResourceName string) r
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		siteDomainDetailsDto, _ := client.GetWebsiteDomains(siteId)
+		siteDomainDetailsDto, _ := client.GetWebsiteDomains(context.Background(), siteId)
 		if siteDomainDetailsDto == nil {
 			return fmt.Errorf("incapsula site domain configuration: get domains for siteId %s response returned null", siteId)
 		}
@@ -199,7 +200,7 @@ func testCheckIncapsulaSiteDomainConfNotDestroy(state *terraform.State) error {
 		}
 
 		domain := res.Primary.Attributes["domain"]
-		siteStatusResponse, err := client.SiteStatus(domain, siteID)
+		siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID)
 
 		if err != nil {
 			return fmt.Errorf("Site status error for %s: %s", siteIDStr, err)
@@ -209,7 +210,7 @@ func testCheckIncapsulaSiteDomainConfNotDestroy(state *terraform.State) error {
 			response, _ := json.Marshal(siteStatusResponse)
 			return fmt.Errorf("Incapsula site status for domain: %s (site id: %d) was not retreived. response: %s", domain, siteID, response)
 		}
-		err = client.DeleteSite(domain, siteID) // clean the env after checking
+		err = client.DeleteSite(context.Background(), domain, siteID) // clean the env after checking
 
 		if err != nil {
 			return fmt.Errorf("Error deleting site (%d) for domain %s: %s", siteID, domain, err)
@@ -1,12 +1,15 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const defaultFailedRequestsMinNumber = 3
@@ -31,12 +34,12 @@ const defaultRequiredMonitors = "MOST"
 
 func resourceSiteMonitoring() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSiteMonitoringUpdate,
-		Read:   resourceSiteMonitoringRead,
-		Update: resourceSiteMonitoringUpdate,
-		Delete: resourceSiteMonitoringDelete,
+		CreateContext: resourceSiteMonitoringUpdate,
+		ReadContext:   resourceSiteMonitoringRead,
+		UpdateContext: resourceSiteMonitoringUpdate,
+		DeleteContext: resourceSiteMonitoringDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				siteID, err := strconv.Atoi(d.Id())
 				if err != nil {
 					fmt.Errorf("failed to convert Site Id from import command, actual value: %s, expected numeric id", d.Id())
@@ -169,10 +172,16 @@ func resourceSiteMonitoring() *schema.Resource {
 			//todo add description  MOST - More than 50%, ask backend for descr
 
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceSiteMonitoringUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMonitoringUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	siteIDStr := strconv.Itoa(siteID)
@@ -205,16 +214,16 @@ func resourceSiteMonitoringUpdate(d *schema.ResourceData, m interface{}) error {
 		},
 	}
 
-	siteMonitoringResponse, err := client.UpdateSiteMonitoring(siteID, &siteMonitoring)
+	siteMonitoringResponse, err := client.UpdateSiteMonitoring(ctx, siteID, &siteMonitoring)
 	if strings.Contains(fmt.Sprint(err), "Missing Load Balancing subscription for Site ID") {
 		log.Printf("[ERROR] Could not get Incapsula Site Monitoring for Site Id: %d - %s\n. Missing Load Balancing subscription for Site ID. The resource will be removed.", siteID, err)
 		d.SetId("")
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula Site Monitoring for Site Id: %d - %s\n", siteID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	siteMonitoringResult := siteMonitoringResponse.Data[0]
@@ -244,20 +253,20 @@ func resourceSiteMonitoringUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSiteMonitoringRead(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMonitoringRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	siteID := d.Get("site_id").(int)
 	siteIdStr := strconv.Itoa(siteID)
 
-	siteMonitoringResponse, err := client.GetSiteMonitoring(siteID)
+	siteMonitoringResponse, err := client.GetSiteMonitoring(ctx, siteID)
 	if strings.Contains(fmt.Sprint(err), "Missing Load Balancing subscription for Site ID") {
 		log.Printf("[ERROR] Could not get Incapsula Site Monitoring for Site Id: %d - %s\n. Missing Load Balancing subscription for Site ID. The resource will be removed.", siteID, err)
 		d.SetId("")
-		return err
+		return diagnosticsFromError(d, err)
 	}
 	if err != nil {
 		log.Printf("[ERROR] Could not get Incapsula Site Monitoring for Site Id: %d - %s\n", siteID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId(siteIdStr)
@@ -288,7 +297,7 @@ func resourceSiteMonitoringRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceSiteMonitoringDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSiteMonitoringDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId("")
 	return nil
 }
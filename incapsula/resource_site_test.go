@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -9,11 +10,9 @@ import (
 	"strconv"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"math/rand"
 )
 
 const siteResourceName = "incapsula_site.testacc-terraform-site"
@@ -21,15 +20,14 @@ const siteResourceName = "incapsula_site.testacc-terraform-site"
 var generatedDomain string
 
 func GenerateTestDomain(t *testing.T) string {
+	setUpAccTestEnvironment()
 	if v := os.Getenv("INCAPSULA_API_ID"); v == "" && t != nil {
 		t.Fatal("INCAPSULA_API_ID must be set for acceptance tests")
 	}
 	if v := os.Getenv("INCAPSULA_CUSTOM_TEST_DOMAIN"); v == "" && t != nil {
 		t.Fatal("INCAPSULA_CUSTOM_TEST_DOMAIN must be set for acceptance tests which require onboarding a domain")
 	}
-	s3 := rand.NewSource(time.Now().UnixNano())
-	r3 := rand.New(s3)
-	initialDomain := "id" + os.Getenv("INCAPSULA_API_ID") + strconv.Itoa(r3.Intn(1000)) + os.Getenv("INCAPSULA_CUSTOM_TEST_DOMAIN")
+	initialDomain := "id" + os.Getenv("INCAPSULA_API_ID") + strconv.Itoa(testNameSuffix(t)) + os.Getenv("INCAPSULA_CUSTOM_TEST_DOMAIN")
 	generatedDomain = strings.ReplaceAll(initialDomain, " ", "")
 	log.Printf("[DEBUG] Generated domain: %s", generatedDomain)
 	return generatedDomain
@@ -245,10 +243,10 @@ func testAccCheckIncapsulaSiteDestroy(state *terraform.State) error {
 			return fmt.Errorf("Site ID conversion error for %s: %s", siteIDStr, err)
 		}
 
-		_, err = client.SiteStatus(generatedDomain, siteID)
+		_, err = client.SiteStatus(context.Background(), generatedDomain, siteID)
 
 		if err == nil {
-			return fmt.Errorf("Incapsula site for domain: %s (site id: %d) still exists", GenerateTestDomain(nil), siteID)
+			return fmt.Errorf("Incapsula site for domain: %s (site id: %d) still exists", generatedDomain, siteID)
 		}
 	}
 
@@ -283,7 +281,7 @@ func testCheckIncapsulaSiteExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		siteStatusResponse, err := client.SiteStatus(GenerateTestDomain(nil), siteID)
+		siteStatusResponse, err := client.SiteStatus(context.Background(), generatedDomain, siteID)
 		if siteStatusResponse == nil {
 			return fmt.Errorf("Incapsula site for domain: %s (site id: %d) does not exist", GenerateTestDomain(nil), siteID)
 		}
@@ -393,7 +391,7 @@ func testCheckIncapsulaSiteNotDestroy(state *terraform.State) error {
 		}
 
 		domain := res.Primary.Attributes["domain"]
-		siteStatusResponse, err := client.SiteStatus(domain, siteID)
+		siteStatusResponse, err := client.SiteStatus(context.Background(), domain, siteID)
 
 		if err != nil {
 			return fmt.Errorf("Site status error for %s: %s", siteIDStr, err)
@@ -403,7 +401,7 @@ func testCheckIncapsulaSiteNotDestroy(state *terraform.State) error {
 			response, _ := json.Marshal(siteStatusResponse)
 			return fmt.Errorf("Incapsula site status for domain: %s (site id: %d) was not retreived. response: %s", domain, siteID, response)
 		}
-		err = client.DeleteSite(domain, siteID) // clean the env after checking
+		err = client.DeleteSite(context.Background(), domain, siteID) // clean the env after checking
 
 		if err != nil {
 			return fmt.Errorf("Error deleting site (%d) for domain %s: %s", siteID, domain, err)
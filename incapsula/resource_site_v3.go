@@ -8,6 +8,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func resourceSiteV3() *schema.Resource {
@@ -17,7 +18,7 @@ func resourceSiteV3() *schema.Resource {
 		UpdateContext: resourceSiteV3Update,
 		DeleteContext: resourceSiteV3Delete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 				idSlice := strings.Split(d.Id(), "/")
 				log.Printf("[DEBUG] Starting to import site v3. Parameters: %s\n", d.Id())
@@ -82,6 +83,12 @@ func resourceSiteV3() *schema.Resource {
 				Default:     true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -98,7 +105,7 @@ func resourceSiteV3Add(ctx context.Context, d *schema.ResourceData, m interface{
 		siteV3Request.RefId = d.Get("ref_id").(string)
 	}
 	siteV3Request.Active = d.Get("active").(bool)
-	siteV3Response, diags := client.AddV3Site(&siteV3Request, accountID)
+	siteV3Response, diags := client.AddV3Site(ctx, &siteV3Request, accountID)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed to add v3 site to Account ID: %s, %v\n", accountID, diags)
 		return diags
@@ -113,7 +120,7 @@ func resourceSiteV3Add(ctx context.Context, d *schema.ResourceData, m interface{
 	err := d.Set("account_id", strconv.Itoa(siteV3Response.Data[0].AccountId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account after add v3 site to Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	siteId := siteV3Response.Data[0].Id
 	d.SetId(strconv.Itoa(siteId))
@@ -136,7 +143,7 @@ func resourceSiteV3Update(ctx context.Context, d *schema.ResourceData, m interfa
 		siteV3Request.RefId = d.Get("ref_id").(string)
 	}
 	siteV3Request.Active = d.Get("active").(bool)
-	siteV3Response, diags := client.UpdateV3Site(&siteV3Request, accountID)
+	siteV3Response, diags := client.UpdateV3Site(ctx, &siteV3Request, accountID)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed to update v3 site to Account ID: %s, %v\n", accountID, diags)
 		return diags
@@ -152,7 +159,7 @@ func resourceSiteV3Update(ctx context.Context, d *schema.ResourceData, m interfa
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account after update v3 site to Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	siteId := siteV3Response.Data[0].Id
 	d.SetId(strconv.Itoa(siteId))
@@ -172,7 +179,7 @@ func resourceSiteV3Read(ctx context.Context, d *schema.ResourceData, m interface
 	siteV3Request.Name = d.Get("name").(string)
 	siteV3Request.AccountId, _ = strconv.Atoi(accountID)
 	siteV3Request.Id, _ = strconv.Atoi(d.Id())
-	siteV3Response, diags := client.GetV3Site(&siteV3Request, accountID)
+	siteV3Response, diags := client.GetV3Site(ctx, &siteV3Request, accountID)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed to get v3 site of Account ID: %s, %v\n", accountID, diags)
 		return diags
@@ -187,44 +194,44 @@ func resourceSiteV3Read(ctx context.Context, d *schema.ResourceData, m interface
 	err := d.Set("account_id", strconv.Itoa(siteV3Response.Data[0].AccountId))
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account after get v3 site of Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	d.SetId(strconv.Itoa(siteV3Response.Data[0].Id))
 
 	err = d.Set("name", siteV3Response.Data[0].Name)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula name after get v3 site of Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 
 	err = d.Set("creation_time", siteV3Response.Data[0].CreationTime)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula creation time after get v3 site of Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 
 	err = d.Set("cname", siteV3Response.Data[0].Cname)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula cname after get v3 site of Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 
 	err = d.Set("type", siteV3Response.Data[0].SiteType)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula type after get v3 site of Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 
 	err = d.Set("ref_id", siteV3Response.Data[0].RefId)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula ref id after get v3 site of Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 
 	err = d.Set("active", siteV3Response.Data[0].Active)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula active mode after get v3 site of Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 	d.SetId(strconv.Itoa(siteV3Response.Data[0].Id))
 	return diags
@@ -241,7 +248,7 @@ func resourceSiteV3Delete(ctx context.Context, d *schema.ResourceData, m interfa
 	siteV3Request.Name = d.Get("name").(string)
 	siteV3Request.AccountId, _ = strconv.Atoi(accountID)
 	siteV3Request.Id, _ = strconv.Atoi(d.Id())
-	siteV3Response, diags := client.DeleteV3Site(&siteV3Request, accountID)
+	siteV3Response, diags := client.DeleteV3Site(ctx, &siteV3Request, accountID)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] failed to delete v3 site of Account ID: %s, %v\n", accountID, diags)
 		return diags
@@ -256,7 +263,7 @@ func resourceSiteV3Delete(ctx context.Context, d *schema.ResourceData, m interfa
 	err := d.Set("account_id", accountID)
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula account after delete v3 site of Account ID: %s, %s\n", accountID, err)
-		return diag.FromErr(err)
+		return diagnosticsFromError(d, err)
 	}
 
 	siteId := strconv.Itoa(siteV3Response.Data[0].Id)
@@ -1,15 +1,14 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
 	"testing"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"math/rand"
 )
 
 const siteV3ResourceName = "incapsula_site_v3.test-terraform-site-v3"
@@ -21,9 +20,7 @@ func GenerateTestSiteName(t *testing.T) string {
 		t.Fatal("INCAPSULA_API_ID must be set for acceptance tests")
 	}
 
-	s3 := rand.NewSource(time.Now().UnixNano())
-	r3 := rand.New(s3)
-	siteName = "id" + os.Getenv("INCAPSULA_API_ID") + strconv.Itoa(r3.Intn(1000))
+	siteName = "id" + os.Getenv("INCAPSULA_API_ID") + strconv.Itoa(testNameSuffix(t))
 	return siteName
 }
 
@@ -118,7 +115,7 @@ func testCheckIncapsulaSiteV3Destroy(state *terraform.State) error {
 		siteV3Request := SiteV3Request{}
 		siteV3Request.Name = siteName
 
-		_, diags := client.GetV3Site(&siteV3Request, "123")
+		_, diags := client.GetV3Site(context.Background(), &siteV3Request, "123")
 
 		if diags == nil {
 			return fmt.Errorf("incapsula site for domain: %s (site id: %d) still exists", siteName, siteID)
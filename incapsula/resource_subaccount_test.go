@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -116,7 +117,7 @@ func testAccIncapsulaSubAccountDestroy(state *terraform.State) error {
 		subAccountID, _ := strconv.Atoi(subAccountIDStr)
 
 		found := true
-		subAccount, err := client.AccountStatus(subAccountID, ReadSubAccount)
+		subAccount, err := client.AccountStatus(context.Background(), subAccountID, ReadSubAccount)
 		if err != nil {
 			found = false
 		} else if subAccount != nil && subAccount.AccountID == subAccountID {
@@ -130,7 +131,7 @@ func testAccIncapsulaSubAccountDestroy(state *terraform.State) error {
 	}
 
 	//clear subAccount defualt waf policy
-	policies, err := client.GetAllPoliciesForAccount(strconv.Itoa(client.accountStatus.AccountID))
+	policies, err := client.GetAllPoliciesForAccount(context.Background(), strconv.Itoa(client.accountStatus.AccountID))
 	if err != nil {
 		return fmt.Errorf("Received an error : %s", err.Error())
 	}
@@ -138,7 +139,7 @@ func testAccIncapsulaSubAccountDestroy(state *terraform.State) error {
 	for i := range *policies {
 		policy := (*policies)[i]
 		if policy.PolicyType == "WAF_RULES" && policy.Name == defaultPolicyName {
-			client.DeletePolicy(strconv.Itoa(policy.ID), nil)
+			client.DeletePolicy(context.Background(), strconv.Itoa(policy.ID), nil)
 		}
 
 	}
@@ -182,7 +183,7 @@ func testCheckIncapsulaSubAccountExists() resource.TestCheckFunc {
 
 		client := testAccProvider.Meta().(*Client)
 		log.Printf("[INFO] **** subAccountID: %d", subAccountID)
-		subAccount, err := client.AccountStatus(subAccountID, ReadSubAccount)
+		subAccount, err := client.AccountStatus(context.Background(), subAccountID, ReadSubAccount)
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -58,7 +59,7 @@ func testACCStateTXTRecordDestroy(s *terraform.State) error {
 		if err != nil {
 			fmt.Errorf("failed to convert Site Id from import command, actual value : %s, expected numeric id", siteID)
 		}
-		recordResponse, err := client.ReadTXTRecords(siteIDInt)
+		recordResponse, err := client.ReadTXTRecords(context.Background(), siteIDInt)
 		if err == nil || !strings.Contains(recordResponse.ResMessage, "no TXT records") {
 			return fmt.Errorf("Resource %s for Incapsula TXT Record : site ID %d still exists", txtRecordResourceName, siteIDInt)
 		}
@@ -92,7 +93,7 @@ func testCheckTxtRecordExists(name string) resource.TestCheckFunc {
 		}
 
 		client := testAccProvider.Meta().(*Client)
-		recordResponse, err := client.ReadTXTRecords(siteId)
+		recordResponse, err := client.ReadTXTRecords(context.Background(), siteId)
 		if err != nil || strings.Contains(recordResponse.ResMessage, "no TXT records") {
 			fmt.Errorf("Incapsula TXT Record doesn't exist")
 		}
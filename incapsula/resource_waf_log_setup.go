@@ -1,18 +1,39 @@
 package incapsula
 
 import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
 	"strconv"
+	"time"
 )
 
 func resourceWAFLogSetup() *schema.Resource {
 	return &schema.Resource{
 		DeprecationMessage: "This resource is deprecated. It will be removed in a future version. Please use resource incapsula_siem_log_configuration instead.",
-		Create:             resourceWAFLogSetupCreate,
-		Read:               resourceWAFLogSetupRead,
-		Update:             resourceWAFLogSetupCreate,
-		Delete:             resourceWAFLogSetupDelete,
+		CreateContext:      resourceWAFLogSetupCreate,
+		ReadContext:        resourceWAFLogSetupRead,
+		UpdateContext:      resourceWAFLogSetupCreate,
+		DeleteContext:      resourceWAFLogSetupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				accountID, err := strconv.Atoi(d.Id())
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert account ID from import command, actual value: %s, expected numeric id", d.Id())
+				}
+
+				// Unlike the refresh, the import fails when the storage settings can't be read
+				if _, err := m.(*Client).ReadWAFLogSetup(ctx, accountID); err != nil {
+					return nil, err
+				}
+
+				d.Set("account_id", accountID)
+				log.Printf("[DEBUG] To Import WAF Log Setup for account ID: %d", accountID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
 
 		Schema: map[string]*schema.Schema{
 			// Required Arguments
@@ -43,12 +64,13 @@ func resourceWAFLogSetup() *schema.Resource {
 				ConflictsWith: []string{"s3_bucket_name", "s3_access_key", "s3_secret_key"},
 			},
 			"sftp_password": {
-				Description:   "A corresponding password for the user account used to log in to the SFTP server.",
-				Type:          schema.TypeString,
-				Optional:      true,
-				Sensitive:     true,
-				RequiredWith:  []string{"sftp_host", "sftp_user_name", "sftp_destination_folder"},
-				ConflictsWith: []string{"s3_bucket_name", "s3_access_key", "s3_secret_key"},
+				Description:      "A corresponding password for the user account used to log in to the SFTP server.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressUnreadableAttributeDiff,
+				RequiredWith:     []string{"sftp_host", "sftp_user_name", "sftp_destination_folder"},
+				ConflictsWith:    []string{"s3_bucket_name", "s3_access_key", "s3_secret_key"},
 			},
 			"sftp_destination_folder": {
 				Description:   "The path to the directory on the SFTP server.",
@@ -72,22 +94,66 @@ func resourceWAFLogSetup() *schema.Resource {
 				ConflictsWith: []string{"sftp_host", "sftp_user_name", "sftp_password", "sftp_destination_folder"},
 			},
 			"s3_secret_key": {
-				Description:   "S3 secret key.",
-				Type:          schema.TypeString,
-				Optional:      true,
-				Sensitive:     true,
-				RequiredWith:  []string{"s3_bucket_name", "s3_secret_key"},
-				ConflictsWith: []string{"sftp_host", "sftp_user_name", "sftp_password", "sftp_destination_folder"},
+				Description:      "S3 secret key.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressUnreadableAttributeDiff,
+				RequiredWith:     []string{"s3_bucket_name", "s3_secret_key"},
+				ConflictsWith:    []string{"sftp_host", "sftp_user_name", "sftp_password", "sftp_destination_folder"},
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceWAFLogSetupRead(d *schema.ResourceData, m interface{}) error {
+func resourceWAFLogSetupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	accountID := d.Get("account_id").(int)
+
+	wafLogSetupReadResponse, err := client.ReadWAFLogSetup(ctx, accountID)
+
+	// The account may have been deleted
+	if isNotFoundError(err) {
+		log.Printf("[INFO] Incapsula account %d of the WAF Log Setup has been deleted: %s\n", accountID, err)
+		d.SetId("")
+		return nil
+	}
+
+	// Other errors are reported as a warning and the values of the state are kept
+	if err != nil {
+		log.Printf("[WARN] Could not read Incapsula WAF Log Setup for account %d, keeping the values of the state: %s\n", accountID, err)
+		diags := diagnosticsFromError(d, err)
+		for i := range diags {
+			diags[i].Severity = diag.Warning
+			diags[i].Summary = fmt.Sprintf("Could not read the WAF Log Setup, keeping the values of the state: %s", diags[i].Summary)
+		}
+		return diags
+	}
+
+	// The SFTP password, the S3 secret key and whether the WAF logs are enabled are not returned, the values of the
+	// state are kept
+	d.Set("s3_bucket_name", wafLogSetupReadResponse.S3BucketName)
+	d.Set("s3_access_key", wafLogSetupReadResponse.S3AccessKey)
+	d.Set("sftp_host", wafLogSetupReadResponse.SftpHost)
+	d.Set("sftp_user_name", wafLogSetupReadResponse.SftpUserName)
+	d.Set("sftp_destination_folder", wafLogSetupReadResponse.SftpDestinationFolder)
+	if wafLogSetupReadResponse.S3BucketName == "" {
+		d.Set("s3_secret_key", "")
+	}
+	if wafLogSetupReadResponse.SftpHost == "" {
+		d.Set("sftp_password", "")
+	}
+
 	return nil
 }
 
-func resourceWAFLogSetupCreate(d *schema.ResourceData, m interface{}) error {
+func resourceWAFLogSetupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 
@@ -109,26 +175,30 @@ func resourceWAFLogSetupCreate(d *schema.ResourceData, m interface{}) error {
 		d.Get("enabled").(bool),
 		d.Get("s3_bucket_name").(string),
 		d.Get("s3_access_key").(string),
-		d.Get("s3_secret_key").(string),
+		configuredString(d, "s3_secret_key"),
 		d.Get("sftp_host").(string),
 		d.Get("sftp_user_name").(string),
-		d.Get("sftp_password").(string),
+		configuredString(d, "sftp_password"),
 		d.Get("sftp_destination_folder").(string),
 	}
 
 	if d.Get("s3_bucket_name") != "" {
-		wafLogSetupResponse, err = client.AddWAFLogSetupS3(&wafLogSetupPayload)
+		wafLogSetupResponse, err = client.AddWAFLogSetupS3(ctx, &wafLogSetupPayload)
 	} else if d.Get("sftp_destination_folder") != "" {
-		wafLogSetupResponse, err = client.AddWAFLogSetupSFTP(&wafLogSetupPayload)
+		wafLogSetupResponse, err = client.AddWAFLogSetupSFTP(ctx, &wafLogSetupPayload)
 	} else {
-		wafLogSetupResponse, err = client.AddWAFLogSetupDefault(&wafLogSetupPayload)
+		wafLogSetupResponse, err = client.AddWAFLogSetupDefault(ctx, &wafLogSetupPayload)
 	}
 
 	if err != nil {
 		log.Printf("[ERROR] Could not create Incapsula WAF Log Setup for account %d, %s\n", accountID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
+	// Once sent, the secrets are tracked in the state even when their diff was suppressed after an import
+	d.Set("s3_secret_key", wafLogSetupPayload.SecretKey)
+	d.Set("sftp_password", wafLogSetupPayload.Password)
+
 	d.SetId(strconv.Itoa(accountID))
 	log.Printf("[INFO] red and res_message:  %d, %s\n", wafLogSetupResponse.Res, wafLogSetupResponse.ResMessage)
 	log.Printf("[INFO] Created Incapsula WAF Log Setup for account %d\n", accountID)
@@ -136,16 +206,16 @@ func resourceWAFLogSetupCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceWAFLogSetupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWAFLogSetupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 	accountID := d.Get("account_id").(int)
 
 	log.Printf("[INFO] Restoring Incapsula WAF Log Setup to default for account: %d\n", accountID)
-	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(accountID)
+	wafLogSetupResponse, err := client.RestoreWAFLogSetupDefault(ctx, accountID)
 
 	if err != nil {
 		log.Printf("[ERROR] Could not restore Incapsula WAF Log Setup to default for account %d, %s\n", accountID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	d.SetId("")
@@ -60,6 +60,12 @@ func TestAccIncapsulaWAFLogSetupSFTP_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(wafSetupLogResourceType+"."+wafSetupLogResourceName, "sftp_destination_folder", sftpDestinationFolder),
 				),
 			},
+			{
+				ResourceName:            wafSetupLogResourceType + "." + wafSetupLogResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"sftp_password", "s3_secret_key", "enabled"},
+			},
 		},
 	})
 }
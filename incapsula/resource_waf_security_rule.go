@@ -1,12 +1,15 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -26,18 +29,22 @@ const botAccessControlChallengeSuspectedBotsDefaultAction = "false"
 
 func resourceWAFSecurityRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceWAFSecurityRuleCreate,
-		Read:   resourceWAFSecurityRuleRead,
-		Update: resourceWAFSecurityRuleUpdate,
-		Delete: resourceWAFSecurityRuleDelete,
+		CreateContext: resourceWAFSecurityRuleCreate,
+		ReadContext:   resourceWAFSecurityRuleRead,
+		UpdateContext: resourceWAFSecurityRuleUpdate,
+		DeleteContext: resourceWAFSecurityRuleDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				idSlice := strings.Split(d.Id(), "/")
 				if len(idSlice) != 2 || idSlice[0] == "" || idSlice[1] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id", d.Id())
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected site_id/rule_id or domain/rule_id", d.Id())
 				}
 
-				siteID, err := strconv.Atoi(idSlice[0])
+				siteIDString, err := meta.(*Client).lookupSiteID(ctx, idSlice[0])
+				if err != nil {
+					return nil, err
+				}
+				siteID, err := strconv.Atoi(siteIDString)
 				ruleID := idSlice[1]
 				if err != nil {
 					return nil, err
@@ -109,10 +116,16 @@ func resourceWAFSecurityRule() *schema.Resource {
 				Optional:    true,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
-func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -121,6 +134,7 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 
 	if ruleID == backdoorRuleID || ruleID == crossSiteScriptingRuleID || ruleID == illegalResourceAccessRuleID || ruleID == remoteFileInclusionRuleID || ruleID == sqlInjectionRuleID {
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			d.Get("security_rule_action").(string),
@@ -133,10 +147,11 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) and security_rule_action (%s) on site_id (%d), %s\n", ruleID, d.Get("security_rule_action").(string), d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	} else if ruleID == ddosRuleID {
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -149,10 +164,11 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) with activation_mode (%s), ddos_traffic_threshold (%s), unknown_clients_challenge (%s) and block_non_essential_bots (%s) on site_id (%d), %s\n", ruleID, d.Get("activation_mode").(string), d.Get("ddos_traffic_threshold").(string), d.Get("unknown_clients_challenge").(string), d.Get("block_non_essential_bots").(string), d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	} else if ruleID == botAccessControlRuleID {
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -165,7 +181,7 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not create Incapsula WAF Rule rule_id (%s) with block_bad_bots (%s) and challenge_suspected_bots (%s) on site_id (%d), %s\n", ruleID, d.Get("block_bad_bots").(string), d.Get("challenge_suspected_bots").(string), d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	}
 
@@ -174,10 +190,10 @@ func resourceWAFSecurityRuleCreate(d *schema.ResourceData, m interface{}) error
 
 	log.Printf("[INFO] Created Incapsula WAF Rule rule_id (%s) on site_id (%d)\n", ruleID, d.Get("site_id").(int))
 
-	return resourceWAFSecurityRuleRead(d, m)
+	return resourceWAFSecurityRuleRead(ctx, d, m)
 }
 
-func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Implement by reading the SiteResponse for the site
 	client := m.(*Client)
 
@@ -185,7 +201,7 @@ func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 
 	log.Printf("[INFO] Reading Incapsula WAF Rule for id: %s\n", ruleID)
 
-	siteStatusResponse, err := client.SiteStatus("waf-rule-read", d.Get("site_id").(int))
+	siteStatusResponse, err := client.SiteStatus(ctx, "waf-rule-read", d.Get("site_id").(int))
 
 	// Site object may have been deleted
 	if siteStatusResponse != nil && siteStatusResponse.Res.(float64) == 9413 {
@@ -196,7 +212,7 @@ func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 
 	if err != nil {
 		log.Printf("[ERROR] Could not read Incapsula WAF Rule for id: %s, %s\n", ruleID, err)
-		return err
+		return diagnosticsFromError(d, err)
 	}
 
 	found := false
@@ -242,9 +258,9 @@ func resourceWAFSecurityRuleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceWAFSecurityRuleUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// This is the same as create
-	return resourceWAFSecurityRuleCreate(d, m)
+	return resourceWAFSecurityRuleCreate(ctx, d, m)
 }
 
 func testAccStateWAFSecurityRuleID(s *terraform.State) (string, error) {
@@ -267,7 +283,7 @@ func testAccStateWAFSecurityRuleID(s *terraform.State) (string, error) {
 	return "", fmt.Errorf("Error finding site_id")
 }
 
-func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWAFSecurityRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
 	ruleID := d.Get("rule_id").(string)
@@ -278,6 +294,7 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 	switch ruleID {
 	case backdoorRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			backdoorRuleIDDefaultAction,
@@ -290,10 +307,11 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, backdoorRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case crossSiteScriptingRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			crossSiteScriptingRuleIDDefaultAction,
@@ -306,10 +324,11 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, crossSiteScriptingRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case illegalResourceAccessRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			illegalResourceAccessRuleIDDefaultAction,
@@ -322,10 +341,11 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, illegalResourceAccessRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case remoteFileInclusionRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			remoteFileInclusionRuleIDDefaultAction,
@@ -338,10 +358,11 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, remoteFileInclusionRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case sqlInjectionRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			sqlInjectionRuleIDDefaultAction,
@@ -354,10 +375,11 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with security_rule_action (%s) on site_id (%d) %s\n", ruleID, sqlInjectionRuleIDDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case ddosRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -370,10 +392,11 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with default_activation_mode (%s), ddos_traffic_threshold (%s), unknown_clients_challenge (%s) and block_non_essential_bots (%s) on site_id (%d) %s\n", ruleID, ddosRuleIDDefaultActivationMode, ddosRuleIDDefaultDDOSTrafficThreshold, ddosRuleIDDefaultDDOSUnknownClientsChallenge, ddosRuleIDDefaultDDOSBlockNonEssentialBots, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	case botAccessControlRuleID:
 		_, err := client.ConfigureWAFSecurityRule(
+			ctx,
 			d.Get("site_id").(int),
 			ruleID,
 			"",
@@ -386,7 +409,7 @@ func resourceWAFSecurityRuleDelete(d *schema.ResourceData, m interface{}) error
 		)
 		if err != nil {
 			log.Printf("[ERROR] Could not reset Incapsula WAF Rule rule_id (%s) with block_bad_bots (%s) and challenge_suspected_bots (%s) on site_id (%d) %s\n", ruleID, botAccessControlBlockBadBotsDefaultAction, botAccessControlChallengeSuspectedBotsDefaultAction, d.Get("site_id").(int), err)
-			return err
+			return diagnosticsFromError(d, err)
 		}
 	}
 
@@ -6,10 +6,12 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-incapsula/incapsula/rulefilter"
 )
 
 func resourceWaitingRoom() *schema.Resource {
@@ -19,15 +21,30 @@ func resourceWaitingRoom() *schema.Resource {
 		UpdateContext: resourceWaitingRoomUpdate,
 		DeleteContext: resourceWaitingRoomDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				idSlice := strings.Split(data.Id(), "/")
+			StateContext: func(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// Waiting room names may contain slashes, domains can't
+				idSlice := strings.SplitN(data.Id(), "/", 3)
 				if len(idSlice) != 3 || idSlice[0] == "" || idSlice[1] == "" || idSlice[2] == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/site_id/waiting_room_id", data.Id())
+					return nil, fmt.Errorf("unexpected format of ID (%q), expected account_id/site_id/waiting_room_id or account_id/domain/waiting_room_name", data.Id())
+				}
+				accountID, err := strconv.Atoi(idSlice[0])
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert account ID from import command, actual value: %s, expected numeric id", idSlice[0])
+				}
+
+				client := meta.(*Client)
+				siteID, err := client.lookupSiteIDInAccount(ctx, accountID, idSlice[1])
+				if err != nil {
+					return nil, err
+				}
+				waitingRoomID, err := client.lookupWaitingRoomID(ctx, idSlice[0], siteID, idSlice[2])
+				if err != nil {
+					return nil, err
 				}
 
 				data.Set("account_id", idSlice[0])
-				data.Set("site_id", idSlice[1])
-				data.SetId(idSlice[2])
+				data.Set("site_id", siteID)
+				data.SetId(waitingRoomID)
 
 				return []*schema.ResourceData{data}, nil
 			},
@@ -62,9 +79,11 @@ func resourceWaitingRoom() *schema.Resource {
 				Optional:    true,
 			},
 			"filter": {
-				Description: "The rule conditions that determine on which sessions this waiting room applies. (no filter means the waiting room applies for the whole site)",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:      "The rule conditions that determine on which sessions this waiting room applies. (no filter means the waiting room applies for the whole site)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateRuleFilter(rulefilter.WaitingRoom),
+				DiffSuppressFunc: suppressEquivalentRuleFilterDiffs,
 			},
 			"bots_action_in_queuing_mode": {
 				Description:  "The waiting room bot handling action. Determines the waiting room behavior for legitimate bots trying to access your website during peak time",
@@ -136,6 +155,12 @@ func resourceWaitingRoom() *schema.Resource {
 				Default:     false,
 			},
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -171,7 +196,7 @@ func resourceWaitingRoomCreate(ctx context.Context, data *schema.ResourceData, m
 		waitingRoom.ThresholdSettings.ConcurrentSessionsEnabled = true
 	}
 
-	waitingRoomDTOResponse, diags := client.CreateWaitingRoom(accountId, siteID, &waitingRoom)
+	waitingRoomDTOResponse, diags := client.CreateWaitingRoom(ctx, accountId, siteID, &waitingRoom)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to create Waiting Room for Site ID %s", siteID)
 		return diags
@@ -215,7 +240,7 @@ func resourceWaitingRoomRead(ctx context.Context, data *schema.ResourceData, m i
 		}}
 	}
 
-	waitingRoomDTOResponse, diags := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+	waitingRoomDTOResponse, diags := client.ReadWaitingRoom(ctx, accountId, siteID, waitingRoomID)
 	if waitingRoomDTOResponse != nil && waitingRoomDTOResponse.Errors != nil && waitingRoomDTOResponse.Errors[0].Status == 404 {
 		data.SetId("")
 		return nil
@@ -306,7 +331,7 @@ func resourceWaitingRoomUpdate(ctx context.Context, data *schema.ResourceData, m
 		waitingRoom.ThresholdSettings.ConcurrentSessionsEnabled = true
 	}
 
-	_, diags = client.UpdateWaitingRoom(accountId, siteID, waitingRoomID, &waitingRoom)
+	_, diags = client.UpdateWaitingRoom(ctx, accountId, siteID, waitingRoomID, &waitingRoom)
 	if diags != nil && diags.HasError() {
 		log.Printf("[ERROR] Failed to update Waiting Room %d for Site ID %s", waitingRoomID, siteID)
 		return diags
@@ -332,7 +357,7 @@ func resourceWaitingRoomDelete(ctx context.Context, data *schema.ResourceData, m
 		}}
 	}
 
-	waitingRoomDTOResponse, diags := client.DeleteWaitingRoom(accountId, siteID, waitingRoomID)
+	waitingRoomDTOResponse, diags := client.DeleteWaitingRoom(ctx, accountId, siteID, waitingRoomID)
 	if waitingRoomDTOResponse != nil && waitingRoomDTOResponse.Errors != nil && waitingRoomDTOResponse.Errors[0].Status == 404 {
 		data.SetId("")
 		return nil
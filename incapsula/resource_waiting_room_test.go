@@ -1,6 +1,7 @@
 package incapsula
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
@@ -114,7 +115,7 @@ func testAccCheckWaitingRoomExists(waitingRoomDTOresponse *WaitingRoomDTO) resou
 
 		client := testAccProvider.Meta().(*Client)
 
-		response, _ := client.ReadWaitingRoom(accountId, siteID, waitingRoomID)
+		response, _ := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomID)
 		if response == nil {
 			return fmt.Errorf("Failed to retrieve Waiting Room (id=%d)", waitingRoomID)
 		}
@@ -229,7 +230,7 @@ func testAccCheckWaitingRoomDestroy(state *terraform.State) error {
 
 		accountId := res.Primary.Attributes["account_id"]
 
-		waitingRoomDTOResponse, _ := client.ReadWaitingRoom(accountId, siteID, waitingRoomIdInt)
+		waitingRoomDTOResponse, _ := client.ReadWaitingRoom(context.Background(), accountId, siteID, waitingRoomIdInt)
 		if waitingRoomDTOResponse == nil {
 			return fmt.Errorf("Failed to check Waiting Room status (id=%s)", waitingRoomID)
 		}
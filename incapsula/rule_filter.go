@@ -0,0 +1,80 @@
+package incapsula
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-incapsula/incapsula/rulefilter"
+)
+
+// responseRuleActions are the incap and delivery rule actions applied to the response of the origin, the only ones
+// whose filter can use the response fields
+var responseRuleActions = []string{
+	"RULE_ACTION_RESPONSE_REWRITE_HEADER",
+	"RULE_ACTION_RESPONSE_DELETE_HEADER",
+	"RULE_ACTION_RESPONSE_REWRITE_RESPONSE_CODE",
+	"RULE_ACTION_CUSTOM_ERROR_RESPONSE",
+}
+
+// validateRuleFilter validates at plan time the syntax of a rule filter and the fields it uses. The misspellings of
+// known fields are errors, the other fields the provider doesn't know are warnings, since the API may support fields
+// added after this version of the provider
+func validateRuleFilter(ruleType rulefilter.RuleType) schema.SchemaValidateDiagFunc {
+	return func(value interface{}, path cty.Path) diag.Diagnostics {
+		filter, ok := value.(string)
+		if !ok {
+			return diag.Errorf("expected the filter to be a string")
+		}
+		warnings, errs := rulefilter.Validate(filter, ruleType)
+		var diags diag.Diagnostics
+		for _, err := range errs {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid filter",
+				Detail:        err.Error(),
+				AttributePath: path,
+			})
+		}
+		for _, warning := range warnings {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "Unknown filter field",
+				Detail:        warning.Error() + ". The filter is sent as is, the API rejects it if the field doesn't exist.",
+				AttributePath: path,
+			})
+		}
+		return diags
+	}
+}
+
+// validateRuleFilterAction rejects the response fields in the filter of a rule whose action applies to the request.
+// Unknown filters and actions are not checked
+func validateRuleFilterAction(filter cty.Value, action cty.Value, rule string, path cty.Path) diag.Diagnostics {
+	if filter.IsNull() || !filter.IsKnown() || action.IsNull() || !action.IsKnown() || contains(responseRuleActions, action.AsString()) {
+		return nil
+	}
+	var diags diag.Diagnostics
+	for _, err := range rulefilter.Restrict(filter.AsString(), rulefilter.RequestFields(), fmt.Sprintf("%s with action %s, which applies to the request", rule, action.AsString())) {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid filter",
+			Detail:        err.Error(),
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
+// suppressEquivalentRuleFilterDiffs suppresses the diff of filters with the same canonical form, that only differ
+// by white spaces or redundant parentheses. Filters that cannot be parsed are compared without the surrounding spaces
+func suppressEquivalentRuleFilterDiffs(k, old, new string, d *schema.ResourceData) bool {
+	canonicalOld, errOld := rulefilter.Canonical(old)
+	canonicalNew, errNew := rulefilter.Canonical(new)
+	if errOld != nil || errNew != nil {
+		return strings.TrimSpace(old) == strings.TrimSpace(new)
+	}
+	return canonicalOld == canonicalNew
+}
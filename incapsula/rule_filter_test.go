@@ -0,0 +1,79 @@
+package incapsula
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/terraform-providers/terraform-provider-incapsula/incapsula/rulefilter"
+)
+
+func TestValidateRuleFilter(t *testing.T) {
+	validate := validateRuleFilter(rulefilter.IncapRule)
+	path := cty.GetAttrPath("filter")
+
+	if diags := validate(`URL == "/admin" & ClientIP != 192.0.2.1`, path); diags.HasError() {
+		t.Errorf("Should not have received an error, got: %v", diags)
+	}
+
+	diags := validate(`ClientIp == 192.0.2.1 & (URL == "/admin"`, path)
+	if len(diags) != 1 || !diags.HasError() || !diags[0].AttributePath.Equals(path) {
+		t.Fatalf("Should have received one error on the filter attribute, got: %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "expected ')'") {
+		t.Errorf("Unexpected error detail: %s", diags[0].Detail)
+	}
+
+	diags = validate(`ClientIp == 192.0.2.1`, path)
+	if len(diags) != 1 || !diags.HasError() || !strings.Contains(diags[0].Detail, "did you mean ClientIP?") {
+		t.Errorf("Should have rejected the misspelled field name, got: %v", diags)
+	}
+
+	diags = validate(`BotCategory == "Malicious"`, path)
+	if len(diags) != 1 || diags.HasError() || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "unknown field BotCategory") {
+		t.Errorf("Should have warned about the unknown field, got: %v", diags)
+	}
+}
+
+func TestValidateDeliveryRulesConfigFilterAction(t *testing.T) {
+	rule := func(action string, filter string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"action": cty.StringVal(action), "filter": cty.StringVal(filter)})
+	}
+	config := cty.ObjectVal(map[string]cty.Value{"rule": cty.ListVal([]cty.Value{
+		rule("RULE_ACTION_RESPONSE_REWRITE_HEADER", `ResponseCode == 404`),
+		rule("RULE_ACTION_REWRITE_URL", `URL == "/a" & ResponseCode == 404`),
+		rule("RULE_ACTION_REWRITE_HEADER", `URL == "/a" & Foo == 1`),
+	})})
+
+	diags := validateDeliveryRulesConfig(config)
+	if len(diags) != 1 || !diags.HasError() || !diags[0].AttributePath.Equals(cty.GetAttrPath("rule").IndexInt(1).GetAttr("filter")) {
+		t.Fatalf("Should have rejected the response field of the second rule, got: %v", diags)
+	}
+	expected := "field ResponseCode cannot be used in the filter of a delivery rule with action RULE_ACTION_REWRITE_URL, which applies to the request (at character 15)"
+	if diags[0].Detail != expected {
+		t.Errorf("Unexpected error, expected: %s, got: %s", expected, diags[0].Detail)
+	}
+}
+
+func TestSuppressEquivalentRuleFilterDiffs(t *testing.T) {
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{`URL == "/admin"`, "  URL  == \"/admin\"\n", true},
+		{`(ASN == 1 & ASN == 2)`, `ASN == 1 & ASN == 2`, true},
+		{`CountryCode == US;CA`, `CountryCode == US; CA`, true},
+		{`ASN == 1 | ASN == 2 & ASN == 3`, `ASN == 1 | (ASN == 2 & ASN == 3)`, true},
+		{`(ASN == 1 | ASN == 2) & ASN == 3`, `ASN == 1 | ASN == 2 & ASN == 3`, false},
+		{`URL == "/admin"`, `URL == "/Admin"`, false},
+		{`URL == "/admin`, ` URL == "/admin`, true},
+		{``, ``, true},
+		{``, `ASN == 1`, false},
+	}
+	for _, c := range cases {
+		if actual := suppressEquivalentRuleFilterDiffs("filter", c.old, c.new, nil); actual != c.suppress {
+			t.Errorf("Unexpected suppression of the diff between %q and %q, expected: %t", c.old, c.new, c.suppress)
+		}
+	}
+}
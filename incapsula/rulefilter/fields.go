@@ -0,0 +1,172 @@
+package rulefilter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agext/levenshtein"
+)
+
+// RuleType is the type of rule a filter belongs to, it determines the fields the filter can use
+type RuleType string
+
+const (
+	IncapRule    RuleType = "incap rule"
+	DeliveryRule RuleType = "delivery rule"
+	CacheRule    RuleType = "cache rule"
+	WaitingRoom  RuleType = "waiting room"
+)
+
+// requestFields are the fields of the request, available to all the rule types. The lists of fields are not
+// exhaustive: the fields they don't contain are reported as warnings, unless they are close to a known field
+var requestFields = []string{
+	"ASN",
+	"ClientId",
+	"ClientIP",
+	"ClientType",
+	"CookieExists",
+	"CountryCode",
+	"FileExtension",
+	"Full-URL",
+	"HeaderExists",
+	"Hostname",
+	"isMobile",
+	"Method",
+	"ParamExists",
+	"PostParamExists",
+	"Protocol",
+	"QueryString",
+	"Referrer",
+	"URL",
+	"UserAgent",
+}
+
+// responseFields are the fields of the origin response, available to the rules applied to responses: the cache rules,
+// and the incap and delivery rules whose action applies to the response, see Restrict
+var responseFields = []string{
+	"ResponseCode",
+	"ResponseContentType",
+	"ResponseHeaderExists",
+}
+
+// ruleTypeFields are the fields of each rule type, whatever its action. The waiting rooms are entered before the
+// request is forwarded to the origin, their filters can't use the response
+var ruleTypeFields = map[RuleType][][]string{
+	IncapRule:    {requestFields, responseFields},
+	DeliveryRule: {requestFields, responseFields},
+	CacheRule:    {requestFields, responseFields},
+	WaitingRoom:  {requestFields},
+}
+
+// maxMisspellingDistance is the largest edit distance between an unknown field and a known field for the unknown field
+// to be considered a misspelling of the known one, case-insensitively. Short names allow a single edit
+const maxMisspellingDistance = 2
+
+// Fields returns the sorted field names a filter of a rule type can use
+func Fields(ruleType RuleType) []string {
+	var fields []string
+	for _, group := range ruleTypeFields[ruleType] {
+		fields = append(fields, group...)
+	}
+	return sortFields(fields)
+}
+
+// RequestFields returns the sorted fields of the request, the only fields the rules applied to requests can use
+func RequestFields() []string {
+	return sortFields(append([]string{}, requestFields...))
+}
+
+// Validate parses a filter and checks the fields of its conditions. Field names are case-sensitive. It returns the
+// syntax error, or one error per field that can't be used by the rule type or that is a misspelling of a known field,
+// such as ClientIp for ClientIP. The other unknown fields are only warnings, since the known fields are not exhaustive.
+// It returns no error for an empty filter
+func Validate(filter string, ruleType RuleType) (warnings []error, errs []error) {
+	node, err := Parse(filter)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	fields := map[string]bool{}
+	for _, field := range Fields(ruleType) {
+		fields[field] = true
+	}
+
+	for _, condition := range Conditions(node) {
+		if fields[condition.Field] {
+			continue
+		}
+		if isKnownField(condition.Field) {
+			errs = append(errs, &Error{Position: condition.Position, Message: fmt.Sprintf("field %s cannot be used in the filter of a %s", condition.Field, ruleType)})
+			continue
+		}
+		if known := misspelledField(condition.Field, fields); known != "" {
+			errs = append(errs, &Error{Position: condition.Position, Message: fmt.Sprintf("unknown field %s, did you mean %s?", condition.Field, known)})
+			continue
+		}
+		warnings = append(warnings, &Error{Position: condition.Position, Message: fmt.Sprintf("unknown field %s, the fields of a %s filter are: %s", condition.Field, ruleType, strings.Join(Fields(ruleType), ", "))})
+	}
+	return warnings, errs
+}
+
+// Restrict returns one error per condition of a filter on a known field missing from the given fields, such as a
+// response field in the filter of a rule applied to requests. The rule describes the rule in the errors. The filters
+// that cannot be parsed and the unknown fields are left to Validate
+func Restrict(filter string, fields []string, rule string) []error {
+	node, err := Parse(filter)
+	if err != nil {
+		return nil
+	}
+
+	var errs []error
+	for _, condition := range Conditions(node) {
+		if isKnownField(condition.Field) && !contains(fields, condition.Field) {
+			errs = append(errs, &Error{Position: condition.Position, Message: fmt.Sprintf("field %s cannot be used in the filter of %s", condition.Field, rule)})
+		}
+	}
+	return errs
+}
+
+func isKnownField(field string) bool {
+	return contains(requestFields, field) || contains(responseFields, field)
+}
+
+// misspelledField returns the field closest to an unknown field, when it is close enough to be a misspelling of it
+func misspelledField(field string, fields map[string]bool) string {
+	maxDistance := maxMisspellingDistance
+	if len(field) <= 4 {
+		maxDistance = 1
+	}
+
+	closest, closestDistance := "", maxDistance+1
+	for _, known := range sortFields(keys(fields)) {
+		if distance := levenshtein.Distance(strings.ToLower(field), strings.ToLower(known), nil); distance < closestDistance {
+			closest, closestDistance = known, distance
+		}
+	}
+	return closest
+}
+
+func sortFields(fields []string) []string {
+	sort.Slice(fields, func(i, j int) bool {
+		return strings.ToLower(fields[i]) < strings.ToLower(fields[j])
+	})
+	return fields
+}
+
+func keys(fields map[string]bool) []string {
+	result := make([]string, 0, len(fields))
+	for field := range fields {
+		result = append(result, field)
+	}
+	return result
+}
+
+func contains(fields []string, field string) bool {
+	for _, known := range fields {
+		if known == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package rulefilter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseCanonical(t *testing.T) {
+	cases := map[string]string{
+		``:                                  ``,
+		`URL == "/admin"`:                   `URL == "/admin"`,
+		"  URL   ==\n\"/admin\"  ":          `URL == "/admin"`,
+		`isMobile == Yes`:                   `isMobile == Yes`,
+		`Full-URL CONTAINS "/block/"`:       `Full-URL contains "/block/"`,
+		`CountryCode == US ; CA;FR`:         `CountryCode == US;CA;FR`,
+		`((ASN == 1))`:                      `ASN == 1`,
+		`ASN == 1 & (ASN == 2 & ASN == 3)`:  `ASN == 1 & ASN == 2 & ASN == 3`,
+		`ASN == 1 | ASN == 2 & ASN == 3`:    `ASN == 1 | (ASN == 2 & ASN == 3)`,
+		`(ASN == 1 | ASN == 2) & ASN == 3`:  `(ASN == 1 | ASN == 2) & ASN == 3`,
+		`ClientIP!=192.0.2.0/24&URL=="/a"`:  `ClientIP != 192.0.2.0/24 & URL == "/a"`,
+		`UserAgent == "say \"hi\" \\ bye"`:  `UserAgent == "say \"hi\" \\ bye"`,
+		`ResponseCode>=500`:                 `ResponseCode >= 500`,
+		`HeaderExists == "X-Forwarded-For"`: `HeaderExists == "X-Forwarded-For"`,
+	}
+	for filter, expected := range cases {
+		actual, err := Canonical(filter)
+		if err != nil {
+			t.Errorf("Should not have received an error parsing %q, got: %s", filter, err)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("Unexpected canonical form of %q, expected: %s, got: %s", filter, expected, actual)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		`URL == "/admin`:            `unterminated quoted value (at character 8)`,
+		`(URL == "/admin"`:          `expected ')' to close the '(' at character 1, got end of filter (at character 17)`,
+		`URL == "/admin")`:          `unexpected ')' ")" (at character 16)`,
+		`URL "/admin"`:              `expected an operator after field URL, got quoted value "/admin" (at character 5)`,
+		`URL ==`:                    `expected a value after URL ==, got end of filter (at character 7)`,
+		`CountryCode == US;`:        `expected a value after CountryCode ==, got end of filter (at character 19)`,
+		`URL == "/a" & | ASN == 1`:  `expected a field name or '(', got '|' "|" (at character 15)`,
+		`URL == "/a" ASN == 1`:      `unexpected word "ASN" (at character 13)`,
+		`URL equals "/a"`:           `expected an operator after field URL, got word "equals" (at character 5)`,
+		`& URL == "/a"`:             `expected a field name or '(', got '&' "&" (at character 1)`,
+		`()`:                        `expected a field name or '(', got ')' ")" (at character 2)`,
+		`URL == "/a" & (ASN == 1))`: `unexpected ')' ")" (at character 25)`,
+	}
+	for filter, expected := range cases {
+		_, err := Parse(filter)
+		var filterError *Error
+		if !errors.As(err, &filterError) {
+			t.Errorf("Should have received a filter error parsing %q, got: %v", filter, err)
+			continue
+		}
+		if err.Error() != expected {
+			t.Errorf("Unexpected error parsing %q, expected: %s, got: %s", filter, expected, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if warnings, errs := Validate(`URL == "/admin" & ClientIP != 192.0.2.1`, IncapRule); len(errs) != 0 || len(warnings) != 0 {
+		t.Errorf("Should not have received an error nor warnings, got: %v %v", errs, warnings)
+	}
+	if warnings, errs := Validate(``, WaitingRoom); len(errs) != 0 || len(warnings) != 0 {
+		t.Errorf("Should not have received an error nor warnings for an empty filter, got: %v %v", errs, warnings)
+	}
+	if _, errs := Validate(`URL == "/admin" &`, IncapRule); len(errs) != 1 {
+		t.Errorf("Should have received the syntax error, got: %v", errs)
+	}
+
+	// The misspellings of known fields are errors
+	misspellings := map[string]string{
+		`ClientIp == 192.0.2.1`:     "unknown field ClientIp, did you mean ClientIP? (at character 1)",
+		`URL == "/" & Hostnme == a`: "unknown field Hostnme, did you mean Hostname? (at character 14)",
+		`CountyCode == US`:          "unknown field CountyCode, did you mean CountryCode? (at character 1)",
+		`UserAgnet contains "curl"`: "unknown field UserAgnet, did you mean UserAgent? (at character 1)",
+		`responsecode == 500`:       "unknown field responsecode, did you mean ResponseCode? (at character 1)",
+		`URL == "/" | UR == "/a"`:   "unknown field UR, did you mean URL? (at character 14)",
+	}
+	for filter, expected := range misspellings {
+		warnings, errs := Validate(filter, CacheRule)
+		if len(warnings) != 0 || len(errs) != 1 || errs[0].Error() != expected {
+			t.Errorf("Should have reported the misspelling of %q as an error, got: %v %v", filter, errs, warnings)
+		}
+	}
+
+	warnings, errs := Validate(`ResponseCode == 404`, WaitingRoom)
+	if len(warnings) != 0 || len(errs) != 1 || !strings.Contains(errs[0].Error(), "field ResponseCode cannot be used in the filter of a waiting room") {
+		t.Errorf("Should have rejected the response field, got: %v %v", errs, warnings)
+	}
+
+	warnings, errs = Validate(`Foo == 1 & URL == "/" & Bar == 2`, CacheRule)
+	if len(errs) != 0 || len(warnings) != 2 || !strings.Contains(warnings[0].Error(), "unknown field Foo") || !strings.Contains(warnings[1].Error(), "unknown field Bar") {
+		t.Errorf("Should have warned about both unknown fields, got: %v %v", errs, warnings)
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	if errs := Restrict(`URL == "/" & Foo == 1`, RequestFields(), "a request rule"); len(errs) != 0 {
+		t.Errorf("Should not have restricted the request and unknown fields, got: %v", errs)
+	}
+	if errs := Restrict(`URL == "/" &`, RequestFields(), "a request rule"); len(errs) != 0 {
+		t.Errorf("Should have left the syntax error to Validate, got: %v", errs)
+	}
+	errs := Restrict(`URL == "/" & ResponseCode == 404`, RequestFields(), "a request rule")
+	if len(errs) != 1 || errs[0].Error() != "field ResponseCode cannot be used in the filter of a request rule (at character 14)" {
+		t.Errorf("Should have rejected the response field, got: %v", errs)
+	}
+}
@@ -0,0 +1,130 @@
+package rulefilter
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenSemicolon
+	tokenOpenParen
+	tokenCloseParen
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of filter"
+	case tokenWord:
+		return "word"
+	case tokenString:
+		return "quoted value"
+	case tokenOperator:
+		return "operator"
+	case tokenAnd:
+		return "'&'"
+	case tokenOr:
+		return "'|'"
+	case tokenSemicolon:
+		return "';'"
+	case tokenOpenParen:
+		return "'('"
+	case tokenCloseParen:
+		return "')'"
+	}
+	return "token"
+}
+
+// token is a token of a filter. Position is the 1-based position of its first character
+type token struct {
+	kind     tokenKind
+	text     string
+	position int
+}
+
+// symbolOperators are the operators written with symbols, longest first
+var symbolOperators = []string{"==", "!=", ">=", "<=", "!^", ">", "<", "^"}
+
+// wordDelimiters end a word, along with white spaces
+const wordDelimiters = `()&|;"`
+
+// tokenize splits a filter into tokens, ending with a tokenEOF token
+func tokenize(filter string) ([]token, error) {
+	runes := []rune(filter)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		position := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenOpenParen, "(", position})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenCloseParen, ")", position})
+			i++
+		case r == '&':
+			tokens = append(tokens, token{tokenAnd, "&", position})
+			i++
+		case r == '|':
+			tokens = append(tokens, token{tokenOr, "|", position})
+			i++
+		case r == ';':
+			tokens = append(tokens, token{tokenSemicolon, ";", position})
+			i++
+		case r == '"':
+			var value strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, &Error{Position: position, Message: "unterminated quoted value"}
+				}
+				if runes[i] == '\\' && i+1 < len(runes) {
+					value.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == '"' {
+					i++
+					break
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token{tokenString, value.String(), position})
+		default:
+			if operator := symbolOperatorAt(runes[i:]); operator != "" {
+				tokens = append(tokens, token{tokenOperator, operator, position})
+				i += len(operator)
+				continue
+			}
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(wordDelimiters, runes[i]) {
+				if i > start && symbolOperatorAt(runes[i:]) != "" {
+					break
+				}
+				i++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[start:i]), position})
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(runes) + 1}), nil
+}
+
+func symbolOperatorAt(runes []rune) string {
+	for _, operator := range symbolOperators {
+		if strings.HasPrefix(string(runes[:min(len(runes), len(operator))]), operator) {
+			return operator
+		}
+	}
+	return ""
+}
@@ -0,0 +1,239 @@
+// Package rulefilter parses and validates the filters of the Imperva rules, the conditions that trigger incap rules,
+// delivery rules, cache rules and waiting rooms, such as:
+//
+//	(URL == "/admin" | URL contains "/wp-") & ClientIP != 192.0.2.1;192.0.2.2
+//
+// A filter combines conditions with the & (and) and | (or) operators, and parentheses. A condition compares a field
+// with a value, or with a ;-separated list of values. Values are either quoted, with \" and \\ escapes, or plain
+// words. The filters are validated offline, before they are sent to the API
+package rulefilter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is a syntax or validation error of a filter
+type Error struct {
+	// Position is the 1-based position of the character the error was found at
+	Position int
+	Message  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (at character %d)", e.Message, e.Position)
+}
+
+// wordOperators are the operators written with words, compared case-insensitively
+var wordOperators = map[string]bool{"contains": true, "not-contains": true}
+
+// Node is a node of a parsed filter: a Condition, or a Logical combination of nodes
+type Node interface {
+	writeCanonical(b *strings.Builder)
+}
+
+// Condition is a comparison of a field with one or more values, such as `CountryCode == US;CA`
+type Condition struct {
+	Field    string
+	Operator string
+	Values   []Value
+	// Position is the 1-based position of the field in the filter
+	Position int
+}
+
+// Value is a value of a condition. Quoted values are unescaped
+type Value struct {
+	Text   string
+	Quoted bool
+}
+
+// Logical is the combination of nodes with the & (and) or | (or) operator
+type Logical struct {
+	Operator string
+	Operands []Node
+}
+
+// Parse parses a filter. The & operator takes precedence over the | operator. An empty filter returns a nil node
+func Parse(filter string) (Node, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, &Error{Position: next.position, Message: fmt.Sprintf("unexpected %s %q", next.kind, next.text)}
+	}
+	return node, nil
+}
+
+type parser struct {
+	tokens []token
+	index  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.index]
+	if t.kind != tokenEOF {
+		p.index++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (Node, error) {
+	return p.parseLogical("|", tokenOr, p.parseAnd)
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	return p.parseLogical("&", tokenAnd, p.parsePrimary)
+}
+
+// parseLogical parses operands separated by an operator. Nested combinations with the same operator are flattened
+func (p *parser) parseLogical(operator string, kind tokenKind, parseOperand func() (Node, error)) (Node, error) {
+	var operands []Node
+	for {
+		operand, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if logical, ok := operand.(*Logical); ok && logical.Operator == operator {
+			operands = append(operands, logical.Operands...)
+		} else {
+			operands = append(operands, operand)
+		}
+		if p.peek().kind != kind {
+			break
+		}
+		p.next()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &Logical{Operator: operator, Operands: operands}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenOpenParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenCloseParen {
+			return nil, &Error{Position: closing.position, Message: fmt.Sprintf("expected ')' to close the '(' at character %d, got %s", t.position, describe(closing))}
+		}
+		return node, nil
+	case tokenWord:
+		return p.parseCondition(t)
+	}
+	return nil, &Error{Position: t.position, Message: fmt.Sprintf("expected a field name or '(', got %s", describe(t))}
+}
+
+func (p *parser) parseCondition(field token) (Node, error) {
+	condition := &Condition{Field: field.text, Position: field.position}
+
+	operator := p.next()
+	switch {
+	case operator.kind == tokenOperator:
+		condition.Operator = operator.text
+	case operator.kind == tokenWord && wordOperators[strings.ToLower(operator.text)]:
+		condition.Operator = strings.ToLower(operator.text)
+	default:
+		return nil, &Error{Position: operator.position, Message: fmt.Sprintf("expected an operator after field %s, got %s", field.text, describe(operator))}
+	}
+
+	for {
+		value := p.next()
+		switch value.kind {
+		case tokenWord:
+			condition.Values = append(condition.Values, Value{Text: value.text})
+		case tokenString:
+			condition.Values = append(condition.Values, Value{Text: value.text, Quoted: true})
+		default:
+			return nil, &Error{Position: value.position, Message: fmt.Sprintf("expected a value after %s %s, got %s", field.text, condition.Operator, describe(value))}
+		}
+		if p.peek().kind != tokenSemicolon {
+			return condition, nil
+		}
+		p.next()
+	}
+}
+
+func describe(t token) string {
+	if t.kind == tokenEOF {
+		return t.kind.String()
+	}
+	return fmt.Sprintf("%s %q", t.kind, t.text)
+}
+
+// Canonical returns the canonical form of a filter: single spaces between the tokens, lower case word operators,
+// no space in the value lists, and parentheses only around nested combinations. Filters with the same canonical
+// form are equivalent
+func Canonical(filter string) (string, error) {
+	node, err := Parse(filter)
+	if err != nil || node == nil {
+		return "", err
+	}
+	var b strings.Builder
+	node.writeCanonical(&b)
+	return b.String(), nil
+}
+
+func (c *Condition) writeCanonical(b *strings.Builder) {
+	b.WriteString(c.Field)
+	b.WriteString(" ")
+	b.WriteString(c.Operator)
+	b.WriteString(" ")
+	for i, value := range c.Values {
+		if i > 0 {
+			b.WriteString(";")
+		}
+		if value.Quoted {
+			b.WriteString(`"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value.Text) + `"`)
+		} else {
+			b.WriteString(value.Text)
+		}
+	}
+}
+
+func (l *Logical) writeCanonical(b *strings.Builder) {
+	for i, operand := range l.Operands {
+		if i > 0 {
+			b.WriteString(" " + l.Operator + " ")
+		}
+		if _, ok := operand.(*Logical); ok {
+			b.WriteString("(")
+			operand.writeCanonical(b)
+			b.WriteString(")")
+		} else {
+			operand.writeCanonical(b)
+		}
+	}
+}
+
+// Conditions returns the conditions of a parsed filter, in the order of the filter
+func Conditions(node Node) []*Condition {
+	switch n := node.(type) {
+	case *Condition:
+		return []*Condition{n}
+	case *Logical:
+		var conditions []*Condition
+		for _, operand := range n.Operands {
+			conditions = append(conditions, Conditions(operand)...)
+		}
+		return conditions
+	}
+	return nil
+}
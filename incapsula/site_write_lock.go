@@ -0,0 +1,129 @@
+package incapsula
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// siteWriteSerializationDefaults are the resource types changing the configuration of a site. The API rejects or loses
+// concurrent updates of the same site, so their writes to the same site are serialized, unless the
+// site_write_serialization provider argument disables it
+var siteWriteSerializationDefaults = map[string]bool{
+	"incapsula_api_security_api_config":                                true,
+	"incapsula_api_security_site_config":                               true,
+	"incapsula_application_delivery":                                   true,
+	"incapsula_ato_endpoint_mitigation_configuration":                  true,
+	"incapsula_ato_site_allowlist":                                     true,
+	"incapsula_bots_configuration":                                     true,
+	"incapsula_cache_rule":                                             true,
+	"incapsula_certificate_signing_request":                            true,
+	"incapsula_csp_site_configuration":                                 true,
+	"incapsula_csp_site_domain":                                        true,
+	"incapsula_custom_certificate":                                     true,
+	"incapsula_custom_hsm_certificate":                                 true,
+	"incapsula_data_center":                                            true,
+	"incapsula_data_center_server":                                     true,
+	"incapsula_data_centers_configuration":                             true,
+	"incapsula_delivery_rules_configuration":                           true,
+	"incapsula_domain":                                                 true,
+	"incapsula_incap_rule":                                             true,
+	"incapsula_managed_certificate_settings":                           true,
+	"incapsula_mtls_client_to_imperva_ca_certificate_site_association": true,
+	"incapsula_mtls_client_to_imperva_ca_certificate_site_settings":    true,
+	"incapsula_mtls_imperva_to_origin_certificate_site_association":    true,
+	"incapsula_origin_pop":                                             true,
+	"incapsula_security_rule_exception":                                true,
+	"incapsula_security_rule_exceptions":                               true,
+	"incapsula_short_renewal_cycle":                                    true,
+	"incapsula_simplified_redirect_rules_configuration":                true,
+	"incapsula_site":                                                   true,
+	"incapsula_site_cache_configuration":                               true,
+	"incapsula_site_domain_configuration":                              true,
+	"incapsula_site_log_configuration":                                 true,
+	"incapsula_site_monitoring":                                        true,
+	"incapsula_site_ssl_settings":                                      true,
+	// The SSL validation polls the certificate until the domains are validated, holding the lock would block the
+	// other changes of the site meanwhile
+	"incapsula_ssl_validation":    false,
+	"incapsula_txt_record":        true,
+	"incapsula_waf_security_rule": true,
+	"incapsula_waiting_room":      true,
+}
+
+// validateSiteWriteSerialization checks that the keys of the site_write_serialization provider argument are
+// resource types changing the configuration of a site
+func validateSiteWriteSerialization(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for resourceType := range v.(map[string]interface{}) {
+		if _, ok := siteWriteSerializationDefaults[resourceType]; ok {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%s does not change the configuration of a site", resourceType),
+			Detail:        fmt.Sprintf("The writes of the following resource types can be serialized: %s", strings.Join(siteWriteSerializationResourceTypes(), ", ")),
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
+func siteWriteSerializationResourceTypes() []string {
+	resourceTypes := make([]string, 0, len(siteWriteSerializationDefaults))
+	for resourceType := range siteWriteSerializationDefaults {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	return resourceTypes
+}
+
+// serializeSiteWrites wraps the create, update and delete functions of the resources changing the configuration of a
+// site, so that they hold the lock of the site while they run. Whether a resource type is serialized is checked when
+// the functions run, since it depends on the provider configuration
+func serializeSiteWrites(resources map[string]*schema.Resource) {
+	for resourceType, resource := range resources {
+		if _, ok := siteWriteSerializationDefaults[resourceType]; !ok {
+			continue
+		}
+		resource.CreateContext = withSiteWriteLock(resourceType, resource.CreateContext)
+		resource.UpdateContext = withSiteWriteLock(resourceType, resource.UpdateContext)
+		resource.DeleteContext = withSiteWriteLock(resourceType, resource.DeleteContext)
+	}
+}
+
+// withSiteWriteLock wraps a create, update or delete function, whose types only differ by name
+func withSiteWriteLock(resourceType string, write func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if write == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client, ok := m.(*Client)
+		if !ok {
+			return write(ctx, d, m)
+		}
+		unlock, err := client.lockSiteWrites(ctx, resourceType, siteWriteLockKey(resourceType, d))
+		if err != nil {
+			return diag.Errorf("Error waiting for the other changes to the site to complete: %s", err)
+		}
+		defer unlock()
+		return write(ctx, d, m)
+	}
+}
+
+// siteWriteLockKey returns the ID of the site a resource changes, empty when it is not known yet
+func siteWriteLockKey(resourceType string, d *schema.ResourceData) string {
+	if resourceType == "incapsula_site" {
+		return d.Id()
+	}
+	siteID := d.Get("site_id")
+	if siteID == nil {
+		return ""
+	}
+	return fmt.Sprint(siteID)
+}
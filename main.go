@@ -1,6 +1,16 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/terraform-providers/terraform-provider-incapsula/incapsula"
@@ -9,6 +19,60 @@ import (
 func main() {
 	schema.DescriptionKind = schema.StringMarkdown
 
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(export(os.Args[2:]))
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: incapsula.Provider})
+		GRPCProviderFunc: func() tfprotov5.ProviderServer {
+			return incapsula.NewGRPCProviderServer(incapsula.Provider())
+		}})
+}
+
+// export writes the Terraform configuration and the import blocks of the resources of an existing account.
+// The provider is configured from the same environment variables and credentials file as with an empty provider block
+func export(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [options]\n\n", os.Args[0])
+		fmt.Fprintf(flags.Output(), "Writes the configuration and import blocks of the resources of an account.\n")
+		fmt.Fprintf(flags.Output(), "Credentials are read from INCAPSULA_API_ID/INCAPSULA_API_KEY or the credentials profile.\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+	outputDir := flags.String("output-dir", ".", "directory the .tf files are written to")
+	accountID := flags.Int("account-id", 0, "account to export, defaults to the account of the credentials")
+	siteIDs := flags.String("site-ids", "", "comma separated IDs of the sites to export, all the sites of the account by default")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	options := incapsula.ExportOptions{OutputDir: *outputDir, AccountID: *accountID}
+	for _, value := range strings.Split(*siteIDs, ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		siteID, err := strconv.Atoi(value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid site ID %q\n", value)
+			return 2
+		}
+		options.SiteIDs = append(options.SiteIDs, siteID)
+	}
+
+	// The provider logs are only useful when debugging, as with Terraform they are enabled with TF_LOG
+	if os.Getenv("TF_LOG") == "" {
+		log.SetOutput(io.Discard)
+	}
+
+	result, err := incapsula.Export(context.Background(), options)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	fmt.Printf("Exported %d resources to %s: %s\n", result.Resources, *outputDir, strings.Join(result.Files, ", "))
+	return 0
 }